@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/config"
 	"github.com/gustionusamba24/kasir-api-go/internal/controllers"
@@ -50,22 +53,105 @@ func main() {
 	}
 	defer db.Close()
 
+	// Load the layout of barcodes printed by deli scales
+	scaleFormat, err := config.LoadScaleBarcodeFormat()
+	if err != nil {
+		log.Fatalf("Invalid scale barcode configuration: %v", err)
+	}
+
+	// Load the merchant account QRIS payments are made to
+	qrisMerchant, err := config.LoadQRISMerchant()
+	if err != nil {
+		log.Fatalf("Invalid QRIS configuration: %v", err)
+	}
+
+	// Load the payment gateway that confirms card, e-wallet and QRIS payments
+	paymentProvider, err := config.LoadPaymentProvider()
+	if err != nil {
+		log.Fatalf("Invalid payment gateway configuration: %v", err)
+	}
+	paymentTimeout, err := config.LoadPaymentTimeout()
+	if err != nil {
+		log.Fatalf("Invalid payment gateway configuration: %v", err)
+	}
+
+	// Load how long carts are kept after their last change
+	cartExpiry, err := config.LoadCartExpiry()
+	if err != nil {
+		log.Fatalf("Invalid cart configuration: %v", err)
+	}
+
+	// Load the time zone promotion days and hours are in
+	location, err := config.LoadTimeZone()
+	if err != nil {
+		log.Fatalf("Invalid time zone configuration: %v", err)
+	}
+
 	// Initialize repositories
 	categoryRepo := impl.NewCategoryRepository(db)
 	productRepo := impl.NewProductRepository(db)
 	transactionRepo := impl.NewTransactionRepository(db)
+	goodsReceiptRepo := impl.NewGoodsReceiptRepository(db)
+	storeRepo := impl.NewStoreRepository(db)
+	stockTransferRepo := impl.NewStockTransferRepository(db)
+	productLotRepo := impl.NewProductLotRepository(db)
+	productSerialRepo := impl.NewProductSerialRepository(db)
+	promotionRepo := impl.NewPromotionRepository(db)
+	voucherRepo := impl.NewVoucherRepository(db)
+	taxRateRepo := impl.NewTaxRateRepository(db)
+	cartRepo := impl.NewCartRepository(db)
 
 	// Initialize services
-	categoryService := serviceImpl.NewCategoryService(categoryRepo)
-	productService := serviceImpl.NewProductService(productRepo, categoryRepo)
-	transactionService := serviceImpl.NewTransactionService(transactionRepo, productRepo)
-	reportService := serviceImpl.NewReportService(transactionRepo)
+	categoryService := serviceImpl.NewCategoryService(categoryRepo, taxRateRepo)
+	productService := serviceImpl.NewProductService(productRepo, categoryRepo, taxRateRepo, goodsReceiptRepo, productLotRepo, productSerialRepo, scaleFormat)
+	storeService := serviceImpl.NewStoreService(storeRepo, productRepo)
+	stockTransferService := serviceImpl.NewStockTransferService(stockTransferRepo, storeRepo, productRepo)
+	promotionService := serviceImpl.NewPromotionService(promotionRepo, productRepo, categoryRepo, location)
+	voucherService := serviceImpl.NewVoucherService(voucherRepo)
+	taxRateService := serviceImpl.NewTaxRateService(taxRateRepo)
+	transactionService := serviceImpl.NewTransactionService(transactionRepo, productRepo, storeRepo, productSerialRepo, promotionRepo, voucherRepo, taxRateRepo, scaleFormat, paymentProvider, paymentTimeout, location)
+	cartService := serviceImpl.NewCartService(cartRepo, storeRepo, transactionService, cartExpiry)
+	paymentService := serviceImpl.NewPaymentService(transactionRepo, qrisMerchant, paymentProvider)
+	reportService := serviceImpl.NewReportService(transactionRepo, productLotRepo)
+	labelService := serviceImpl.NewLabelService(productRepo)
 
 	// Initialize controllers
 	categoryController := controllers.NewCategoryController(categoryService)
 	productController := controllers.NewProductController(productService)
+	storeController := controllers.NewStoreController(storeService)
+	stockTransferController := controllers.NewStockTransferController(stockTransferService)
+	promotionController := controllers.NewPromotionController(promotionService)
+	voucherController := controllers.NewVoucherController(voucherService)
+	taxRateController := controllers.NewTaxRateController(taxRateService)
 	transactionController := controllers.NewTransactionController(transactionService)
+	cartController := controllers.NewCartController(cartService)
+	paymentController := controllers.NewPaymentController(paymentService)
 	reportController := controllers.NewReportController(reportService)
+	labelController := controllers.NewLabelController(labelService)
+
+	// Expire pending transactions whose gateway payments were not confirmed in time,
+	// putting their stock back, and carts left untouched for too long
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			expired, err := paymentService.ExpirePending(context.Background())
+			if err != nil {
+				log.Printf("Failed to expire pending transactions: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("Expired %d pending transactions", expired)
+			}
+
+			expiredCarts, err := cartService.ExpireCarts(context.Background())
+			if err != nil {
+				log.Printf("Failed to expire carts: %v", err)
+			}
+			if expiredCarts > 0 {
+				log.Printf("Expired %d carts", expiredCarts)
+			}
+		}
+	}()
 
 	// Setup routes
 	mux := http.NewServeMux()
@@ -82,7 +168,25 @@ func main() {
 		}
 	})
 
+	mux.HandleFunc("/categories/tree", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			categoryController.GetTree(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	mux.HandleFunc("/categories/", func(w http.ResponseWriter, r *http.Request) {
+		// Archive routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/restore") {
+			if r.Method == http.MethodPost {
+				categoryController.Restore(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			categoryController.GetByID(w, r)
@@ -107,7 +211,93 @@ func main() {
 		}
 	})
 
+	mux.HandleFunc("/products/lookup", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			productController.Lookup(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	mux.HandleFunc("/products/", func(w http.ResponseWriter, r *http.Request) {
+		// Goods receipt routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/receipts") {
+			switch r.Method {
+			case http.MethodGet:
+				productController.GetReceipts(w, r)
+			case http.MethodPost:
+				productController.ReceiveGoods(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Lot routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/lots") {
+			if r.Method == http.MethodGet {
+				productController.GetLots(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Serial number routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/serials") {
+			if r.Method == http.MethodGet {
+				productController.GetSerials(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Bundle routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/components") {
+			switch r.Method {
+			case http.MethodGet:
+				productController.GetComponents(w, r)
+			case http.MethodPut:
+				productController.SetComponents(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Archive routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/restore") {
+			if r.Method == http.MethodPost {
+				productController.Restore(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Variant routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/options") {
+			if r.Method == http.MethodPut {
+				productController.SetOptions(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/variants") {
+			switch r.Method {
+			case http.MethodGet:
+				productController.GetVariants(w, r)
+			case http.MethodPost:
+				productController.CreateVariant(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		switch r.Method {
 		case http.MethodGet:
 			productController.GetByID(w, r)
@@ -120,6 +310,240 @@ func main() {
 		}
 	})
 
+	// Store routes
+	mux.HandleFunc("/stores", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			storeController.GetAll(w, r)
+		case http.MethodPost:
+			storeController.Create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/stores/", func(w http.ResponseWriter, r *http.Request) {
+		// Store stock routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/products") {
+			switch r.Method {
+			case http.MethodGet:
+				storeController.GetProducts(w, r)
+			case http.MethodPut:
+				storeController.UpdateStock(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			storeController.GetByID(w, r)
+		case http.MethodPut:
+			storeController.Update(w, r)
+		case http.MethodDelete:
+			storeController.Delete(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Label routes
+	mux.HandleFunc("/labels", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			labelController.Generate(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Stock transfer routes
+	mux.HandleFunc("/transfers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			stockTransferController.GetAll(w, r)
+		case http.MethodPost:
+			stockTransferController.Create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/transfers/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+
+		// Stock transfer actions
+		var action http.HandlerFunc
+		switch {
+		case strings.HasSuffix(path, "/ship"):
+			action = stockTransferController.Ship
+		case strings.HasSuffix(path, "/receive"):
+			action = stockTransferController.Receive
+		case strings.HasSuffix(path, "/cancel"):
+			action = stockTransferController.Cancel
+		}
+		if action != nil {
+			if r.Method == http.MethodPost {
+				action(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			stockTransferController.GetByID(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Promotion routes
+	mux.HandleFunc("/promotions", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			promotionController.GetAll(w, r)
+		case http.MethodPost:
+			promotionController.Create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/promotions/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			promotionController.GetByID(w, r)
+		case http.MethodPut:
+			promotionController.Update(w, r)
+		case http.MethodDelete:
+			promotionController.Delete(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Voucher routes
+	mux.HandleFunc("/vouchers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			voucherController.GetAll(w, r)
+		case http.MethodPost:
+			voucherController.Create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/vouchers/", func(w http.ResponseWriter, r *http.Request) {
+		// Redemption routes
+		if strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/redemptions") {
+			if r.Method == http.MethodGet {
+				voucherController.GetRedemptions(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			voucherController.GetByID(w, r)
+		case http.MethodPut:
+			voucherController.Update(w, r)
+		case http.MethodDelete:
+			voucherController.Delete(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Tax rate routes
+	mux.HandleFunc("/tax-rates", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			taxRateController.GetAll(w, r)
+		case http.MethodPost:
+			taxRateController.Create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/tax-rates/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			taxRateController.GetByID(w, r)
+		case http.MethodPut:
+			taxRateController.Update(w, r)
+		case http.MethodDelete:
+			taxRateController.Delete(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Cart routes
+	mux.HandleFunc("/carts", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			cartController.GetAll(w, r)
+		case http.MethodPost:
+			cartController.Create(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/carts/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+
+		// Cart item routes
+		if strings.Contains(path, "/items/") {
+			switch r.Method {
+			case http.MethodPut:
+				cartController.UpdateItem(w, r)
+			case http.MethodDelete:
+				cartController.RemoveItem(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Cart actions
+		var action http.HandlerFunc
+		switch {
+		case strings.HasSuffix(path, "/items"):
+			action = cartController.AddItem
+		case strings.HasSuffix(path, "/park"):
+			action = cartController.Park
+		case strings.HasSuffix(path, "/resume"):
+			action = cartController.Resume
+		case strings.HasSuffix(path, "/checkout"):
+			action = cartController.Checkout
+		}
+		if action != nil {
+			if r.Method == http.MethodPost {
+				action(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			cartController.GetByID(w, r)
+		case http.MethodPut:
+			cartController.Update(w, r)
+		case http.MethodDelete:
+			cartController.Delete(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	// Transaction routes
 	mux.HandleFunc("/transactions/checkout", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPost {
@@ -138,6 +562,37 @@ func main() {
 	})
 
 	mux.HandleFunc("/transactions/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimSuffix(r.URL.Path, "/")
+
+		// QRIS code route
+		if strings.HasSuffix(path, "/qris") {
+			if r.Method == http.MethodGet {
+				paymentController.GetQRIS(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Transaction status transitions
+		var action http.HandlerFunc
+		switch {
+		case strings.HasSuffix(path, "/complete"):
+			action = transactionController.Complete
+		case strings.HasSuffix(path, "/void"):
+			action = transactionController.Void
+		case strings.HasSuffix(path, "/refund"):
+			action = transactionController.Refund
+		}
+		if action != nil {
+			if r.Method == http.MethodPost {
+				action(w, r)
+			} else {
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		if r.Method == http.MethodGet {
 			transactionController.GetByID(w, r)
 		} else {
@@ -145,6 +600,24 @@ func main() {
 		}
 	})
 
+	// Payment gateway webhook route
+	mux.HandleFunc("/payments/webhook", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			paymentController.Webhook(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Serial number lookup route
+	mux.HandleFunc("/serials/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			transactionController.LookupSerial(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	// Report routes
 	mux.HandleFunc("/report/today", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
@@ -154,6 +627,30 @@ func main() {
 		}
 	})
 
+	mux.HandleFunc("/report/expiring", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			reportController.GetExpiringReport(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/report/tax", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			reportController.GetTaxReport(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	mux.HandleFunc("/report/payments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			reportController.GetPaymentReport(w, r)
+		} else {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
 	mux.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodGet {
 			reportController.GetDateRangeReport(w, r)
@@ -185,13 +682,18 @@ func main() {
   "version": "1.0",
   "status": "running",
   "documentation": "http://localhost:%s/swagger/index.html",
+  "storeHeader": "Send X-Store-ID to act on a specific store (defaults to the main store)",
   "endpoints": {
     "categories": {
       "getAll": "GET http://localhost:%s/categories",
+      "getAllWithStats": "GET http://localhost:%s/categories?with=stats",
+      "getTree": "GET http://localhost:%s/categories/tree",
       "getById": "GET http://localhost:%s/categories/{id}",
       "create": "POST http://localhost:%s/categories",
       "update": "PUT http://localhost:%s/categories/{id}",
-      "delete": "DELETE http://localhost:%s/categories/{id}"
+      "delete": "DELETE http://localhost:%s/categories/{id}",
+      "deleteAndReassign": "DELETE http://localhost:%s/categories/{id}?reassign_to={id}",
+      "restore": "POST http://localhost:%s/categories/{id}/restore"
     },
     "products": {
       "getAll": "GET http://localhost:%s/products",
@@ -199,22 +701,100 @@ func main() {
       "filterByActive": "GET http://localhost:%s/products?active={true|false}",
       "searchWithFilters": "GET http://localhost:%s/products?name={search_term}&active={true|false}",
       "getByCategory": "GET http://localhost:%s/products?category_id={id}",
+      "getByCategoryTree": "GET http://localhost:%s/products?category_id={id}&include_descendants=true",
       "getById": "GET http://localhost:%s/products/{id}",
+      "lookup": "GET http://localhost:%s/products/lookup?barcode={barcode}",
       "create": "POST http://localhost:%s/products",
       "update": "PUT http://localhost:%s/products/{id}",
-      "delete": "DELETE http://localhost:%s/products/{id}"
+      "delete": "DELETE http://localhost:%s/products/{id}",
+      "restore": "POST http://localhost:%s/products/{id}/restore",
+      "receiveGoods": "POST http://localhost:%s/products/{id}/receipts",
+      "getReceipts": "GET http://localhost:%s/products/{id}/receipts",
+      "getLots": "GET http://localhost:%s/products/{id}/lots",
+      "getSerials": "GET http://localhost:%s/products/{id}/serials",
+      "setOptions": "PUT http://localhost:%s/products/{id}/options",
+      "getVariants": "GET http://localhost:%s/products/{id}/variants",
+      "createVariant": "POST http://localhost:%s/products/{id}/variants",
+      "getComponents": "GET http://localhost:%s/products/{id}/components",
+      "setComponents": "PUT http://localhost:%s/products/{id}/components"
+    },
+    "stores": {
+      "getAll": "GET http://localhost:%s/stores",
+      "getById": "GET http://localhost:%s/stores/{id}",
+      "create": "POST http://localhost:%s/stores",
+      "update": "PUT http://localhost:%s/stores/{id}",
+      "delete": "DELETE http://localhost:%s/stores/{id}",
+      "getProducts": "GET http://localhost:%s/stores/{id}/products",
+      "updateStock": "PUT http://localhost:%s/stores/{id}/products"
+    },
+    "labels": {
+      "generate": "POST http://localhost:%s/labels"
+    },
+    "transfers": {
+      "getAll": "GET http://localhost:%s/transfers?status={status}&store_id={id}",
+      "getById": "GET http://localhost:%s/transfers/{id}",
+      "create": "POST http://localhost:%s/transfers",
+      "ship": "POST http://localhost:%s/transfers/{id}/ship",
+      "receive": "POST http://localhost:%s/transfers/{id}/receive",
+      "cancel": "POST http://localhost:%s/transfers/{id}/cancel"
+    },
+    "promotions": {
+      "getAll": "GET http://localhost:%s/promotions",
+      "getRunning": "GET http://localhost:%s/promotions?running=true",
+      "getById": "GET http://localhost:%s/promotions/{id}",
+      "create": "POST http://localhost:%s/promotions",
+      "update": "PUT http://localhost:%s/promotions/{id}",
+      "delete": "DELETE http://localhost:%s/promotions/{id}"
+    },
+    "vouchers": {
+      "getAll": "GET http://localhost:%s/vouchers",
+      "getById": "GET http://localhost:%s/vouchers/{id}",
+      "create": "POST http://localhost:%s/vouchers",
+      "update": "PUT http://localhost:%s/vouchers/{id}",
+      "delete": "DELETE http://localhost:%s/vouchers/{id}",
+      "getRedemptions": "GET http://localhost:%s/vouchers/{id}/redemptions"
+    },
+    "taxRates": {
+      "getAll": "GET http://localhost:%s/tax-rates",
+      "getById": "GET http://localhost:%s/tax-rates/{id}",
+      "create": "POST http://localhost:%s/tax-rates",
+      "update": "PUT http://localhost:%s/tax-rates/{id}",
+      "delete": "DELETE http://localhost:%s/tax-rates/{id}"
     },
     "transactions": {
       "checkout": "POST http://localhost:%s/transactions/checkout",
       "getAll": "GET http://localhost:%s/transactions",
-      "getById": "GET http://localhost:%s/transactions/{id}"
+      "getById": "GET http://localhost:%s/transactions/{id}",
+      "complete": "POST http://localhost:%s/transactions/{id}/complete",
+      "void": "POST http://localhost:%s/transactions/{id}/void",
+      "refund": "POST http://localhost:%s/transactions/{id}/refund",
+      "qris": "GET http://localhost:%s/transactions/{id}/qris?format={json|png}",
+      "paymentWebhook": "POST http://localhost:%s/payments/webhook",
+      "lookupSerial": "GET http://localhost:%s/serials/{serial_number}"
+    },
+    "carts": {
+      "getAll": "GET http://localhost:%s/carts?status={status}",
+      "getById": "GET http://localhost:%s/carts/{id}",
+      "create": "POST http://localhost:%s/carts",
+      "update": "PUT http://localhost:%s/carts/{id}",
+      "delete": "DELETE http://localhost:%s/carts/{id}",
+      "addItem": "POST http://localhost:%s/carts/{id}/items",
+      "updateItem": "PUT http://localhost:%s/carts/{id}/items/{itemId}",
+      "removeItem": "DELETE http://localhost:%s/carts/{id}/items/{itemId}",
+      "park": "POST http://localhost:%s/carts/{id}/park",
+      "resume": "POST http://localhost:%s/carts/{id}/resume",
+      "checkout": "POST http://localhost:%s/carts/{id}/checkout"
     },
     "reports": {
       "todayReport": "GET http://localhost:%s/report/today",
-      "dateRangeReport": "GET http://localhost:%s/report?start_date={YYYY-MM-DD}&end_date={YYYY-MM-DD}"
+      "dateRangeReport": "GET http://localhost:%s/report?start_date={YYYY-MM-DD}&end_date={YYYY-MM-DD}",
+      "categoryReport": "GET http://localhost:%s/report?start_date={YYYY-MM-DD}&end_date={YYYY-MM-DD}&group_by=category",
+      "expiringReport": "GET http://localhost:%s/report/expiring?days={days}",
+      "taxReport": "GET http://localhost:%s/report/tax?start_date={YYYY-MM-DD}&end_date={YYYY-MM-DD}",
+      "paymentReport": "GET http://localhost:%s/report/payments?start_date={YYYY-MM-DD}&end_date={YYYY-MM-DD}"
     }
   }
-}`, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port)
+}`, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port, port)
 
 		fmt.Fprint(w, response)
 	})
@@ -23,9 +23,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/categories": {
+        "/carts": {
             "get": {
-                "description": "Retrieve a list of all categories",
+                "description": "Retrieve the carts of a store, most recently changed first. Use status=parked to find baskets to resume. Lists leave out live totals; get a single cart for them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -33,12 +33,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
+                ],
+                "summary": "Get all carts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (open, parked, checked_out, expired)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get all categories",
                 "responses": {
                     "200": {
-                        "description": "success response with categories data",
+                        "description": "success response with carts data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameter",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -54,7 +75,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new category with the provided data",
+                "description": "Open a cart at a terminal of the caller's store, optionally with its first items. Nothing is taken from stock until the cart is checked out.",
                 "consumes": [
                     "application/json"
                 ],
@@ -62,30 +83,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Create a new category",
+                "summary": "Open a cart",
                 "parameters": [
                     {
-                        "description": "Category data",
-                        "name": "category",
+                        "description": "Cart data",
+                        "name": "cart",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryCreateRequestDto"
+                            "$ref": "#/definitions/dtos.CartCreateRequestDto"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "success response with created category",
+                        "description": "success response with created cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid request payload",
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store or product not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -101,9 +134,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{id}": {
+        "/carts/{id}": {
             "get": {
-                "description": "Retrieve a single category by its ID",
+                "description": "Retrieve a cart with its live totals, priced with current prices, promotions and stock without recording anything. pricing_error says why a cart cannot be checked out as it stands.",
                 "consumes": [
                     "application/json"
                 ],
@@ -111,35 +144,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Get a category by ID",
+                "summary": "Get a cart by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Cart ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response with category data",
+                        "description": "success response with cart data",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid category ID",
+                        "description": "invalid cart ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "category not found",
+                        "description": "cart not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -155,7 +194,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Update an existing category by its ID",
+                "description": "Replace the customer, voucher codes and cart discount of an open cart. The change is rejected if the cart could not be priced with it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -163,44 +202,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Update a category",
+                "summary": "Update a cart",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Cart ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Category data",
-                        "name": "category",
+                        "description": "Cart data",
+                        "name": "cart",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.CategoryUpdateRequestDto"
+                            "$ref": "#/definitions/dtos.CartUpdateRequestDto"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response with updated category",
+                        "description": "success response with updated cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid request",
+                        "description": "invalid request or cart not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "category not found",
+                        "description": "cart or voucher not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -216,7 +261,7 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete a category by its ID",
+                "description": "Discard an open or parked cart",
                 "consumes": [
                     "application/json"
                 ],
@@ -224,16 +269,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "categories"
+                    "carts"
                 ],
-                "summary": "Delete a category",
+                "summary": "Delete a cart",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
+                        "description": "Cart ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -245,14 +296,14 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "invalid category ID",
+                        "description": "invalid cart ID or cart already checked out or expired",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "category not found",
+                        "description": "cart not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -268,9 +319,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
-            "get": {
-                "description": "Retrieve a list of all products, optionally filtered by category ID, name, or active status",
+        "/carts/{id}/checkout": {
+            "post": {
+                "description": "Check out an open cart into a transaction, as POST /transactions/checkout would with the cart's items, vouchers and discounts. The cart stays open if the checkout fails.",
                 "consumes": [
                     "application/json"
                 ],
@@ -278,46 +329,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Get all products",
+                "summary": "Check out a cart",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Filter by Category ID",
-                        "name": "category_id",
-                        "in": "query"
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Search by product name (case-insensitive partial match)",
-                        "name": "name",
-                        "in": "query"
+                        "description": "Payments",
+                        "name": "checkout",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartCheckoutRequestDto"
+                        }
                     },
                     {
-                        "type": "boolean",
-                        "description": "Filter by active status",
-                        "name": "active",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "success response with products data",
+                    "201": {
+                        "description": "success response with transaction data",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid parameter",
+                        "description": "invalid request or cart not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "category not found",
+                        "description": "cart or product not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -331,9 +385,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/carts/{id}/items": {
             "post": {
-                "description": "Create a new product with the provided data",
+                "description": "Add a line to an open cart by product ID or scanned barcode, as at checkout. The line is rejected if the cart could not be priced with it, such as when stock is short.",
                 "consumes": [
                     "application/json"
                 ],
@@ -341,37 +397,50 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Create a new product",
+                "summary": "Add an item to a cart",
                 "parameters": [
                     {
-                        "description": "Product data",
-                        "name": "product",
+                        "type": "integer",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cart item",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductCreateRequestDto"
+                            "$ref": "#/definitions/dtos.CheckoutItemDto"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "success response with created product",
+                        "description": "success response with updated cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid request payload",
+                        "description": "invalid request or cart not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "category not found",
+                        "description": "cart or product not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -387,9 +456,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieve a single product by its ID",
+        "/carts/{id}/items/{itemId}": {
+            "put": {
+                "description": "Change the quantity, unit, serial numbers and discount of a line of an open cart. The change is rejected if the cart could not be priced with it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -397,35 +466,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Update a cart item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Cart ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cart item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Cart item data",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartItemUpdateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response with product data",
+                        "description": "success response with updated cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid product ID",
+                        "description": "invalid request or cart not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "product not found",
+                        "description": "cart or cart item not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -440,8 +531,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Update an existing product by its ID",
+            "delete": {
+                "description": "Remove a line from an open cart",
                 "consumes": [
                     "application/json"
                 ],
@@ -449,44 +540,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Update a product",
+                "summary": "Remove a cart item",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Cart ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product data",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ProductUpdateRequestDto"
-                        }
+                        "type": "integer",
+                        "description": "Cart item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response with updated product",
+                        "description": "success response with updated cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid request",
+                        "description": "invalid request or cart not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "product not found",
+                        "description": "cart or cart item not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -500,9 +595,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a product by its ID",
+            }
+        },
+        "/carts/{id}/park": {
+            "post": {
+                "description": "Set an open cart aside so the terminal can serve the next customer. A parked cart cannot be changed until it is resumed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -510,35 +607,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "carts"
                 ],
-                "summary": "Delete a product",
+                "summary": "Park a cart",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Cart ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Note to find the cart by",
+                        "name": "park",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartParkRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response",
+                        "description": "success response with parked cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid product ID",
+                        "description": "invalid request or cart not open",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "product not found",
+                        "description": "cart not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -554,9 +665,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/report": {
-            "get": {
-                "description": "Retrieve transaction report for a given date range including total revenue, transaction count, and best selling product",
+        "/carts/{id}/resume": {
+            "post": {
+                "description": "Reopen a parked cart at any terminal of its store",
                 "consumes": [
                     "application/json"
                 ],
@@ -564,35 +675,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "carts"
                 ],
-                "summary": "Get transaction report for date range",
+                "summary": "Resume a cart",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Start date in YYYY-MM-DD format",
-                        "name": "start_date",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "Cart ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End date in YYYY-MM-DD format",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Terminal resuming the cart",
+                        "name": "resume",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartResumeRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response with date range report data",
+                        "description": "success response with resumed cart",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "bad request - missing or invalid parameters",
+                        "description": "invalid request or cart not parked",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "cart not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -608,9 +733,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/report/today": {
+        "/categories": {
             "get": {
-                "description": "Retrieve today's transaction report including total revenue, transaction count, and best selling product",
+                "description": "Retrieve a list of all categories. Archived categories are left out unless include_archived is true. With with=stats each category carries its active product count, units in stock and the stock value at price and at cost for the caller's store, counting the products assigned directly to it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -618,12 +743,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reports"
+                    "categories"
+                ],
+                "summary": "Get all categories",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived categories",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to stats to add product counts and stock value per category",
+                        "name": "with",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get today's transaction report",
                 "responses": {
                     "200": {
-                        "description": "success response with today's report data",
+                        "description": "success response with categories data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid store ID",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -637,11 +789,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/transactions": {
-            "get": {
-                "description": "Retrieve a list of all transactions with their details",
+            },
+            "post": {
+                "description": "Create a new category with the provided data, optionally nested under a parent category",
                 "consumes": [
                     "application/json"
                 ],
@@ -649,13 +799,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "categories"
                 ],
-                "summary": "Get all transactions",
-                "responses": {
-                    "200": {
-                        "description": "success response with transactions data",
-                        "schema": {
+                "summary": "Create a new category",
+                "parameters": [
+                    {
+                        "description": "Category data",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CategoryCreateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created category",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request payload or parent category",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "parent category not found",
+                        "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
@@ -670,9 +845,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/transactions/checkout": {
-            "post": {
-                "description": "Create a new transaction with multiple products",
+        "/categories/tree": {
+            "get": {
+                "description": "Retrieve all categories nested under their parents, top-level categories first. Archived categories are left out unless include_archived is true.",
                 "consumes": [
                     "application/json"
                 ],
@@ -680,23 +855,121 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "categories"
                 ],
-                "summary": "Create a new transaction (checkout)",
+                "summary": "Get the category tree",
                 "parameters": [
                     {
-                        "description": "Checkout request with items",
-                        "name": "request",
+                        "type": "boolean",
+                        "description": "Include archived categories",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with the category tree",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Retrieve a single category by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Get a category by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with category data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid category ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing category by its ID. parent_id moves it under another category and 0 makes it top-level; a category cannot be moved under one of its own subcategories.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Update a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category data",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.TransactionCreateRequestDto"
+                            "$ref": "#/definitions/dtos.CategoryUpdateRequestDto"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "success response with transaction data",
+                    "200": {
+                        "description": "success response with updated category",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -710,7 +983,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "product not found",
+                        "description": "category not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -724,11 +997,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/transactions/{id}": {
-            "get": {
-                "description": "Retrieve a single transaction by its ID with details",
+            },
+            "delete": {
+                "description": "Archive a category by its ID. A category that still has products is only deleted when reassign_to names the category they move to, or orphan=true leaves them uncategorized. Products are moved and the category archived in one transaction.",
                 "consumes": [
                     "application/json"
                 ],
@@ -736,35 +1007,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transactions"
+                    "categories"
                 ],
-                "summary": "Get a transaction by ID",
+                "summary": "Delete a category",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Transaction ID",
+                        "description": "Category ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category to move the products to",
+                        "name": "reassign_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave the products uncategorized",
+                        "name": "orphan",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "success response with transaction data",
+                        "description": "success response with the number of products moved",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "invalid transaction ID",
+                        "description": "invalid category ID, category still has products or already archived",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     },
                     "404": {
-                        "description": "transaction not found",
+                        "description": "category not found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -779,111 +1062,4370 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dtos.CategoryCreateRequestDto": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                }
-            }
-        },
-        "dtos.CategoryUpdateRequestDto": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "maxLength": 500
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                }
-            }
         },
-        "dtos.CheckoutItemDto": {
-            "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
-            ],
-            "properties": {
-                "product_id": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
+        "/categories/{id}/restore": {
+            "post": {
+                "description": "Bring back an archived category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Restore an archived category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with restored category",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid category ID or category not archived",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
                 }
             }
         },
-        "dtos.ProductCreateRequestDto": {
-            "type": "object",
-            "required": [
-                "name",
+        "/labels": {
+            "post": {
+                "description": "Render shelf labels / barcode stickers with product name, formatted store price and a Code128 (SKU) or EAN-13 barcode. PDF output contains every sheet; PNG output renders one sheet, selected with page, and reports the sheet count in the X-Total-Pages header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf",
+                    "image/png"
+                ],
+                "tags": [
+                    "labels"
+                ],
+                "summary": "Generate shelf labels",
+                "parameters": [
+                    {
+                        "description": "Products, output format and sheet layout",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LabelRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID whose prices are printed (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "rendered labels",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request or product without a printable code",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/payments/webhook": {
+            "post": {
+                "description": "Called by the payment gateway to report whether a payment charged through it was paid or failed. The request must carry the gateway's signature. A paid payment completes its pending transaction once none of its payments is pending; a failed payment fails the transaction and puts its stock back. Repeated deliveries of the same outcome are ignored. A paid webhook whose amount differs from the payment's is rejected and the payment stays pending. With the fake provider the body is {\"provider_ref\", \"status\", \"amount\"} signed with HMAC-SHA256 of the body in the X-Fake-Signature header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Receive a payment gateway webhook",
+                "responses": {
+                    "200": {
+                        "description": "success response with the payment's transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid body, amount not matching the payment or no payment gateway configured",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "invalid signature",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "payment not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Retrieve a list of all products, optionally filtered by category ID, name, or active status. Archived products are left out unless include_archived is true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by Category ID",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by product name (case-insensitive partial match)",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "With category_id, also list products of its subcategories",
+                        "name": "include_descendants",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include archived products",
+                        "name": "include_archived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with products data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new product with the provided data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID holding the initial stock (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Product data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductCreateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created product",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request payload",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/lookup": {
+            "get": {
+                "description": "Find the product a scanned barcode (EAN-13 or UPC-A) or SKU belongs to, with the stock and price of the caller's store",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Look up a product by barcode or SKU",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Scanned barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product SKU, used when no barcode is given",
+                        "name": "sku",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with product data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "missing or invalid barcode",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieve a single product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with product data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing product by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product data",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductUpdateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with updated product",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Archive a product by its ID. Archived products are hidden from listings and cannot be sold, but stay in transaction history and can be restored. Variants are archived with their parent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID or product already archived",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/components": {
+            "get": {
+                "description": "Retrieve the component products and quantities of a bundle with their stock at the caller's store",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get components of a bundle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with components",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the component products and quantities that make up one unit of a bundle. Selling the bundle takes its components out of stock and its stock is derived from theirs. A product only becomes a bundle while no store holds or awaits stock of it. An empty list makes it a regular product again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set the components of a bundle",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bundle components",
+                        "name": "components",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BundleComponentsUpdateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with the bundle and its components",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product or component not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/lots": {
+            "get": {
+                "description": "Retrieve the lots of a product held by a store that still have stock, earliest expiry first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get lots of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with product lots",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/options": {
+            "put": {
+                "description": "Replace the option axes, such as Size and Color, of a parent product. Up to 3 axes are allowed and existing variants must still match them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Set the options of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Option axes",
+                        "name": "options",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductOptionsUpdateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with product and its variant matrix",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/receipts": {
+            "get": {
+                "description": "Retrieve the goods receipt history of a product, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get goods receipts of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with goods receipts",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Record incoming stock for a product. The received quantity is added to stock and the product cost is recalculated as a weighted average.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Receive goods for a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Goods receipt data",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GoodsReceiptCreateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID receiving the goods (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with goods receipt",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/restore": {
+            "post": {
+                "description": "Bring back an archived product, together with the variants archived with it. A variant can only be restored once its parent is.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Restore an archived product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with restored product",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID or product not archived",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/serials": {
+            "get": {
+                "description": "Retrieve the unsold serial numbers of a serialized product held by a store, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get serial numbers of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with serial numbers in stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/variants": {
+            "get": {
+                "description": "Retrieve the variants of a parent product with the price and stock of the caller's store",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Get variants of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with variants",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid product ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a variant for one combination of the parent's option values. The variant has its own price, stock, SKU and barcodes and is what checkout sells.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "products"
+                ],
+                "summary": "Create a variant of a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Parent product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant data",
+                        "name": "variant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductVariantCreateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID holding the initial stock (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created variant",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions": {
+            "get": {
+                "description": "Retrieve all promotions, highest priority first. Use running=true to see only the promotions checkout applies right now.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get all promotions",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only promotions running now",
+                        "name": "running",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with promotions data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a promotion applied automatically at checkout: buy_x_get_y, quantity_tier, category_percent or bundle_price, optionally limited to dates, days of the week and hours of the day",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Create a promotion",
+                "parameters": [
+                    {
+                        "description": "Promotion data",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PromotionRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created promotion",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product or category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/promotions/{id}": {
+            "get": {
+                "description": "Retrieve a single promotion by its ID with its products and tiers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Get a promotion by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with promotion data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid promotion ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "promotion not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the settings, products and tiers of an existing promotion",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Update a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promotion data",
+                        "name": "promotion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PromotionRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with updated promotion",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "promotion, product or category not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a promotion by its ID. Past transactions keep the discounts it gave.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "promotions"
+                ],
+                "summary": "Delete a promotion",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid promotion ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "promotion not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/report": {
+            "get": {
+                "description": "Retrieve transaction report for a given date range including total revenue, transaction count, best selling product and the components of the total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get transaction report for date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date in YYYY-MM-DD format",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date in YYYY-MM-DD format",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to category to add sales per top-level category",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with date range report data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "bad request - missing or invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/report/expiring": {
+            "get": {
+                "description": "Retrieve the lots of a store that expire within the given number of days, including lots that have already expired, earliest expiry first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get expiring stock report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days ahead to look (defaults to 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with expiring lots",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "bad request - invalid days",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/report/payments": {
+            "get": {
+                "description": "Retrieve the payments taken per method for a given date range, with the amount handed over, the change given and the amount kept, for reconciling the cash drawer and card and QRIS settlements",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get payment report for date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date in YYYY-MM-DD format",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date in YYYY-MM-DD format",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with payment report data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "bad request - missing or invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/report/tax": {
+            "get": {
+                "description": "Retrieve the tax charged per rate for a given date range, with the taxable amount, tax and number of transactions at each rate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get tax report for date range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date in YYYY-MM-DD format",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date in YYYY-MM-DD format",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with tax report data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "bad request - missing or invalid parameters",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/report/today": {
+            "get": {
+                "description": "Retrieve today's transaction report including total revenue, transaction count, best selling product and the components of the total",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get today's transaction report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to category to add sales per top-level category",
+                        "name": "group_by",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with today's report data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/serials/{serial_number}": {
+            "get": {
+                "description": "Retrieve a serial number with its product, status and the transaction it was sold in, for warranty claims",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Look up a serial number",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Serial number",
+                        "name": "serial_number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with serial number and sale transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "missing serial number",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "serial number not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/stores": {
+            "get": {
+                "description": "Retrieve a list of all stores",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Get all stores",
+                "responses": {
+                    "200": {
+                        "description": "success response with stores data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new store with the provided data. tax_mode says whether its prices include tax (inclusive, the default) or have it added at checkout (exclusive). service_charge_rate adds a service charge, taxed at the default rate when service_charge_taxable is set, and cash_rounding rounds cash totals to a multiple of that many rupiah.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Create a new store",
+                "parameters": [
+                    {
+                        "description": "Store data",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StoreCreateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created store",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}": {
+            "get": {
+                "description": "Retrieve a single store by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Get a store by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with store data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid store ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update an existing store by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Update a store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Store data",
+                        "name": "store",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StoreUpdateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with updated store",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a store by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Delete a store",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid store ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/stores/{id}/products": {
+            "get": {
+                "description": "Retrieve the shared catalog with the stock levels and price overrides of a store",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Get store products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with products data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid store ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Set a store's stock level for a product and optionally a store-specific price. A null price removes the override.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stores"
+                ],
+                "summary": "Update store stock and price",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Store ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock data",
+                        "name": "stock",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StoreStockUpdateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with updated product",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store or product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates": {
+            "get": {
+                "description": "Retrieve all tax rates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get all tax rates",
+                "responses": {
+                    "200": {
+                        "description": "success response with tax rates data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a tax rate, such as PPN 11%, to assign to products or categories. A default rate applies to products that get no rate from themselves or their category.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Create a tax rate",
+                "parameters": [
+                    {
+                        "description": "Tax rate data",
+                        "name": "tax_rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TaxRateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created tax rate",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/tax-rates/{id}": {
+            "get": {
+                "description": "Retrieve a single tax rate by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Get a tax rate by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with tax rate data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid tax rate ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "tax rate not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the name, rate and default setting of a tax rate. Past transactions keep the rate they were charged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Update a tax rate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Tax rate data",
+                        "name": "tax_rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TaxRateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with updated tax rate",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "tax rate not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a tax rate that no product or category is assigned to. Past transactions keep the tax they were charged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax-rates"
+                ],
+                "summary": "Delete a tax rate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax rate ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid tax rate ID or tax rate still assigned",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "tax rate not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "description": "Retrieve a list of all transactions with their details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get all transactions",
+                "responses": {
+                    "200": {
+                        "description": "success response with transactions data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/checkout": {
+            "post": {
+                "description": "Create a new transaction with multiple products. payments lists the tenders paid, split over several methods if needed; only cash gives change, and a checkout whose payments do not cover the total is rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Create a new transaction (checkout)",
+                "parameters": [
+                    {
+                        "description": "Checkout request with items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TransactionCreateRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with transaction data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "description": "Retrieve a single transaction by its ID with details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Get a transaction by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with transaction data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid transaction ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "transaction not found at the store",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/complete": {
+            "post": {
+                "description": "Pay a draft transaction and take its stock. The total is rounded to the store's cash rounding when any payment is cash. The transaction becomes completed, or pending while gateway payments await confirmation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Complete a draft transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payments",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TransactionCompleteRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with the completed transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "not a draft, insufficient stock or payments that do not cover the total",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "transaction not found at the store",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/qris": {
+            "get": {
+                "description": "Build a dynamic QRIS code asking for the exact amount of the pending QRIS payment of a pending transaction, with the transaction as bill number. Returns the QRIS payload as JSON, or with format=png the QR code to show the customer.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "image/png"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Get the QRIS code of a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json (default) or png",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Width and height of the png in pixels, 128 to 2048 (defaults to 512)",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with the QRIS payload, or the png image",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid ID, transaction not pending or without a pending QRIS payment, or QRIS not configured",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "transaction not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/refund": {
+            "post": {
+                "description": "Refund a completed transaction in full. Its stock is put back and its payments are marked refunded, so it no longer counts in reports.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Refund a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for the refund",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TransactionReasonRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with the refunded transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "missing reason or transaction is not completed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "transaction not found at the store",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/void": {
+            "post": {
+                "description": "Void a draft or pending transaction, or a completed one on the day of sale. Any stock it took is put back, paid payments are refunded and pending ones cancelled. Completed sales from earlier days are refunded instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Void a transaction",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Transaction ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for voiding",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TransactionReasonRequestDto"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Store ID (defaults to the main store)",
+                        "name": "X-Store-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with the voided transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "missing reason or transaction can no longer be voided",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "transaction not found at the store",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "description": "Retrieve stock transfers between stores, optionally filtered by status or store. Use status=shipped to see goods in transit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get all stock transfers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by status (requested, shipped, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by source or destination store ID",
+                        "name": "store_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with stock transfers data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid parameter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Request goods to be moved from one store to another. Stock is not moved until the transfer is shipped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Request a stock transfer",
+                "parameters": [
+                    {
+                        "description": "Stock transfer data",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StockTransferCreateRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created stock transfer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "store or product not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "description": "Retrieve a single stock transfer by its ID with its items",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Get a stock transfer by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with stock transfer data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid stock transfer ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "stock transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/cancel": {
+            "post": {
+                "description": "Cancel a transfer that has not been shipped yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Cancel a stock transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with cancelled stock transfer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "transfer can no longer be cancelled",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "stock transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/receive": {
+            "post": {
+                "description": "Receive a shipped transfer at the destination store. Items not listed are received in full; a note is required when received quantities differ from shipped quantities. When fewer units of a serialized product arrive than were shipped, the item must list the serial numbers that arrived.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Receive a stock transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Received quantities",
+                        "name": "receipt",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StockTransferReceiveRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with received stock transfer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "stock transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}/ship": {
+            "post": {
+                "description": "Ship a requested transfer. The requested quantities are deducted from the source store and shown as in transit at the destination. Serialized products ship the units the source store received earliest.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Ship a stock transfer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Stock transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with shipped stock transfer",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request or insufficient stock",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "stock transfer not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers": {
+            "get": {
+                "description": "Retrieve all vouchers with their redemption counts, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Get all vouchers",
+                "responses": {
+                    "200": {
+                        "description": "success response with vouchers data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a voucher code taking a percent or amount off the cart, with an optional minimum spend, usage limits and validity dates",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Create a voucher",
+                "parameters": [
+                    {
+                        "description": "Voucher data",
+                        "name": "voucher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.VoucherRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "success response with created voucher",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}": {
+            "get": {
+                "description": "Retrieve a single voucher by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Get a voucher by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with voucher data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid voucher ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "voucher not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the settings of an existing voucher. The code of a redeemed voucher cannot be changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Update a voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Voucher data",
+                        "name": "voucher",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.VoucherRequestDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with updated voucher",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "voucher not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a voucher that has never been redeemed. Deactivate redeemed vouchers instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Delete a voucher",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid voucher ID or voucher already redeemed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "voucher not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/vouchers/{id}/redemptions": {
+            "get": {
+                "description": "Retrieve the transactions a voucher was redeemed on, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vouchers"
+                ],
+                "summary": "Get voucher redemptions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Voucher ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "success response with redemptions data",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "invalid voucher ID",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "voucher not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "internal server error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.BundleComponentRequestDto": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.BundleComponentsUpdateRequestDto": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BundleComponentRequestDto"
+                    }
+                }
+            }
+        },
+        "dtos.CartCheckoutRequestDto": {
+            "type": "object",
+            "properties": {
+                "draft": {
+                    "description": "Draft saves the transaction as a draft to be completed later",
+                    "type": "boolean",
+                    "example": false
+                },
+                "payments": {
+                    "description": "Payments pay the total as at checkout. Without payments the total is taken as paid in cash exactly.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PaymentDto"
+                    }
+                }
+            }
+        },
+        "dtos.CartCreateRequestDto": {
+            "type": "object",
+            "properties": {
+                "customer_ref": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "0812345678"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CheckoutItemDto"
+                    }
+                },
+                "terminal_id": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "POS-01"
+                }
+            }
+        },
+        "dtos.CartItemUpdateRequestDto": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "discount": {
+                    "$ref": "#/definitions/dtos.DiscountDto"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "serial_numbers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "dtos.CartParkRequestDto": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Customer fetching wallet"
+                }
+            }
+        },
+        "dtos.CartResumeRequestDto": {
+            "type": "object",
+            "properties": {
+                "terminal_id": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "POS-02"
+                }
+            }
+        },
+        "dtos.CartUpdateRequestDto": {
+            "type": "object",
+            "properties": {
+                "customer_ref": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "0812345678"
+                },
+                "discount": {
+                    "$ref": "#/definitions/dtos.DiscountDto"
+                },
+                "voucher_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HEMAT10"
+                    ]
+                }
+            }
+        },
+        "dtos.CategoryCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CategoryUpdateRequestDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "tax_rate_id": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.CheckoutItemDto": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "discount": {
+                    "description": "Discount is taken off this line",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.DiscountDto"
+                        }
+                    ]
+                },
+                "product_id": {
+                    "description": "ProductID or Barcode identifies the product; ProductID wins if both are set.\nA scanned scale label sets the quantity itself.",
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "serial_numbers": {
+                    "description": "SerialNumbers must list one serial per unit for serialized products",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit": {
+                    "description": "Unit the quantity is given in, such as g for a product sold by the kg; defaults to the product's unit",
+                    "type": "string",
+                    "example": "g"
+                }
+            }
+        },
+        "dtos.DiscountDto": {
+            "type": "object",
+            "required": [
+                "type",
+                "value"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Damaged packaging"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "amount"
+                    ],
+                    "example": "percent"
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "dtos.GoodsReceiptCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "quantity",
+                "unit_cost"
+            ],
+            "properties": {
+                "expiry_date": {
+                    "description": "ExpiryDate of the lot in YYYY-MM-DD format",
+                    "type": "string",
+                    "example": "2026-12-31"
+                },
+                "lot_number": {
+                    "description": "LotNumber is required for products that track lots and rejected otherwise",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "serial_numbers": {
+                    "description": "SerialNumbers must list one serial per unit for serialized products",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit": {
+                    "description": "Unit the quantity and unit cost are given in, such as a box; defaults to the product's unit",
+                    "type": "string",
+                    "example": "box"
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.LabelLayoutDto": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "gap_mm": {
+                    "type": "number",
+                    "maximum": 20,
+                    "minimum": 0
+                },
+                "label_height_mm": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 15
+                },
+                "label_width_mm": {
+                    "type": "number",
+                    "maximum": 150,
+                    "minimum": 25
+                },
+                "margin_mm": {
+                    "type": "number",
+                    "maximum": 30,
+                    "minimum": 0
+                },
+                "rows": {
+                    "type": "integer",
+                    "maximum": 20,
+                    "minimum": 1
+                }
+            }
+        },
+        "dtos.LabelRequestDto": {
+            "type": "object",
+            "required": [
+                "product_ids"
+            ],
+            "properties": {
+                "copies": {
+                    "description": "Copies of each label (defaults to 1)",
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1
+                },
+                "dpi": {
+                    "description": "DPI of png output (defaults to 300)",
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 72
+                },
+                "format": {
+                    "description": "Format of the output, png or pdf (defaults to pdf)",
+                    "type": "string",
+                    "enum": [
+                        "png",
+                        "pdf"
+                    ],
+                    "example": "pdf"
+                },
+                "layout": {
+                    "$ref": "#/definitions/dtos.LabelLayoutDto"
+                },
+                "page": {
+                    "description": "Page of the sheet to render for png output (defaults to 1)",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "product_ids": {
+                    "type": "array",
+                    "maxItems": 500,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "symbology": {
+                    "description": "Symbology of the barcode, code128 (SKU) or ean13 (first barcode); defaults to code128",
+                    "type": "string",
+                    "enum": [
+                        "code128",
+                        "ean13"
+                    ],
+                    "example": "code128"
+                }
+            }
+        },
+        "dtos.PaymentDto": {
+            "type": "object",
+            "required": [
+                "amount",
+                "method"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Amount is what the customer hands over; cash may exceed the amount due and gets change",
+                    "type": "integer",
+                    "example": 100000
+                },
+                "gateway": {
+                    "description": "Gateway charges the payment through the payment gateway instead of taking it at\nthe counter. The transaction stays pending, holding its stock, until the gateway\nconfirms the payment. Not available for cash and voucher payments.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "debit",
+                        "credit",
+                        "qris",
+                        "e_wallet",
+                        "voucher"
+                    ],
+                    "example": "cash"
+                },
+                "reference": {
+                    "description": "Reference identifies a non-cash payment, such as a card approval code or QRIS reference",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "APPR-482913"
+                }
+            }
+        },
+        "dtos.ProductCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "price",
+                "stock"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "cost": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "plu": {
+                    "type": "string",
+                    "maxLength": 12
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity_precision": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 0
+                },
+                "serialized": {
+                    "type": "boolean"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                },
+                "track_lots": {
+                    "type": "boolean"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "pcs",
+                        "kg",
+                        "g",
+                        "L",
+                        "ml"
+                    ]
+                },
+                "unit_conversions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.UnitConversionDto"
+                    }
+                }
+            }
+        },
+        "dtos.ProductOptionDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "values"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Size"
+                },
+                "values": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "S",
+                        "M",
+                        "L"
+                    ]
+                }
+            }
+        },
+        "dtos.ProductOptionsUpdateRequestDto": {
+            "type": "object",
+            "required": [
+                "options"
+            ],
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "maxItems": 3,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProductOptionDto"
+                    }
+                }
+            }
+        },
+        "dtos.ProductUpdateRequestDto": {
+            "type": "object",
+            "required": [
+                "name",
                 "price",
                 "stock"
             ],
             "properties": {
                 "active": {
-                    "type": "boolean"
+                    "type": "boolean"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "plu": {
+                    "type": "string",
+                    "maxLength": 12
+                },
+                "price": {
+                    "type": "number"
+                },
+                "quantity_precision": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 0
+                },
+                "serialized": {
+                    "type": "boolean"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "tax_rate_id": {
+                    "type": "integer"
+                },
+                "track_lots": {
+                    "type": "boolean"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "pcs",
+                        "kg",
+                        "g",
+                        "L",
+                        "ml"
+                    ]
+                },
+                "unit_conversions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.UnitConversionDto"
+                    }
+                }
+            }
+        },
+        "dtos.ProductVariantCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "option_values"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "barcodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cost": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "option_values": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "M",
+                        "Red"
+                    ]
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "stock": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "dtos.PromotionItemRequestDto": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "quantity": {
+                    "description": "Quantity is the number of units in one bundle; defaults to 1",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "dtos.PromotionRequestDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "bundle_price": {
+                    "type": "integer",
+                    "example": 25000
+                },
+                "buy_quantity": {
+                    "type": "integer",
+                    "example": 2
                 },
                 "category_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "days": {
+                    "description": "Days limits the promotion to days of the week, 0 (Sunday) to 6 (Saturday)",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-12-01T00:00:00+07:00"
+                },
+                "get_quantity": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PromotionItemRequestDto"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "Buy 2 get 1 noodles"
+                },
+                "percent": {
+                    "type": "number",
+                    "example": 15
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "stackable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "start_time": {
+                    "description": "StartTime and EndTime limit the promotion to hours of the day, such as a happy hour",
+                    "type": "string",
+                    "example": "15:00"
+                },
+                "starts_at": {
+                    "description": "StartsAt and EndsAt bound the dates the promotion runs; EndsAt is exclusive",
+                    "type": "string",
+                    "example": "2026-11-01T00:00:00+07:00"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PromotionTierRequestDto"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "buy_x_get_y",
+                        "quantity_tier",
+                        "category_percent",
+                        "bundle_price"
+                    ],
+                    "example": "buy_x_get_y"
+                }
+            }
+        },
+        "dtos.PromotionTierRequestDto": {
+            "type": "object",
+            "required": [
+                "min_quantity",
+                "unit_price"
+            ],
+            "properties": {
+                "min_quantity": {
+                    "type": "number",
+                    "example": 3
+                },
+                "unit_price": {
+                    "type": "integer",
+                    "example": 2500
+                }
+            }
+        },
+        "dtos.StockTransferCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "destination_store_id",
+                "items",
+                "source_store_id"
+            ],
+            "properties": {
+                "destination_store_id": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.StockTransferItemRequestDto"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "source_store_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.StockTransferItemRequestDto": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.StockTransferReceiveItemRequestDto": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
+                "product_id": {
                     "type": "integer"
                 },
+                "quantity_received": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "serial_numbers": {
+                    "description": "SerialNumbers lists the units of a serialized product that arrived; it is\nrequired when fewer arrived than were shipped",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dtos.StockTransferReceiveRequestDto": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.StockTransferReceiveItemRequestDto"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dtos.StoreCreateRequestDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "cash_rounding": {
+                    "description": "CashRounding rounds cash totals to the nearest multiple of this many rupiah",
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 100
+                },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 3
                 },
+                "service_charge_rate": {
+                    "description": "ServiceChargeRate is the percentage added to the cart as a service charge",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "service_charge_taxable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "tax_mode": {
+                    "description": "TaxMode says whether the store's prices include tax; defaults to inclusive",
+                    "type": "string",
+                    "enum": [
+                        "inclusive",
+                        "exclusive"
+                    ],
+                    "example": "inclusive"
+                }
+            }
+        },
+        "dtos.StoreStockUpdateRequestDto": {
+            "type": "object",
+            "required": [
+                "product_id"
+            ],
+            "properties": {
                 "price": {
                     "type": "number"
                 },
+                "product_id": {
+                    "type": "integer"
+                },
                 "stock": {
-                    "type": "integer",
+                    "type": "number",
                     "minimum": 0
                 }
             }
         },
-        "dtos.ProductUpdateRequestDto": {
+        "dtos.StoreUpdateRequestDto": {
             "type": "object",
             "required": [
-                "name",
-                "price",
-                "stock"
+                "name"
             ],
             "properties": {
-                "active": {
-                    "type": "boolean"
+                "address": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "category_id": {
-                    "type": "integer"
+                "cash_rounding": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 0,
+                    "example": 100
                 },
                 "name": {
                     "type": "string",
                     "maxLength": 100,
                     "minLength": 3
                 },
-                "price": {
-                    "type": "number"
+                "service_charge_rate": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
+                "service_charge_taxable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "tax_mode": {
+                    "type": "string",
+                    "enum": [
+                        "inclusive",
+                        "exclusive"
+                    ],
+                    "example": "inclusive"
+                }
+            }
+        },
+        "dtos.TaxRateRequestDto": {
+            "type": "object",
+            "required": [
+                "name",
+                "rate"
+            ],
+            "properties": {
+                "is_default": {
+                    "description": "IsDefault makes this the rate of products that get no rate from themselves or their category",
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "PPN"
+                },
+                "rate": {
+                    "description": "Rate is a percentage; 0 marks goods exempt from tax",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 11
+                }
+            }
+        },
+        "dtos.TransactionCompleteRequestDto": {
+            "type": "object",
+            "properties": {
+                "payments": {
+                    "description": "Payments pay the total as at checkout. Without payments the total is taken as paid in cash exactly.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PaymentDto"
+                    }
                 }
             }
         },
@@ -893,12 +5435,146 @@ const docTemplate = `{
                 "items"
             ],
             "properties": {
+                "customer_ref": {
+                    "description": "CustomerRef identifies the customer, such as a member number or phone number.\nIt is required for vouchers limited per customer.",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "0812345678"
+                },
+                "discount": {
+                    "description": "Discount is taken off the whole cart after promotions, line discounts and vouchers",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.DiscountDto"
+                        }
+                    ]
+                },
+                "draft": {
+                    "description": "Draft saves the priced transaction without payments to be completed later.\nIts stock is taken and its vouchers redeemed when it is completed.",
+                    "type": "boolean",
+                    "example": false
+                },
                 "items": {
                     "type": "array",
                     "minItems": 1,
                     "items": {
                         "$ref": "#/definitions/dtos.CheckoutItemDto"
                     }
+                },
+                "payments": {
+                    "description": "Payments pay the total, split over several tenders if needed. The total is rounded\nto the store's cash rounding when any of them is cash. Without payments the\ntotal is taken as paid in cash exactly.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PaymentDto"
+                    }
+                },
+                "voucher_codes": {
+                    "description": "VoucherCodes are redeemed in order against the cart after promotions and line discounts",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HEMAT10"
+                    ]
+                }
+            }
+        },
+        "dtos.TransactionReasonRequestDto": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Customer changed their mind"
+                }
+            }
+        },
+        "dtos.UnitConversionDto": {
+            "type": "object",
+            "required": [
+                "factor",
+                "unit"
+            ],
+            "properties": {
+                "factor": {
+                    "type": "number",
+                    "example": 24
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "box"
+                }
+            }
+        },
+        "dtos.VoucherRequestDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "type",
+                "value"
+            ],
+            "properties": {
+                "active": {
+                    "description": "Active defaults to true",
+                    "type": "boolean",
+                    "example": true
+                },
+                "code": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "HEMAT10"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "10% off for new members"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-12-01T00:00:00+07:00"
+                },
+                "max_discount": {
+                    "description": "MaxDiscount caps a percent voucher's discount; 0 means no cap",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 20000
+                },
+                "min_spend": {
+                    "description": "MinSpend is the cart amount, after promotions and line discounts, the voucher requires",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 50000
+                },
+                "per_customer_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2026-11-01T00:00:00+07:00"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "amount"
+                    ],
+                    "example": "percent"
+                },
+                "usage_limit": {
+                    "description": "UsageLimit and PerCustomerLimit limit redemptions overall and per customer; omit for no limit",
+                    "type": "integer",
+                    "example": 100
+                },
+                "value": {
+                    "type": "number",
+                    "example": 10
                 }
             }
         }
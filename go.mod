@@ -3,10 +3,13 @@ module github.com/gustionusamba24/kasir-api-go
 go 1.25.6
 
 require (
+	github.com/boombuler/barcode v1.1.0
 	github.com/joho/godotenv v1.5.1
+	github.com/jung-kurt/gofpdf v1.16.2
 	github.com/lib/pq v1.10.9
 	github.com/swaggo/http-swagger v1.3.4
 	github.com/swaggo/swag v1.16.6
+	golang.org/x/image v0.25.0
 )
 
 require (
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultCartExpiry is how long an open or parked cart is kept after its last change
+const DefaultCartExpiry = 2 * time.Hour
+
+// LoadCartExpiry reads from CART_EXPIRY_MINUTES how long an open or parked cart
+// is kept after its last change before it expires
+func LoadCartExpiry() (time.Duration, error) {
+	value := os.Getenv("CART_EXPIRY_MINUTES")
+	if value == "" {
+		return DefaultCartExpiry, nil
+	}
+
+	minutes, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("CART_EXPIRY_MINUTES must be a number: %w", err)
+	}
+	if minutes <= 0 {
+		return 0, fmt.Errorf("CART_EXPIRY_MINUTES must be greater than 0")
+	}
+
+	return time.Duration(minutes) * time.Minute, nil
+}
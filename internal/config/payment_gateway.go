@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/gateway"
+)
+
+// DefaultPaymentTimeout is how long a pending transaction waits for its gateway payments
+const DefaultPaymentTimeout = 15 * time.Minute
+
+// LoadPaymentProvider sets up the payment gateway named by PAYMENT_PROVIDER. It
+// returns nil when PAYMENT_PROVIDER is not set, in which case payments cannot be
+// charged through a gateway.
+func LoadPaymentProvider() (gateway.PaymentProvider, error) {
+	switch name := os.Getenv("PAYMENT_PROVIDER"); name {
+	case "":
+		return nil, nil
+	case "fake":
+		return gateway.NewFakeProvider(os.Getenv("PAYMENT_WEBHOOK_SECRET"))
+	default:
+		return nil, fmt.Errorf("unknown PAYMENT_PROVIDER %q", name)
+	}
+}
+
+// LoadPaymentTimeout reads from PAYMENT_TIMEOUT_MINUTES how long a pending
+// transaction waits for its gateway payments before it expires
+func LoadPaymentTimeout() (time.Duration, error) {
+	value := os.Getenv("PAYMENT_TIMEOUT_MINUTES")
+	if value == "" {
+		return DefaultPaymentTimeout, nil
+	}
+
+	minutes, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("PAYMENT_TIMEOUT_MINUTES must be a number: %w", err)
+	}
+	if minutes <= 0 {
+		return 0, fmt.Errorf("PAYMENT_TIMEOUT_MINUTES must be greater than 0")
+	}
+
+	return time.Duration(minutes) * time.Minute, nil
+}
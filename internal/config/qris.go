@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/qris"
+)
+
+// LoadQRISMerchant reads the merchant account QRIS payments are made to from
+// environment variables. It returns nil when QRIS_MERCHANT_PAN is not set, in
+// which case QRIS codes are not available.
+func LoadQRISMerchant() (*qris.Merchant, error) {
+	if os.Getenv("QRIS_MERCHANT_PAN") == "" {
+		return nil, nil
+	}
+
+	merchant := &qris.Merchant{
+		AcquirerDomain:     os.Getenv("QRIS_ACQUIRER_DOMAIN"),
+		PAN:                os.Getenv("QRIS_MERCHANT_PAN"),
+		AcquirerMerchantID: os.Getenv("QRIS_ACQUIRER_MERCHANT_ID"),
+		NMID:               os.Getenv("QRIS_NMID"),
+		Criteria:           getEnvDefault("QRIS_MERCHANT_CRITERIA", qris.CriteriaMicro),
+		CategoryCode:       getEnvDefault("QRIS_MERCHANT_CATEGORY_CODE", "5499"),
+		Name:               os.Getenv("QRIS_MERCHANT_NAME"),
+		City:               os.Getenv("QRIS_MERCHANT_CITY"),
+		PostalCode:         os.Getenv("QRIS_MERCHANT_POSTAL_CODE"),
+	}
+
+	if err := merchant.Validate(); err != nil {
+		return nil, err
+	}
+
+	return merchant, nil
+}
+
+// getEnvDefault returns the value of an environment variable, or the fallback when it is not set
+func getEnvDefault(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
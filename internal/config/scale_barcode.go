@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// LoadScaleBarcodeFormat reads the layout of scale printed barcodes from
+// environment variables, falling back to the defaults for any that are not set
+func LoadScaleBarcodeFormat() (entities.ScaleBarcodeFormat, error) {
+	format := entities.DefaultScaleBarcodeFormat()
+
+	if value, ok := os.LookupEnv("SCALE_BARCODE_WEIGHT_PREFIXES"); ok {
+		format.WeightPrefixes = splitList(value)
+	}
+	if value, ok := os.LookupEnv("SCALE_BARCODE_PRICE_PREFIXES"); ok {
+		format.PricePrefixes = splitList(value)
+	}
+
+	ints := []struct {
+		name   string
+		target *int
+	}{
+		{"SCALE_BARCODE_ITEM_DIGITS", &format.ItemDigits},
+		{"SCALE_BARCODE_VALUE_DIGITS", &format.ValueDigits},
+		{"SCALE_BARCODE_WEIGHT_DECIMALS", &format.WeightDecimals},
+		{"SCALE_BARCODE_PRICE_DECIMALS", &format.PriceDecimals},
+	}
+	for _, setting := range ints {
+		value := os.Getenv(setting.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return format, fmt.Errorf("%s must be a number: %w", setting.name, err)
+		}
+		*setting.target = parsed
+	}
+
+	if err := format.Validate(); err != nil {
+		return format, err
+	}
+
+	return format, nil
+}
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	// Embedded so the time zone loads on hosts without a time zone database
+	_ "time/tzdata"
+)
+
+// DefaultTimeZone is the time zone the stores are in when TIMEZONE is not set
+const DefaultTimeZone = "Asia/Jakarta"
+
+// LoadTimeZone reads from TIMEZONE the IANA time zone the stores are in. Promotion
+// days and hours are local times in it, whatever time zone the server runs in.
+func LoadTimeZone() (*time.Location, error) {
+	name := os.Getenv("TIMEZONE")
+	if name == "" {
+		name = DefaultTimeZone
+	}
+
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("TIMEZONE must be an IANA time zone such as %s: %w", DefaultTimeZone, err)
+	}
+
+	return location, nil
+}
@@ -0,0 +1,573 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type CartController struct {
+	service services.CartService
+}
+
+// NewCartController creates a new instance of CartController
+func NewCartController(service services.CartService) *CartController {
+	return &CartController{
+		service: service,
+	}
+}
+
+// GetAll godoc
+// @Summary      Get all carts
+// @Description  Retrieve the carts of a store, most recently changed first. Use status=parked to find baskets to resume. Lists leave out live totals; get a single cart for them.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        status      query   string  false  "Filter by status (open, parked, checked_out, expired)"
+// @Param        X-Store-ID  header  int     false  "Store ID (defaults to the main store)"
+// @Success      200         {object}  map[string]interface{}  "success response with carts data"
+// @Failure      400         {object}  map[string]interface{}  "invalid parameter"
+// @Failure      500         {object}  map[string]interface{}  "internal server error"
+// @Router       /carts [get]
+func (c *CartController) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	carts, err := c.service.GetAll(ctx, storeID, r.URL.Query().Get("status"))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    carts,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get a cart by ID
+// @Description  Retrieve a cart with its live totals, priced with current prices, promotions and stock without recording anything. pricing_error says why a cart cannot be checked out as it stands.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Cart ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with cart data"
+// @Failure      400  {object}  map[string]interface{}  "invalid cart ID"
+// @Failure      404  {object}  map[string]interface{}  "cart not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id} [get]
+func (c *CartController) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/carts/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	cart, err := c.service.GetByID(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+	})
+}
+
+// Create godoc
+// @Summary      Open a cart
+// @Description  Open a cart at a terminal of the caller's store, optionally with its first items. Nothing is taken from stock until the cart is checked out.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        cart        body    dtos.CartCreateRequestDto  false  "Cart data"
+// @Param        X-Store-ID  header  int                        false  "Store ID (defaults to the main store)"
+// @Success      201         {object}  map[string]interface{}  "success response with created cart"
+// @Failure      400         {object}  map[string]interface{}  "invalid request"
+// @Failure      404         {object}  map[string]interface{}  "store or product not found"
+// @Failure      500         {object}  map[string]interface{}  "internal server error"
+// @Router       /carts [post]
+func (c *CartController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// The body is optional: an empty body opens an empty cart
+	var dto dtos.CartCreateRequestDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+	defer r.Body.Close()
+
+	cart, err := c.service.Create(ctx, storeID, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart created successfully",
+	})
+}
+
+// Update godoc
+// @Summary      Update a cart
+// @Description  Replace the customer, voucher codes and cart discount of an open cart. The change is rejected if the cart could not be priced with it.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "Cart ID"
+// @Param        cart  body      dtos.CartUpdateRequestDto  true  "Cart data"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200   {object}  map[string]interface{}  "success response with updated cart"
+// @Failure      400   {object}  map[string]interface{}  "invalid request or cart not open"
+// @Failure      404   {object}  map[string]interface{}  "cart or voucher not found"
+// @Failure      500   {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id} [put]
+func (c *CartController) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/carts/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	var dto dtos.CartUpdateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	cart, err := c.service.Update(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a cart
+// @Description  Discard an open or parked cart
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Cart ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response"
+// @Failure      400  {object}  map[string]interface{}  "invalid cart ID or cart already checked out or expired"
+// @Failure      404  {object}  map[string]interface{}  "cart not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id} [delete]
+func (c *CartController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/carts/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	err = c.service.Delete(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Cart deleted successfully",
+	})
+}
+
+// AddItem godoc
+// @Summary      Add an item to a cart
+// @Description  Add a line to an open cart by product ID or scanned barcode, as at checkout. The line is rejected if the cart could not be priced with it, such as when stock is short.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                   true  "Cart ID"
+// @Param        item  body      dtos.CheckoutItemDto  true  "Cart item"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      201   {object}  map[string]interface{}  "success response with updated cart"
+// @Failure      400   {object}  map[string]interface{}  "invalid request or cart not open"
+// @Failure      404   {object}  map[string]interface{}  "cart or product not found"
+// @Failure      500   {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id}/items [post]
+func (c *CartController) AddItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/carts/", "/items")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	var dto dtos.CheckoutItemDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	cart, err := c.service.AddItem(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart item added successfully",
+	})
+}
+
+// UpdateItem godoc
+// @Summary      Update a cart item
+// @Description  Change the quantity, unit, serial numbers and discount of a line of an open cart. The change is rejected if the cart could not be priced with it.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                            true  "Cart ID"
+// @Param        itemId  path      int                            true  "Cart item ID"
+// @Param        item    body      dtos.CartItemUpdateRequestDto  true  "Cart item data"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200     {object}  map[string]interface{}  "success response with updated cart"
+// @Failure      400     {object}  map[string]interface{}  "invalid request or cart not open"
+// @Failure      404     {object}  map[string]interface{}  "cart or cart item not found"
+// @Failure      500     {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id}/items/{itemId} [put]
+func (c *CartController) UpdateItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract IDs from URL path
+	id, itemID, err := extractIDsFromSubPath(r, "/carts/", "/items/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart or cart item ID")
+		return
+	}
+
+	var dto dtos.CartItemUpdateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	cart, err := c.service.UpdateItem(ctx, storeID, id, itemID, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart item updated successfully",
+	})
+}
+
+// RemoveItem godoc
+// @Summary      Remove a cart item
+// @Description  Remove a line from an open cart
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int  true  "Cart ID"
+// @Param        itemId  path      int  true  "Cart item ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200     {object}  map[string]interface{}  "success response with updated cart"
+// @Failure      400     {object}  map[string]interface{}  "invalid request or cart not open"
+// @Failure      404     {object}  map[string]interface{}  "cart or cart item not found"
+// @Failure      500     {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id}/items/{itemId} [delete]
+func (c *CartController) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract IDs from URL path
+	id, itemID, err := extractIDsFromSubPath(r, "/carts/", "/items/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart or cart item ID")
+		return
+	}
+
+	cart, err := c.service.RemoveItem(ctx, storeID, id, itemID)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart item removed successfully",
+	})
+}
+
+// Park godoc
+// @Summary      Park a cart
+// @Description  Set an open cart aside so the terminal can serve the next customer. A parked cart cannot be changed until it is resumed.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                      true   "Cart ID"
+// @Param        park  body      dtos.CartParkRequestDto  false  "Note to find the cart by"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200   {object}  map[string]interface{}  "success response with parked cart"
+// @Failure      400   {object}  map[string]interface{}  "invalid request or cart not open"
+// @Failure      404   {object}  map[string]interface{}  "cart not found"
+// @Failure      500   {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id}/park [post]
+func (c *CartController) Park(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/carts/", "/park")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	// The body is optional: an empty body parks the cart without a note
+	var dto dtos.CartParkRequestDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+	defer r.Body.Close()
+
+	cart, err := c.service.Park(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart parked successfully",
+	})
+}
+
+// Resume godoc
+// @Summary      Resume a cart
+// @Description  Reopen a parked cart at any terminal of its store
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id          path    int                        true   "Cart ID"
+// @Param        resume      body    dtos.CartResumeRequestDto  false  "Terminal resuming the cart"
+// @Param        X-Store-ID  header  int                        false  "Store ID (defaults to the main store)"
+// @Success      200         {object}  map[string]interface{}  "success response with resumed cart"
+// @Failure      400         {object}  map[string]interface{}  "invalid request or cart not parked"
+// @Failure      404         {object}  map[string]interface{}  "cart not found"
+// @Failure      500         {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id}/resume [post]
+func (c *CartController) Resume(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/carts/", "/resume")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	// The body is optional: an empty body resumes the cart without a terminal
+	var dto dtos.CartResumeRequestDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+	defer r.Body.Close()
+
+	cart, err := c.service.Resume(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    cart,
+		"message": "Cart resumed successfully",
+	})
+}
+
+// Checkout godoc
+// @Summary      Check out a cart
+// @Description  Check out an open cart into a transaction, as POST /transactions/checkout would with the cart's items, vouchers and discounts. The cart stays open if the checkout fails.
+// @Tags         carts
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int                          true   "Cart ID"
+// @Param        checkout  body      dtos.CartCheckoutRequestDto  false  "Payments"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      201       {object}  map[string]interface{}  "success response with transaction data"
+// @Failure      400       {object}  map[string]interface{}  "invalid request or cart not open"
+// @Failure      404       {object}  map[string]interface{}  "cart or product not found"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /carts/{id}/checkout [post]
+func (c *CartController) Checkout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/carts/", "/checkout")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid cart ID")
+		return
+	}
+
+	// The body is optional: an empty body takes the total as paid in cash exactly
+	var dto dtos.CartCheckoutRequestDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+	defer r.Body.Close()
+
+	transaction, err := c.service.Checkout(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    transaction,
+		"message": "Transaction created successfully",
+	})
+}
@@ -3,6 +3,9 @@ package controllers
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
 	"github.com/gustionusamba24/kasir-api-go/internal/services"
@@ -21,17 +24,29 @@ func NewCategoryController(service services.CategoryService) *CategoryController
 
 // GetAll godoc
 // @Summary      Get all categories
-// @Description  Retrieve a list of all categories
+// @Description  Retrieve a list of all categories. Archived categories are left out unless include_archived is true. With with=stats each category carries its active product count, units in stock and the stock value at price and at cost for the caller's store, counting the products assigned directly to it.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Param        include_archived  query  bool  false  "Include archived categories"
+// @Param        with  query  string  false  "Set to stats to add product counts and stock value per category"
 // @Success      200  {object}  map[string]interface{}  "success response with categories data"
+// @Failure      400  {object}  map[string]interface{}  "invalid store ID"
 // @Failure      500  {object}  map[string]interface{}  "internal server error"
 // @Router       /categories [get]
 func (c *CategoryController) GetAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	categories, err := c.service.GetAll(ctx)
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	withStats := slices.Contains(strings.Split(r.URL.Query().Get("with"), ","), "stats")
+
+	categories, err := c.service.GetAll(ctx, storeID, includeArchived(r), withStats)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -43,6 +58,31 @@ func (c *CategoryController) GetAll(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetTree godoc
+// @Summary      Get the category tree
+// @Description  Retrieve all categories nested under their parents, top-level categories first. Archived categories are left out unless include_archived is true.
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        include_archived  query  bool  false  "Include archived categories"
+// @Success      200  {object}  map[string]interface{}  "success response with the category tree"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /categories/tree [get]
+func (c *CategoryController) GetTree(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tree, err := c.service.GetTree(ctx, includeArchived(r))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    tree,
+	})
+}
+
 // GetByID godoc
 // @Summary      Get a category by ID
 // @Description  Retrieve a single category by its ID
@@ -83,13 +123,14 @@ func (c *CategoryController) GetByID(w http.ResponseWriter, r *http.Request) {
 
 // Create godoc
 // @Summary      Create a new category
-// @Description  Create a new category with the provided data
+// @Description  Create a new category with the provided data, optionally nested under a parent category
 // @Tags         categories
 // @Accept       json
 // @Produce      json
 // @Param        category  body      dtos.CategoryCreateRequestDto  true  "Category data"
 // @Success      201       {object}  map[string]interface{}  "success response with created category"
-// @Failure      400       {object}  map[string]interface{}  "invalid request payload"
+// @Failure      400       {object}  map[string]interface{}  "invalid request payload or parent category"
+// @Failure      404       {object}  map[string]interface{}  "parent category not found"
 // @Failure      500       {object}  map[string]interface{}  "internal server error"
 // @Router       /categories [post]
 func (c *CategoryController) Create(w http.ResponseWriter, r *http.Request) {
@@ -106,7 +147,11 @@ func (c *CategoryController) Create(w http.ResponseWriter, r *http.Request) {
 
 	category, err := c.service.Create(ctx, &dto)
 	if err != nil {
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -119,7 +164,7 @@ func (c *CategoryController) Create(w http.ResponseWriter, r *http.Request) {
 
 // Update godoc
 // @Summary      Update a category
-// @Description  Update an existing category by its ID
+// @Description  Update an existing category by its ID. parent_id moves it under another category and 0 makes it top-level; a category cannot be moved under one of its own subcategories.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
@@ -155,7 +200,7 @@ func (c *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
 			respondWithError(w, http.StatusNotFound, err.Error())
 			return
 		}
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -168,15 +213,17 @@ func (c *CategoryController) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete godoc
 // @Summary      Delete a category
-// @Description  Delete a category by its ID
+// @Description  Archive a category by its ID. A category that still has products is only deleted when reassign_to names the category they move to, or orphan=true leaves them uncategorized. Products are moved and the category archived in one transaction.
 // @Tags         categories
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Category ID"
-// @Success      200  {object}  map[string]interface{}  "success response"
-// @Failure      400  {object}  map[string]interface{}  "invalid category ID"
-// @Failure      404  {object}  map[string]interface{}  "category not found"
-// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Param        id           path      int   true   "Category ID"
+// @Param        reassign_to  query     int   false  "Category to move the products to"
+// @Param        orphan       query     bool  false  "Leave the products uncategorized"
+// @Success      200          {object}  map[string]interface{}  "success response with the number of products moved"
+// @Failure      400          {object}  map[string]interface{}  "invalid category ID, category still has products or already archived"
+// @Failure      404          {object}  map[string]interface{}  "category not found"
+// @Failure      500          {object}  map[string]interface{}  "internal server error"
 // @Router       /categories/{id} [delete]
 func (c *CategoryController) Delete(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -188,18 +235,67 @@ func (c *CategoryController) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = c.service.Delete(ctx, id)
+	var reassignTo *int
+	if reassignToStr := r.URL.Query().Get("reassign_to"); reassignToStr != "" {
+		reassignToID, err := strconv.Atoi(reassignToStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid reassign_to category ID")
+			return
+		}
+		reassignTo = &reassignToID
+	}
+	orphan := r.URL.Query().Get("orphan") == "true"
+
+	result, err := c.service.Delete(ctx, id, reassignTo, orphan)
 	if err != nil {
 		if isNotFoundError(err) {
 			respondWithError(w, http.StatusNotFound, err.Error())
 			return
 		}
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	respondWithJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
+		"data":    result,
 		"message": "Category deleted successfully",
 	})
 }
+
+// Restore godoc
+// @Summary      Restore an archived category
+// @Description  Bring back an archived category
+// @Tags         categories
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Category ID"
+// @Success      200  {object}  map[string]interface{}  "success response with restored category"
+// @Failure      400  {object}  map[string]interface{}  "invalid category ID or category not archived"
+// @Failure      404  {object}  map[string]interface{}  "category not found"
+// @Router       /categories/{id}/restore [post]
+func (c *CategoryController) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := extractIDFromSubPath(r, "/categories/", "/restore")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	category, err := c.service.Restore(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    category,
+		"message": "Category restored successfully",
+	})
+}
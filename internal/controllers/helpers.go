@@ -2,11 +2,17 @@ package controllers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
 )
 
+// storeIDHeader is the request header that identifies the caller's store
+const storeIDHeader = "X-Store-ID"
+
 // respondWithJSON writes a JSON response with the given status code
 func respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -45,6 +51,66 @@ func extractIDFromPath(r *http.Request, prefix string) (int, error) {
 	return id, nil
 }
 
+// extractIDFromSubPath extracts the ID from a nested resource URL path
+// Example: /products/123/receipts -> 123
+func extractIDFromSubPath(r *http.Request, prefix, suffix string) (int, error) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	idStr := strings.TrimPrefix(path, prefix)
+	idStr = strings.TrimSuffix(idStr, suffix)
+
+	// Parse the ID
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// extractIDsFromSubPath extracts the parent and child IDs from a nested resource URL path
+// Example: /carts/123/items/45 -> 123, 45
+func extractIDsFromSubPath(r *http.Request, prefix, segment string) (int, int, error) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	parentStr, childStr, found := strings.Cut(strings.TrimPrefix(path, prefix), segment)
+	if !found {
+		return 0, 0, fmt.Errorf("path %s has no %s segment", path, segment)
+	}
+
+	// Parse the IDs
+	parentID, err := strconv.Atoi(parentStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	childID, err := strconv.Atoi(childStr)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return parentID, childID, nil
+}
+
+// includeArchived reports whether the request asks for archived rows with ?include_archived=true
+func includeArchived(r *http.Request) bool {
+	return r.URL.Query().Get("include_archived") == "true"
+}
+
+// extractStoreID extracts the caller's store ID from the X-Store-ID header,
+// falling back to the main store when the header is absent
+func extractStoreID(r *http.Request) (int, error) {
+	storeIDStr := strings.TrimSpace(r.Header.Get(storeIDHeader))
+	if storeIDStr == "" {
+		return entities.DefaultStoreID, nil
+	}
+
+	storeID, err := strconv.Atoi(storeIDStr)
+	if err != nil {
+		return 0, err
+	}
+
+	return storeID, nil
+}
+
 // isNotFoundError checks if the error message indicates a not found error
 func isNotFoundError(err error) bool {
 	if err == nil {
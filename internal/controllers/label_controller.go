@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type LabelController struct {
+	service services.LabelService
+}
+
+// NewLabelController creates a new instance of LabelController
+func NewLabelController(service services.LabelService) *LabelController {
+	return &LabelController{
+		service: service,
+	}
+}
+
+// Generate godoc
+// @Summary      Generate shelf labels
+// @Description  Render shelf labels / barcode stickers with product name, formatted store price and a Code128 (SKU) or EAN-13 barcode. PDF output contains every sheet; PNG output renders one sheet, selected with page, and reports the sheet count in the X-Total-Pages header.
+// @Tags         labels
+// @Accept       json
+// @Produce      application/pdf
+// @Produce      png
+// @Param        request     body    dtos.LabelRequestDto  true   "Products, output format and sheet layout"
+// @Param        X-Store-ID  header  int                   false  "Store ID whose prices are printed (defaults to the main store)"
+// @Success      200  {file}    file                    "rendered labels"
+// @Failure      400  {object}  map[string]interface{}  "invalid request or product without a printable code"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Router       /labels [post]
+func (c *LabelController) Generate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.LabelRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	file, err := c.service.Generate(ctx, storeID, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", file.FileName))
+	w.Header().Set("X-Total-Pages", strconv.Itoa(file.Pages))
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Data)
+}
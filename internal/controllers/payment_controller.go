@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/gateway"
+	"github.com/gustionusamba24/kasir-api-go/internal/qris"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type PaymentController struct {
+	service services.PaymentService
+}
+
+// NewPaymentController creates a new instance of PaymentController
+func NewPaymentController(service services.PaymentService) *PaymentController {
+	return &PaymentController{
+		service: service,
+	}
+}
+
+// GetQRIS godoc
+// @Summary      Get the QRIS code of a transaction
+// @Description  Build a dynamic QRIS code asking for the exact amount of the pending QRIS payment of a pending transaction, with the transaction as bill number. Returns the QRIS payload as JSON, or with format=png the QR code to show the customer.
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Produce      png
+// @Param        id      path   int     true   "Transaction ID"
+// @Param        format  query  string  false  "json (default) or png"
+// @Param        size    query  int     false  "Width and height of the png in pixels, 128 to 2048 (defaults to 512)"
+// @Success      200  {object}  map[string]interface{}  "success response with the QRIS payload, or the png image"
+// @Failure      400  {object}  map[string]interface{}  "invalid ID, transaction not pending or without a pending QRIS payment, or QRIS not configured"
+// @Failure      404  {object}  map[string]interface{}  "transaction not found"
+// @Router       /transactions/{id}/qris [get]
+func (c *PaymentController) GetQRIS(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/transactions/", "/qris")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		code, err := c.service.GetQRIS(ctx, id)
+		if err != nil {
+			if isNotFoundError(err) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		respondWithJSON(w, http.StatusOK, map[string]interface{}{
+			"success": true,
+			"data":    code,
+		})
+	case "png":
+		size := qris.DefaultSize
+		if value := r.URL.Query().Get("size"); value != "" {
+			size, err = strconv.Atoi(value)
+			if err != nil {
+				respondWithError(w, http.StatusBadRequest, "Invalid size")
+				return
+			}
+		}
+
+		image, err := c.service.RenderQRIS(ctx, id, size)
+		if err != nil {
+			if isNotFoundError(err) {
+				respondWithError(w, http.StatusNotFound, err.Error())
+				return
+			}
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", fmt.Sprintf("qris-%d.png", id)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(image)
+	default:
+		respondWithError(w, http.StatusBadRequest, "format must be json or png")
+	}
+}
+
+// maxWebhookBody limits the size of webhook bodies read from payment gateways
+const maxWebhookBody = 1 << 20
+
+// Webhook godoc
+// @Summary      Receive a payment gateway webhook
+// @Description  Called by the payment gateway to report whether a payment charged through it was paid or failed. The request must carry the gateway's signature. A paid payment completes its pending transaction once none of its payments is pending; a failed payment fails the transaction and puts its stock back. Repeated deliveries of the same outcome are ignored. A paid webhook whose amount differs from the payment's is rejected and the payment stays pending. With the fake provider the body is {"provider_ref", "status", "amount"} signed with HMAC-SHA256 of the body in the X-Fake-Signature header.
+// @Tags         payments
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}  "success response with the payment's transaction"
+// @Failure      400  {object}  map[string]interface{}  "invalid body, amount not matching the payment or no payment gateway configured"
+// @Failure      401  {object}  map[string]interface{}  "invalid signature"
+// @Failure      404  {object}  map[string]interface{}  "payment not found"
+// @Router       /payments/webhook [post]
+func (c *PaymentController) Webhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	transaction, err := c.service.HandleWebhook(ctx, r.Header, body)
+	if err != nil {
+		if errors.Is(err, gateway.ErrInvalidSignature) {
+			respondWithError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Payment updated successfully",
+		"data":    transaction,
+	})
+}
@@ -22,13 +22,16 @@ func NewProductController(service services.ProductService) *ProductController {
 
 // GetAll godoc
 // @Summary      Get all products
-// @Description  Retrieve a list of all products, optionally filtered by category ID, name, or active status
+// @Description  Retrieve a list of all products, optionally filtered by category ID, name, or active status. Archived products are left out unless include_archived is true.
 // @Tags         products
 // @Accept       json
 // @Produce      json
+// @Param        X-Store-ID   header    int     false  "Store ID (defaults to the main store)"
 // @Param        category_id  query     int     false  "Filter by Category ID"
 // @Param        name         query     string  false  "Search by product name (case-insensitive partial match)"
 // @Param        active       query     bool    false  "Filter by active status"
+// @Param        include_descendants  query  bool  false  "With category_id, also list products of its subcategories"
+// @Param        include_archived  query  bool  false  "Include archived products"
 // @Success      200          {object}  map[string]interface{}  "success response with products data"
 // @Failure      400          {object}  map[string]interface{}  "invalid parameter"
 // @Failure      404          {object}  map[string]interface{}  "category not found"
@@ -37,10 +40,18 @@ func NewProductController(service services.ProductService) *ProductController {
 func (c *ProductController) GetAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
 	// Get query parameters
 	categoryIDStr := r.URL.Query().Get("category_id")
 	nameQuery := r.URL.Query().Get("name")
 	activeStr := r.URL.Query().Get("active")
+	includeDescendants := r.URL.Query().Get("include_descendants") == "true"
+	archived := includeArchived(r)
 
 	// Check if searching by name or active status
 	if nameQuery != "" || activeStr != "" {
@@ -50,7 +61,7 @@ func (c *ProductController) GetAll(w http.ResponseWriter, r *http.Request) {
 			activePtr = &activeBool
 		}
 
-		products, err := c.service.Search(ctx, nameQuery, activePtr)
+		products, err := c.service.Search(ctx, storeID, nameQuery, activePtr, archived)
 		if err != nil {
 			respondWithError(w, http.StatusInternalServerError, err.Error())
 			return
@@ -71,7 +82,7 @@ func (c *ProductController) GetAll(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		products, err := c.service.GetByCategoryID(ctx, categoryID)
+		products, err := c.service.GetByCategoryID(ctx, storeID, categoryID, includeDescendants, archived)
 		if err != nil {
 			if isNotFoundError(err) {
 				respondWithError(w, http.StatusNotFound, err.Error())
@@ -89,7 +100,7 @@ func (c *ProductController) GetAll(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get all products
-	products, err := c.service.GetAll(ctx)
+	products, err := c.service.GetAll(ctx, storeID, archived)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -108,6 +119,7 @@ func (c *ProductController) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Product ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
 // @Success      200  {object}  map[string]interface{}  "success response with product data"
 // @Failure      400  {object}  map[string]interface{}  "invalid product ID"
 // @Failure      404  {object}  map[string]interface{}  "product not found"
@@ -123,7 +135,13 @@ func (c *ProductController) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	product, err := c.service.GetByID(ctx, id)
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	product, err := c.service.GetByID(ctx, storeID, id)
 	if err != nil {
 		if isNotFoundError(err) {
 			respondWithError(w, http.StatusNotFound, err.Error())
@@ -145,6 +163,7 @@ func (c *ProductController) GetByID(w http.ResponseWriter, r *http.Request) {
 // @Tags         products
 // @Accept       json
 // @Produce      json
+// @Param        X-Store-ID  header  int  false  "Store ID holding the initial stock (defaults to the main store)"
 // @Param        product  body      dtos.ProductCreateRequestDto  true  "Product data"
 // @Success      201      {object}  map[string]interface{}  "success response with created product"
 // @Failure      400      {object}  map[string]interface{}  "invalid request payload"
@@ -154,6 +173,12 @@ func (c *ProductController) GetByID(w http.ResponseWriter, r *http.Request) {
 func (c *ProductController) Create(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
 	var dto dtos.ProductCreateRequestDto
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
 		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
@@ -163,7 +188,7 @@ func (c *ProductController) Create(w http.ResponseWriter, r *http.Request) {
 
 	// TODO: Add validation here using validator library
 
-	product, err := c.service.Create(ctx, &dto)
+	product, err := c.service.Create(ctx, storeID, &dto)
 	if err != nil {
 		if isNotFoundError(err) {
 			respondWithError(w, http.StatusNotFound, err.Error())
@@ -188,6 +213,7 @@ func (c *ProductController) Create(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id       path      int  true  "Product ID"
 // @Param        product  body      dtos.ProductUpdateRequestDto  true  "Product data"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
 // @Success      200      {object}  map[string]interface{}  "success response with updated product"
 // @Failure      400      {object}  map[string]interface{}  "invalid request"
 // @Failure      404      {object}  map[string]interface{}  "product not found"
@@ -203,6 +229,12 @@ func (c *ProductController) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
 	var dto dtos.ProductUpdateRequestDto
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
 		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
@@ -212,7 +244,7 @@ func (c *ProductController) Update(w http.ResponseWriter, r *http.Request) {
 
 	// TODO: Add validation here using validator library
 
-	product, err := c.service.Update(ctx, id, &dto)
+	product, err := c.service.Update(ctx, storeID, id, &dto)
 	if err != nil {
 		if isNotFoundError(err) {
 			respondWithError(w, http.StatusNotFound, err.Error())
@@ -231,13 +263,13 @@ func (c *ProductController) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete godoc
 // @Summary      Delete a product
-// @Description  Delete a product by its ID
+// @Description  Archive a product by its ID. Archived products are hidden from listings and cannot be sold, but stay in transaction history and can be restored. Variants are archived with their parent.
 // @Tags         products
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Product ID"
 // @Success      200  {object}  map[string]interface{}  "success response"
-// @Failure      400  {object}  map[string]interface{}  "invalid product ID"
+// @Failure      400  {object}  map[string]interface{}  "invalid product ID or product already archived"
 // @Failure      404  {object}  map[string]interface{}  "product not found"
 // @Failure      500  {object}  map[string]interface{}  "internal server error"
 // @Router       /products/{id} [delete]
@@ -257,7 +289,7 @@ func (c *ProductController) Delete(w http.ResponseWriter, r *http.Request) {
 			respondWithError(w, http.StatusNotFound, err.Error())
 			return
 		}
-		respondWithError(w, http.StatusInternalServerError, err.Error())
+		respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
@@ -266,3 +298,523 @@ func (c *ProductController) Delete(w http.ResponseWriter, r *http.Request) {
 		"message": "Product deleted successfully",
 	})
 }
+
+// Restore godoc
+// @Summary      Restore an archived product
+// @Description  Bring back an archived product, together with the variants archived with it. A variant can only be restored once its parent is.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        X-Store-ID  header    int  false  "Store ID (defaults to the main store)"
+// @Param        id          path      int  true   "Product ID"
+// @Success      200         {object}  map[string]interface{}  "success response with restored product"
+// @Failure      400         {object}  map[string]interface{}  "invalid product ID or product not archived"
+// @Failure      404         {object}  map[string]interface{}  "product not found"
+// @Router       /products/{id}/restore [post]
+func (c *ProductController) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	id, err := extractIDFromSubPath(r, "/products/", "/restore")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	product, err := c.service.Restore(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    product,
+		"message": "Product restored successfully",
+	})
+}
+
+// ReceiveGoods godoc
+// @Summary      Receive goods for a product
+// @Description  Record incoming stock for a product. The received quantity is added to stock and the product cost is recalculated as a weighted average.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Product ID"
+// @Param        receipt  body      dtos.GoodsReceiptCreateRequestDto  true  "Goods receipt data"
+// @Param        X-Store-ID  header  int  false  "Store ID receiving the goods (defaults to the main store)"
+// @Success      201      {object}  map[string]interface{}  "success response with goods receipt"
+// @Failure      400      {object}  map[string]interface{}  "invalid request"
+// @Failure      404      {object}  map[string]interface{}  "product not found"
+// @Failure      500      {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/receipts [post]
+func (c *ProductController) ReceiveGoods(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/receipts")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.GoodsReceiptCreateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	receipt, err := c.service.ReceiveGoods(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    receipt,
+		"message": "Goods received successfully",
+	})
+}
+
+// GetReceipts godoc
+// @Summary      Get goods receipts of a product
+// @Description  Retrieve the goods receipt history of a product, newest first
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Success      200  {object}  map[string]interface{}  "success response with goods receipts"
+// @Failure      400  {object}  map[string]interface{}  "invalid product ID"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/receipts [get]
+func (c *ProductController) GetReceipts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/receipts")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	receipts, err := c.service.GetReceipts(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    receipts,
+	})
+}
+
+// GetLots godoc
+// @Summary      Get lots of a product
+// @Description  Retrieve the lots of a product held by a store that still have stock, earliest expiry first
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with product lots"
+// @Failure      400  {object}  map[string]interface{}  "invalid product ID"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/lots [get]
+func (c *ProductController) GetLots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/lots")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	lots, err := c.service.GetLots(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    lots,
+	})
+}
+
+// GetSerials godoc
+// @Summary      Get serial numbers of a product
+// @Description  Retrieve the unsold serial numbers of a serialized product held by a store, oldest first
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Product ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with serial numbers in stock"
+// @Failure      400  {object}  map[string]interface{}  "invalid product ID"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/serials [get]
+func (c *ProductController) GetSerials(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/serials")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	serials, err := c.service.GetSerials(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    serials,
+	})
+}
+
+// SetComponents godoc
+// @Summary      Set the components of a bundle
+// @Description  Replace the component products and quantities that make up one unit of a bundle. Selling the bundle takes its components out of stock and its stock is derived from theirs. A product only becomes a bundle while no store holds or awaits stock of it. An empty list makes it a regular product again.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Product ID"
+// @Param        components  body      dtos.BundleComponentsUpdateRequestDto  true  "Bundle components"
+// @Param        X-Store-ID  header    int  false  "Store ID (defaults to the main store)"
+// @Success      200         {object}  map[string]interface{}  "success response with the bundle and its components"
+// @Failure      400         {object}  map[string]interface{}  "invalid request"
+// @Failure      404         {object}  map[string]interface{}  "product or component not found"
+// @Failure      500         {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/components [put]
+func (c *ProductController) SetComponents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/components")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.BundleComponentsUpdateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	product, err := c.service.SetComponents(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    product,
+		"message": "Bundle components updated successfully",
+	})
+}
+
+// GetComponents godoc
+// @Summary      Get components of a bundle
+// @Description  Retrieve the component products and quantities of a bundle with their stock at the caller's store
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id          path    int  true   "Product ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with components"
+// @Failure      400  {object}  map[string]interface{}  "invalid product ID"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/components [get]
+func (c *ProductController) GetComponents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/components")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	components, err := c.service.GetComponents(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    components,
+	})
+}
+
+// SetOptions godoc
+// @Summary      Set the options of a product
+// @Description  Replace the option axes, such as Size and Color, of a parent product. Up to 3 axes are allowed and existing variants must still match them.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Product ID"
+// @Param        options  body      dtos.ProductOptionsUpdateRequestDto  true  "Option axes"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200      {object}  map[string]interface{}  "success response with product and its variant matrix"
+// @Failure      400      {object}  map[string]interface{}  "invalid request"
+// @Failure      404      {object}  map[string]interface{}  "product not found"
+// @Failure      500      {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/options [put]
+func (c *ProductController) SetOptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/options")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.ProductOptionsUpdateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	product, err := c.service.SetOptions(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    product,
+		"message": "Product options updated successfully",
+	})
+}
+
+// GetVariants godoc
+// @Summary      Get variants of a product
+// @Description  Retrieve the variants of a parent product with the price and stock of the caller's store
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id          path    int  true   "Product ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with variants"
+// @Failure      400  {object}  map[string]interface{}  "invalid product ID"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/variants [get]
+func (c *ProductController) GetVariants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/variants")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	variants, err := c.service.GetVariants(ctx, storeID, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    variants,
+	})
+}
+
+// CreateVariant godoc
+// @Summary      Create a variant of a product
+// @Description  Create a variant for one combination of the parent's option values. The variant has its own price, stock, SKU and barcodes and is what checkout sells.
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Parent product ID"
+// @Param        variant  body      dtos.ProductVariantCreateRequestDto  true  "Variant data"
+// @Param        X-Store-ID  header  int  false  "Store ID holding the initial stock (defaults to the main store)"
+// @Success      201      {object}  map[string]interface{}  "success response with created variant"
+// @Failure      400      {object}  map[string]interface{}  "invalid request"
+// @Failure      404      {object}  map[string]interface{}  "product not found"
+// @Failure      500      {object}  map[string]interface{}  "internal server error"
+// @Router       /products/{id}/variants [post]
+func (c *ProductController) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/products/", "/variants")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.ProductVariantCreateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	variant, err := c.service.CreateVariant(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    variant,
+		"message": "Variant created successfully",
+	})
+}
+
+// Lookup godoc
+// @Summary      Look up a product by barcode or SKU
+// @Description  Find the product a scanned barcode (EAN-13 or UPC-A) or SKU belongs to, with the stock and price of the caller's store
+// @Tags         products
+// @Accept       json
+// @Produce      json
+// @Param        barcode     query   string  false  "Scanned barcode"
+// @Param        sku         query   string  false  "Product SKU, used when no barcode is given"
+// @Param        X-Store-ID  header  int     false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with product data"
+// @Failure      400  {object}  map[string]interface{}  "missing or invalid barcode"
+// @Failure      404  {object}  map[string]interface{}  "product not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /products/lookup [get]
+func (c *ProductController) Lookup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	barcode := r.URL.Query().Get("barcode")
+	sku := r.URL.Query().Get("sku")
+
+	product, err := c.service.Lookup(ctx, storeID, barcode, sku)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    product,
+	})
+}
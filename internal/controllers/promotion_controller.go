@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type PromotionController struct {
+	service services.PromotionService
+}
+
+// NewPromotionController creates a new instance of PromotionController
+func NewPromotionController(service services.PromotionService) *PromotionController {
+	return &PromotionController{
+		service: service,
+	}
+}
+
+// GetAll godoc
+// @Summary      Get all promotions
+// @Description  Retrieve all promotions, highest priority first. Use running=true to see only the promotions checkout applies right now.
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        running  query     bool    false  "Only promotions running now"
+// @Success      200      {object}  map[string]interface{}  "success response with promotions data"
+// @Failure      500      {object}  map[string]interface{}  "internal server error"
+// @Router       /promotions [get]
+func (c *PromotionController) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	promotions, err := c.service.GetAll(ctx, r.URL.Query().Get("running") == "true")
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    promotions,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get a promotion by ID
+// @Description  Retrieve a single promotion by its ID with its products and tiers
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  map[string]interface{}  "success response with promotion data"
+// @Failure      400  {object}  map[string]interface{}  "invalid promotion ID"
+// @Failure      404  {object}  map[string]interface{}  "promotion not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /promotions/{id} [get]
+func (c *PromotionController) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/promotions/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid promotion ID")
+		return
+	}
+
+	promotion, err := c.service.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    promotion,
+	})
+}
+
+// Create godoc
+// @Summary      Create a promotion
+// @Description  Create a promotion applied automatically at checkout: buy_x_get_y, quantity_tier, category_percent or bundle_price, optionally limited to dates, days of the week and hours of the day
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        promotion  body      dtos.PromotionRequestDto  true  "Promotion data"
+// @Success      201        {object}  map[string]interface{}  "success response with created promotion"
+// @Failure      400        {object}  map[string]interface{}  "invalid request"
+// @Failure      404        {object}  map[string]interface{}  "product or category not found"
+// @Failure      500        {object}  map[string]interface{}  "internal server error"
+// @Router       /promotions [post]
+func (c *PromotionController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto dtos.PromotionRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	promotion, err := c.service.Create(ctx, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    promotion,
+		"message": "Promotion created successfully",
+	})
+}
+
+// Update godoc
+// @Summary      Update a promotion
+// @Description  Replace the settings, products and tiers of an existing promotion
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Promotion ID"
+// @Param        promotion  body      dtos.PromotionRequestDto  true  "Promotion data"
+// @Success      200        {object}  map[string]interface{}  "success response with updated promotion"
+// @Failure      400        {object}  map[string]interface{}  "invalid request"
+// @Failure      404        {object}  map[string]interface{}  "promotion, product or category not found"
+// @Failure      500        {object}  map[string]interface{}  "internal server error"
+// @Router       /promotions/{id} [put]
+func (c *PromotionController) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/promotions/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid promotion ID")
+		return
+	}
+
+	var dto dtos.PromotionRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	promotion, err := c.service.Update(ctx, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    promotion,
+		"message": "Promotion updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a promotion
+// @Description  Delete a promotion by its ID. Past transactions keep the discounts it gave.
+// @Tags         promotions
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Promotion ID"
+// @Success      200  {object}  map[string]interface{}  "success response"
+// @Failure      400  {object}  map[string]interface{}  "invalid promotion ID"
+// @Failure      404  {object}  map[string]interface{}  "promotion not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /promotions/{id} [delete]
+func (c *PromotionController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/promotions/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid promotion ID")
+		return
+	}
+
+	err = c.service.Delete(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Promotion deleted successfully",
+	})
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/services"
 )
@@ -19,17 +20,25 @@ func NewReportController(service services.ReportService) *ReportController {
 
 // GetTodayReport godoc
 // @Summary      Get today's transaction report
-// @Description  Retrieve today's transaction report including total revenue, transaction count, and best selling product
+// @Description  Retrieve today's transaction report including total revenue, transaction count, best selling product and the components of the total
 // @Tags         reports
 // @Accept       json
 // @Produce      json
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Param        group_by    query   string  false  "Set to category to add sales per top-level category"
 // @Success      200  {object}  map[string]interface{}  "success response with today's report data"
 // @Failure      500  {object}  map[string]interface{}  "internal server error"
 // @Router       /report/today [get]
 func (c *ReportController) GetTodayReport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	report, err := c.service.GetTodayReport(ctx)
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	report, err := c.service.GetTodayReport(ctx, storeID, groupByCategory(r))
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -43,12 +52,14 @@ func (c *ReportController) GetTodayReport(w http.ResponseWriter, r *http.Request
 
 // GetDateRangeReport godoc
 // @Summary      Get transaction report for date range
-// @Description  Retrieve transaction report for a given date range including total revenue, transaction count, and best selling product
+// @Description  Retrieve transaction report for a given date range including total revenue, transaction count, best selling product and the components of the total
 // @Tags         reports
 // @Accept       json
 // @Produce      json
 // @Param        start_date  query  string  true  "Start date in YYYY-MM-DD format"
 // @Param        end_date    query  string  true  "End date in YYYY-MM-DD format"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Param        group_by    query   string  false  "Set to category to add sales per top-level category"
 // @Success      200  {object}  map[string]interface{}  "success response with date range report data"
 // @Failure      400  {object}  map[string]interface{}  "bad request - missing or invalid parameters"
 // @Failure      500  {object}  map[string]interface{}  "internal server error"
@@ -56,6 +67,12 @@ func (c *ReportController) GetTodayReport(w http.ResponseWriter, r *http.Request
 func (c *ReportController) GetDateRangeReport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
 	// Get query parameters
 	startDate := r.URL.Query().Get("start_date")
 	endDate := r.URL.Query().Get("end_date")
@@ -66,7 +83,7 @@ func (c *ReportController) GetDateRangeReport(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	report, err := c.service.GetDateRangeReport(ctx, startDate, endDate)
+	report, err := c.service.GetDateRangeReport(ctx, storeID, startDate, endDate, groupByCategory(r))
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, err.Error())
 		return
@@ -77,3 +94,138 @@ func (c *ReportController) GetDateRangeReport(w http.ResponseWriter, r *http.Req
 		"data":    report,
 	})
 }
+
+// GetTaxReport godoc
+// @Summary      Get tax report for date range
+// @Description  Retrieve the tax charged per rate for a given date range, with the taxable amount, tax and number of transactions at each rate
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        start_date  query  string  true  "Start date in YYYY-MM-DD format"
+// @Param        end_date    query  string  true  "End date in YYYY-MM-DD format"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with tax report data"
+// @Failure      400  {object}  map[string]interface{}  "bad request - missing or invalid parameters"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /report/tax [get]
+func (c *ReportController) GetTaxReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Get query parameters
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+
+	// Validate required parameters
+	if startDate == "" || endDate == "" {
+		respondWithError(w, http.StatusBadRequest, "start_date and end_date are required")
+		return
+	}
+
+	report, err := c.service.GetTaxReport(ctx, storeID, startDate, endDate)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// GetPaymentReport godoc
+// @Summary      Get payment report for date range
+// @Description  Retrieve the payments taken per method for a given date range, with the amount handed over, the change given and the amount kept, for reconciling the cash drawer and card and QRIS settlements
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        start_date  query  string  true  "Start date in YYYY-MM-DD format"
+// @Param        end_date    query  string  true  "End date in YYYY-MM-DD format"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with payment report data"
+// @Failure      400  {object}  map[string]interface{}  "bad request - missing or invalid parameters"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /report/payments [get]
+func (c *ReportController) GetPaymentReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Get query parameters
+	startDate := r.URL.Query().Get("start_date")
+	endDate := r.URL.Query().Get("end_date")
+
+	// Validate required parameters
+	if startDate == "" || endDate == "" {
+		respondWithError(w, http.StatusBadRequest, "start_date and end_date are required")
+		return
+	}
+
+	report, err := c.service.GetPaymentReport(ctx, storeID, startDate, endDate)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// GetExpiringReport godoc
+// @Summary      Get expiring stock report
+// @Description  Retrieve the lots of a store that expire within the given number of days, including lots that have already expired, earliest expiry first
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        days        query   int  false  "Number of days ahead to look (defaults to 30)"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200  {object}  map[string]interface{}  "success response with expiring lots"
+// @Failure      400  {object}  map[string]interface{}  "bad request - invalid days"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /report/expiring [get]
+func (c *ReportController) GetExpiringReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	days := 30
+	if daysParam := r.URL.Query().Get("days"); daysParam != "" {
+		days, err = strconv.Atoi(daysParam)
+		if err != nil || days < 0 {
+			respondWithError(w, http.StatusBadRequest, "days must be a non-negative number")
+			return
+		}
+	}
+
+	report, err := c.service.GetExpiringReport(ctx, storeID, days)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// groupByCategory reports whether the request asks for sales per top-level category with ?group_by=category
+func groupByCategory(r *http.Request) bool {
+	return r.URL.Query().Get("group_by") == "category"
+}
@@ -0,0 +1,267 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type StockTransferController struct {
+	service services.StockTransferService
+}
+
+// NewStockTransferController creates a new instance of StockTransferController
+func NewStockTransferController(service services.StockTransferService) *StockTransferController {
+	return &StockTransferController{
+		service: service,
+	}
+}
+
+// GetAll godoc
+// @Summary      Get all stock transfers
+// @Description  Retrieve stock transfers between stores, optionally filtered by status or store. Use status=shipped to see goods in transit.
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        status    query     string  false  "Filter by status (requested, shipped, received, cancelled)"
+// @Param        store_id  query     int     false  "Filter by source or destination store ID"
+// @Success      200       {object}  map[string]interface{}  "success response with stock transfers data"
+// @Failure      400       {object}  map[string]interface{}  "invalid parameter"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /transfers [get]
+func (c *StockTransferController) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Get query parameters
+	status := r.URL.Query().Get("status")
+	storeIDStr := r.URL.Query().Get("store_id")
+
+	storeID := 0
+	if storeIDStr != "" {
+		var err error
+		storeID, err = strconv.Atoi(storeIDStr)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+			return
+		}
+	}
+
+	transfers, err := c.service.GetAll(ctx, status, storeID)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transfers,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get a stock transfer by ID
+// @Description  Retrieve a single stock transfer by its ID with its items
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Stock transfer ID"
+// @Success      200  {object}  map[string]interface{}  "success response with stock transfer data"
+// @Failure      400  {object}  map[string]interface{}  "invalid stock transfer ID"
+// @Failure      404  {object}  map[string]interface{}  "stock transfer not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /transfers/{id} [get]
+func (c *StockTransferController) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/transfers/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return
+	}
+
+	transfer, err := c.service.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transfer,
+	})
+}
+
+// Create godoc
+// @Summary      Request a stock transfer
+// @Description  Request goods to be moved from one store to another. Stock is not moved until the transfer is shipped.
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        transfer  body      dtos.StockTransferCreateRequestDto  true  "Stock transfer data"
+// @Success      201       {object}  map[string]interface{}  "success response with created stock transfer"
+// @Failure      400       {object}  map[string]interface{}  "invalid request"
+// @Failure      404       {object}  map[string]interface{}  "store or product not found"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /transfers [post]
+func (c *StockTransferController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto dtos.StockTransferCreateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	transfer, err := c.service.Create(ctx, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    transfer,
+		"message": "Stock transfer requested successfully",
+	})
+}
+
+// Ship godoc
+// @Summary      Ship a stock transfer
+// @Description  Ship a requested transfer. The requested quantities are deducted from the source store and shown as in transit at the destination. Serialized products ship the units the source store received earliest.
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Stock transfer ID"
+// @Success      200  {object}  map[string]interface{}  "success response with shipped stock transfer"
+// @Failure      400  {object}  map[string]interface{}  "invalid request or insufficient stock"
+// @Failure      404  {object}  map[string]interface{}  "stock transfer not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /transfers/{id}/ship [post]
+func (c *StockTransferController) Ship(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/transfers/", "/ship")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return
+	}
+
+	transfer, err := c.service.Ship(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transfer,
+		"message": "Stock transfer shipped successfully",
+	})
+}
+
+// Receive godoc
+// @Summary      Receive a stock transfer
+// @Description  Receive a shipped transfer at the destination store. Items not listed are received in full; a note is required when received quantities differ from shipped quantities. When fewer units of a serialized product arrive than were shipped, the item must list the serial numbers that arrived.
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int  true  "Stock transfer ID"
+// @Param        receipt  body      dtos.StockTransferReceiveRequestDto  false  "Received quantities"
+// @Success      200      {object}  map[string]interface{}  "success response with received stock transfer"
+// @Failure      400      {object}  map[string]interface{}  "invalid request"
+// @Failure      404      {object}  map[string]interface{}  "stock transfer not found"
+// @Failure      500      {object}  map[string]interface{}  "internal server error"
+// @Router       /transfers/{id}/receive [post]
+func (c *StockTransferController) Receive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/transfers/", "/receive")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return
+	}
+
+	// The body is optional: an empty body receives everything that was shipped
+	var dto dtos.StockTransferReceiveRequestDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+	}
+	defer r.Body.Close()
+
+	transfer, err := c.service.Receive(ctx, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transfer,
+		"message": "Stock transfer received successfully",
+	})
+}
+
+// Cancel godoc
+// @Summary      Cancel a stock transfer
+// @Description  Cancel a transfer that has not been shipped yet
+// @Tags         transfers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Stock transfer ID"
+// @Success      200  {object}  map[string]interface{}  "success response with cancelled stock transfer"
+// @Failure      400  {object}  map[string]interface{}  "transfer can no longer be cancelled"
+// @Failure      404  {object}  map[string]interface{}  "stock transfer not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /transfers/{id}/cancel [post]
+func (c *StockTransferController) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/transfers/", "/cancel")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid stock transfer ID")
+		return
+	}
+
+	transfer, err := c.service.Cancel(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transfer,
+		"message": "Stock transfer cancelled successfully",
+	})
+}
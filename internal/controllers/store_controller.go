@@ -0,0 +1,290 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type StoreController struct {
+	service services.StoreService
+}
+
+// NewStoreController creates a new instance of StoreController
+func NewStoreController(service services.StoreService) *StoreController {
+	return &StoreController{
+		service: service,
+	}
+}
+
+// GetAll godoc
+// @Summary      Get all stores
+// @Description  Retrieve a list of all stores
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}  "success response with stores data"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /stores [get]
+func (c *StoreController) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stores, err := c.service.GetAll(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    stores,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get a store by ID
+// @Description  Retrieve a single store by its ID
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Store ID"
+// @Success      200  {object}  map[string]interface{}  "success response with store data"
+// @Failure      400  {object}  map[string]interface{}  "invalid store ID"
+// @Failure      404  {object}  map[string]interface{}  "store not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /stores/{id} [get]
+func (c *StoreController) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/stores/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	store, err := c.service.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    store,
+	})
+}
+
+// Create godoc
+// @Summary      Create a new store
+// @Description  Create a new store with the provided data. tax_mode says whether its prices include tax (inclusive, the default) or have it added at checkout (exclusive). service_charge_rate adds a service charge, taxed at the default rate when service_charge_taxable is set, and cash_rounding rounds cash totals to a multiple of that many rupiah.
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Param        store  body      dtos.StoreCreateRequestDto  true  "Store data"
+// @Success      201       {object}  map[string]interface{}  "success response with created store"
+// @Failure      400       {object}  map[string]interface{}  "invalid request"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /stores [post]
+func (c *StoreController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto dtos.StoreCreateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	// TODO: Add validation here using validator library
+
+	store, err := c.service.Create(ctx, &dto)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    store,
+		"message": "Store created successfully",
+	})
+}
+
+// Update godoc
+// @Summary      Update a store
+// @Description  Update an existing store by its ID
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int  true  "Store ID"
+// @Param        store  body      dtos.StoreUpdateRequestDto  true  "Store data"
+// @Success      200       {object}  map[string]interface{}  "success response with updated store"
+// @Failure      400       {object}  map[string]interface{}  "invalid request"
+// @Failure      404       {object}  map[string]interface{}  "store not found"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /stores/{id} [put]
+func (c *StoreController) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/stores/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.StoreUpdateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	// TODO: Add validation here using validator library
+
+	store, err := c.service.Update(ctx, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    store,
+		"message": "Store updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a store
+// @Description  Delete a store by its ID
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Store ID"
+// @Success      200  {object}  map[string]interface{}  "success response"
+// @Failure      400  {object}  map[string]interface{}  "invalid store ID"
+// @Failure      404  {object}  map[string]interface{}  "store not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /stores/{id} [delete]
+func (c *StoreController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/stores/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	err = c.service.Delete(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Store deleted successfully",
+	})
+}
+
+// GetProducts godoc
+// @Summary      Get store products
+// @Description  Retrieve the shared catalog with the stock levels and price overrides of a store
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Store ID"
+// @Success      200  {object}  map[string]interface{}  "success response with products data"
+// @Failure      400  {object}  map[string]interface{}  "invalid store ID"
+// @Failure      404  {object}  map[string]interface{}  "store not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /stores/{id}/products [get]
+func (c *StoreController) GetProducts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/stores/", "/products")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	products, err := c.service.GetProducts(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    products,
+	})
+}
+
+// UpdateStock godoc
+// @Summary      Update store stock and price
+// @Description  Set a store's stock level for a product and optionally a store-specific price. A null price removes the override.
+// @Tags         stores
+// @Accept       json
+// @Produce      json
+// @Param        id     path      int  true  "Store ID"
+// @Param        stock  body      dtos.StoreStockUpdateRequestDto  true  "Stock data"
+// @Success      200    {object}  map[string]interface{}  "success response with updated product"
+// @Failure      400    {object}  map[string]interface{}  "invalid request"
+// @Failure      404    {object}  map[string]interface{}  "store or product not found"
+// @Failure      500    {object}  map[string]interface{}  "internal server error"
+// @Router       /stores/{id}/products [put]
+func (c *StoreController) UpdateStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/stores/", "/products")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	var dto dtos.StoreStockUpdateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	product, err := c.service.UpdateStock(ctx, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    product,
+		"message": "Store stock updated successfully",
+	})
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type TaxRateController struct {
+	service services.TaxRateService
+}
+
+// NewTaxRateController creates a new instance of TaxRateController
+func NewTaxRateController(service services.TaxRateService) *TaxRateController {
+	return &TaxRateController{
+		service: service,
+	}
+}
+
+// GetAll godoc
+// @Summary      Get all tax rates
+// @Description  Retrieve all tax rates
+// @Tags         tax-rates
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}  "success response with tax rates data"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /tax-rates [get]
+func (c *TaxRateController) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taxRates, err := c.service.GetAll(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    taxRates,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get a tax rate by ID
+// @Description  Retrieve a single tax rate by its ID
+// @Tags         tax-rates
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tax rate ID"
+// @Success      200  {object}  map[string]interface{}  "success response with tax rate data"
+// @Failure      400  {object}  map[string]interface{}  "invalid tax rate ID"
+// @Failure      404  {object}  map[string]interface{}  "tax rate not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /tax-rates/{id} [get]
+func (c *TaxRateController) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/tax-rates/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid tax rate ID")
+		return
+	}
+
+	taxRate, err := c.service.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    taxRate,
+	})
+}
+
+// Create godoc
+// @Summary      Create a tax rate
+// @Description  Create a tax rate, such as PPN 11%, to assign to products or categories. A default rate applies to products that get no rate from themselves or their category.
+// @Tags         tax-rates
+// @Accept       json
+// @Produce      json
+// @Param        tax_rate  body      dtos.TaxRateRequestDto  true  "Tax rate data"
+// @Success      201       {object}  map[string]interface{}  "success response with created tax rate"
+// @Failure      400       {object}  map[string]interface{}  "invalid request"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /tax-rates [post]
+func (c *TaxRateController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto dtos.TaxRateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	taxRate, err := c.service.Create(ctx, &dto)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    taxRate,
+		"message": "Tax rate created successfully",
+	})
+}
+
+// Update godoc
+// @Summary      Update a tax rate
+// @Description  Replace the name, rate and default setting of a tax rate. Past transactions keep the rate they were charged.
+// @Tags         tax-rates
+// @Accept       json
+// @Produce      json
+// @Param        id        path      int  true  "Tax rate ID"
+// @Param        tax_rate  body      dtos.TaxRateRequestDto  true  "Tax rate data"
+// @Success      200       {object}  map[string]interface{}  "success response with updated tax rate"
+// @Failure      400       {object}  map[string]interface{}  "invalid request"
+// @Failure      404       {object}  map[string]interface{}  "tax rate not found"
+// @Failure      500       {object}  map[string]interface{}  "internal server error"
+// @Router       /tax-rates/{id} [put]
+func (c *TaxRateController) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/tax-rates/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid tax rate ID")
+		return
+	}
+
+	var dto dtos.TaxRateRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	taxRate, err := c.service.Update(ctx, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    taxRate,
+		"message": "Tax rate updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a tax rate
+// @Description  Delete a tax rate that no product or category is assigned to. Past transactions keep the tax they were charged.
+// @Tags         tax-rates
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Tax rate ID"
+// @Success      200  {object}  map[string]interface{}  "success response"
+// @Failure      400  {object}  map[string]interface{}  "invalid tax rate ID or tax rate still assigned"
+// @Failure      404  {object}  map[string]interface{}  "tax rate not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /tax-rates/{id} [delete]
+func (c *TaxRateController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/tax-rates/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid tax rate ID")
+		return
+	}
+
+	err = c.service.Delete(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Tax rate deleted successfully",
+	})
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
 	"github.com/gustionusamba24/kasir-api-go/internal/services"
@@ -21,11 +23,12 @@ func NewTransactionController(service services.TransactionService) *TransactionC
 
 // Checkout godoc
 // @Summary      Create a new transaction (checkout)
-// @Description  Create a new transaction with multiple products
+// @Description  Create a new transaction with multiple products. payments lists the tenders paid, split over several methods if needed; only cash gives change, and a checkout whose payments do not cover the total is rejected.
 // @Tags         transactions
 // @Accept       json
 // @Produce      json
 // @Param        request  body      dtos.TransactionCreateRequestDto  true  "Checkout request with items"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
 // @Success      201      {object}  map[string]interface{}  "success response with transaction data"
 // @Failure      400      {object}  map[string]interface{}  "invalid request"
 // @Failure      404      {object}  map[string]interface{}  "product not found"
@@ -34,13 +37,19 @@ func NewTransactionController(service services.TransactionService) *TransactionC
 func (c *TransactionController) Checkout(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
 	var dto dtos.TransactionCreateRequestDto
 	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
 		respondWithError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	transaction, err := c.service.Checkout(ctx, &dto)
+	transaction, err := c.service.Checkout(ctx, storeID, &dto)
 	if err != nil {
 		if isNotFoundError(err) {
 			respondWithError(w, http.StatusNotFound, err.Error())
@@ -88,14 +97,21 @@ func (c *TransactionController) GetAll(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Transaction ID"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
 // @Success      200  {object}  map[string]interface{}  "success response with transaction data"
 // @Failure      400  {object}  map[string]interface{}  "invalid transaction ID"
-// @Failure      404  {object}  map[string]interface{}  "transaction not found"
+// @Failure      404  {object}  map[string]interface{}  "transaction not found at the store"
 // @Failure      500  {object}  map[string]interface{}  "internal server error"
 // @Router       /transactions/{id} [get]
 func (c *TransactionController) GetByID(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
 	// Extract ID from URL path
 	id, err := extractIDFromPath(r, "/transactions/")
 	if err != nil {
@@ -103,7 +119,7 @@ func (c *TransactionController) GetByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	transaction, err := c.service.GetByID(ctx, id)
+	transaction, err := c.service.GetByID(ctx, storeID, id)
 	if err != nil {
 		if isNotFoundError(err) {
 			respondWithError(w, http.StatusNotFound, err.Error())
@@ -118,3 +134,173 @@ func (c *TransactionController) GetByID(w http.ResponseWriter, r *http.Request)
 		"data":    transaction,
 	})
 }
+
+// Complete godoc
+// @Summary      Complete a draft transaction
+// @Description  Pay a draft transaction and take its stock. The total is rounded to the store's cash rounding when any payment is cash. The transaction becomes completed, or pending while gateway payments await confirmation.
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                                 true   "Transaction ID"
+// @Param        request  body      dtos.TransactionCompleteRequestDto  false  "Payments"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200      {object}  map[string]interface{}  "success response with the completed transaction"
+// @Failure      400      {object}  map[string]interface{}  "not a draft, insufficient stock or payments that do not cover the total"
+// @Failure      404      {object}  map[string]interface{}  "transaction not found at the store"
+// @Router       /transactions/{id}/complete [post]
+func (c *TransactionController) Complete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/transactions/", "/complete")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	// The body is optional: an empty body takes the total as paid in cash exactly
+	var dto dtos.TransactionCompleteRequestDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	defer r.Body.Close()
+
+	transaction, err := c.service.Complete(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transaction,
+		"message": "Transaction completed successfully",
+	})
+}
+
+// Void godoc
+// @Summary      Void a transaction
+// @Description  Void a draft or pending transaction, or a completed one on the day of sale. Any stock it took is put back, paid payments are refunded and pending ones cancelled. Completed sales from earlier days are refunded instead.
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                               true  "Transaction ID"
+// @Param        request  body      dtos.TransactionReasonRequestDto  true  "Reason for voiding"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200      {object}  map[string]interface{}  "success response with the voided transaction"
+// @Failure      400      {object}  map[string]interface{}  "missing reason or transaction can no longer be voided"
+// @Failure      404      {object}  map[string]interface{}  "transaction not found at the store"
+// @Router       /transactions/{id}/void [post]
+func (c *TransactionController) Void(w http.ResponseWriter, r *http.Request) {
+	c.cancel(w, r, "/void", c.service.Void, "Transaction voided successfully")
+}
+
+// Refund godoc
+// @Summary      Refund a transaction
+// @Description  Refund a completed transaction in full. Its stock is put back and its payments are marked refunded, so it no longer counts in reports.
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                               true  "Transaction ID"
+// @Param        request  body      dtos.TransactionReasonRequestDto  true  "Reason for the refund"
+// @Param        X-Store-ID  header  int  false  "Store ID (defaults to the main store)"
+// @Success      200      {object}  map[string]interface{}  "success response with the refunded transaction"
+// @Failure      400      {object}  map[string]interface{}  "missing reason or transaction is not completed"
+// @Failure      404      {object}  map[string]interface{}  "transaction not found at the store"
+// @Router       /transactions/{id}/refund [post]
+func (c *TransactionController) Refund(w http.ResponseWriter, r *http.Request) {
+	c.cancel(w, r, "/refund", c.service.Refund, "Transaction refunded successfully")
+}
+
+// cancel handles the void and refund actions, which both take a reason
+func (c *TransactionController) cancel(w http.ResponseWriter, r *http.Request, suffix string,
+	apply func(ctx context.Context, storeID, id int, dto *dtos.TransactionReasonRequestDto) (*dtos.TransactionDto, error), message string) {
+	ctx := r.Context()
+
+	storeID, err := extractStoreID(r)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid store ID")
+		return
+	}
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/transactions/", suffix)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid transaction ID")
+		return
+	}
+
+	var dto dtos.TransactionReasonRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	defer r.Body.Close()
+
+	transaction, err := apply(ctx, storeID, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    transaction,
+		"message": message,
+	})
+}
+
+// LookupSerial godoc
+// @Summary      Look up a serial number
+// @Description  Retrieve a serial number with its product, status and the transaction it was sold in, for warranty claims
+// @Tags         transactions
+// @Accept       json
+// @Produce      json
+// @Param        serial_number  path      string  true  "Serial number"
+// @Success      200  {object}  map[string]interface{}  "success response with serial number and sale transaction"
+// @Failure      400  {object}  map[string]interface{}  "missing serial number"
+// @Failure      404  {object}  map[string]interface{}  "serial number not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /serials/{serial_number} [get]
+func (c *TransactionController) LookupSerial(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract serial number from URL path
+	serialNumber := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/serials/"), "/")
+	if serialNumber == "" {
+		respondWithError(w, http.StatusBadRequest, "Serial number is required")
+		return
+	}
+
+	serial, err := c.service.LookupSerial(ctx, serialNumber)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    serial,
+	})
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type VoucherController struct {
+	service services.VoucherService
+}
+
+// NewVoucherController creates a new instance of VoucherController
+func NewVoucherController(service services.VoucherService) *VoucherController {
+	return &VoucherController{
+		service: service,
+	}
+}
+
+// GetAll godoc
+// @Summary      Get all vouchers
+// @Description  Retrieve all vouchers with their redemption counts, newest first
+// @Tags         vouchers
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  map[string]interface{}  "success response with vouchers data"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /vouchers [get]
+func (c *VoucherController) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	vouchers, err := c.service.GetAll(ctx)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    vouchers,
+	})
+}
+
+// GetByID godoc
+// @Summary      Get a voucher by ID
+// @Description  Retrieve a single voucher by its ID
+// @Tags         vouchers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Voucher ID"
+// @Success      200  {object}  map[string]interface{}  "success response with voucher data"
+// @Failure      400  {object}  map[string]interface{}  "invalid voucher ID"
+// @Failure      404  {object}  map[string]interface{}  "voucher not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /vouchers/{id} [get]
+func (c *VoucherController) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/vouchers/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid voucher ID")
+		return
+	}
+
+	voucher, err := c.service.GetByID(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    voucher,
+	})
+}
+
+// Create godoc
+// @Summary      Create a voucher
+// @Description  Create a voucher code taking a percent or amount off the cart, with an optional minimum spend, usage limits and validity dates
+// @Tags         vouchers
+// @Accept       json
+// @Produce      json
+// @Param        voucher  body      dtos.VoucherRequestDto  true  "Voucher data"
+// @Success      201        {object}  map[string]interface{}  "success response with created voucher"
+// @Failure      400        {object}  map[string]interface{}  "invalid request"
+// @Failure      500        {object}  map[string]interface{}  "internal server error"
+// @Router       /vouchers [post]
+func (c *VoucherController) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var dto dtos.VoucherRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	voucher, err := c.service.Create(ctx, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusCreated, map[string]interface{}{
+		"success": true,
+		"data":    voucher,
+		"message": "Voucher created successfully",
+	})
+}
+
+// Update godoc
+// @Summary      Update a voucher
+// @Description  Replace the settings of an existing voucher. The code of a redeemed voucher cannot be changed.
+// @Tags         vouchers
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int  true  "Voucher ID"
+// @Param        voucher  body      dtos.VoucherRequestDto  true  "Voucher data"
+// @Success      200        {object}  map[string]interface{}  "success response with updated voucher"
+// @Failure      400        {object}  map[string]interface{}  "invalid request"
+// @Failure      404        {object}  map[string]interface{}  "voucher not found"
+// @Failure      500        {object}  map[string]interface{}  "internal server error"
+// @Router       /vouchers/{id} [put]
+func (c *VoucherController) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/vouchers/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid voucher ID")
+		return
+	}
+
+	var dto dtos.VoucherRequestDto
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	voucher, err := c.service.Update(ctx, id, &dto)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    voucher,
+		"message": "Voucher updated successfully",
+	})
+}
+
+// Delete godoc
+// @Summary      Delete a voucher
+// @Description  Delete a voucher that has never been redeemed. Deactivate redeemed vouchers instead.
+// @Tags         vouchers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Voucher ID"
+// @Success      200  {object}  map[string]interface{}  "success response"
+// @Failure      400  {object}  map[string]interface{}  "invalid voucher ID or voucher already redeemed"
+// @Failure      404  {object}  map[string]interface{}  "voucher not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /vouchers/{id} [delete]
+func (c *VoucherController) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromPath(r, "/vouchers/")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid voucher ID")
+		return
+	}
+
+	err = c.service.Delete(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "Voucher deleted successfully",
+	})
+}
+
+// GetRedemptions godoc
+// @Summary      Get voucher redemptions
+// @Description  Retrieve the transactions a voucher was redeemed on, newest first
+// @Tags         vouchers
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Voucher ID"
+// @Success      200  {object}  map[string]interface{}  "success response with redemptions data"
+// @Failure      400  {object}  map[string]interface{}  "invalid voucher ID"
+// @Failure      404  {object}  map[string]interface{}  "voucher not found"
+// @Failure      500  {object}  map[string]interface{}  "internal server error"
+// @Router       /vouchers/{id}/redemptions [get]
+func (c *VoucherController) GetRedemptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Extract ID from URL path
+	id, err := extractIDFromSubPath(r, "/vouchers/", "/redemptions")
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid voucher ID")
+		return
+	}
+
+	redemptions, err := c.service.GetRedemptions(ctx, id)
+	if err != nil {
+		if isNotFoundError(err) {
+			respondWithError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"data":    redemptions,
+	})
+}
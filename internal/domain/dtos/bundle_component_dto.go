@@ -0,0 +1,8 @@
+package dtos
+
+type BundleComponentDto struct {
+	ProductID   int     `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	Quantity    float64 `json:"quantity"`
+	Stock       float64 `json:"stock"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type BundleComponentRequestDto struct {
+	ProductID int     `json:"product_id" validate:"required,gt=0"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+}
+
+type BundleComponentsUpdateRequestDto struct {
+	Components []BundleComponentRequestDto `json:"components" validate:"dive"`
+}
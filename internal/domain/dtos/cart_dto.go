@@ -0,0 +1,35 @@
+package dtos
+
+import "time"
+
+type CartDto struct {
+	ID            int           `json:"id"`
+	StoreID       int           `json:"store_id"`
+	Status        string        `json:"status" example:"open"`
+	TerminalID    string        `json:"terminal_id,omitempty" example:"POS-01"`
+	CustomerRef   string        `json:"customer_ref,omitempty"`
+	VoucherCodes  []string      `json:"voucher_codes,omitempty"`
+	Discount      *DiscountDto  `json:"discount,omitempty"`
+	Note          string        `json:"note,omitempty"`
+	TransactionID *int          `json:"transaction_id,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+	ParkedAt      *time.Time    `json:"parked_at,omitempty"`
+	ExpiresAt     time.Time     `json:"expires_at"`
+	Items         []CartItemDto `json:"items"`
+	// Quote is the cart priced as it would be checked out now. It is left out of
+	// cart lists, for empty carts and when the cart cannot be priced, in which
+	// case PricingError says why.
+	Quote        *TransactionDto `json:"quote,omitempty"`
+	PricingError string          `json:"pricing_error,omitempty"`
+}
+
+type CartItemDto struct {
+	ID            int          `json:"id"`
+	ProductID     int          `json:"product_id,omitempty"`
+	Barcode       string       `json:"barcode,omitempty"`
+	Quantity      float64      `json:"quantity"`
+	Unit          string       `json:"unit,omitempty"`
+	SerialNumbers []string     `json:"serial_numbers,omitempty"`
+	Discount      *DiscountDto `json:"discount,omitempty"`
+}
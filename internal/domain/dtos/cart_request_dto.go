@@ -0,0 +1,42 @@
+package dtos
+
+// CartCreateRequestDto opens a cart at a terminal, optionally with its first items
+type CartCreateRequestDto struct {
+	TerminalID  string            `json:"terminal_id,omitempty" validate:"max=50" example:"POS-01"`
+	CustomerRef string            `json:"customer_ref,omitempty" validate:"max=100" example:"0812345678"`
+	Items       []CheckoutItemDto `json:"items,omitempty" validate:"omitempty,dive"`
+}
+
+// CartUpdateRequestDto replaces the customer, vouchers and cart discount of a cart
+type CartUpdateRequestDto struct {
+	CustomerRef  string       `json:"customer_ref,omitempty" validate:"max=100" example:"0812345678"`
+	VoucherCodes []string     `json:"voucher_codes,omitempty" example:"HEMAT10"`
+	Discount     *DiscountDto `json:"discount,omitempty"`
+}
+
+// CartItemUpdateRequestDto replaces the quantity, serial numbers and discount of a
+// cart line; its product stays the same
+type CartItemUpdateRequestDto struct {
+	Quantity      float64      `json:"quantity" validate:"required,gt=0"`
+	Unit          string       `json:"unit,omitempty" example:"g"`
+	SerialNumbers []string     `json:"serial_numbers,omitempty"`
+	Discount      *DiscountDto `json:"discount,omitempty"`
+}
+
+// CartParkRequestDto parks a cart, with a note to find it by when it is resumed
+type CartParkRequestDto struct {
+	Note string `json:"note,omitempty" validate:"max=255" example:"Customer fetching wallet"`
+}
+
+// CartResumeRequestDto resumes a parked cart at a terminal
+type CartResumeRequestDto struct {
+	TerminalID string `json:"terminal_id,omitempty" validate:"max=50" example:"POS-02"`
+}
+
+// CartCheckoutRequestDto checks out a cart into a transaction
+type CartCheckoutRequestDto struct {
+	// Payments pay the total as at checkout. Without payments the total is taken as paid in cash exactly.
+	Payments []PaymentDto `json:"payments,omitempty" validate:"omitempty,dive"`
+	// Draft saves the transaction as a draft to be completed later
+	Draft bool `json:"draft,omitempty" example:"false"`
+}
@@ -3,4 +3,6 @@ package dtos
 type CategoryCreateRequest struct {
 	Name        string
 	Description string
+	ParentID    *int
+	TaxRateID   *int
 }
@@ -3,4 +3,6 @@ package dtos
 type CategoryCreateRequestDto struct {
 	Name        string `json:"name" validate:"required,min=3,max=100"`
 	Description string `json:"description" validate:"max=500"`
+	ParentID    *int   `json:"parent_id" validate:"omitempty,gt=0"`
+	TaxRateID   *int   `json:"tax_rate_id" validate:"omitempty,gt=0"`
 }
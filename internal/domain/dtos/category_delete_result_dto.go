@@ -0,0 +1,8 @@
+package dtos
+
+// CategoryDeleteResultDto reports what happened to the products of a deleted category
+type CategoryDeleteResultDto struct {
+	ID            int  `json:"id"`
+	ProductsMoved int  `json:"products_moved"`
+	ReassignedTo  *int `json:"reassigned_to"`
+}
@@ -3,9 +3,14 @@ package dtos
 import "time"
 
 type CategoryDto struct {
-	ID          int       `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int               `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	ParentID    *int              `json:"parent_id"`
+	TaxRateID   *int              `json:"tax_rate_id"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   *time.Time        `json:"deleted_at,omitempty"`
+	Children    []CategoryDto     `json:"children,omitempty"`
+	Stats       *CategoryStatsDto `json:"stats,omitempty"`
 }
@@ -0,0 +1,8 @@
+package dtos
+
+type CategoryStatsDto struct {
+	ActiveProducts int     `json:"active_products"`
+	UnitsInStock   float64 `json:"units_in_stock"`
+	StockValue     float64 `json:"stock_value"`
+	StockCost      float64 `json:"stock_cost"`
+}
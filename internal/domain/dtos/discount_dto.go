@@ -0,0 +1,15 @@
+package dtos
+
+// DiscountDto is a discount given at checkout: a percentage or a fixed rupiah amount off
+type DiscountDto struct {
+	Type   string  `json:"type" validate:"required,oneof=percent amount" example:"percent"`
+	Value  float64 `json:"value" validate:"required,gt=0" example:"10"`
+	Reason string  `json:"reason,omitempty" example:"Damaged packaging"`
+}
+
+type AppliedDiscountDto struct {
+	VoucherID   *int   `json:"voucher_id,omitempty"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+	Amount      int    `json:"amount"`
+}
@@ -0,0 +1,14 @@
+package dtos
+
+type GoodsReceiptCreateRequestDto struct {
+	Quantity float64 `json:"quantity" validate:"required,gt=0"`
+	UnitCost float64 `json:"unit_cost" validate:"required,gte=0"`
+	// Unit the quantity and unit cost are given in, such as a box; defaults to the product's unit
+	Unit *string `json:"unit,omitempty" example:"box"`
+	// LotNumber is required for products that track lots and rejected otherwise
+	LotNumber *string `json:"lot_number,omitempty" validate:"omitempty,max=100"`
+	// ExpiryDate of the lot in YYYY-MM-DD format
+	ExpiryDate *string `json:"expiry_date,omitempty" example:"2026-12-31"`
+	// SerialNumbers must list one serial per unit for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
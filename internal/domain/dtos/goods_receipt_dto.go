@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+type GoodsReceiptDto struct {
+	ID            int       `json:"id"`
+	StoreID       int       `json:"store_id"`
+	ProductID     int       `json:"product_id"`
+	Quantity      float64   `json:"quantity"`
+	UnitCost      float64   `json:"unit_cost"`
+	LotNumber     *string   `json:"lot_number,omitempty"`
+	ExpiryDate    *string   `json:"expiry_date,omitempty"`
+	SerialNumbers []string  `json:"serial_numbers,omitempty"`
+	StockAfter    float64   `json:"stock_after,omitempty"`
+	AverageCost   float64   `json:"average_cost,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+// LabelFileDto is a rendered label sheet
+type LabelFileDto struct {
+	ContentType string
+	FileName    string
+	Pages       int
+	Data        []byte
+}
@@ -0,0 +1,26 @@
+package dtos
+
+type LabelRequestDto struct {
+	ProductIDs []int `json:"product_ids" validate:"required,min=1,max=500,dive,gt=0"`
+	// Format of the output, png or pdf (defaults to pdf)
+	Format string `json:"format" validate:"omitempty,oneof=png pdf" example:"pdf"`
+	// Symbology of the barcode, code128 (SKU) or ean13 (first barcode); defaults to code128
+	Symbology string `json:"symbology" validate:"omitempty,oneof=code128 ean13" example:"code128"`
+	// Copies of each label (defaults to 1)
+	Copies int `json:"copies" validate:"omitempty,gte=1,lte=100"`
+	// Page of the sheet to render for png output (defaults to 1)
+	Page int `json:"page" validate:"omitempty,gte=1"`
+	// DPI of png output (defaults to 300)
+	DPI    int             `json:"dpi" validate:"omitempty,gte=72,lte=600"`
+	Layout *LabelLayoutDto `json:"layout"`
+}
+
+// LabelLayoutDto describes the label sheet in millimetres; omitted fields use the default 3 x 8 sheet of 60 x 30 mm labels
+type LabelLayoutDto struct {
+	Columns     int      `json:"columns" validate:"omitempty,gte=1,lte=10"`
+	Rows        int      `json:"rows" validate:"omitempty,gte=1,lte=20"`
+	LabelWidth  float64  `json:"label_width_mm" validate:"omitempty,gte=25,lte=150"`
+	LabelHeight float64  `json:"label_height_mm" validate:"omitempty,gte=15,lte=100"`
+	Margin      *float64 `json:"margin_mm" validate:"omitempty,gte=0,lte=30"`
+	Gap         *float64 `json:"gap_mm" validate:"omitempty,gte=0,lte=20"`
+}
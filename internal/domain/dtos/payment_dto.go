@@ -0,0 +1,29 @@
+package dtos
+
+import "time"
+
+// PaymentDto is a tender the customer pays with at checkout
+type PaymentDto struct {
+	Method string `json:"method" validate:"required,oneof=cash debit credit qris e_wallet voucher" example:"cash"`
+	// Amount is what the customer hands over; cash may exceed the amount due and gets change
+	Amount int `json:"amount" validate:"required,gt=0" example:"100000"`
+	// Reference identifies a non-cash payment, such as a card approval code or QRIS reference
+	Reference string `json:"reference,omitempty" validate:"max=100" example:"APPR-482913"`
+	// Gateway charges the payment through the payment gateway instead of taking it at
+	// the counter. The transaction stays pending, holding its stock, until the gateway
+	// confirms the payment. Not available for cash and voucher payments.
+	Gateway bool `json:"gateway,omitempty" example:"false"`
+}
+
+type TransactionPaymentDto struct {
+	ID           int        `json:"id"`
+	Method       string     `json:"method"`
+	Amount       int        `json:"amount"`
+	ChangeAmount int        `json:"change_amount"`
+	Reference    string     `json:"reference,omitempty"`
+	Status       string     `json:"status" example:"paid"`
+	Provider     string     `json:"provider,omitempty" example:"fake"`
+	ProviderRef  string     `json:"provider_ref,omitempty" example:"fake_3f9a1c2b7d4e5f60"`
+	CheckoutURL  string     `json:"checkout_url,omitempty"`
+	ResolvedAt   *time.Time `json:"resolved_at,omitempty"`
+}
@@ -1,9 +1,19 @@
 package dtos
 
 type ProductCreateRequestDto struct {
-	Name       string  `json:"name" validate:"required,min=3,max=100"`
-	Price      float64 `json:"price" validate:"required,gt=0"`
-	Stock      int     `json:"stock" validate:"required,gte=0"`
-	Active     *bool   `json:"active" validate:"omitempty"`
-	CategoryID *int    `json:"category_id" validate:"omitempty,gt=0"`
+	Name              string              `json:"name" validate:"required,min=3,max=100"`
+	SKU               *string             `json:"sku" validate:"omitempty,max=64"`
+	PLU               *string             `json:"plu" validate:"omitempty,numeric,max=12"`
+	Barcodes          []string            `json:"barcodes" validate:"omitempty,dive,len=13|len=12"`
+	Price             float64             `json:"price" validate:"required,gt=0"`
+	Cost              *float64            `json:"cost" validate:"omitempty,gte=0"`
+	Stock             float64             `json:"stock" validate:"required,gte=0"`
+	Unit              *string             `json:"unit" validate:"omitempty,oneof=pcs kg g L ml"`
+	QuantityPrecision *int                `json:"quantity_precision" validate:"omitempty,gte=0,lte=3"`
+	UnitConversions   []UnitConversionDto `json:"unit_conversions" validate:"omitempty,dive"`
+	Active            *bool               `json:"active" validate:"omitempty"`
+	TrackLots         bool                `json:"track_lots"`
+	Serialized        bool                `json:"serialized"`
+	CategoryID        *int                `json:"category_id" validate:"omitempty,gt=0"`
+	TaxRateID         *int                `json:"tax_rate_id" validate:"omitempty,gt=0"`
 }
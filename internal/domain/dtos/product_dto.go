@@ -3,12 +3,33 @@ package dtos
 import "time"
 
 type ProductDto struct {
-	ID         int       `json:"id"`
-	Name       string    `json:"name"`
-	Price      float64   `json:"price"`
-	Stock      int       `json:"stock"`
-	Active     bool      `json:"active"`
-	CategoryID *int      `json:"category_id"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID                int                  `json:"id"`
+	Name              string               `json:"name"`
+	SKU               *string              `json:"sku"`
+	PLU               *string              `json:"plu"`
+	Barcodes          []string             `json:"barcodes"`
+	Price             float64              `json:"price"`
+	StorePrice        *float64             `json:"store_price"`
+	Cost              float64              `json:"cost"`
+	Stock             float64              `json:"stock"`
+	InTransit         float64              `json:"in_transit"`
+	Unit              string               `json:"unit"`
+	QuantityPrecision int                  `json:"quantity_precision"`
+	UnitConversions   []UnitConversionDto  `json:"unit_conversions,omitempty"`
+	Active            bool                 `json:"active"`
+	TrackLots         bool                 `json:"track_lots"`
+	Serialized        bool                 `json:"serialized"`
+	CategoryID        *int                 `json:"category_id"`
+	TaxRateID         *int                 `json:"tax_rate_id"`
+	ParentID          *int                 `json:"parent_id"`
+	OptionValues      []string             `json:"option_values,omitempty"`
+	HasVariants       bool                 `json:"has_variants"`
+	IsBundle          bool                 `json:"is_bundle"`
+	Components        []BundleComponentDto `json:"components,omitempty"`
+	Options           []ProductOptionDto   `json:"options,omitempty"`
+	Variants          []ProductDto         `json:"variants,omitempty"`
+	ScaleLabel        *ScaleLabelDto       `json:"scale_label,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	DeletedAt         *time.Time           `json:"deleted_at,omitempty"`
 }
@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+type ProductLotDto struct {
+	ID              int       `json:"id"`
+	StoreID         int       `json:"store_id"`
+	ProductID       int       `json:"product_id"`
+	ProductName     string    `json:"product_name,omitempty"`
+	LotNumber       string    `json:"lot_number"`
+	ExpiryDate      *string   `json:"expiry_date"`
+	DaysUntilExpiry *int      `json:"days_until_expiry,omitempty"`
+	Quantity        float64   `json:"quantity"`
+	ReceivedAt      time.Time `json:"received_at"`
+}
+
+type LotAllocationDto struct {
+	LotID      int     `json:"lot_id"`
+	LotNumber  string  `json:"lot_number"`
+	ExpiryDate *string `json:"expiry_date"`
+	Quantity   float64 `json:"quantity"`
+}
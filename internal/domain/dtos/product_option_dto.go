@@ -0,0 +1,10 @@
+package dtos
+
+type ProductOptionDto struct {
+	Name   string   `json:"name" validate:"required,max=50" example:"Size"`
+	Values []string `json:"values" validate:"required,min=1,dive,required,max=50" example:"S,M,L"`
+}
+
+type ProductOptionsUpdateRequestDto struct {
+	Options []ProductOptionDto `json:"options" validate:"required,min=1,max=3,dive"`
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+type ProductSerialDto struct {
+	ID              int             `json:"id"`
+	ProductID       int             `json:"product_id"`
+	ProductName     string          `json:"product_name,omitempty"`
+	SerialNumber    string          `json:"serial_number"`
+	Status          string          `json:"status"`
+	ReceivedStoreID int             `json:"received_store_id"`
+	StoreID         *int            `json:"store_id"`
+	ReceivedAt      time.Time       `json:"received_at"`
+	SoldAt          *time.Time      `json:"sold_at"`
+	TransactionID   *int            `json:"transaction_id"`
+	Transaction     *TransactionDto `json:"transaction,omitempty"`
+}
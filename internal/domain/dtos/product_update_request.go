@@ -1,9 +1,18 @@
 package dtos
 
 type ProductUpdateRequest struct {
-	Name       string
-	Price      float64
-	Stock      int
-	Active     bool
-	CategoryID *int
+	Name              string
+	SKU               *string
+	PLU               *string
+	Barcodes          []string
+	Price             float64
+	Stock             float64
+	Unit              *string
+	QuantityPrecision *int
+	UnitConversions   []UnitConversionDto
+	Active            bool
+	TrackLots         *bool
+	Serialized        *bool
+	CategoryID        *int
+	TaxRateID         *int
 }
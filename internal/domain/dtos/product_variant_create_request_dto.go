@@ -0,0 +1,11 @@
+package dtos
+
+type ProductVariantCreateRequestDto struct {
+	OptionValues []string `json:"option_values" validate:"required,min=1,dive,required" example:"M,Red"`
+	Price        *float64 `json:"price" validate:"omitempty,gt=0"`
+	Cost         *float64 `json:"cost" validate:"omitempty,gte=0"`
+	Stock        float64  `json:"stock" validate:"gte=0"`
+	SKU          *string  `json:"sku" validate:"omitempty,max=64"`
+	Barcodes     []string `json:"barcodes" validate:"omitempty,dive,len=13|len=12"`
+	Active       *bool    `json:"active" validate:"omitempty"`
+}
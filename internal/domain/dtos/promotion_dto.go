@@ -0,0 +1,38 @@
+package dtos
+
+import "time"
+
+type PromotionDto struct {
+	ID          int                `json:"id"`
+	Name        string             `json:"name"`
+	Type        string             `json:"type"`
+	Priority    int                `json:"priority"`
+	Stackable   bool               `json:"stackable"`
+	Active      bool               `json:"active"`
+	StartsAt    *time.Time         `json:"starts_at"`
+	EndsAt      *time.Time         `json:"ends_at"`
+	Days        []int              `json:"days"`
+	StartTime   string             `json:"start_time,omitempty"`
+	EndTime     string             `json:"end_time,omitempty"`
+	BuyQuantity int                `json:"buy_quantity,omitempty"`
+	GetQuantity int                `json:"get_quantity,omitempty"`
+	CategoryID  *int               `json:"category_id,omitempty"`
+	Percent     float64            `json:"percent,omitempty"`
+	BundlePrice int                `json:"bundle_price,omitempty"`
+	Items       []PromotionItemDto `json:"items"`
+	Tiers       []PromotionTierDto `json:"tiers,omitempty"`
+	RunningNow  bool               `json:"running_now"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type PromotionItemDto struct {
+	ProductID   int    `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+}
+
+type PromotionTierDto struct {
+	MinQuantity float64 `json:"min_quantity"`
+	UnitPrice   int     `json:"unit_price"`
+}
@@ -0,0 +1,39 @@
+package dtos
+
+import "time"
+
+// PromotionRequestDto creates a promotion or replaces an existing one
+type PromotionRequestDto struct {
+	Name      string `json:"name" validate:"required,min=1,max=255" example:"Buy 2 get 1 noodles"`
+	Type      string `json:"type" validate:"required,oneof=buy_x_get_y quantity_tier category_percent bundle_price" example:"buy_x_get_y"`
+	Priority  int    `json:"priority" example:"10"`
+	Stackable bool   `json:"stackable" example:"false"`
+	// Active defaults to true
+	Active *bool `json:"active,omitempty" example:"true"`
+	// StartsAt and EndsAt bound the dates the promotion runs; EndsAt is exclusive
+	StartsAt *time.Time `json:"starts_at,omitempty" example:"2026-11-01T00:00:00+07:00"`
+	EndsAt   *time.Time `json:"ends_at,omitempty" example:"2026-12-01T00:00:00+07:00"`
+	// Days limits the promotion to days of the week, 0 (Sunday) to 6 (Saturday)
+	Days []int `json:"days,omitempty" validate:"dive,min=0,max=6"`
+	// StartTime and EndTime limit the promotion to hours of the day, such as a happy hour
+	StartTime   string                    `json:"start_time,omitempty" example:"15:00"`
+	EndTime     string                    `json:"end_time,omitempty" example:"17:00"`
+	BuyQuantity int                       `json:"buy_quantity,omitempty" example:"2"`
+	GetQuantity int                       `json:"get_quantity,omitempty" example:"1"`
+	CategoryID  *int                      `json:"category_id,omitempty" example:"2"`
+	Percent     float64                   `json:"percent,omitempty" example:"15"`
+	BundlePrice int                       `json:"bundle_price,omitempty" example:"25000"`
+	Items       []PromotionItemRequestDto `json:"items,omitempty" validate:"dive"`
+	Tiers       []PromotionTierRequestDto `json:"tiers,omitempty" validate:"dive"`
+}
+
+type PromotionItemRequestDto struct {
+	ProductID int `json:"product_id" validate:"required,gt=0" example:"1"`
+	// Quantity is the number of units in one bundle; defaults to 1
+	Quantity int `json:"quantity,omitempty" validate:"omitempty,gt=0" example:"1"`
+}
+
+type PromotionTierRequestDto struct {
+	MinQuantity float64 `json:"min_quantity" validate:"required,gt=0" example:"3"`
+	UnitPrice   int     `json:"unit_price" validate:"required,gt=0" example:"2500"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+// QRISDto is a dynamic QRIS code asking for the exact amount of a transaction's QRIS payment
+type QRISDto struct {
+	TransactionID int    `json:"transaction_id"`
+	Amount        int    `json:"amount"`
+	BillNumber    string `json:"bill_number"`
+	// Payload is the QRIS string to encode in the QR code
+	Payload string `json:"payload"`
+}
@@ -1,18 +1,86 @@
 package dtos
 
 type TodayReportDto struct {
+	StoreID            int                    `json:"store_id"`
 	TotalRevenue       int                    `json:"total_revenue"`
+	TotalCost          int                    `json:"total_cost"`
+	GrossProfit        int                    `json:"gross_profit"`
+	GrossMarginPercent float64                `json:"gross_margin_percent"`
 	TotalTransactions  int                    `json:"total_transactions"`
+	Totals             SalesTotalsDto         `json:"totals"`
 	BestSellingProduct *BestSellingProductDto `json:"best_selling_product"`
+	Categories         []CategorySalesDto     `json:"categories,omitempty"`
 }
 type DateRangeReportDto struct {
+	StoreID            int                    `json:"store_id"`
 	StartDate          string                 `json:"start_date"`
 	EndDate            string                 `json:"end_date"`
 	TotalRevenue       int                    `json:"total_revenue"`
+	TotalCost          int                    `json:"total_cost"`
+	GrossProfit        int                    `json:"gross_profit"`
+	GrossMarginPercent float64                `json:"gross_margin_percent"`
 	TotalTransactions  int                    `json:"total_transactions"`
+	Totals             SalesTotalsDto         `json:"totals"`
 	BestSellingProduct *BestSellingProductDto `json:"best_selling_product"`
+	Categories         []CategorySalesDto     `json:"categories,omitempty"`
 }
 type BestSellingProductDto struct {
-	Name     string `json:"name"`
-	QtySold  int    `json:"qty_sold"`
+	Name     string  `json:"name"`
+	QtySold  float64 `json:"qty_sold"`
+}
+type ExpiringReportDto struct {
+	StoreID       int             `json:"store_id"`
+	Days          int             `json:"days"`
+	TotalLots     int             `json:"total_lots"`
+	TotalQuantity float64         `json:"total_quantity"`
+	Lots          []ProductLotDto `json:"lots"`
+}
+type CategorySalesDto struct {
+	CategoryID   *int    `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Revenue      int     `json:"revenue"`
+	Cost         int     `json:"cost"`
+	GrossProfit  int     `json:"gross_profit"`
+	QtySold      float64 `json:"qty_sold"`
+}
+type TaxReportDto struct {
+	StoreID            int                    `json:"store_id"`
+	StartDate          string                 `json:"start_date"`
+	EndDate            string                 `json:"end_date"`
+	TotalTaxable       int                    `json:"total_taxable"`
+	TotalTax           int                    `json:"total_tax"`
+	Rates              []TaxSummaryDto        `json:"rates"`
+}
+type TaxSummaryDto struct {
+	TaxRateID        *int    `json:"tax_rate_id"`
+	Name             string  `json:"name"`
+	Rate             float64 `json:"rate"`
+	TransactionCount int     `json:"transaction_count"`
+	TaxableAmount    int     `json:"taxable_amount"`
+	TaxAmount        int     `json:"tax_amount"`
+}
+type SalesTotalsDto struct {
+	GrossAmount    int `json:"gross_amount"`
+	DiscountAmount int `json:"discount_amount"`
+	ServiceCharge  int `json:"service_charge"`
+	TaxIncluded    int `json:"tax_included"`
+	TaxAdded       int `json:"tax_added"`
+	RoundingAmount int `json:"rounding_amount"`
+	TotalAmount    int `json:"total_amount"`
+}
+type PaymentReportDto struct {
+	StoreID            int                    `json:"store_id"`
+	StartDate          string                 `json:"start_date"`
+	EndDate            string                 `json:"end_date"`
+	TotalReceived      int                    `json:"total_received"`
+	CashInDrawer       int                    `json:"cash_in_drawer"`
+	Methods            []PaymentSummaryDto    `json:"methods"`
+}
+type PaymentSummaryDto struct {
+	Method           string `json:"method"`
+	PaymentCount     int    `json:"payment_count"`
+	TransactionCount int    `json:"transaction_count"`
+	Amount           int    `json:"amount"`
+	ChangeAmount     int    `json:"change_amount"`
+	NetAmount        int    `json:"net_amount"`
 }
@@ -0,0 +1,9 @@
+package dtos
+
+type ScaleLabelDto struct {
+	Barcode  string  `json:"barcode"`
+	PLU      string  `json:"plu"`
+	Quantity float64 `json:"quantity"`
+	Unit     string  `json:"unit"`
+	Price    float64 `json:"price"`
+}
@@ -0,0 +1,13 @@
+package dtos
+
+type StockTransferCreateRequestDto struct {
+	SourceStoreID      int                           `json:"source_store_id" validate:"required,gt=0"`
+	DestinationStoreID int                           `json:"destination_store_id" validate:"required,gt=0,nefield=SourceStoreID"`
+	Note               string                        `json:"note" validate:"max=500"`
+	Items              []StockTransferItemRequestDto `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockTransferItemRequestDto struct {
+	ProductID int     `json:"product_id" validate:"required,gt=0"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+}
@@ -0,0 +1,32 @@
+package dtos
+
+import "time"
+
+type StockTransferDto struct {
+	ID                 int                    `json:"id"`
+	SourceStoreID      int                    `json:"source_store_id"`
+	DestinationStoreID int                    `json:"destination_store_id"`
+	Status             string                 `json:"status"`
+	Note               string                 `json:"note"`
+	ReceivingNote      string                 `json:"receiving_note"`
+	HasDiscrepancy     bool                   `json:"has_discrepancy"`
+	CreatedAt          time.Time              `json:"created_at"`
+	ShippedAt          *time.Time             `json:"shipped_at"`
+	ReceivedAt         *time.Time             `json:"received_at"`
+	CancelledAt        *time.Time             `json:"cancelled_at"`
+	Items              []StockTransferItemDto `json:"items"`
+}
+
+type StockTransferItemDto struct {
+	ID                int     `json:"id"`
+	ProductID         int     `json:"product_id"`
+	ProductName       string  `json:"product_name"`
+	QuantityRequested float64 `json:"quantity_requested"`
+	QuantityShipped   float64 `json:"quantity_shipped"`
+	QuantityReceived  float64 `json:"quantity_received"`
+	Discrepancy       float64 `json:"discrepancy"`
+	// SerialNumbers are the units shipped of a serialized product and
+	// ReceivedSerialNumbers those of them that arrived
+	SerialNumbers         []string `json:"serial_numbers,omitempty"`
+	ReceivedSerialNumbers []string `json:"received_serial_numbers,omitempty"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+// StockTransferReceiveRequestDto records what actually arrived at the destination.
+// Items that are not listed are considered received in full.
+type StockTransferReceiveRequestDto struct {
+	Note  string                               `json:"note" validate:"max=500"`
+	Items []StockTransferReceiveItemRequestDto `json:"items" validate:"omitempty,dive"`
+}
+
+type StockTransferReceiveItemRequestDto struct {
+	ProductID        int     `json:"product_id" validate:"required,gt=0"`
+	QuantityReceived float64 `json:"quantity_received" validate:"gte=0"`
+	// SerialNumbers lists the units of a serialized product that arrived; it is
+	// required when fewer arrived than were shipped
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+}
@@ -0,0 +1,10 @@
+package dtos
+
+type StoreCreateRequest struct {
+	Name                 string
+	Address              string
+	TaxMode              string
+	ServiceChargeRate    float64
+	ServiceChargeTaxable bool
+	CashRounding         int
+}
@@ -0,0 +1,13 @@
+package dtos
+
+type StoreCreateRequestDto struct {
+	Name    string `json:"name" validate:"required,min=3,max=100"`
+	Address string `json:"address" validate:"max=500"`
+	// TaxMode says whether the store's prices include tax; defaults to inclusive
+	TaxMode string `json:"tax_mode" validate:"omitempty,oneof=inclusive exclusive" example:"inclusive"`
+	// ServiceChargeRate is the percentage added to the cart as a service charge
+	ServiceChargeRate    float64 `json:"service_charge_rate" validate:"gte=0,lt=100" example:"5"`
+	ServiceChargeTaxable bool    `json:"service_charge_taxable" example:"true"`
+	// CashRounding rounds cash totals to the nearest multiple of this many rupiah
+	CashRounding int `json:"cash_rounding" validate:"gte=0,lte=1000" example:"100"`
+}
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+type StoreDto struct {
+	ID                   int       `json:"id"`
+	Name                 string    `json:"name"`
+	Address              string    `json:"address"`
+	TaxMode              string    `json:"tax_mode"`
+	ServiceChargeRate    float64   `json:"service_charge_rate"`
+	ServiceChargeTaxable bool      `json:"service_charge_taxable"`
+	CashRounding         int       `json:"cash_rounding"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+// StoreStockUpdateRequestDto sets a store's stock level and price override for a product.
+// A null price removes the override so the store sells at the catalog price.
+type StoreStockUpdateRequestDto struct {
+	ProductID int      `json:"product_id" validate:"required,gt=0"`
+	Stock     float64  `json:"stock" validate:"gte=0"`
+	Price     *float64 `json:"price" validate:"omitempty,gt=0"`
+}
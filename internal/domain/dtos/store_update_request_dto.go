@@ -0,0 +1,10 @@
+package dtos
+
+type StoreUpdateRequestDto struct {
+	Name                 string   `json:"name" validate:"required,min=3,max=100"`
+	Address              string   `json:"address" validate:"max=500"`
+	TaxMode              *string  `json:"tax_mode" validate:"omitempty,oneof=inclusive exclusive" example:"inclusive"`
+	ServiceChargeRate    *float64 `json:"service_charge_rate" validate:"omitempty,gte=0,lt=100" example:"5"`
+	ServiceChargeTaxable *bool    `json:"service_charge_taxable" example:"true"`
+	CashRounding         *int     `json:"cash_rounding" validate:"omitempty,gte=0,lte=1000" example:"100"`
+}
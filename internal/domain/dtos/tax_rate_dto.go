@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+type TaxRateDto struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Rate      float64   `json:"rate"`
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TransactionTaxDto is the tax charged at one rate on a transaction
+type TransactionTaxDto struct {
+	TaxRateID     *int    `json:"tax_rate_id"`
+	Name          string  `json:"name"`
+	Rate          float64 `json:"rate"`
+	TaxableAmount int     `json:"taxable_amount"`
+	TaxAmount     int     `json:"tax_amount"`
+}
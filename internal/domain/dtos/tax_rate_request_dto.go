@@ -0,0 +1,10 @@
+package dtos
+
+// TaxRateRequestDto creates a tax rate or replaces the settings of an existing one
+type TaxRateRequestDto struct {
+	Name string `json:"name" validate:"required,max=100" example:"PPN"`
+	// Rate is a percentage; 0 marks goods exempt from tax
+	Rate *float64 `json:"rate" validate:"required,gte=0,lt=100" example:"11"`
+	// IsDefault makes this the rate of products that get no rate from themselves or their category
+	IsDefault bool `json:"is_default" example:"true"`
+}
@@ -0,0 +1,7 @@
+package dtos
+
+// TransactionCompleteRequestDto completes a draft transaction
+type TransactionCompleteRequestDto struct {
+	// Payments pay the total as at checkout. Without payments the total is taken as paid in cash exactly.
+	Payments []PaymentDto `json:"payments,omitempty" validate:"omitempty,dive"`
+}
@@ -2,9 +2,32 @@ package dtos
 
 type TransactionCreateRequestDto struct {
 	Items []CheckoutItemDto `json:"items" validate:"required,min=1,dive"`
+	// VoucherCodes are redeemed in order against the cart after promotions and line discounts
+	VoucherCodes []string `json:"voucher_codes,omitempty" example:"HEMAT10"`
+	// CustomerRef identifies the customer, such as a member number or phone number.
+	// It is required for vouchers limited per customer.
+	CustomerRef string `json:"customer_ref,omitempty" validate:"max=100" example:"0812345678"`
+	// Discount is taken off the whole cart after promotions, line discounts and vouchers
+	Discount *DiscountDto `json:"discount,omitempty"`
+	// Payments pay the total, split over several tenders if needed. The total is rounded
+	// to the store's cash rounding when any of them is cash. Without payments the
+	// total is taken as paid in cash exactly.
+	Payments []PaymentDto `json:"payments,omitempty" validate:"omitempty,dive"`
+	// Draft saves the priced transaction without payments to be completed later.
+	// Its stock is taken and its vouchers redeemed when it is completed.
+	Draft bool `json:"draft,omitempty" example:"false"`
 }
 
 type CheckoutItemDto struct {
-	ProductID int `json:"product_id" validate:"required,gt=0"`
-	Quantity  int `json:"quantity" validate:"required,gt=0"`
+	// ProductID or Barcode identifies the product; ProductID wins if both are set.
+	// A scanned scale label sets the quantity itself.
+	ProductID int     `json:"product_id" validate:"required_without=Barcode,omitempty,gt=0"`
+	Barcode   string  `json:"barcode,omitempty" validate:"required_without=ProductID,omitempty"`
+	Quantity  float64 `json:"quantity" validate:"required,gt=0"`
+	// Unit the quantity is given in, such as g for a product sold by the kg; defaults to the product's unit
+	Unit string `json:"unit,omitempty" example:"g"`
+	// SerialNumbers must list one serial per unit for serialized products
+	SerialNumbers []string `json:"serial_numbers,omitempty"`
+	// Discount is taken off this line
+	Discount *DiscountDto `json:"discount,omitempty"`
 }
@@ -3,17 +3,51 @@ package dtos
 import "time"
 
 type TransactionDto struct {
-	ID          int                     `json:"id"`
-	TotalAmount int                     `json:"total_amount"`
-	CreatedAt   time.Time               `json:"created_at"`
-	Details     []TransactionDetailDto  `json:"details"`
+	ID             int                     `json:"id"`
+	StoreID        int                     `json:"store_id"`
+	CustomerRef    string                  `json:"customer_ref,omitempty"`
+	GrossAmount    int                     `json:"gross_amount"`
+	DiscountAmount int                     `json:"discount_amount"`
+	ServiceCharge  int                     `json:"service_charge"`
+	TaxMode        string                  `json:"tax_mode"`
+	TaxAmount      int                     `json:"tax_amount"`
+	RoundingAmount int                     `json:"rounding_amount"`
+	TotalAmount    int                     `json:"total_amount"`
+	PaidAmount     int                     `json:"paid_amount"`
+	ChangeAmount   int                     `json:"change_amount"`
+	PaymentMethod  string                  `json:"payment_method,omitempty"`
+	Status         string                  `json:"status" example:"completed"`
+	CreatedAt      time.Time               `json:"created_at"`
+	ExpiresAt      *time.Time              `json:"expires_at,omitempty"`
+	CompletedAt    *time.Time              `json:"completed_at,omitempty"`
+	FailedAt       *time.Time              `json:"failed_at,omitempty"`
+	ExpiredAt      *time.Time              `json:"expired_at,omitempty"`
+	VoidedAt       *time.Time              `json:"voided_at,omitempty"`
+	RefundedAt     *time.Time              `json:"refunded_at,omitempty"`
+	VoidReason     string                  `json:"void_reason,omitempty"`
+	RefundReason   string                  `json:"refund_reason,omitempty"`
+	Details        []TransactionDetailDto  `json:"details"`
+	Discounts      []AppliedDiscountDto    `json:"discounts,omitempty"`
+	Taxes          []TransactionTaxDto     `json:"taxes,omitempty"`
+	Payments       []TransactionPaymentDto `json:"payments,omitempty"`
 }
 
 type TransactionDetailDto struct {
-	ID            int    `json:"id"`
-	TransactionID int    `json:"transaction_id"`
-	ProductID     int    `json:"product_id"`
-	ProductName   string `json:"product_name"`
-	Quantity      int    `json:"quantity"`
-	Subtotal      int    `json:"subtotal"`
+	ID             int                  `json:"id"`
+	TransactionID  int                  `json:"transaction_id"`
+	ProductID      int                  `json:"product_id"`
+	ProductName    string               `json:"product_name"`
+	Quantity       float64              `json:"quantity"`
+	Unit           string               `json:"unit"`
+	GrossAmount    int                  `json:"gross_amount"`
+	DiscountAmount int                  `json:"discount_amount"`
+	Subtotal       int                  `json:"subtotal"`
+	TaxRateID      *int                 `json:"tax_rate_id,omitempty"`
+	TaxRate        float64              `json:"tax_rate"`
+	TaxableAmount  int                  `json:"taxable_amount"`
+	TaxAmount      int                  `json:"tax_amount"`
+	UnitCost       float64              `json:"unit_cost"`
+	Lots           []LotAllocationDto   `json:"lots,omitempty"`
+	SerialNumbers  []string             `json:"serial_numbers,omitempty"`
+	Discounts      []AppliedDiscountDto `json:"discounts,omitempty"`
 }
@@ -0,0 +1,6 @@
+package dtos
+
+// TransactionReasonRequestDto gives the reason a transaction is voided or refunded
+type TransactionReasonRequestDto struct {
+	Reason string `json:"reason" validate:"required,max=255" example:"Customer changed their mind"`
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type UnitConversionDto struct {
+	Unit   string  `json:"unit" validate:"required,max=20" example:"box"`
+	Factor float64 `json:"factor" validate:"required,gt=0" example:"24"`
+}
@@ -0,0 +1,32 @@
+package dtos
+
+import "time"
+
+type VoucherDto struct {
+	ID               int        `json:"id"`
+	Code             string     `json:"code"`
+	Description      string     `json:"description"`
+	Type             string     `json:"type"`
+	Value            float64    `json:"value"`
+	MaxDiscount      int        `json:"max_discount"`
+	MinSpend         int        `json:"min_spend"`
+	UsageLimit       *int       `json:"usage_limit"`
+	PerCustomerLimit *int       `json:"per_customer_limit"`
+	StartsAt         *time.Time `json:"starts_at"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	Active           bool       `json:"active"`
+	TimesRedeemed    int        `json:"times_redeemed"`
+	// RemainingUses is nil for vouchers without a usage limit
+	RemainingUses *int      `json:"remaining_uses"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type VoucherRedemptionDto struct {
+	ID            int       `json:"id"`
+	VoucherID     int       `json:"voucher_id"`
+	TransactionID int       `json:"transaction_id"`
+	CustomerRef   string    `json:"customer_ref,omitempty"`
+	Amount        int       `json:"amount"`
+	RedeemedAt    time.Time `json:"redeemed_at"`
+}
@@ -0,0 +1,22 @@
+package dtos
+
+import "time"
+
+// VoucherRequestDto creates a voucher or replaces the settings of an existing one
+type VoucherRequestDto struct {
+	Code        string  `json:"code" validate:"required,min=3,max=50" example:"HEMAT10"`
+	Description string  `json:"description" validate:"max=255" example:"10% off for new members"`
+	Type        string  `json:"type" validate:"required,oneof=percent amount" example:"percent"`
+	Value       float64 `json:"value" validate:"required,gt=0" example:"10"`
+	// MaxDiscount caps a percent voucher's discount; 0 means no cap
+	MaxDiscount int `json:"max_discount,omitempty" validate:"gte=0" example:"20000"`
+	// MinSpend is the cart amount, after promotions and line discounts, the voucher requires
+	MinSpend int `json:"min_spend,omitempty" validate:"gte=0" example:"50000"`
+	// UsageLimit and PerCustomerLimit limit redemptions overall and per customer; omit for no limit
+	UsageLimit       *int       `json:"usage_limit,omitempty" validate:"omitempty,gt=0" example:"100"`
+	PerCustomerLimit *int       `json:"per_customer_limit,omitempty" validate:"omitempty,gt=0" example:"1"`
+	StartsAt         *time.Time `json:"starts_at,omitempty" example:"2026-11-01T00:00:00+07:00"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty" example:"2026-12-01T00:00:00+07:00"`
+	// Active defaults to true
+	Active *bool `json:"active,omitempty" example:"true"`
+}
@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BarcodeCheckDigit computes the GS1 check digit for the digits of a barcode
+// without its check digit, as used by EAN-13 and UPC-A
+func BarcodeCheckDigit(payload string) int {
+	sum := 0
+	// Weights alternate 3, 1, ... starting from the rightmost payload digit
+	for i := len(payload) - 1; i >= 0; i-- {
+		digit := int(payload[i] - '0')
+		if (len(payload)-1-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10 - sum%10) % 10
+}
+
+// NormalizeBarcode validates an EAN-13 or UPC-A barcode and returns it in
+// EAN-13 form. A 12 digit UPC-A code is the same as the EAN-13 code with a
+// leading zero, so both scan to the same product.
+func NormalizeBarcode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("barcode %s must contain digits only", code)
+		}
+	}
+
+	switch len(code) {
+	case 12:
+		code = "0" + code
+	case 13:
+	default:
+		return "", fmt.Errorf("barcode %s must be 12 digits (UPC-A) or 13 digits (EAN-13)", code)
+	}
+
+	if BarcodeCheckDigit(code[:12]) != int(code[12]-'0') {
+		return "", fmt.Errorf("barcode %s has an invalid check digit", code)
+	}
+
+	return code, nil
+}
@@ -0,0 +1,50 @@
+package entities
+
+import "testing"
+
+func TestBarcodeCheckDigit(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    int
+	}{
+		{payload: "400638133393", want: 1},
+		{payload: "400638133390", want: 0},
+		{payload: "03600029145", want: 2},
+		{payload: "003600029145", want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := BarcodeCheckDigit(tt.payload); got != tt.want {
+			t.Errorf("BarcodeCheckDigit(%s) = %d, want %d", tt.payload, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{name: "EAN-13", code: "4006381333931", want: "4006381333931"},
+		{name: "UPC-A gets a leading zero", code: "036000291452", want: "0036000291452"},
+		{name: "surrounding spaces", code: " 4006381333931\n", want: "4006381333931"},
+		{name: "wrong check digit", code: "4006381333932", wantErr: true},
+		{name: "letters", code: "40063813339A1", wantErr: true},
+		{name: "EAN-8", code: "96385074", wantErr: true},
+		{name: "empty", code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBarcode(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeBarcode(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeBarcode(%q) = %s, want %s", tt.code, got, tt.want)
+			}
+		})
+	}
+}
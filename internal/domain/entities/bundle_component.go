@@ -0,0 +1,12 @@
+package entities
+
+// BundleComponent is a product and quantity that makes up one unit of a bundle
+type BundleComponent struct {
+	ID            int     `json:"id" db:"id"`
+	BundleID      int     `json:"bundle_id" db:"bundle_id"`
+	ComponentID   int     `json:"component_id" db:"component_id"`
+	ComponentName string  `json:"component_name,omitempty"`
+	Quantity      float64 `json:"quantity" db:"quantity"`
+	Cost          float64 `json:"cost"`
+	Stock         float64 `json:"stock"`
+}
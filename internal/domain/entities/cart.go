@@ -0,0 +1,75 @@
+package entities
+
+import "time"
+
+// Cart statuses. A cart is open while a cashier builds it at a terminal and can be
+// parked to serve the next customer, then resumed at any terminal of its store.
+// Checking it out records a transaction; an open or parked cart left untouched
+// for too long expires.
+const (
+	CartStatusOpen       = "open"
+	CartStatusParked     = "parked"
+	CartStatusCheckedOut = "checked_out"
+	CartStatusExpired    = "expired"
+)
+
+// Cart is a customer's basket. It holds what the cashier entered rather than
+// priced lines, so its totals are worked out live from current prices, promotions
+// and stock until it is checked out. Nothing is taken from stock before then.
+type Cart struct {
+	ID            int           `json:"id" db:"id"`
+	StoreID       int           `json:"store_id" db:"store_id"`
+	Status        string        `json:"status" db:"status"`
+	TerminalID    string        `json:"terminal_id" db:"terminal_id"`
+	CustomerRef   string        `json:"customer_ref" db:"customer_ref"`
+	VoucherCodes  []string      `json:"voucher_codes" db:"voucher_codes"`
+	Discount      *CartDiscount `json:"discount"`
+	Note          string        `json:"note" db:"note"`
+	TransactionID *int          `json:"transaction_id" db:"transaction_id"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+	ParkedAt      *time.Time    `json:"parked_at" db:"parked_at"`
+	ExpiresAt     time.Time     `json:"expires_at" db:"expires_at"`
+	Items         []CartItem    `json:"items"`
+}
+
+// CartItem is a line of a cart as entered: a product or a scanned barcode, which
+// may be a scale label carrying the quantity itself
+type CartItem struct {
+	ID            int           `json:"id" db:"id"`
+	CartID        int           `json:"cart_id" db:"cart_id"`
+	ProductID     int           `json:"product_id" db:"product_id"`
+	Barcode       string        `json:"barcode" db:"barcode"`
+	Quantity      float64       `json:"quantity" db:"quantity"`
+	Unit          string        `json:"unit" db:"unit"`
+	SerialNumbers []string      `json:"serial_numbers" db:"serial_numbers"`
+	Discount      *CartDiscount `json:"discount"`
+}
+
+// CartDiscount is a manual discount kept on a cart or one of its lines until checkout
+type CartDiscount struct {
+	Type   string  `json:"type" db:"discount_type"`
+	Value  float64 `json:"value" db:"discount_value"`
+	Reason string  `json:"reason" db:"discount_reason"`
+}
+
+// IsActive reports whether the cart can still be resumed or checked out
+func (c *Cart) IsActive() bool {
+	return c.Status == CartStatusOpen || c.Status == CartStatusParked
+}
+
+// Touch records a change to the cart at the given time, pushing back its expiry
+func (c *Cart) Touch(at time.Time, expiry time.Duration) {
+	c.UpdatedAt = at
+	c.ExpiresAt = at.Add(expiry)
+}
+
+// FindItem returns the cart's line with the given ID, or nil if it has none
+func (c *Cart) FindItem(id int) *CartItem {
+	for i := range c.Items {
+		if c.Items[i].ID == id {
+			return &c.Items[i]
+		}
+	}
+	return nil
+}
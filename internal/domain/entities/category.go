@@ -3,9 +3,19 @@ package entities
 import "time"
 
 type Category struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Description string    `json:"description" db:"description"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	ID          int            `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name"`
+	Description string         `json:"description" db:"description"`
+	ParentID    *int           `json:"parent_id" db:"parent_id"`
+	TaxRateID   *int           `json:"tax_rate_id" db:"tax_rate_id"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at" db:"updated_at"`
+	DeletedAt   *time.Time     `json:"deleted_at" db:"deleted_at"`
+	Children    []Category     `json:"children,omitempty"`
+	Stats       *CategoryStats `json:"stats,omitempty"`
+}
+
+// IsArchived reports whether the category has been deleted
+func (c *Category) IsArchived() bool {
+	return c.DeletedAt != nil
 }
@@ -0,0 +1,11 @@
+package entities
+
+// CategorySales is the sales of a top-level category and all its subcategories.
+// CategoryID is nil for uncategorized products.
+type CategorySales struct {
+	CategoryID   *int
+	CategoryName string
+	Revenue      int
+	Cost         int
+	QtySold      float64
+}
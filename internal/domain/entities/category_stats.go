@@ -0,0 +1,13 @@
+package entities
+
+// CategoryStats aggregates the products directly assigned to a category, archived
+// products excluded, with the stock held by one store
+type CategoryStats struct {
+	CategoryID     int
+	ActiveProducts int
+	UnitsInStock   float64
+	// StockValue is the stock valued at the store's selling price
+	StockValue float64
+	// StockCost is the stock valued at the products' average cost
+	StockCost float64
+}
@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+)
+
+// Discount value types
+const (
+	DiscountPercent = "percent"
+	DiscountAmount  = "amount"
+)
+
+// Discount sources recorded with an applied discount
+const (
+	DiscountSourceManual    = "manual"
+	DiscountSourcePromotion = "promotion"
+	DiscountSourceVoucher   = "voucher"
+)
+
+// Discount takes a percentage or a fixed rupiah amount off a line or a cart
+type Discount struct {
+	Type  string
+	Value float64
+}
+
+// Validate checks the discount type and that its value is in range
+func (d Discount) Validate() error {
+	switch d.Type {
+	case DiscountPercent:
+		if d.Value <= 0 || d.Value > 100 {
+			return fmt.Errorf("percent discount must be greater than 0 and at most 100")
+		}
+	case DiscountAmount:
+		if d.Value <= 0 {
+			return fmt.Errorf("amount discount must be greater than 0")
+		}
+	default:
+		return fmt.Errorf("discount type must be %s or %s", DiscountPercent, DiscountAmount)
+	}
+	return nil
+}
+
+// Of returns the discount on an amount, rounded to whole rupiah and never more than the amount
+func (d Discount) Of(amount int) int {
+	if amount <= 0 {
+		return 0
+	}
+
+	var discount int
+	switch d.Type {
+	case DiscountPercent:
+		discount = int(math.Round(float64(amount) * d.Value / 100))
+	case DiscountAmount:
+		discount = int(math.Round(d.Value))
+	}
+	return min(discount, amount)
+}
+
+// String describes the discount, such as "10% off" or "Rp 5000 off"
+func (d Discount) String() string {
+	if d.Type == DiscountPercent {
+		return fmt.Sprintf("%g%% off", d.Value)
+	}
+	return fmt.Sprintf("Rp %g off", d.Value)
+}
+
+// AppliedDiscount explains a discount given on a transaction. TransactionDetailID
+// is set for line discounts and nil for discounts on the whole cart. VoucherID
+// is set for discounts given by a voucher.
+type AppliedDiscount struct {
+	ID                  int    `json:"id" db:"id"`
+	TransactionID       int    `json:"transaction_id" db:"transaction_id"`
+	TransactionDetailID *int   `json:"transaction_detail_id" db:"transaction_detail_id"`
+	VoucherID           *int   `json:"voucher_id" db:"voucher_id"`
+	Source              string `json:"source" db:"source"`
+	Description         string `json:"description" db:"description"`
+	Amount              int    `json:"amount" db:"amount"`
+}
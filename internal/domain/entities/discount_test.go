@@ -0,0 +1,52 @@
+package entities
+
+import "testing"
+
+func TestDiscountValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount Discount
+		wantErr  bool
+	}{
+		{name: "percent", discount: Discount{Type: DiscountPercent, Value: 12.5}},
+		{name: "whole cart free", discount: Discount{Type: DiscountPercent, Value: 100}},
+		{name: "percent over 100", discount: Discount{Type: DiscountPercent, Value: 100.01}, wantErr: true},
+		{name: "zero percent", discount: Discount{Type: DiscountPercent, Value: 0}, wantErr: true},
+		{name: "amount", discount: Discount{Type: DiscountAmount, Value: 5000}},
+		{name: "negative amount", discount: Discount{Type: DiscountAmount, Value: -1}, wantErr: true},
+		{name: "unknown type", discount: Discount{Type: "coupon", Value: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.discount.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDiscountOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		discount Discount
+		amount   int
+		want     int
+	}{
+		{name: "percent", discount: Discount{Type: DiscountPercent, Value: 10}, amount: 15000, want: 1500},
+		{name: "percent rounds half up", discount: Discount{Type: DiscountPercent, Value: 10}, amount: 15, want: 2},
+		{name: "percent rounds down", discount: Discount{Type: DiscountPercent, Value: 10}, amount: 14, want: 1},
+		{name: "amount", discount: Discount{Type: DiscountAmount, Value: 2500}, amount: 15000, want: 2500},
+		{name: "amount capped at the amount", discount: Discount{Type: DiscountAmount, Value: 20000}, amount: 15000, want: 15000},
+		{name: "nothing to discount", discount: Discount{Type: DiscountAmount, Value: 2500}, amount: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.discount.Of(tt.amount); got != tt.want {
+				t.Errorf("Of(%d) = %d, want %d", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+type GoodsReceipt struct {
+	ID         int        `json:"id" db:"id"`
+	StoreID    int        `json:"store_id" db:"store_id"`
+	ProductID  int        `json:"product_id" db:"product_id"`
+	Quantity   float64    `json:"quantity" db:"quantity"`
+	UnitCost   float64    `json:"unit_cost" db:"unit_cost"`
+	LotNumber  *string    `json:"lot_number,omitempty" db:"lot_number"`
+	ExpiryDate *time.Time `json:"expiry_date,omitempty" db:"expiry_date"`
+	// SerialNumbers received with the goods, stored in product_serials
+	SerialNumbers []string  `json:"serial_numbers,omitempty"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,169 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Payment methods a customer can pay with. Only cash gives change; the other
+// tenders are charged for an exact amount. A voucher tender is a prepaid gift
+// voucher taken as payment, not a voucher code redeemed for a discount. A
+// transaction paid with several methods is recorded as split.
+const (
+	PaymentCash    = "cash"
+	PaymentDebit   = "debit"
+	PaymentCredit  = "credit"
+	PaymentQRIS    = "qris"
+	PaymentEWallet = "e_wallet"
+	PaymentVoucher = "voucher"
+	PaymentSplit   = "split"
+)
+
+// PaymentMethods lists the methods a payment can be made with
+var PaymentMethods = []string{PaymentCash, PaymentDebit, PaymentCredit, PaymentQRIS, PaymentEWallet, PaymentVoucher}
+
+// Payment statuses. Payments are paid when taken at the counter; a payment
+// charged through a payment gateway is pending until the gateway reports it paid
+// or failed, and expires when the transaction stops waiting for it. Voiding or
+// refunding a transaction refunds its paid payments and cancels pending ones.
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusPaid      = "paid"
+	PaymentStatusFailed    = "failed"
+	PaymentStatusExpired   = "expired"
+	PaymentStatusRefunded  = "refunded"
+	PaymentStatusCancelled = "cancelled"
+)
+
+// TransactionPayment is one tender paid toward a transaction. Amount is what
+// the customer handed over; ChangeAmount is the change given back from it, so
+// Amount less ChangeAmount is what the store kept.
+type TransactionPayment struct {
+	ID            int    `json:"id" db:"id"`
+	TransactionID int    `json:"transaction_id" db:"transaction_id"`
+	Method        string `json:"method" db:"method"`
+	Amount        int    `json:"amount" db:"amount"`
+	ChangeAmount  int    `json:"change_amount" db:"change_amount"`
+	Reference     string `json:"reference,omitempty" db:"reference"`
+	Status        string `json:"status" db:"status"`
+	// Provider names the payment gateway the payment is charged through, if any
+	Provider    string     `json:"provider,omitempty" db:"provider"`
+	ProviderRef string     `json:"provider_ref,omitempty" db:"provider_ref"`
+	CheckoutURL string     `json:"checkout_url,omitempty" db:"checkout_url"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// Validate checks the payment's method, amount and reference
+func (p *TransactionPayment) Validate() error {
+	valid := false
+	for _, method := range PaymentMethods {
+		if p.Method == method {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("payment method must be one of %s", strings.Join(PaymentMethods, ", "))
+	}
+
+	if p.Amount <= 0 {
+		return fmt.Errorf("payment amount must be greater than 0")
+	}
+
+	p.Reference = strings.TrimSpace(p.Reference)
+	if len(p.Reference) > 100 {
+		return fmt.Errorf("payment reference cannot be longer than 100 characters")
+	}
+
+	// Cash and prepaid vouchers are taken at the counter; only the other tenders can be charged through a gateway
+	if p.Provider != "" && (p.Method == PaymentCash || p.Method == PaymentVoucher) {
+		return fmt.Errorf("%s payments cannot be charged through a payment gateway", p.Method)
+	}
+
+	return nil
+}
+
+// HasCash reports whether any of the payments is cash
+func HasCash(payments []TransactionPayment) bool {
+	for _, payment := range payments {
+		if payment.Method == PaymentCash {
+			return true
+		}
+	}
+	return false
+}
+
+// SettlePayments checks that the payments cover the amount due and works out the
+// change, which is given from the cash. Non-cash tenders cannot give change, so
+// together they may not exceed the amount due. The change is recorded on the cash
+// payments, largest first, each giving back at most its own amount. It returns the
+// amount paid and the change.
+func SettlePayments(payments []TransactionPayment, due int) (paid, change int, err error) {
+	nonCash := 0
+	for i := range payments {
+		if err := payments[i].Validate(); err != nil {
+			return 0, 0, fmt.Errorf("payment %d: %w", i+1, err)
+		}
+		payments[i].ChangeAmount = 0
+
+		paid += payments[i].Amount
+		if payments[i].Method != PaymentCash {
+			nonCash += payments[i].Amount
+		}
+	}
+
+	if paid < due {
+		return 0, 0, fmt.Errorf("payments of Rp %d do not cover the total of Rp %d; Rp %d is still due", paid, due, due-paid)
+	}
+
+	if nonCash > due {
+		return 0, 0, fmt.Errorf("non-cash payments of Rp %d exceed the total of Rp %d; only cash can give change", nonCash, due)
+	}
+
+	// The change never exceeds the cash, since the non-cash tenders do not exceed the amount due
+	change = paid - due
+	cash := make([]int, 0, len(payments))
+	for i := range payments {
+		if payments[i].Method == PaymentCash {
+			cash = append(cash, i)
+		}
+	}
+	sort.SliceStable(cash, func(a, b int) bool {
+		return payments[cash[a]].Amount > payments[cash[b]].Amount
+	})
+	remaining := change
+	for _, i := range cash {
+		payments[i].ChangeAmount = min(remaining, payments[i].Amount)
+		remaining -= payments[i].ChangeAmount
+	}
+
+	return paid, change, nil
+}
+
+// PaymentMethodOf summarizes how a transaction was paid: the method of its
+// payments when they all use one, otherwise split
+func PaymentMethodOf(payments []TransactionPayment) string {
+	if len(payments) == 0 {
+		return PaymentCash
+	}
+
+	method := payments[0].Method
+	for _, payment := range payments[1:] {
+		if payment.Method != method {
+			return PaymentSplit
+		}
+	}
+	return method
+}
+
+// HasPending reports whether any of the payments is pending
+func HasPending(payments []TransactionPayment) bool {
+	for _, payment := range payments {
+		if payment.Status == PaymentStatusPending {
+			return true
+		}
+	}
+	return false
+}
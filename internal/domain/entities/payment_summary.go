@@ -0,0 +1,11 @@
+package entities
+
+// PaymentSummary is what was paid with one method over a period. Amount is what
+// customers handed over and ChangeAmount the change given back from it.
+type PaymentSummary struct {
+	Method           string
+	PaymentCount     int
+	TransactionCount int
+	Amount           int
+	ChangeAmount     int
+}
@@ -0,0 +1,148 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSettlePayments(t *testing.T) {
+	tests := []struct {
+		name       string
+		payments   []TransactionPayment
+		due        int
+		wantPaid   int
+		wantChange int
+		// wantChanges is the change recorded on each payment
+		wantChanges []int
+		wantErr     string
+	}{
+		{
+			name:        "exact cash",
+			payments:    []TransactionPayment{{Method: PaymentCash, Amount: 20000}},
+			due:         20000,
+			wantPaid:    20000,
+			wantChanges: []int{0},
+		},
+		{
+			name:        "cash with change",
+			payments:    []TransactionPayment{{Method: PaymentCash, Amount: 50000}},
+			due:         37500,
+			wantPaid:    50000,
+			wantChange:  12500,
+			wantChanges: []int{12500},
+		},
+		{
+			name: "split card and cash gives change from cash",
+			payments: []TransactionPayment{
+				{Method: PaymentDebit, Amount: 30000},
+				{Method: PaymentCash, Amount: 20000},
+			},
+			due:         45000,
+			wantPaid:    50000,
+			wantChange:  5000,
+			wantChanges: []int{0, 5000},
+		},
+		{
+			name: "change from the largest cash tender first",
+			payments: []TransactionPayment{
+				{Method: PaymentCash, Amount: 1000},
+				{Method: PaymentCash, Amount: 50000},
+			},
+			due:         20000,
+			wantPaid:    51000,
+			wantChange:  31000,
+			wantChanges: []int{0, 31000},
+		},
+		{
+			name: "change spread when the largest cash tender is not enough",
+			payments: []TransactionPayment{
+				{Method: PaymentQRIS, Amount: 10000},
+				{Method: PaymentCash, Amount: 20000},
+				{Method: PaymentCash, Amount: 50000},
+			},
+			due:         10000,
+			wantPaid:    80000,
+			wantChange:  70000,
+			wantChanges: []int{0, 20000, 50000},
+		},
+		{
+			name:     "underpaid",
+			payments: []TransactionPayment{{Method: PaymentCash, Amount: 10000}},
+			due:      15000,
+			wantErr:  "Rp 5000 is still due",
+		},
+		{
+			name: "non-cash over the amount due",
+			payments: []TransactionPayment{
+				{Method: PaymentCredit, Amount: 25000},
+			},
+			due:     20000,
+			wantErr: "only cash can give change",
+		},
+		{
+			name:     "unknown method",
+			payments: []TransactionPayment{{Method: "cheque", Amount: 10000}},
+			due:      10000,
+			wantErr:  "payment 1: payment method must be one of",
+		},
+		{
+			name:     "zero amount",
+			payments: []TransactionPayment{{Method: PaymentCash, Amount: 0}},
+			due:      10000,
+			wantErr:  "payment amount must be greater than 0",
+		},
+		{
+			name:     "cash through a gateway",
+			payments: []TransactionPayment{{Method: PaymentCash, Amount: 10000, Provider: "fake"}},
+			due:      10000,
+			wantErr:  "cannot be charged through a payment gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paid, change, err := SettlePayments(tt.payments, tt.due)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SettlePayments() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SettlePayments() unexpected error: %v", err)
+			}
+
+			if paid != tt.wantPaid || change != tt.wantChange {
+				t.Errorf("SettlePayments() = (%d, %d), want (%d, %d)", paid, change, tt.wantPaid, tt.wantChange)
+			}
+			for i, payment := range tt.payments {
+				if payment.ChangeAmount != tt.wantChanges[i] {
+					t.Errorf("payment %d change = %d, want %d", i+1, payment.ChangeAmount, tt.wantChanges[i])
+				}
+				if payment.ChangeAmount > payment.Amount {
+					t.Errorf("payment %d gives back %d from %d", i+1, payment.ChangeAmount, payment.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestPaymentMethodOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		payments []TransactionPayment
+		want     string
+	}{
+		{name: "no payments", want: PaymentCash},
+		{name: "single method", payments: []TransactionPayment{{Method: PaymentQRIS}, {Method: PaymentQRIS}}, want: PaymentQRIS},
+		{name: "several methods", payments: []TransactionPayment{{Method: PaymentCash}, {Method: PaymentDebit}}, want: PaymentSplit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PaymentMethodOf(tt.payments); got != tt.want {
+				t.Errorf("PaymentMethodOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
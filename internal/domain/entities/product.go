@@ -3,12 +3,45 @@ package entities
 import "time"
 
 type Product struct {
-	ID         int       `json:"id" db:"id"`
-	Name       string    `json:"name" db:"name"`
-	Price      float64   `json:"price" db:"price"`
-	Stock      int       `json:"stock" db:"stock"`
-	Active     bool      `json:"active" db:"active"`
-	CategoryID *int      `json:"category_id" db:"category_id"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	ID                int               `json:"id" db:"id"`
+	Name              string            `json:"name" db:"name"`
+	SKU               *string           `json:"sku" db:"sku"`
+	PLU               *string           `json:"plu" db:"plu"`
+	Barcodes          []string          `json:"barcodes"`
+	Price             float64           `json:"price" db:"price"`
+	StorePrice        *float64          `json:"store_price" db:"store_price"`
+	Cost              float64           `json:"cost" db:"cost"`
+	Stock             float64           `json:"stock" db:"stock"`
+	InTransit         float64           `json:"in_transit" db:"in_transit"`
+	Unit              string            `json:"unit" db:"unit"`
+	QuantityPrecision int               `json:"quantity_precision" db:"quantity_precision"`
+	UnitConversions   []UnitConversion  `json:"unit_conversions,omitempty"`
+	Active            bool              `json:"active" db:"active"`
+	TrackLots         bool              `json:"track_lots" db:"track_lots"`
+	Serialized        bool              `json:"serialized" db:"serialized"`
+	CategoryID        *int              `json:"category_id" db:"category_id"`
+	TaxRateID         *int              `json:"tax_rate_id" db:"tax_rate_id"`
+	ParentID          *int              `json:"parent_id" db:"parent_id"`
+	OptionValues      []string          `json:"option_values" db:"option_values"`
+	HasVariants       bool              `json:"has_variants"`
+	IsBundle          bool              `json:"is_bundle"`
+	Components        []BundleComponent `json:"components,omitempty"`
+	Options           []ProductOption   `json:"options,omitempty"`
+	Variants          []Product         `json:"variants,omitempty"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at" db:"updated_at"`
+	DeletedAt         *time.Time        `json:"deleted_at" db:"deleted_at"`
+}
+
+// SellingPrice returns the store price override if set, otherwise the catalog price
+func (p *Product) SellingPrice() float64 {
+	if p.StorePrice != nil {
+		return *p.StorePrice
+	}
+	return p.Price
+}
+
+// IsArchived reports whether the product has been deleted
+func (p *Product) IsArchived() bool {
+	return p.DeletedAt != nil
 }
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// ProductLot is a batch of a lot-tracked product held by a store
+type ProductLot struct {
+	ID          int        `json:"id" db:"id"`
+	StoreID     int        `json:"store_id" db:"store_id"`
+	ProductID   int        `json:"product_id" db:"product_id"`
+	ProductName string     `json:"product_name,omitempty"`
+	LotNumber   string     `json:"lot_number" db:"lot_number"`
+	ExpiryDate  *time.Time `json:"expiry_date" db:"expiry_date"`
+	Quantity    float64    `json:"quantity" db:"quantity"`
+	ReceivedAt  time.Time  `json:"received_at" db:"received_at"`
+}
+
+// LotAllocation records how many units of a lot were drawn for a sale or transfer line
+type LotAllocation struct {
+	LotID      int        `json:"lot_id" db:"lot_id"`
+	LotNumber  string     `json:"lot_number" db:"lot_number"`
+	ExpiryDate *time.Time `json:"expiry_date" db:"expiry_date"`
+	Quantity   float64    `json:"quantity" db:"quantity"`
+}
@@ -0,0 +1,10 @@
+package entities
+
+// ProductOption is an option axis of a product with variants, such as Size or Color
+type ProductOption struct {
+	ID        int      `json:"id" db:"id"`
+	ProductID int      `json:"product_id" db:"product_id"`
+	Name      string   `json:"name" db:"name"`
+	Values    []string `json:"values" db:"values"`
+	Position  int      `json:"position" db:"position"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Product serial statuses
+const (
+	SerialStatusInStock = "in_stock"
+	SerialStatusSold    = "sold"
+)
+
+// ProductSerial is a single serialized unit of a product, such as a laptop
+type ProductSerial struct {
+	ID              int    `json:"id" db:"id"`
+	ProductID       int    `json:"product_id" db:"product_id"`
+	ProductName     string `json:"product_name,omitempty"`
+	SerialNumber    string `json:"serial_number" db:"serial_number"`
+	Status          string `json:"status" db:"status"`
+	ReceivedStoreID int    `json:"received_store_id" db:"received_store_id"`
+	// StoreID is the store holding the unit, or the store it was sold at; it is
+	// nil while the unit travels on a stock transfer
+	StoreID             *int       `json:"store_id" db:"store_id"`
+	GoodsReceiptID      *int       `json:"goods_receipt_id" db:"goods_receipt_id"`
+	TransactionDetailID *int       `json:"transaction_detail_id" db:"transaction_detail_id"`
+	TransactionID       *int       `json:"transaction_id,omitempty"`
+	ReceivedAt          time.Time  `json:"received_at" db:"received_at"`
+	SoldAt              *time.Time `json:"sold_at" db:"sold_at"`
+}
@@ -0,0 +1,218 @@
+package entities
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Promotion types
+const (
+	// PromotionBuyXGetY gives get_quantity units free for every buy_quantity units bought,
+	// such as buy 2 get 1. The cheapest units are the free ones.
+	PromotionBuyXGetY = "buy_x_get_y"
+	// PromotionQuantityTier lowers the unit price once enough units of a product are bought
+	PromotionQuantityTier = "quantity_tier"
+	// PromotionCategoryPercent takes a percentage off everything in a category and its subcategories
+	PromotionCategoryPercent = "category_percent"
+	// PromotionBundlePrice sells a set of products together for a fixed price
+	PromotionBundlePrice = "bundle_price"
+)
+
+// PromotionTypes lists the supported promotion types
+var PromotionTypes = []string{PromotionBuyXGetY, PromotionQuantityTier, PromotionCategoryPercent, PromotionBundlePrice}
+
+// Promotion is a discount rule applied automatically at checkout while it runs.
+// Promotions run between StartsAt and EndsAt, on the listed days of the week
+// (0 is Sunday) and between StartTime and EndTime ("HH:MM", the end is
+// exclusive and may be past midnight, in which case the hours after midnight
+// belong to the day the window started on). Unset limits do not restrict it.
+//
+// Higher priority promotions are applied first. A promotion that is not
+// stackable only discounts lines no other promotion has discounted, and keeps
+// later promotions off the lines it discounts.
+type Promotion struct {
+	ID          int             `json:"id" db:"id"`
+	Name        string          `json:"name" db:"name"`
+	Type        string          `json:"type" db:"type"`
+	Priority    int             `json:"priority" db:"priority"`
+	Stackable   bool            `json:"stackable" db:"stackable"`
+	Active      bool            `json:"active" db:"active"`
+	StartsAt    *time.Time      `json:"starts_at" db:"starts_at"`
+	EndsAt      *time.Time      `json:"ends_at" db:"ends_at"`
+	Days        []int           `json:"days" db:"days_of_week"`
+	StartTime   string          `json:"start_time" db:"start_time"`
+	EndTime     string          `json:"end_time" db:"end_time"`
+	BuyQuantity int             `json:"buy_quantity" db:"buy_quantity"`
+	GetQuantity int             `json:"get_quantity" db:"get_quantity"`
+	CategoryID  *int            `json:"category_id" db:"category_id"`
+	Percent     float64         `json:"percent" db:"percent"`
+	BundlePrice int             `json:"bundle_price" db:"bundle_price"`
+	Items       []PromotionItem `json:"items"`
+	Tiers       []PromotionTier `json:"tiers"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+	// CategoryIDs holds the promoted category and its subcategories
+	CategoryIDs []int `json:"-"`
+}
+
+// PromotionItem is a product a promotion applies to. Quantity is the number of
+// units of the product in one bundle of a bundle price promotion.
+type PromotionItem struct {
+	ID          int    `json:"id" db:"id"`
+	PromotionID int    `json:"promotion_id" db:"promotion_id"`
+	ProductID   int    `json:"product_id" db:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	Quantity    int    `json:"quantity" db:"quantity"`
+}
+
+// PromotionTier sets the unit price once at least MinQuantity units are bought
+type PromotionTier struct {
+	ID          int     `json:"id" db:"id"`
+	PromotionID int     `json:"promotion_id" db:"promotion_id"`
+	MinQuantity float64 `json:"min_quantity" db:"min_quantity"`
+	UnitPrice   int     `json:"unit_price" db:"unit_price"`
+}
+
+// Validate checks the promotion's window and the settings its type needs
+func (p *Promotion) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("promotion name is required")
+	}
+
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+
+	for _, day := range p.Days {
+		if day < 0 || day > 6 {
+			return fmt.Errorf("days must be between 0 (Sunday) and 6 (Saturday)")
+		}
+	}
+
+	if (p.StartTime == "") != (p.EndTime == "") {
+		return fmt.Errorf("start_time and end_time must be set together")
+	}
+	for _, clock := range []string{p.StartTime, p.EndTime} {
+		if _, err := time.Parse("15:04", clock); clock != "" && err != nil {
+			return fmt.Errorf("invalid time %q, expected HH:MM", clock)
+		}
+	}
+	if p.StartTime != "" && p.StartTime == p.EndTime {
+		return fmt.Errorf("start_time and end_time must be different")
+	}
+
+	seen := make(map[int]bool)
+	for _, item := range p.Items {
+		if seen[item.ProductID] {
+			return fmt.Errorf("product %d is listed more than once", item.ProductID)
+		}
+		seen[item.ProductID] = true
+	}
+
+	switch p.Type {
+	case PromotionBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return fmt.Errorf("buy_quantity and get_quantity must be greater than 0")
+		}
+		if len(p.Items) == 0 {
+			return fmt.Errorf("items are required for a %s promotion", p.Type)
+		}
+	case PromotionQuantityTier:
+		if len(p.Tiers) == 0 {
+			return fmt.Errorf("tiers are required for a %s promotion", p.Type)
+		}
+		minQuantities := make(map[float64]bool)
+		for _, tier := range p.Tiers {
+			if tier.MinQuantity <= 0 || tier.UnitPrice <= 0 {
+				return fmt.Errorf("tier min_quantity and unit_price must be greater than 0")
+			}
+			if minQuantities[tier.MinQuantity] {
+				return fmt.Errorf("more than one tier starts at %g", tier.MinQuantity)
+			}
+			minQuantities[tier.MinQuantity] = true
+		}
+		if len(p.Items) == 0 {
+			return fmt.Errorf("items are required for a %s promotion", p.Type)
+		}
+	case PromotionCategoryPercent:
+		if p.CategoryID == nil {
+			return fmt.Errorf("category_id is required for a %s promotion", p.Type)
+		}
+		if p.Percent <= 0 || p.Percent > 100 {
+			return fmt.Errorf("percent must be greater than 0 and at most 100")
+		}
+	case PromotionBundlePrice:
+		if p.BundlePrice <= 0 {
+			return fmt.Errorf("bundle_price must be greater than 0")
+		}
+		units := 0
+		for _, item := range p.Items {
+			if item.Quantity <= 0 {
+				return fmt.Errorf("item quantity must be greater than 0")
+			}
+			units += item.Quantity
+		}
+		if units < 2 {
+			return fmt.Errorf("a bundle must contain at least 2 units")
+		}
+	default:
+		return fmt.Errorf("promotion type must be one of %v", PromotionTypes)
+	}
+
+	return nil
+}
+
+// ActiveAt reports whether the promotion runs at the given time. Its days and
+// hours are local times in loc, the time zone of the stores.
+func (p *Promotion) ActiveAt(at time.Time, loc *time.Location) bool {
+	if !p.Active {
+		return false
+	}
+
+	if p.StartsAt != nil && at.Before(*p.StartsAt) {
+		return false
+	}
+	if p.EndsAt != nil && !at.Before(*p.EndsAt) {
+		return false
+	}
+
+	at = at.In(loc)
+	day := at.Weekday()
+	if p.StartTime != "" {
+		clock := at.Format("15:04")
+		switch {
+		case p.StartTime < p.EndTime:
+			if clock < p.StartTime || clock >= p.EndTime {
+				return false
+			}
+		case clock >= p.StartTime:
+		case clock < p.EndTime:
+			// The window runs past midnight and belongs to the day it started on
+			day = (day + 6) % 7
+		default:
+			return false
+		}
+	}
+
+	return len(p.Days) == 0 || slices.Contains(p.Days, int(day))
+}
+
+// HasProduct reports whether the promotion lists the product
+func (p *Promotion) HasProduct(productID int) bool {
+	return slices.ContainsFunc(p.Items, func(item PromotionItem) bool {
+		return item.ProductID == productID
+	})
+}
+
+// TierFor returns the tier reached by a quantity, or nil if none is
+func (p *Promotion) TierFor(quantity float64) *PromotionTier {
+	var reached *PromotionTier
+	for i := range p.Tiers {
+		tier := &p.Tiers[i]
+		if quantity >= tier.MinQuantity && (reached == nil || tier.MinQuantity > reached.MinQuantity) {
+			reached = tier
+		}
+	}
+	return reached
+}
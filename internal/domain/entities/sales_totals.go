@@ -0,0 +1,15 @@
+package entities
+
+// SalesTotals breaks the amount of a set of transactions down into its
+// components. The gross amount less discounts, plus the service charge, the tax
+// added on top of exclusive prices and the rounding, adds up to the total.
+// Tax included in inclusive prices is already part of the gross amount.
+type SalesTotals struct {
+	GrossAmount    int
+	DiscountAmount int
+	ServiceCharge  int
+	TaxIncluded    int
+	TaxAdded       int
+	RoundingAmount int
+	TotalAmount    int
+}
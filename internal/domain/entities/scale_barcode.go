@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ScaleBarcodeFormat describes the in-store EAN-13 labels printed by deli and
+// produce scales. Codes starting with one of the prefixes (GS1 reserves 20-29
+// for in-store use) carry an item code (the product's PLU) followed by either a
+// weight or a price. Digits between the item code and the value, if any, are a
+// price check digit and are ignored; the last digit is the EAN-13 check digit.
+type ScaleBarcodeFormat struct {
+	WeightPrefixes []string
+	PricePrefixes  []string
+	ItemDigits     int
+	ValueDigits    int
+	// WeightDecimals is the number of decimal places of the weight in kg, so 3 reads 01250 as 1.250 kg
+	WeightDecimals int
+	// PriceDecimals is the number of decimal places of the price, 0 for whole rupiah
+	PriceDecimals int
+}
+
+// ScaleBarcode is a decoded scale label
+type ScaleBarcode struct {
+	Barcode string
+	PLU     string
+	// Weight in kg for weight labels
+	Weight *float64
+	// Price of the item for price labels
+	Price *float64
+}
+
+// DefaultScaleBarcodeFormat returns the common layout 2P IIIII VVVVV C: prefixes
+// 20 and 21 carry a weight in grams, 22 and 23 a price in rupiah
+func DefaultScaleBarcodeFormat() ScaleBarcodeFormat {
+	return ScaleBarcodeFormat{
+		WeightPrefixes: []string{"20", "21"},
+		PricePrefixes:  []string{"22", "23"},
+		ItemDigits:     5,
+		ValueDigits:    5,
+		WeightDecimals: 3,
+		PriceDecimals:  0,
+	}
+}
+
+// Validate checks that every prefix leaves room for the item code and value
+func (f ScaleBarcodeFormat) Validate() error {
+	if f.ItemDigits <= 0 || f.ValueDigits <= 0 {
+		return fmt.Errorf("scale barcode item and value digits must be greater than 0")
+	}
+	if f.WeightDecimals < 0 || f.PriceDecimals < 0 {
+		return fmt.Errorf("scale barcode decimals cannot be negative")
+	}
+
+	seen := make(map[string]bool)
+	for _, prefix := range append(append([]string{}, f.WeightPrefixes...), f.PricePrefixes...) {
+		if prefix == "" || strings.Trim(prefix, "0123456789") != "" {
+			return fmt.Errorf("scale barcode prefix %q must contain digits only", prefix)
+		}
+		if seen[prefix] {
+			return fmt.Errorf("scale barcode prefix %s is configured more than once", prefix)
+		}
+		seen[prefix] = true
+
+		if len(prefix)+f.ItemDigits+f.ValueDigits > 12 {
+			return fmt.Errorf("scale barcode prefix %s with %d item and %d value digits does not fit in 12 digits", prefix, f.ItemDigits, f.ValueDigits)
+		}
+	}
+
+	return nil
+}
+
+// Parse decodes a normalized EAN-13 barcode. It returns false when the code
+// does not start with a configured prefix.
+func (f ScaleBarcodeFormat) Parse(code string) (*ScaleBarcode, bool) {
+	if len(code) != 13 {
+		return nil, false
+	}
+
+	weightPrefix := matchPrefix(code, f.WeightPrefixes)
+	pricePrefix := matchPrefix(code, f.PricePrefixes)
+	isWeight := len(weightPrefix) >= len(pricePrefix)
+	prefix := pricePrefix
+	if isWeight {
+		prefix = weightPrefix
+	}
+	if prefix == "" {
+		return nil, false
+	}
+
+	item := code[len(prefix) : len(prefix)+f.ItemDigits]
+	digits := code[12-f.ValueDigits : 12]
+	raw, err := strconv.Atoi(digits)
+	if err != nil {
+		return nil, false
+	}
+
+	label := &ScaleBarcode{Barcode: code, PLU: NormalizePLU(item)}
+	if isWeight {
+		weight := float64(raw) / math.Pow10(f.WeightDecimals)
+		label.Weight = &weight
+	} else {
+		price := float64(raw) / math.Pow10(f.PriceDecimals)
+		label.Price = &price
+	}
+
+	return label, true
+}
+
+// matchPrefix returns the longest prefix the code starts with
+func matchPrefix(code string, prefixes []string) string {
+	match := ""
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(code, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	return match
+}
+
+// NormalizePLU strips the leading zeros scales pad item codes with, so PLU 123
+// matches the item code 00123
+func NormalizePLU(plu string) string {
+	plu = strings.TrimSpace(plu)
+	trimmed := strings.TrimLeft(plu, "0")
+	if trimmed == "" && plu != "" {
+		return "0"
+	}
+	return trimmed
+}
+
+// Quantity returns the quantity of product the label was printed for, in the
+// product's unit and rounded to its quantity precision. Weight labels convert
+// the weight from kg; price labels divide the price by the product's price.
+func (l *ScaleBarcode) Quantity(product *Product) (float64, error) {
+	var quantity float64
+	if l.Weight != nil {
+		converted, err := product.ConvertQuantity(*l.Weight, UnitKilogram)
+		if err != nil {
+			return 0, err
+		}
+		quantity = converted
+	} else if l.Price != nil {
+		price := product.SellingPrice()
+		if price <= 0 {
+			return 0, fmt.Errorf("product %s has no price to weigh the label against", product.Name)
+		}
+		quantity = *l.Price / price
+	}
+
+	quantity = product.RoundToPrecision(quantity)
+	if quantity <= 0 {
+		return 0, fmt.Errorf("scale label %s is below the smallest quantity of product %s", l.Barcode, product.Name)
+	}
+
+	return quantity, nil
+}
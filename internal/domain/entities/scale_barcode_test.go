@@ -0,0 +1,160 @@
+package entities
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ean13 completes a 12 digit payload with its check digit
+func ean13(payload string) string {
+	return payload + strconv.Itoa(BarcodeCheckDigit(payload))
+}
+
+func TestScaleBarcodeFormatParse(t *testing.T) {
+	// custom has an overlapping weight and price prefix and a price check digit
+	// between the item code and the price
+	custom := ScaleBarcodeFormat{
+		WeightPrefixes: []string{"2"},
+		PricePrefixes:  []string{"28"},
+		ItemDigits:     4,
+		ValueDigits:    5,
+		WeightDecimals: 3,
+		PriceDecimals:  2,
+	}
+	weight := func(kg float64) *float64 { return &kg }
+	price := func(rupiah float64) *float64 { return &rupiah }
+
+	tests := []struct {
+		name       string
+		format     ScaleBarcodeFormat
+		code       string
+		wantOK     bool
+		wantPLU    string
+		wantWeight *float64
+		wantPrice  *float64
+	}{
+		{name: "weight label", format: DefaultScaleBarcodeFormat(), code: ean13("200012301250"), wantOK: true, wantPLU: "123", wantWeight: weight(1.25)},
+		{name: "second weight prefix", format: DefaultScaleBarcodeFormat(), code: ean13("210012300085"), wantOK: true, wantPLU: "123", wantWeight: weight(0.085)},
+		{name: "price label", format: DefaultScaleBarcodeFormat(), code: ean13("220045615000"), wantOK: true, wantPLU: "456", wantPrice: price(15000)},
+		{name: "item code of zeros", format: DefaultScaleBarcodeFormat(), code: ean13("230000000500"), wantOK: true, wantPLU: "0", wantPrice: price(500)},
+		{name: "regular product barcode", format: DefaultScaleBarcodeFormat(), code: "4006381333931"},
+		{name: "unconfigured in-store prefix", format: DefaultScaleBarcodeFormat(), code: ean13("290012301250")},
+		{name: "not 13 digits", format: DefaultScaleBarcodeFormat(), code: "200012301250"},
+		{name: "longest prefix wins", format: custom, code: ean13("280042712345"), wantOK: true, wantPLU: "42", wantPrice: price(123.45)},
+		{name: "shorter prefix", format: custom, code: ean13("200420012345"), wantOK: true, wantPLU: "42", wantWeight: weight(12.345)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, ok := tt.format.Parse(tt.code)
+			if ok != tt.wantOK {
+				t.Fatalf("Parse(%s) ok = %v, want %v", tt.code, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if label.Barcode != tt.code || label.PLU != tt.wantPLU {
+				t.Errorf("Parse(%s) = barcode %s, PLU %s, want PLU %s", tt.code, label.Barcode, label.PLU, tt.wantPLU)
+			}
+			if !sameValue(label.Weight, tt.wantWeight) {
+				t.Errorf("Weight = %v, want %v", deref(label.Weight), deref(tt.wantWeight))
+			}
+			if !sameValue(label.Price, tt.wantPrice) {
+				t.Errorf("Price = %v, want %v", deref(label.Price), deref(tt.wantPrice))
+			}
+		})
+	}
+}
+
+func sameValue(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func TestScaleBarcodeFormatValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(f *ScaleBarcodeFormat)
+		wantErr bool
+	}{
+		{name: "default", change: func(f *ScaleBarcodeFormat) {}},
+		{name: "no item digits", change: func(f *ScaleBarcodeFormat) { f.ItemDigits = 0 }, wantErr: true},
+		{name: "negative decimals", change: func(f *ScaleBarcodeFormat) { f.PriceDecimals = -1 }, wantErr: true},
+		{name: "prefix with letters", change: func(f *ScaleBarcodeFormat) { f.WeightPrefixes = []string{"2A"} }, wantErr: true},
+		{name: "empty prefix", change: func(f *ScaleBarcodeFormat) { f.PricePrefixes = []string{""} }, wantErr: true},
+		{name: "prefix for weight and price", change: func(f *ScaleBarcodeFormat) { f.PricePrefixes = []string{"21"} }, wantErr: true},
+		{name: "prefix too long", change: func(f *ScaleBarcodeFormat) { f.WeightPrefixes = []string{"200"} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format := DefaultScaleBarcodeFormat()
+			tt.change(&format)
+			err := format.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizePLU(t *testing.T) {
+	tests := map[string]string{
+		"00123": "123",
+		"123":   "123",
+		" 42 ":  "42",
+		"000":   "0",
+		"":      "",
+	}
+
+	for plu, want := range tests {
+		if got := NormalizePLU(plu); got != want {
+			t.Errorf("NormalizePLU(%q) = %q, want %q", plu, got, want)
+		}
+	}
+}
+
+func TestScaleBarcodeQuantity(t *testing.T) {
+	weight := func(kg float64) *float64 { return &kg }
+	price := func(rupiah float64) *float64 { return &rupiah }
+	storePrice := 50000.0
+
+	tests := []struct {
+		name    string
+		label   ScaleBarcode
+		product Product
+		want    float64
+		wantErr bool
+	}{
+		{name: "weight in kg", label: ScaleBarcode{Weight: weight(1.25)}, product: Product{Unit: UnitKilogram, QuantityPrecision: 3}, want: 1.25},
+		{name: "weight converted to grams", label: ScaleBarcode{Weight: weight(0.085)}, product: Product{Unit: UnitGram}, want: 85},
+		{name: "weight rounded to precision", label: ScaleBarcode{Weight: weight(1.257)}, product: Product{Unit: UnitKilogram, QuantityPrecision: 2}, want: 1.26},
+		{name: "weight for a counted product", label: ScaleBarcode{Weight: weight(1)}, product: Product{Unit: UnitPiece}, wantErr: true},
+		{name: "weight below precision", label: ScaleBarcode{Weight: weight(0.004)}, product: Product{Unit: UnitKilogram, QuantityPrecision: 2}, wantErr: true},
+		{name: "price divided by unit price", label: ScaleBarcode{Price: price(15000)}, product: Product{Unit: UnitKilogram, Price: 60000, QuantityPrecision: 3}, want: 0.25},
+		{name: "price against the store price", label: ScaleBarcode{Price: price(15000)}, product: Product{Unit: UnitKilogram, Price: 60000, StorePrice: &storePrice, QuantityPrecision: 3}, want: 0.3},
+		{name: "price for a product without price", label: ScaleBarcode{Price: price(15000)}, product: Product{Unit: UnitKilogram}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.label.Quantity(&tt.product)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Quantity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Quantity() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// Stock transfer statuses. A transfer moves requested -> shipped -> received,
+// and can be cancelled while it is still requested.
+const (
+	StockTransferStatusRequested = "requested"
+	StockTransferStatusShipped   = "shipped"
+	StockTransferStatusReceived  = "received"
+	StockTransferStatusCancelled = "cancelled"
+)
+
+type StockTransfer struct {
+	ID                 int                 `json:"id" db:"id"`
+	SourceStoreID      int                 `json:"source_store_id" db:"source_store_id"`
+	DestinationStoreID int                 `json:"destination_store_id" db:"destination_store_id"`
+	Status             string              `json:"status" db:"status"`
+	Note               string              `json:"note" db:"note"`
+	ReceivingNote      string              `json:"receiving_note" db:"receiving_note"`
+	CreatedAt          time.Time           `json:"created_at" db:"created_at"`
+	ShippedAt          *time.Time          `json:"shipped_at" db:"shipped_at"`
+	ReceivedAt         *time.Time          `json:"received_at" db:"received_at"`
+	CancelledAt        *time.Time          `json:"cancelled_at" db:"cancelled_at"`
+	Items              []StockTransferItem `json:"items"`
+}
+
+type StockTransferItem struct {
+	ID                int     `json:"id" db:"id"`
+	TransferID        int     `json:"transfer_id" db:"transfer_id"`
+	ProductID         int     `json:"product_id" db:"product_id"`
+	ProductName       string  `json:"product_name,omitempty"`
+	QuantityRequested float64 `json:"quantity_requested" db:"quantity_requested"`
+	QuantityShipped   float64 `json:"quantity_shipped" db:"quantity_shipped"`
+	QuantityReceived  float64 `json:"quantity_received" db:"quantity_received"`
+	// SerialNumbers are the units shipped of a serialized product and
+	// ReceivedSerialNumbers those of them that arrived
+	SerialNumbers         []string `json:"serial_numbers,omitempty"`
+	ReceivedSerialNumbers []string `json:"received_serial_numbers,omitempty"`
+}
+
+// Discrepancy returns how many shipped units did not arrive (negative when more arrived than shipped)
+func (i *StockTransferItem) Discrepancy() float64 {
+	return RoundQuantity(i.QuantityShipped - i.QuantityReceived)
+}
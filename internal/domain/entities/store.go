@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultStoreID is the main store created by the multi-outlet migration.
+// Requests that do not name a store operate on it.
+const DefaultStoreID = 1
+
+// MaxCashRounding is the largest amount cash totals can be rounded to
+const MaxCashRounding = 1000
+
+type Store struct {
+	ID      int    `json:"id" db:"id"`
+	Name    string `json:"name" db:"name"`
+	Address string `json:"address" db:"address"`
+	TaxMode string `json:"tax_mode" db:"tax_mode"`
+	// ServiceChargeRate is the percentage of the cart added as a service charge, 0 for none
+	ServiceChargeRate float64 `json:"service_charge_rate" db:"service_charge_rate"`
+	// ServiceChargeTaxable taxes the service charge at the default tax rate
+	ServiceChargeTaxable bool `json:"service_charge_taxable" db:"service_charge_taxable"`
+	// CashRounding rounds totals paid in cash to the nearest multiple of this many rupiah, 0 for none
+	CashRounding int       `json:"cash_rounding" db:"cash_rounding"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the store's tax mode, service charge and cash rounding
+func (s *Store) Validate() error {
+	if err := ValidateTaxMode(s.TaxMode); err != nil {
+		return err
+	}
+
+	if s.ServiceChargeRate < 0 || s.ServiceChargeRate >= 100 {
+		return fmt.Errorf("service_charge_rate must be at least 0 and less than 100")
+	}
+
+	if math.Abs(s.ServiceChargeRate*100-math.Round(s.ServiceChargeRate*100)) > 1e-9 {
+		return fmt.Errorf("service_charge_rate can have at most 2 decimals")
+	}
+
+	if s.CashRounding < 0 || s.CashRounding > MaxCashRounding {
+		return fmt.Errorf("cash_rounding must be between 0 and %d", MaxCashRounding)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Tax price modes. With inclusive prices the selling price already contains the
+// tax; with exclusive prices the tax is added on top at checkout.
+const (
+	TaxInclusive = "inclusive"
+	TaxExclusive = "exclusive"
+)
+
+// ValidateTaxMode checks that a tax price mode is inclusive or exclusive
+func ValidateTaxMode(mode string) error {
+	if mode != TaxInclusive && mode != TaxExclusive {
+		return fmt.Errorf("tax_mode must be %s or %s", TaxInclusive, TaxExclusive)
+	}
+	return nil
+}
+
+// TaxRate is a rate of tax, such as PPN 11%, assigned to products directly or
+// through their category. The default rate applies to products that get no
+// rate either way.
+type TaxRate struct {
+	ID        int       `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Rate      float64   `json:"rate" db:"rate"`
+	IsDefault bool      `json:"is_default" db:"is_default"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate checks the tax rate's name and that its rate is a percentage with at most two decimals
+func (t *TaxRate) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" || len(t.Name) > 100 {
+		return fmt.Errorf("tax rate name must be 1 to 100 characters")
+	}
+
+	if t.Rate < 0 || t.Rate >= 100 {
+		return fmt.Errorf("tax rate must be at least 0 and less than 100")
+	}
+
+	if math.Abs(t.Rate*100-math.Round(t.Rate*100)) > 1e-9 {
+		return fmt.Errorf("tax rate can have at most 2 decimals")
+	}
+
+	return nil
+}
+
+// Split divides an amount sold at this rate into its taxable base and the tax on
+// it, both in whole rupiah rounded half up. With exclusive prices the amount is
+// the base and the tax comes on top; with inclusive prices the tax is taken out
+// of the amount, so base and tax add up to it.
+func (t *TaxRate) Split(amount int, mode string) (taxable, tax int) {
+	if amount <= 0 {
+		return 0, 0
+	}
+
+	// Work in hundredths of a percent so the result does not depend on float rounding
+	basisPoints := int(math.Round(t.Rate * 100))
+	if mode == TaxExclusive {
+		return amount, (amount*basisPoints*2 + 10000) / 20000
+	}
+
+	divisor := 10000 + basisPoints
+	taxable = (amount*10000*2 + divisor) / (divisor * 2)
+	return taxable, amount - taxable
+}
+
+// String describes the rate, such as "PPN 11%"
+func (t *TaxRate) String() string {
+	return fmt.Sprintf("%s %g%%", t.Name, t.Rate)
+}
+
+// TransactionTax is the tax charged at one rate on a transaction. Name and Rate
+// are copied at the time of sale, so the breakdown stays as it was charged if the
+// rate is later changed; TaxRateID is nil once the rate is deleted.
+type TransactionTax struct {
+	ID            int     `json:"id" db:"id"`
+	TransactionID int     `json:"transaction_id" db:"transaction_id"`
+	TaxRateID     *int    `json:"tax_rate_id" db:"tax_rate_id"`
+	Name          string  `json:"name" db:"name"`
+	Rate          float64 `json:"rate" db:"rate"`
+	TaxableAmount int     `json:"taxable_amount" db:"taxable_amount"`
+	TaxAmount     int     `json:"tax_amount" db:"tax_amount"`
+}
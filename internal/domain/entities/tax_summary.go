@@ -0,0 +1,12 @@
+package entities
+
+// TaxSummary is the tax charged at one rate over a period, summed from the tax
+// breakdown of each transaction. TaxRateID is nil for rates since deleted.
+type TaxSummary struct {
+	TaxRateID        *int
+	Name             string
+	Rate             float64
+	TransactionCount int
+	TaxableAmount    int
+	TaxAmount        int
+}
@@ -0,0 +1,69 @@
+package entities
+
+import "testing"
+
+func TestTaxRateSplit(t *testing.T) {
+	tests := []struct {
+		name        string
+		rate        float64
+		amount      int
+		mode        string
+		wantTaxable int
+		wantTax     int
+	}{
+		{name: "exclusive", rate: 11, amount: 10000, mode: TaxExclusive, wantTaxable: 10000, wantTax: 1100},
+		{name: "exclusive rounds half up", rate: 11, amount: 50, mode: TaxExclusive, wantTaxable: 50, wantTax: 6},
+		{name: "exclusive rounds down", rate: 11, amount: 104, mode: TaxExclusive, wantTaxable: 104, wantTax: 11},
+		{name: "exclusive with decimals", rate: 12.5, amount: 1000, mode: TaxExclusive, wantTaxable: 1000, wantTax: 125},
+		{name: "inclusive", rate: 11, amount: 11100, mode: TaxInclusive, wantTaxable: 10000, wantTax: 1100},
+		{name: "inclusive adds up to the amount", rate: 11, amount: 100, mode: TaxInclusive, wantTaxable: 90, wantTax: 10},
+		{name: "zero rate", rate: 0, amount: 100, mode: TaxInclusive, wantTaxable: 100, wantTax: 0},
+		{name: "nothing to tax", rate: 11, amount: 0, mode: TaxExclusive, wantTaxable: 0, wantTax: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := TaxRate{Name: "PPN", Rate: tt.rate}
+			taxable, tax := rate.Split(tt.amount, tt.mode)
+			if taxable != tt.wantTaxable || tax != tt.wantTax {
+				t.Errorf("Split(%d, %s) = %d, %d, want %d, %d", tt.amount, tt.mode, taxable, tax, tt.wantTaxable, tt.wantTax)
+			}
+		})
+	}
+}
+
+func TestTaxRateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rate    TaxRate
+		wantErr bool
+	}{
+		{name: "PPN", rate: TaxRate{Name: "PPN", Rate: 11}},
+		{name: "two decimals", rate: TaxRate{Name: "PPN", Rate: 11.25}},
+		{name: "zero rate", rate: TaxRate{Name: "Exempt", Rate: 0}},
+		{name: "three decimals", rate: TaxRate{Name: "PPN", Rate: 11.125}, wantErr: true},
+		{name: "negative", rate: TaxRate{Name: "PPN", Rate: -1}, wantErr: true},
+		{name: "100 percent", rate: TaxRate{Name: "PPN", Rate: 100}, wantErr: true},
+		{name: "blank name", rate: TaxRate{Name: "   ", Rate: 11}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rate.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTaxMode(t *testing.T) {
+	for _, mode := range []string{TaxInclusive, TaxExclusive} {
+		if err := ValidateTaxMode(mode); err != nil {
+			t.Errorf("ValidateTaxMode(%s) error = %v", mode, err)
+		}
+	}
+	if err := ValidateTaxMode("included"); err == nil {
+		t.Error("ValidateTaxMode(included) error = nil, want an error")
+	}
+}
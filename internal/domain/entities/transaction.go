@@ -3,19 +3,54 @@ package entities
 import "time"
 
 type Transaction struct {
-	ID          int                 `json:"id" db:"id"`
-	TotalAmount int                 `json:"total_amount" db:"total_amount"`
-	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
-	Details     []TransactionDetail `json:"details"`
+	ID             int                  `json:"id" db:"id"`
+	StoreID        int                  `json:"store_id" db:"store_id"`
+	CustomerRef    string               `json:"customer_ref" db:"customer_ref"`
+	GrossAmount    int                  `json:"gross_amount" db:"gross_amount"`
+	DiscountAmount int                  `json:"discount_amount" db:"discount_amount"`
+	ServiceCharge  int                  `json:"service_charge" db:"service_charge"`
+	TaxMode        string               `json:"tax_mode" db:"tax_mode"`
+	TaxAmount      int                  `json:"tax_amount" db:"tax_amount"`
+	RoundingAmount int                  `json:"rounding_amount" db:"rounding_amount"`
+	TotalAmount    int                  `json:"total_amount" db:"total_amount"`
+	PaidAmount     int                  `json:"paid_amount" db:"paid_amount"`
+	ChangeAmount   int                  `json:"change_amount" db:"change_amount"`
+	PaymentMethod  string               `json:"payment_method" db:"payment_method"`
+	Status         string               `json:"status" db:"status"`
+	CreatedAt      time.Time            `json:"created_at" db:"created_at"`
+	ExpiresAt      *time.Time           `json:"expires_at" db:"expires_at"`
+	CompletedAt    *time.Time           `json:"completed_at" db:"completed_at"`
+	FailedAt       *time.Time           `json:"failed_at" db:"failed_at"`
+	ExpiredAt      *time.Time           `json:"expired_at" db:"expired_at"`
+	VoidedAt       *time.Time           `json:"voided_at" db:"voided_at"`
+	RefundedAt     *time.Time           `json:"refunded_at" db:"refunded_at"`
+	VoidReason     string               `json:"void_reason" db:"void_reason"`
+	RefundReason   string               `json:"refund_reason" db:"refund_reason"`
+	Details        []TransactionDetail  `json:"details"`
+	Discounts      []AppliedDiscount    `json:"discounts,omitempty"`
+	Taxes          []TransactionTax     `json:"taxes,omitempty"`
+	Payments       []TransactionPayment `json:"payments,omitempty"`
 }
 
 type TransactionDetail struct {
-	ID            int    `json:"id" db:"id"`
-	TransactionID int    `json:"transaction_id" db:"transaction_id"`
-	ProductID     int    `json:"product_id" db:"product_id"`
-	ProductName   string `json:"product_name,omitempty"`
-	Quantity      int    `json:"quantity" db:"quantity"`
-	Subtotal      int    `json:"subtotal" db:"subtotal"`
+	ID             int               `json:"id" db:"id"`
+	TransactionID  int               `json:"transaction_id" db:"transaction_id"`
+	ProductID      int               `json:"product_id" db:"product_id"`
+	ProductName    string            `json:"product_name,omitempty"`
+	Quantity       float64           `json:"quantity" db:"quantity"`
+	Unit           string            `json:"unit"`
+	GrossAmount    int               `json:"gross_amount" db:"gross_amount"`
+	DiscountAmount int               `json:"discount_amount" db:"discount_amount"`
+	Subtotal       int               `json:"subtotal" db:"subtotal"`
+	TaxRateID      *int              `json:"tax_rate_id" db:"tax_rate_id"`
+	TaxRate        float64           `json:"tax_rate" db:"tax_rate"`
+	TaxableAmount  int               `json:"taxable_amount" db:"taxable_amount"`
+	TaxAmount      int               `json:"tax_amount" db:"tax_amount"`
+	UnitCost       float64           `json:"unit_cost" db:"unit_cost"`
+	Lots           []LotAllocation   `json:"lots,omitempty"`
+	SerialNumbers  []string          `json:"serial_numbers,omitempty"`
+	Discounts      []AppliedDiscount `json:"discounts,omitempty"`
+	Components     []BundleComponent `json:"-"`
 }
 
 type CheckoutItem struct {
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// Transaction statuses. A checkout completes straight away, or is saved as a
+// draft to be completed later, or is pending while a payment gateway confirms
+// its payments. A pending transaction holds its stock: it completes once its
+// payments are confirmed, and fails when one fails or expires when they are not
+// confirmed in time, putting its stock back. A completed transaction can be
+// voided on the day of sale and refunded after that; both put its stock back.
+const (
+	TransactionStatusDraft     = "draft"
+	TransactionStatusPending   = "pending"
+	TransactionStatusCompleted = "completed"
+	TransactionStatusFailed    = "failed"
+	TransactionStatusExpired   = "expired"
+	TransactionStatusVoided    = "voided"
+	TransactionStatusRefunded  = "refunded"
+)
+
+// transactionTransitions lists the statuses a transaction can move to from each
+// status. Failed, expired, voided and refunded transactions are final.
+var transactionTransitions = map[string][]string{
+	TransactionStatusDraft:     {TransactionStatusPending, TransactionStatusCompleted, TransactionStatusVoided},
+	TransactionStatusPending:   {TransactionStatusCompleted, TransactionStatusFailed, TransactionStatusExpired, TransactionStatusVoided},
+	TransactionStatusCompleted: {TransactionStatusVoided, TransactionStatusRefunded},
+}
+
+// CanTransition reports whether a transaction can move from one status to another
+func CanTransition(from, to string) bool {
+	for _, status := range transactionTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckTransition checks that the transaction can move to the given status at
+// the given time. Completed sales can only be voided on the day they completed,
+// which is the day reports count them; after that they are refunded.
+func (t *Transaction) CheckTransition(to string, at time.Time) error {
+	if !CanTransition(t.Status, to) {
+		return fmt.Errorf("transaction %d is %s and cannot become %s", t.ID, t.Status, to)
+	}
+
+	if t.Status == TransactionStatusCompleted && to == TransactionStatusVoided {
+		completed := t.CreatedAt
+		if t.CompletedAt != nil {
+			completed = *t.CompletedAt
+		}
+		year, month, day := completed.Date()
+		atYear, atMonth, atDay := at.Date()
+		if year != atYear || month != atMonth || day != atDay {
+			return fmt.Errorf("transaction %d can only be voided on the day of sale; refund it instead", t.ID)
+		}
+	}
+
+	return nil
+}
+
+// HoldsStock reports whether the transaction has taken its stock, which must be
+// put back when it is voided or refunded. Drafts take their stock on completion.
+func (t *Transaction) HoldsStock() bool {
+	return t.Status == TransactionStatusPending || t.Status == TransactionStatusCompleted
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckTransition(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.Local) }
+	at := func(tm time.Time) *time.Time { return &tm }
+
+	tests := []struct {
+		name        string
+		transaction Transaction
+		to          string
+		at          time.Time
+		wantErr     bool
+	}{
+		{name: "void a sale the day it completed", transaction: Transaction{Status: TransactionStatusCompleted, CreatedAt: day(2, 9), CompletedAt: at(day(2, 9))}, to: TransactionStatusVoided, at: day(2, 21)},
+		{name: "void a sale the day after it completed", transaction: Transaction{Status: TransactionStatusCompleted, CreatedAt: day(2, 9), CompletedAt: at(day(2, 9))}, to: TransactionStatusVoided, at: day(3, 8), wantErr: true},
+		{name: "void a gateway sale that completed a day after checkout", transaction: Transaction{Status: TransactionStatusCompleted, CreatedAt: day(2, 23), CompletedAt: at(day(3, 1))}, to: TransactionStatusVoided, at: day(3, 8)},
+		{name: "void a gateway sale on its checkout day only", transaction: Transaction{Status: TransactionStatusCompleted, CreatedAt: day(2, 23), CompletedAt: at(day(3, 1))}, to: TransactionStatusVoided, at: day(2, 23), wantErr: true},
+		{name: "refund a sale from an earlier day", transaction: Transaction{Status: TransactionStatusCompleted, CreatedAt: day(2, 9), CompletedAt: at(day(2, 9))}, to: TransactionStatusRefunded, at: day(9, 10)},
+		{name: "void a pending transaction", transaction: Transaction{Status: TransactionStatusPending, CreatedAt: day(2, 9)}, to: TransactionStatusVoided, at: day(5, 9)},
+		{name: "refund a voided sale", transaction: Transaction{Status: TransactionStatusVoided, CreatedAt: day(2, 9)}, to: TransactionStatusRefunded, at: day(2, 10), wantErr: true},
+		{name: "complete a draft", transaction: Transaction{Status: TransactionStatusDraft, CreatedAt: day(2, 9)}, to: TransactionStatusCompleted, at: day(4, 9)},
+		{name: "refund a draft", transaction: Transaction{Status: TransactionStatusDraft, CreatedAt: day(2, 9)}, to: TransactionStatusRefunded, at: day(2, 9), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.transaction.CheckTransition(tt.to, tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckTransition(%s) error = %v, wantErr %v", tt.to, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Units of measure a product can be stocked and sold in
+const (
+	UnitPiece      = "pcs"
+	UnitKilogram   = "kg"
+	UnitGram       = "g"
+	UnitLiter      = "L"
+	UnitMilliliter = "ml"
+)
+
+// MaxQuantityPrecision is the most decimal places a quantity can have, matching
+// the NUMERIC(14,3) quantity columns
+const MaxQuantityPrecision = 3
+
+// standardUnit expresses a unit in the smallest unit of its dimension so that
+// units of the same dimension convert into each other
+type standardUnit struct {
+	dimension string
+	factor    float64
+}
+
+var standardUnits = map[string]standardUnit{
+	UnitPiece:      {dimension: "count", factor: 1},
+	UnitKilogram:   {dimension: "mass", factor: 1000},
+	UnitGram:       {dimension: "mass", factor: 1},
+	UnitLiter:      {dimension: "volume", factor: 1000},
+	UnitMilliliter: {dimension: "volume", factor: 1},
+}
+
+// UnitConversion is a purchasing or selling unit of a product expressed in the
+// product's stock unit, such as a box holding 24 pcs
+type UnitConversion struct {
+	ID        int     `json:"id" db:"id"`
+	ProductID int     `json:"product_id" db:"product_id"`
+	Unit      string  `json:"unit" db:"unit"`
+	Factor    float64 `json:"factor" db:"factor"`
+}
+
+// NormalizeUnit returns the standard spelling of a unit of measure, matching
+// case-insensitively so that "KG" and "l" are accepted
+func NormalizeUnit(unit string) (string, bool) {
+	unit = strings.TrimSpace(unit)
+	for name := range standardUnits {
+		if strings.EqualFold(name, unit) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// DefaultQuantityPrecision returns the decimal places a unit is sold in when a
+// product does not configure them: grams of kilos and milliliters of liters
+func DefaultQuantityPrecision(unit string) int {
+	if unit == UnitKilogram || unit == UnitLiter {
+		return MaxQuantityPrecision
+	}
+	return 0
+}
+
+// RoundQuantity rounds a quantity to MaxQuantityPrecision decimal places to drop
+// floating point noise from conversions
+func RoundQuantity(quantity float64) float64 {
+	scale := math.Pow10(MaxQuantityPrecision)
+	return math.Round(quantity*scale) / scale
+}
+
+// RoundToPrecision rounds a quantity to the product's quantity precision
+func (p *Product) RoundToPrecision(quantity float64) float64 {
+	scale := math.Pow10(p.QuantityPrecision)
+	return math.Round(quantity*scale) / scale
+}
+
+// ConvertQuantity converts a quantity given in unit to the product's stock unit.
+// An empty unit means the stock unit. Custom conversions of the product are
+// checked first, then the standard conversions between kg and g and between L and ml.
+func (p *Product) ConvertQuantity(quantity float64, unit string) (float64, error) {
+	unit = strings.TrimSpace(unit)
+	if unit == "" || unit == p.Unit {
+		return quantity, nil
+	}
+
+	for _, conversion := range p.UnitConversions {
+		if strings.EqualFold(conversion.Unit, unit) {
+			return RoundQuantity(quantity * conversion.Factor), nil
+		}
+	}
+
+	if name, ok := NormalizeUnit(unit); ok {
+		from, to := standardUnits[name], standardUnits[p.Unit]
+		if name == p.Unit {
+			return quantity, nil
+		}
+		if from.dimension == to.dimension && from.dimension != "count" {
+			return RoundQuantity(quantity * from.factor / to.factor), nil
+		}
+	}
+
+	return 0, fmt.Errorf("product %s is measured in %s and cannot be converted from %s", p.Name, p.Unit, unit)
+}
+
+// ValidateQuantity checks that a quantity in the product's stock unit is positive
+// and has no more decimal places than the product's quantity precision
+func (p *Product) ValidateQuantity(quantity float64) error {
+	if quantity <= 0 {
+		return fmt.Errorf("quantity of product %s must be greater than 0", p.Name)
+	}
+	return p.validatePrecision(quantity)
+}
+
+// ValidateStock checks that a stock level in the product's stock unit is not
+// negative and has no more decimal places than the product's quantity precision
+func (p *Product) ValidateStock(stock float64) error {
+	if stock < 0 {
+		return fmt.Errorf("stock of product %s cannot be negative", p.Name)
+	}
+	return p.validatePrecision(stock)
+}
+
+func (p *Product) validatePrecision(quantity float64) error {
+	scaled := quantity * math.Pow10(p.QuantityPrecision)
+	if math.Abs(scaled-math.Round(scaled)) > 1e-6 {
+		if p.QuantityPrecision == 0 {
+			return fmt.Errorf("product %s is counted in whole %s", p.Name, p.Unit)
+		}
+		return fmt.Errorf("quantity of product %s allows at most %d decimal places", p.Name, p.QuantityPrecision)
+	}
+	return nil
+}
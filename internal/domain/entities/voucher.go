@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// voucherCodePattern limits voucher codes to what a cashier can type
+var voucherCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{2,49}$`)
+
+// NormalizeVoucherCode trims a voucher code and upper-cases it, so codes match regardless of case
+func NormalizeVoucherCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Voucher is a code that takes a percentage or a fixed amount off a cart. A
+// voucher can require a minimum spend, cap its percentage discount at
+// MaxDiscount, run between StartsAt and ExpiresAt, and be limited to UsageLimit
+// redemptions overall and PerCustomerLimit redemptions per customer.
+type Voucher struct {
+	ID               int        `json:"id" db:"id"`
+	Code             string     `json:"code" db:"code"`
+	Description      string     `json:"description" db:"description"`
+	Type             string     `json:"type" db:"type"`
+	Value            float64    `json:"value" db:"value"`
+	MaxDiscount      int        `json:"max_discount" db:"max_discount"`
+	MinSpend         int        `json:"min_spend" db:"min_spend"`
+	UsageLimit       *int       `json:"usage_limit" db:"usage_limit"`
+	PerCustomerLimit *int       `json:"per_customer_limit" db:"per_customer_limit"`
+	StartsAt         *time.Time `json:"starts_at" db:"starts_at"`
+	ExpiresAt        *time.Time `json:"expires_at" db:"expires_at"`
+	Active           bool       `json:"active" db:"active"`
+	TimesRedeemed    int        `json:"times_redeemed" db:"times_redeemed"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// VoucherRedemption records a voucher used on a transaction
+type VoucherRedemption struct {
+	ID            int       `json:"id" db:"id"`
+	VoucherID     int       `json:"voucher_id" db:"voucher_id"`
+	TransactionID int       `json:"transaction_id" db:"transaction_id"`
+	CustomerRef   string    `json:"customer_ref" db:"customer_ref"`
+	Amount        int       `json:"amount" db:"amount"`
+	RedeemedAt    time.Time `json:"redeemed_at" db:"redeemed_at"`
+}
+
+// Validate checks the voucher's code, value and limits
+func (v *Voucher) Validate() error {
+	if !voucherCodePattern.MatchString(v.Code) {
+		return fmt.Errorf("voucher code must be 3 to 50 letters, digits or dashes")
+	}
+
+	if err := v.Discount().Validate(); err != nil {
+		return err
+	}
+
+	if v.MaxDiscount < 0 || v.MinSpend < 0 {
+		return fmt.Errorf("max_discount and min_spend cannot be negative")
+	}
+
+	if v.UsageLimit != nil && *v.UsageLimit <= 0 {
+		return fmt.Errorf("usage_limit must be greater than 0")
+	}
+
+	if v.PerCustomerLimit != nil && *v.PerCustomerLimit <= 0 {
+		return fmt.Errorf("per_customer_limit must be greater than 0")
+	}
+
+	if v.StartsAt != nil && v.ExpiresAt != nil && !v.ExpiresAt.After(*v.StartsAt) {
+		return fmt.Errorf("expires_at must be after starts_at")
+	}
+
+	return nil
+}
+
+// Discount returns the discount the voucher gives
+func (v *Voucher) Discount() Discount {
+	return Discount{Type: v.Type, Value: v.Value}
+}
+
+// Of returns the discount the voucher gives on an amount, capped at MaxDiscount when set
+func (v *Voucher) Of(amount int) int {
+	discount := v.Discount().Of(amount)
+	if v.MaxDiscount > 0 {
+		discount = min(discount, v.MaxDiscount)
+	}
+	return discount
+}
+
+// CheckRedeemable returns why the voucher cannot be redeemed at the given time, if it cannot
+func (v *Voucher) CheckRedeemable(at time.Time) error {
+	if !v.Active {
+		return fmt.Errorf("voucher %s is inactive", v.Code)
+	}
+
+	if v.StartsAt != nil && at.Before(*v.StartsAt) {
+		return fmt.Errorf("voucher %s is not valid until %s", v.Code, v.StartsAt.Format(time.DateTime))
+	}
+
+	if v.ExpiresAt != nil && !at.Before(*v.ExpiresAt) {
+		return fmt.Errorf("voucher %s expired on %s", v.Code, v.ExpiresAt.Format(time.DateTime))
+	}
+
+	if v.UsageLimit != nil && v.TimesRedeemed >= *v.UsageLimit {
+		return fmt.Errorf("voucher %s has reached its usage limit", v.Code)
+	}
+
+	return nil
+}
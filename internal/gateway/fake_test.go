@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newTestProvider(t *testing.T) *FakeProvider {
+	t.Helper()
+	provider, err := NewFakeProvider("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestNewFakeProvider(t *testing.T) {
+	if _, err := NewFakeProvider(""); err == nil {
+		t.Error("NewFakeProvider(\"\") error = nil, want an error")
+	}
+}
+
+func TestFakeSign(t *testing.T) {
+	// HMAC-SHA256 test case 2 of RFC 4231
+	provider, err := NewFakeProvider("Jefe")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := hex.EncodeToString(provider.sign([]byte("what do ya want for nothing?")))
+	want := "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if got != want {
+		t.Errorf("sign() = %s, want %s", got, want)
+	}
+}
+
+func TestFakeCreateCharge(t *testing.T) {
+	provider := newTestProvider(t)
+
+	first, err := provider.CreateCharge(context.Background(), Charge{Reference: "TRX-12-3", Method: "credit", Amount: 50000})
+	if err != nil {
+		t.Fatalf("CreateCharge() error = %v", err)
+	}
+	second, err := provider.CreateCharge(context.Background(), Charge{Reference: "TRX-12-4", Method: "credit", Amount: 50000})
+	if err != nil {
+		t.Fatalf("CreateCharge() error = %v", err)
+	}
+
+	if !strings.HasPrefix(first.ProviderRef, "fake_") {
+		t.Errorf("ProviderRef = %s, want a fake_ prefix", first.ProviderRef)
+	}
+	if first.ProviderRef == second.ProviderRef {
+		t.Errorf("two charges share the reference %s", first.ProviderRef)
+	}
+	if first.CheckoutURL != "https://fake-gateway.local/pay/"+first.ProviderRef {
+		t.Errorf("CheckoutURL = %s", first.CheckoutURL)
+	}
+
+	if _, err := provider.CreateCharge(context.Background(), Charge{Amount: 0}); err == nil {
+		t.Error("CreateCharge() with no amount error = nil, want an error")
+	}
+}
+
+func TestFakeWebhookRoundTrip(t *testing.T) {
+	provider := newTestProvider(t)
+	want := Event{ProviderRef: "fake_1", Status: EventPaid, Amount: 50000}
+
+	body, header, err := provider.Webhook(want)
+	if err != nil {
+		t.Fatalf("Webhook() error = %v", err)
+	}
+
+	got, err := provider.ParseWebhook(header, body)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if *got != want {
+		t.Errorf("ParseWebhook() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestFakeParseWebhook(t *testing.T) {
+	provider := newTestProvider(t)
+
+	// signed returns the header a correctly signed body carries
+	signed := func(body string) http.Header {
+		header := http.Header{}
+		header.Set(FakeSignatureHeader, hex.EncodeToString(provider.sign([]byte(body))))
+		return header
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		header  http.Header
+		wantErr string
+	}{
+		{name: "missing signature", body: `{"provider_ref":"fake_1","status":"paid","amount":1}`, header: http.Header{}, wantErr: ErrInvalidSignature.Error()},
+		{name: "signature of another body", body: `{"provider_ref":"fake_1","status":"paid","amount":1}`, header: signed(`{"provider_ref":"fake_1","status":"paid","amount":2}`), wantErr: ErrInvalidSignature.Error()},
+		{name: "invalid JSON", body: `{`, header: signed(`{`), wantErr: "invalid webhook body"},
+		{name: "missing provider_ref", body: `{"status":"paid","amount":1}`, header: signed(`{"status":"paid","amount":1}`), wantErr: "provider_ref is required"},
+		{name: "unknown status", body: `{"provider_ref":"fake_1","status":"refunded","amount":1}`, header: signed(`{"provider_ref":"fake_1","status":"refunded","amount":1}`), wantErr: "status must be paid or failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := provider.ParseWebhook(tt.header, []byte(tt.body))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseWebhook() error = %v, want %q", err, tt.wantErr)
+			}
+			if tt.wantErr == ErrInvalidSignature.Error() && !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("ParseWebhook() error = %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
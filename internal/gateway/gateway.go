@@ -0,0 +1,60 @@
+// Package gateway connects checkout to payment gateways that confirm card and
+// e-wallet payments asynchronously. A charge is created when the transaction is
+// checked out and the gateway later reports its outcome through a signed webhook.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Outcomes of a charge reported by a webhook
+const (
+	EventPaid   = "paid"
+	EventFailed = "failed"
+)
+
+// ErrInvalidSignature is returned for webhooks whose signature does not match
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// PaymentProvider is a payment gateway. Implementations must be safe for concurrent use.
+type PaymentProvider interface {
+	// Name identifies the provider on the payments it charges
+	Name() string
+
+	// CreateCharge asks the provider to charge a payment. The charge is pending
+	// until the provider reports its outcome through a webhook.
+	CreateCharge(ctx context.Context, charge Charge) (*ChargeResult, error)
+
+	// ParseWebhook checks the signature of a webhook call and reads the event it
+	// reports, returning ErrInvalidSignature when the signature does not match
+	ParseWebhook(header http.Header, body []byte) (*Event, error)
+}
+
+// Charge is a payment to be charged through a provider
+type Charge struct {
+	// Reference identifies the payment at the store, such as TRX-12-3 for payment 3 of transaction 12
+	Reference string
+	Method    string
+	Amount    int
+	// ExpiresAt is when the store stops waiting for the payment
+	ExpiresAt time.Time
+}
+
+// ChargeResult is a charge created by a provider
+type ChargeResult struct {
+	// ProviderRef identifies the charge at the provider; webhooks refer to it
+	ProviderRef string
+	// CheckoutURL is where the customer completes the payment, if the provider has one
+	CheckoutURL string
+}
+
+// Event is the outcome of a charge reported by a webhook
+type Event struct {
+	ProviderRef string `json:"provider_ref"`
+	// Status is paid or failed
+	Status string `json:"status"`
+	Amount int    `json:"amount"`
+}
@@ -0,0 +1,136 @@
+// Package labels renders shelf labels and barcode stickers as PNG images or PDF
+// sheets. Rendering is done in pure Go so that labels can be printed without any
+// external service.
+package labels
+
+import (
+	"fmt"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+)
+
+// Supported barcode symbologies
+const (
+	SymbologyCode128 = "code128"
+	SymbologyEAN13   = "ean13"
+)
+
+// Label is the content of a single label
+type Label struct {
+	Name      string
+	Price     string
+	Symbology string
+	Content   string
+}
+
+// Layout describes a label sheet. All lengths are in millimetres. The page size
+// follows from the layout: margins on every side plus the label grid.
+type Layout struct {
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	Margin      float64
+	Gap         float64
+}
+
+// DefaultLayout returns a sheet of 3 x 8 labels of 60 x 30 mm, which fits on A4 paper
+func DefaultLayout() Layout {
+	return Layout{
+		Columns:     3,
+		Rows:        8,
+		LabelWidth:  60,
+		LabelHeight: 30,
+		Margin:      10,
+		Gap:         3,
+	}
+}
+
+// Validate checks that the layout is within printable bounds
+func (l Layout) Validate() error {
+	if l.Columns < 1 || l.Columns > 10 {
+		return fmt.Errorf("columns must be between 1 and 10")
+	}
+	if l.Rows < 1 || l.Rows > 20 {
+		return fmt.Errorf("rows must be between 1 and 20")
+	}
+	if l.LabelWidth < 25 || l.LabelWidth > 150 {
+		return fmt.Errorf("label width must be between 25 and 150 mm")
+	}
+	if l.LabelHeight < 15 || l.LabelHeight > 100 {
+		return fmt.Errorf("label height must be between 15 and 100 mm")
+	}
+	if l.Margin < 0 || l.Margin > 30 {
+		return fmt.Errorf("margin must be between 0 and 30 mm")
+	}
+	if l.Gap < 0 || l.Gap > 20 {
+		return fmt.Errorf("gap must be between 0 and 20 mm")
+	}
+	return nil
+}
+
+// PerPage returns the number of labels on one sheet
+func (l Layout) PerPage() int {
+	return l.Columns * l.Rows
+}
+
+// Pages returns the number of sheets needed for count labels
+func (l Layout) Pages(count int) int {
+	return (count + l.PerPage() - 1) / l.PerPage()
+}
+
+// PageSize returns the width and height of a sheet
+func (l Layout) PageSize() (float64, float64) {
+	width := 2*l.Margin + float64(l.Columns)*l.LabelWidth + float64(l.Columns-1)*l.Gap
+	height := 2*l.Margin + float64(l.Rows)*l.LabelHeight + float64(l.Rows-1)*l.Gap
+	return width, height
+}
+
+// labelOrigin returns the top left corner of the i-th label on its sheet
+func (l Layout) labelOrigin(i int) (float64, float64) {
+	i %= l.PerPage()
+	column, row := i%l.Columns, i/l.Columns
+	x := l.Margin + float64(column)*(l.LabelWidth+l.Gap)
+	y := l.Margin + float64(row)*(l.LabelHeight+l.Gap)
+	return x, y
+}
+
+// Label areas as fractions of the label height, top to bottom: name, price,
+// bars and the human readable barcode text. padding is in millimetres.
+const (
+	padding       = 1.5
+	nameHeight    = 0.14
+	priceHeight   = 0.17
+	captionHeight = 0.11
+)
+
+// encode encodes the label content in its symbology, one pixel per module
+func encode(label Label) (barcode.Barcode, error) {
+	switch label.Symbology {
+	case SymbologyCode128:
+		bc, err := code128.Encode(label.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s as Code128: %w", label.Content, err)
+		}
+		return bc, nil
+	case SymbologyEAN13:
+		if len(label.Content) != 13 {
+			return nil, fmt.Errorf("EAN-13 content must be 13 digits, got %s", label.Content)
+		}
+		bc, err := ean.Encode(label.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s as EAN-13: %w", label.Content, err)
+		}
+		return bc, nil
+	default:
+		return nil, fmt.Errorf("unsupported symbology %s", label.Symbology)
+	}
+}
+
+// isBar reports whether module x of a one-dimensional barcode is dark
+func isBar(bc barcode.Barcode, x int) bool {
+	r, _, _, _ := bc.At(x, 0).RGBA()
+	return r < 0x8000
+}
@@ -0,0 +1,89 @@
+package labels
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+func testLabels(count int) []Label {
+	labels := make([]Label, count)
+	for i := range labels {
+		labels[i] = Label{Name: "Kopi Susu", Price: "Rp 15.000", Symbology: SymbologyEAN13, Content: "4006381333931"}
+	}
+	return labels
+}
+
+func TestRenderPNG(t *testing.T) {
+	largest := Layout{Columns: 10, Rows: 20, LabelWidth: 150, LabelHeight: 100, Margin: 30, Gap: 20}
+
+	tests := []struct {
+		name    string
+		layout  Layout
+		dpi     int
+		wantErr string
+	}{
+		{name: "default sheet", layout: DefaultLayout(), dpi: 72},
+		{name: "largest sheet at 150 dpi", layout: largest, dpi: 150, wantErr: "too large for a PNG"},
+		{name: "largest sheet at the highest dpi", layout: largest, dpi: 600, wantErr: "too large for a PNG"},
+		{name: "dpi out of range", layout: DefaultLayout(), dpi: 1200, wantErr: "dpi must be between 72 and 600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := RenderPNG(testLabels(3), tt.layout, 1, tt.dpi)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("RenderPNG() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenderPNG() error = %v", err)
+			}
+			if !bytes.HasPrefix(img, []byte("\x89PNG")) {
+				t.Error("RenderPNG() did not return a PNG")
+			}
+		})
+	}
+}
+
+func TestFitPDFText(t *testing.T) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetFont("Helvetica", "", 10)
+	translate := pdf.UnicodeTranslatorFromDescriptor("cp1252")
+
+	tests := []struct {
+		name  string
+		text  string
+		width float64
+		want  string
+	}{
+		{name: "accented Latin", text: "Café Crème", width: 100, want: "Caf\xe9 Cr\xe8me"},
+		{name: "outside cp1252", text: "Teh 茶", width: 100, want: "Teh ."},
+		{name: "shortened after an accent", text: "Crème brûlée à la vanille", width: 20, want: "Cr\xe8me br\xfb..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fitPDFText(pdf, translate, tt.text, tt.width); got != tt.want {
+				t.Errorf("fitPDFText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderPDF(t *testing.T) {
+	labels := testLabels(25)
+	labels[0].Name = "Café Crème"
+
+	doc, err := RenderPDF(labels, DefaultLayout())
+	if err != nil {
+		t.Fatalf("RenderPDF() error = %v", err)
+	}
+	if !bytes.HasPrefix(doc, []byte("%PDF-")) {
+		t.Error("RenderPDF() did not return a PDF")
+	}
+}
@@ -0,0 +1,116 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// pointsPerMM converts millimetres to PDF font points
+const pointsPerMM = 72 / 25.4
+
+// RenderPDF renders labels onto as many sheets as needed. Bars are drawn as
+// vector rectangles so they stay sharp at any printer resolution.
+func RenderPDF(labels []Label, layout Layout) ([]byte, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	width, height := layout.PageSize()
+	pdf := gofpdf.NewCustom(&gofpdf.InitType{
+		UnitStr: "mm",
+		Size:    gofpdf.SizeType{Wd: width, Ht: height},
+	})
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetFillColor(0, 0, 0)
+
+	// The core fonts are encoded in cp1252, so UTF-8 names are translated to it;
+	// characters it lacks print as a dot
+	translate := pdf.UnicodeTranslatorFromDescriptor("cp1252")
+
+	for i, label := range labels {
+		if i%layout.PerPage() == 0 {
+			pdf.AddPage()
+		}
+
+		x, y := layout.labelOrigin(i)
+		if err := drawPDFLabel(pdf, translate, label, layout, x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// drawPDFLabel draws a single label with its top left corner at x, y, passing its
+// text through translate into the encoding of the fonts
+func drawPDFLabel(pdf *gofpdf.Fpdf, translate func(string) string, label Label, layout Layout, x, y float64) error {
+	bc, err := encode(label)
+	if err != nil {
+		return err
+	}
+
+	innerWidth := layout.LabelWidth - 2*padding
+	cursor := y + padding
+
+	// Name and price
+	lineHeight := layout.LabelHeight * nameHeight
+	pdf.SetFont("Helvetica", "", lineHeight*pointsPerMM*0.8)
+	pdf.SetXY(x+padding, cursor)
+	pdf.CellFormat(innerWidth, lineHeight, fitPDFText(pdf, translate, label.Name, innerWidth), "", 0, "L", false, 0, "")
+	cursor += lineHeight
+
+	lineHeight = layout.LabelHeight * priceHeight
+	pdf.SetFont("Helvetica", "B", lineHeight*pointsPerMM*0.8)
+	pdf.SetXY(x+padding, cursor)
+	pdf.CellFormat(innerWidth, lineHeight, translate(label.Price), "", 0, "L", false, 0, "")
+	cursor += lineHeight
+
+	// Bars, centred, with whole-module widths kept proportional
+	captionLine := layout.LabelHeight * captionHeight
+	barsHeight := y + layout.LabelHeight - padding - captionLine - cursor
+	modules := bc.Bounds().Dx()
+	moduleWidth := innerWidth / float64(modules)
+	barsX := x + padding
+
+	for start := 0; start < modules; {
+		if !isBar(bc, start) {
+			start++
+			continue
+		}
+		end := start
+		for end < modules && isBar(bc, end) {
+			end++
+		}
+		pdf.Rect(barsX+float64(start)*moduleWidth, cursor, float64(end-start)*moduleWidth, barsHeight, "F")
+		start = end
+	}
+	cursor += barsHeight
+
+	// Human readable content under the bars
+	pdf.SetFont("Courier", "", captionLine*pointsPerMM*0.8)
+	pdf.SetXY(x+padding, cursor)
+	pdf.CellFormat(innerWidth, captionLine, translate(label.Content), "", 0, "C", false, 0, "")
+
+	return nil
+}
+
+// fitPDFText translates text and shortens it with an ellipsis until it fits the
+// given width. It shortens the UTF-8 text, so no character is cut in half.
+func fitPDFText(pdf *gofpdf.Fpdf, translate func(string) string, text string, width float64) string {
+	if pdf.GetStringWidth(translate(text)) <= width {
+		return translate(text)
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(translate(string(runes)+"...")) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return translate(string(runes) + "...")
+}
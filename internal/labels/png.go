@@ -0,0 +1,140 @@
+package labels
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/boombuler/barcode"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// DefaultDPI is the PNG resolution used when none is given
+const DefaultDPI = 300
+
+// maxPNGPixels bounds the size of a PNG sheet, which takes 4 bytes per pixel in
+// memory while it is drawn. An A4 sheet at 600 dpi fits.
+const maxPNGPixels = 40_000_000
+
+// RenderPNG renders one sheet of labels (page is 1-based) as a PNG image at the given resolution
+func RenderPNG(labels []Label, layout Layout, page, dpi int) ([]byte, error) {
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	if dpi < 72 || dpi > 600 {
+		return nil, fmt.Errorf("dpi must be between 72 and 600")
+	}
+
+	pages := layout.Pages(len(labels))
+	if page < 1 || page > pages {
+		return nil, fmt.Errorf("page must be between 1 and %d", pages)
+	}
+
+	pxPerMM := float64(dpi) / 25.4
+	width, height := layout.PageSize()
+	pxWidth, pxHeight := int(width*pxPerMM), int(height*pxPerMM)
+	if pxWidth*pxHeight > maxPNGPixels {
+		return nil, fmt.Errorf("a %.0f x %.0f mm sheet is too large for a PNG at %d dpi; lower the dpi, use fewer labels per sheet or render a PDF", width, height, dpi)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, pxWidth, pxHeight))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+
+	first := (page - 1) * layout.PerPage()
+	last := min(first+layout.PerPage(), len(labels))
+	for i := first; i < last; i++ {
+		x, y := layout.labelOrigin(i)
+		if err := drawPNGLabel(img, labels[i], layout, pxPerMM, x, y); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to render PNG: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// drawPNGLabel draws a single label with its top left corner at x, y (in millimetres)
+func drawPNGLabel(img *image.RGBA, label Label, layout Layout, pxPerMM, x, y float64) error {
+	bc, err := encode(label)
+	if err != nil {
+		return err
+	}
+
+	px := func(mm float64) int { return int(mm * pxPerMM) }
+
+	left := px(x + padding)
+	innerWidth := px(layout.LabelWidth - 2*padding)
+	cursor := px(y + padding)
+
+	// Name and price
+	lineHeight := px(layout.LabelHeight * nameHeight)
+	drawText(img, label.Name, left, cursor, innerWidth, lineHeight, false)
+	cursor += lineHeight
+
+	lineHeight = px(layout.LabelHeight * priceHeight)
+	drawText(img, label.Price, left, cursor, innerWidth, lineHeight, false)
+	cursor += lineHeight
+
+	// Bars, scaled by a whole number of pixels per module
+	captionLine := px(layout.LabelHeight * captionHeight)
+	barsHeight := px(y+layout.LabelHeight-padding) - captionLine - cursor
+	scaled, err := barcode.Scale(bc, innerWidth, barsHeight)
+	if err != nil {
+		return fmt.Errorf("label is too narrow for barcode %s at this resolution: %w", label.Content, err)
+	}
+	draw.Draw(img, image.Rect(left, cursor, left+innerWidth, cursor+barsHeight), scaled, image.Point{}, draw.Src)
+	cursor += barsHeight
+
+	// Human readable content under the bars
+	drawText(img, label.Content, left, cursor, innerWidth, captionLine, true)
+
+	return nil
+}
+
+// drawText draws a line of text scaled to the given height, left aligned or
+// centred within maxWidth, shortened with an ellipsis if it does not fit
+func drawText(img *image.RGBA, text string, x, y, maxWidth, height int, centred bool) {
+	face := basicfont.Face7x13
+	glyphHeight := face.Metrics().Height.Ceil()
+	scale := float64(height) * 0.8 / float64(glyphHeight)
+	if scale <= 0 {
+		return
+	}
+
+	measure := func(s string) int { return font.MeasureString(face, s).Ceil() }
+	if float64(measure(text))*scale > float64(maxWidth) {
+		runes := []rune(text)
+		for len(runes) > 0 && float64(measure(string(runes)+"..."))*scale > float64(maxWidth) {
+			runes = runes[:len(runes)-1]
+		}
+		text = string(runes) + "..."
+	}
+
+	// Draw at the font's native size, then scale up onto the label
+	glyphs := image.NewRGBA(image.Rect(0, 0, measure(text), glyphHeight))
+	draw.Draw(glyphs, glyphs.Bounds(), image.White, image.Point{}, draw.Src)
+	drawer := &font.Drawer{
+		Dst:  glyphs,
+		Src:  image.NewUniform(color.Black),
+		Face: face,
+		Dot:  fixed.P(0, face.Metrics().Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+
+	width := int(float64(glyphs.Bounds().Dx()) * scale)
+	if centred {
+		x += (maxWidth - width) / 2
+	}
+	top := y + (height-int(float64(glyphHeight)*scale))/2
+	draw.NearestNeighbor.Scale(img, image.Rect(x, top, x+width, top+int(float64(glyphHeight)*scale)), glyphs, glyphs.Bounds(), draw.Over, nil)
+}
@@ -0,0 +1,132 @@
+package mappers
+
+import (
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// CartMapper handles mapping between Cart entity and DTOs
+type CartMapper struct{}
+
+// ToDto converts Cart entity to CartDto without its quote
+func (m *CartMapper) ToDto(cart *entities.Cart) *dtos.CartDto {
+	if cart == nil {
+		return nil
+	}
+
+	dto := &dtos.CartDto{
+		ID:            cart.ID,
+		StoreID:       cart.StoreID,
+		Status:        cart.Status,
+		TerminalID:    cart.TerminalID,
+		CustomerRef:   cart.CustomerRef,
+		VoucherCodes:  cart.VoucherCodes,
+		Discount:      m.ToDiscountDto(cart.Discount),
+		Note:          cart.Note,
+		TransactionID: cart.TransactionID,
+		CreatedAt:     cart.CreatedAt,
+		UpdatedAt:     cart.UpdatedAt,
+		ParkedAt:      cart.ParkedAt,
+		ExpiresAt:     cart.ExpiresAt,
+		Items:         make([]dtos.CartItemDto, len(cart.Items)),
+	}
+
+	for i, item := range cart.Items {
+		dto.Items[i] = dtos.CartItemDto{
+			ID:            item.ID,
+			ProductID:     item.ProductID,
+			Barcode:       item.Barcode,
+			Quantity:      item.Quantity,
+			Unit:          item.Unit,
+			SerialNumbers: item.SerialNumbers,
+			Discount:      m.ToDiscountDto(item.Discount),
+		}
+	}
+
+	return dto
+}
+
+// ToDtoList converts slice of Cart entities to slice of CartDto
+func (m *CartMapper) ToDtoList(carts []entities.Cart) []dtos.CartDto {
+	if carts == nil {
+		return nil
+	}
+
+	result := make([]dtos.CartDto, len(carts))
+	for i, cart := range carts {
+		dto := m.ToDto(&cart)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToItemEntity converts a requested checkout item to a CartItem entity
+func (m *CartMapper) ToItemEntity(dto *dtos.CheckoutItemDto) *entities.CartItem {
+	if dto == nil {
+		return nil
+	}
+
+	return &entities.CartItem{
+		ProductID:     dto.ProductID,
+		Barcode:       dto.Barcode,
+		Quantity:      dto.Quantity,
+		Unit:          dto.Unit,
+		SerialNumbers: dto.SerialNumbers,
+		Discount:      m.ToDiscountEntity(dto.Discount),
+	}
+}
+
+// ToCheckoutRequest converts a cart to the checkout request it would be checked out with
+func (m *CartMapper) ToCheckoutRequest(cart *entities.Cart) *dtos.TransactionCreateRequestDto {
+	if cart == nil {
+		return nil
+	}
+
+	dto := &dtos.TransactionCreateRequestDto{
+		Items:        make([]dtos.CheckoutItemDto, len(cart.Items)),
+		VoucherCodes: cart.VoucherCodes,
+		CustomerRef:  cart.CustomerRef,
+		Discount:     m.ToDiscountDto(cart.Discount),
+	}
+
+	for i, item := range cart.Items {
+		dto.Items[i] = dtos.CheckoutItemDto{
+			ProductID:     item.ProductID,
+			Barcode:       item.Barcode,
+			Quantity:      item.Quantity,
+			Unit:          item.Unit,
+			SerialNumbers: item.SerialNumbers,
+			Discount:      m.ToDiscountDto(item.Discount),
+		}
+	}
+
+	return dto
+}
+
+// ToDiscountDto converts a CartDiscount entity to DiscountDto
+func (m *CartMapper) ToDiscountDto(discount *entities.CartDiscount) *dtos.DiscountDto {
+	if discount == nil {
+		return nil
+	}
+
+	return &dtos.DiscountDto{
+		Type:   discount.Type,
+		Value:  discount.Value,
+		Reason: discount.Reason,
+	}
+}
+
+// ToDiscountEntity converts DiscountDto to a CartDiscount entity
+func (m *CartMapper) ToDiscountEntity(dto *dtos.DiscountDto) *entities.CartDiscount {
+	if dto == nil {
+		return nil
+	}
+
+	return &entities.CartDiscount{
+		Type:   dto.Type,
+		Value:  dto.Value,
+		Reason: dto.Reason,
+	}
+}
@@ -14,8 +14,11 @@ type CategoryMapper struct{}
 // @Mapping(target = "id", source = "id")
 // @Mapping(target = "name", source = "name")
 // @Mapping(target = "description", source = "description")
+// @Mapping(target = "parentId", source = "parentId")
+// @Mapping(target = "taxRateId", source = "taxRateId")
 // @Mapping(target = "createdAt", source = "createdAt")
 // @Mapping(target = "updatedAt", source = "updatedAt")
+// @Mapping(target = "deletedAt", source = "deletedAt")
 func (m *CategoryMapper) ToDto(category *entities.Category) *dtos.CategoryDto {
 	if category == nil {
 		return nil
@@ -25,8 +28,27 @@ func (m *CategoryMapper) ToDto(category *entities.Category) *dtos.CategoryDto {
 		ID:          category.ID,
 		Name:        category.Name,
 		Description: category.Description,
+		ParentID:    category.ParentID,
+		TaxRateID:   category.TaxRateID,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
+		DeletedAt:   category.DeletedAt,
+		Children:    m.ToDtoList(category.Children),
+		Stats:       m.ToStatsDto(category.Stats),
+	}
+}
+
+// ToStatsDto converts CategoryStats to CategoryStatsDto
+func (m *CategoryMapper) ToStatsDto(stats *entities.CategoryStats) *dtos.CategoryStatsDto {
+	if stats == nil {
+		return nil
+	}
+
+	return &dtos.CategoryStatsDto{
+		ActiveProducts: stats.ActiveProducts,
+		UnitsInStock:   stats.UnitsInStock,
+		StockValue:     stats.StockValue,
+		StockCost:      stats.StockCost,
 	}
 }
 
@@ -49,6 +71,8 @@ func (m *CategoryMapper) ToDtoList(categories []entities.Category) []dtos.Catego
 // ToCreateRequest converts CategoryCreateRequestDto to CategoryCreateRequest
 // @Mapping(target = "name", source = "name")
 // @Mapping(target = "description", source = "description")
+// @Mapping(target = "parentId", source = "parentId")
+// @Mapping(target = "taxRateId", source = "taxRateId")
 func (m *CategoryMapper) ToCreateRequest(dto *dtos.CategoryCreateRequestDto) *dtos.CategoryCreateRequest {
 	if dto == nil {
 		return nil
@@ -57,12 +81,16 @@ func (m *CategoryMapper) ToCreateRequest(dto *dtos.CategoryCreateRequestDto) *dt
 	return &dtos.CategoryCreateRequest{
 		Name:        dto.Name,
 		Description: dto.Description,
+		ParentID:    dto.ParentID,
+		TaxRateID:   dto.TaxRateID,
 	}
 }
 
 // ToUpdateRequest converts CategoryUpdateRequestDto to CategoryUpdateRequest
 // @Mapping(target = "name", source = "name")
 // @Mapping(target = "description", source = "description")
+// @Mapping(target = "parentId", source = "parentId")
+// @Mapping(target = "taxRateId", source = "taxRateId")
 func (m *CategoryMapper) ToUpdateRequest(dto *dtos.CategoryUpdateRequestDto) *dtos.CategoryUpdateRequest {
 	if dto == nil {
 		return nil
@@ -71,6 +99,8 @@ func (m *CategoryMapper) ToUpdateRequest(dto *dtos.CategoryUpdateRequestDto) *dt
 	return &dtos.CategoryUpdateRequest{
 		Name:        dto.Name,
 		Description: dto.Description,
+		ParentID:    dto.ParentID,
+		TaxRateID:   dto.TaxRateID,
 	}
 }
 
@@ -84,6 +114,8 @@ func (m *CategoryMapper) ToEntity(request *dtos.CategoryCreateRequest) *entities
 	return &entities.Category{
 		Name:        request.Name,
 		Description: request.Description,
+		ParentID:    request.ParentID,
+		TaxRateID:   request.TaxRateID,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -97,5 +129,55 @@ func (m *CategoryMapper) UpdateEntity(category *entities.Category, request *dtos
 
 	category.Name = request.Name
 	category.Description = request.Description
+	if request.ParentID != nil {
+		if *request.ParentID == 0 {
+			category.ParentID = nil
+		} else {
+			parentID := *request.ParentID
+			category.ParentID = &parentID
+		}
+	}
+	if request.TaxRateID != nil { // Keep the current tax rate if not provided; 0 clears it
+		if *request.TaxRateID == 0 {
+			category.TaxRateID = nil
+		} else {
+			taxRateID := *request.TaxRateID
+			category.TaxRateID = &taxRateID
+		}
+	}
 	category.UpdatedAt = time.Now()
 }
+
+// ToTree nests a flat list of categories under their parents and returns the
+// top-level categories. A category whose parent is not in the list is treated
+// as top-level.
+func (m *CategoryMapper) ToTree(categories []entities.Category) []dtos.CategoryDto {
+	present := make(map[int]bool, len(categories))
+	for _, category := range categories {
+		present[category.ID] = true
+	}
+
+	children := make(map[int][]entities.Category)
+	var roots []entities.Category
+	for _, category := range categories {
+		if category.ParentID != nil && present[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var attach func(nodes []entities.Category) []entities.Category
+	attach = func(nodes []entities.Category) []entities.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+
+	tree := m.ToDtoList(attach(roots))
+	if tree == nil {
+		return []dtos.CategoryDto{}
+	}
+	return tree
+}
@@ -0,0 +1,69 @@
+package mappers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// outline renders a category tree as "Name[Child Child[Grandchild]]"
+func outline(categories []dtos.CategoryDto) string {
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+		if len(category.Children) > 0 {
+			names[i] += "[" + outline(category.Children) + "]"
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+func TestToTree(t *testing.T) {
+	parent := func(id int) *int { return &id }
+
+	tests := []struct {
+		name       string
+		categories []entities.Category
+		want       string
+	}{
+		{name: "no categories"},
+		{
+			name:       "flat",
+			categories: []entities.Category{{ID: 1, Name: "Minuman"}, {ID: 2, Name: "Makanan"}},
+			want:       "Minuman Makanan",
+		},
+		{
+			name: "nested, children listed before their parent",
+			categories: []entities.Category{
+				{ID: 3, Name: "Espresso", ParentID: parent(2)},
+				{ID: 1, Name: "Minuman"},
+				{ID: 2, Name: "Kopi", ParentID: parent(1)},
+				{ID: 4, Name: "Teh", ParentID: parent(1)},
+			},
+			want: "Minuman[Kopi[Espresso] Teh]",
+		},
+		{
+			name: "parent left out of the list",
+			categories: []entities.Category{
+				{ID: 1, Name: "Minuman"},
+				{ID: 3, Name: "Espresso", ParentID: parent(2)},
+			},
+			want: "Minuman Espresso",
+		},
+	}
+
+	mapper := &CategoryMapper{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := mapper.ToTree(tt.categories)
+			if tree == nil {
+				t.Fatal("ToTree() = nil, want an empty list")
+			}
+			if got := outline(tree); got != tt.want {
+				t.Errorf("ToTree() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// GoodsReceiptMapper handles mapping between GoodsReceipt entity and DTOs
+type GoodsReceiptMapper struct{}
+
+// ToDto converts GoodsReceipt entity to GoodsReceiptDto
+func (m *GoodsReceiptMapper) ToDto(receipt *entities.GoodsReceipt) *dtos.GoodsReceiptDto {
+	if receipt == nil {
+		return nil
+	}
+
+	return &dtos.GoodsReceiptDto{
+		ID:            receipt.ID,
+		StoreID:       receipt.StoreID,
+		ProductID:     receipt.ProductID,
+		Quantity:      receipt.Quantity,
+		UnitCost:      receipt.UnitCost,
+		LotNumber:     receipt.LotNumber,
+		ExpiryDate:    formatDate(receipt.ExpiryDate),
+		SerialNumbers: receipt.SerialNumbers,
+		CreatedAt:     receipt.CreatedAt,
+	}
+}
+
+// ToDtoList converts slice of GoodsReceipt entities to slice of GoodsReceiptDto
+func (m *GoodsReceiptMapper) ToDtoList(receipts []entities.GoodsReceipt) []dtos.GoodsReceiptDto {
+	if receipts == nil {
+		return nil
+	}
+
+	result := make([]dtos.GoodsReceiptDto, len(receipts))
+	for i, receipt := range receipts {
+		dto := m.ToDto(&receipt)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToEntity converts GoodsReceiptCreateRequestDto to GoodsReceipt entity
+func (m *GoodsReceiptMapper) ToEntity(storeID, productID int, dto *dtos.GoodsReceiptCreateRequestDto) *entities.GoodsReceipt {
+	if dto == nil {
+		return nil
+	}
+
+	return &entities.GoodsReceipt{
+		StoreID:       storeID,
+		ProductID:     productID,
+		Quantity:      dto.Quantity,
+		UnitCost:      dto.UnitCost,
+		LotNumber:     dto.LotNumber,
+		ExpiryDate:    parseDate(dto.ExpiryDate),
+		SerialNumbers: dto.SerialNumbers,
+		CreatedAt:     time.Now(),
+	}
+}
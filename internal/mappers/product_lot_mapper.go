@@ -0,0 +1,101 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// dateLayout is the format used for calendar dates such as expiry dates
+const dateLayout = "2006-01-02"
+
+// ProductLotMapper handles mapping between ProductLot entity and DTOs
+type ProductLotMapper struct{}
+
+// ToDto converts ProductLot entity to ProductLotDto
+func (m *ProductLotMapper) ToDto(lot *entities.ProductLot) *dtos.ProductLotDto {
+	if lot == nil {
+		return nil
+	}
+
+	dto := &dtos.ProductLotDto{
+		ID:          lot.ID,
+		StoreID:     lot.StoreID,
+		ProductID:   lot.ProductID,
+		ProductName: lot.ProductName,
+		LotNumber:   lot.LotNumber,
+		ExpiryDate:  formatDate(lot.ExpiryDate),
+		Quantity:    lot.Quantity,
+		ReceivedAt:  lot.ReceivedAt,
+	}
+
+	if lot.ExpiryDate != nil {
+		days := daysBetween(time.Now(), *lot.ExpiryDate)
+		dto.DaysUntilExpiry = &days
+	}
+
+	return dto
+}
+
+// ToDtoList converts slice of ProductLot entities to slice of ProductLotDto
+func (m *ProductLotMapper) ToDtoList(lots []entities.ProductLot) []dtos.ProductLotDto {
+	if lots == nil {
+		return nil
+	}
+
+	result := make([]dtos.ProductLotDto, len(lots))
+	for i, lot := range lots {
+		dto := m.ToDto(&lot)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToAllocationDtoList converts slice of LotAllocation entities to slice of LotAllocationDto
+func (m *ProductLotMapper) ToAllocationDtoList(allocations []entities.LotAllocation) []dtos.LotAllocationDto {
+	if allocations == nil {
+		return nil
+	}
+
+	result := make([]dtos.LotAllocationDto, len(allocations))
+	for i, allocation := range allocations {
+		result[i] = dtos.LotAllocationDto{
+			LotID:      allocation.LotID,
+			LotNumber:  allocation.LotNumber,
+			ExpiryDate: formatDate(allocation.ExpiryDate),
+			Quantity:   allocation.Quantity,
+		}
+	}
+	return result
+}
+
+// daysBetween counts calendar days from one date to another, ignoring time of day
+func daysBetween(from, to time.Time) int {
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// parseDate parses an optional YYYY-MM-DD date; callers validate the format beforehand
+func parseDate(date *string) *time.Time {
+	if date == nil {
+		return nil
+	}
+	parsed, err := time.Parse(dateLayout, *date)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// formatDate formats an optional date as YYYY-MM-DD
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format(dateLayout)
+	return &formatted
+}
@@ -13,9 +13,24 @@ type ProductMapper struct{}
 // ToDto converts Product entity to ProductDto
 // @Mapping(target = "id", source = "id")
 // @Mapping(target = "name", source = "name")
+// @Mapping(target = "sku", source = "sku")
+// @Mapping(target = "plu", source = "plu")
+// @Mapping(target = "barcodes", source = "barcodes")
 // @Mapping(target = "price", source = "price")
+// @Mapping(target = "storePrice", source = "storePrice")
+// @Mapping(target = "cost", source = "cost")
 // @Mapping(target = "stock", source = "stock")
+// @Mapping(target = "inTransit", source = "inTransit")
+// @Mapping(target = "unit", source = "unit")
+// @Mapping(target = "quantityPrecision", source = "quantityPrecision")
+// @Mapping(target = "unitConversions", source = "unitConversions")
 // @Mapping(target = "categoryId", source = "categoryId")
+// @Mapping(target = "taxRateId", source = "taxRateId")
+// @Mapping(target = "parentId", source = "parentId")
+// @Mapping(target = "optionValues", source = "optionValues")
+// @Mapping(target = "components", source = "components")
+// @Mapping(target = "options", source = "options")
+// @Mapping(target = "variants", source = "variants")
 // @Mapping(target = "createdAt", source = "createdAt")
 // @Mapping(target = "updatedAt", source = "updatedAt")
 func (m *ProductMapper) ToDto(product *entities.Product) *dtos.ProductDto {
@@ -24,17 +39,138 @@ func (m *ProductMapper) ToDto(product *entities.Product) *dtos.ProductDto {
 	}
 
 	return &dtos.ProductDto{
-		ID:         product.ID,
-		Name:       product.Name,
-		Price:      product.Price,
-		Stock:      product.Stock,
-		Active:     product.Active,
-		CategoryID: product.CategoryID,
-		CreatedAt:  product.CreatedAt,
-		UpdatedAt:  product.UpdatedAt,
+		ID:                product.ID,
+		Name:              product.Name,
+		SKU:               product.SKU,
+		PLU:               product.PLU,
+		Barcodes:          product.Barcodes,
+		Price:             product.Price,
+		StorePrice:        product.StorePrice,
+		Cost:              product.Cost,
+		Stock:             product.Stock,
+		InTransit:         product.InTransit,
+		Unit:              product.Unit,
+		QuantityPrecision: product.QuantityPrecision,
+		UnitConversions:   m.ToUnitConversionDtoList(product.UnitConversions),
+		Active:            product.Active,
+		TrackLots:         product.TrackLots,
+		Serialized:        product.Serialized,
+		CategoryID:        product.CategoryID,
+		TaxRateID:         product.TaxRateID,
+		ParentID:          product.ParentID,
+		OptionValues:      product.OptionValues,
+		HasVariants:       product.HasVariants,
+		IsBundle:          product.IsBundle,
+		Components:        m.ToComponentDtoList(product.Components),
+		Options:           m.ToOptionDtoList(product.Options),
+		Variants:          m.ToDtoList(product.Variants),
+		CreatedAt:         product.CreatedAt,
+		UpdatedAt:         product.UpdatedAt,
+		DeletedAt:         product.DeletedAt,
 	}
 }
 
+// ToUnitConversionDtoList converts slice of UnitConversion entities to slice of UnitConversionDto
+func (m *ProductMapper) ToUnitConversionDtoList(conversions []entities.UnitConversion) []dtos.UnitConversionDto {
+	if conversions == nil {
+		return nil
+	}
+
+	result := make([]dtos.UnitConversionDto, len(conversions))
+	for i, conversion := range conversions {
+		result[i] = dtos.UnitConversionDto{
+			Unit:   conversion.Unit,
+			Factor: conversion.Factor,
+		}
+	}
+	return result
+}
+
+// ToUnitConversionEntities converts slice of UnitConversionDto to slice of UnitConversion entities
+func (m *ProductMapper) ToUnitConversionEntities(conversions []dtos.UnitConversionDto) []entities.UnitConversion {
+	if conversions == nil {
+		return nil
+	}
+
+	result := make([]entities.UnitConversion, len(conversions))
+	for i, conversion := range conversions {
+		result[i] = entities.UnitConversion{
+			Unit:   conversion.Unit,
+			Factor: conversion.Factor,
+		}
+	}
+	return result
+}
+
+// ToComponentDtoList converts slice of BundleComponent entities to slice of BundleComponentDto
+func (m *ProductMapper) ToComponentDtoList(components []entities.BundleComponent) []dtos.BundleComponentDto {
+	if components == nil {
+		return nil
+	}
+
+	result := make([]dtos.BundleComponentDto, len(components))
+	for i, component := range components {
+		result[i] = dtos.BundleComponentDto{
+			ProductID:   component.ComponentID,
+			ProductName: component.ComponentName,
+			Quantity:    component.Quantity,
+			Stock:       component.Stock,
+		}
+	}
+	return result
+}
+
+// ToComponentEntities converts BundleComponentsUpdateRequestDto to BundleComponent entities
+func (m *ProductMapper) ToComponentEntities(bundleID int, dto *dtos.BundleComponentsUpdateRequestDto) []entities.BundleComponent {
+	if dto == nil {
+		return nil
+	}
+
+	result := make([]entities.BundleComponent, len(dto.Components))
+	for i, component := range dto.Components {
+		result[i] = entities.BundleComponent{
+			BundleID:    bundleID,
+			ComponentID: component.ProductID,
+			Quantity:    component.Quantity,
+		}
+	}
+	return result
+}
+
+// ToOptionDtoList converts slice of ProductOption entities to slice of ProductOptionDto
+func (m *ProductMapper) ToOptionDtoList(options []entities.ProductOption) []dtos.ProductOptionDto {
+	if options == nil {
+		return nil
+	}
+
+	result := make([]dtos.ProductOptionDto, len(options))
+	for i, option := range options {
+		result[i] = dtos.ProductOptionDto{
+			Name:   option.Name,
+			Values: option.Values,
+		}
+	}
+	return result
+}
+
+// ToOptionEntities converts ProductOptionsUpdateRequestDto to ProductOption entities
+func (m *ProductMapper) ToOptionEntities(productID int, dto *dtos.ProductOptionsUpdateRequestDto) []entities.ProductOption {
+	if dto == nil {
+		return nil
+	}
+
+	result := make([]entities.ProductOption, len(dto.Options))
+	for i, option := range dto.Options {
+		result[i] = entities.ProductOption{
+			ProductID: productID,
+			Name:      option.Name,
+			Values:    option.Values,
+			Position:  i + 1,
+		}
+	}
+	return result
+}
+
 // ToDtoList converts slice of Product entities to slice of ProductDto
 func (m *ProductMapper) ToDtoList(products []entities.Product) []dtos.ProductDto {
 	if products == nil {
@@ -53,9 +189,17 @@ func (m *ProductMapper) ToDtoList(products []entities.Product) []dtos.ProductDto
 
 // ToCreateRequest converts ProductCreateRequestDto to ProductCreateRequest
 // @Mapping(target = "name", source = "name")
+// @Mapping(target = "sku", source = "sku")
+// @Mapping(target = "plu", source = "plu")
+// @Mapping(target = "barcodes", source = "barcodes")
 // @Mapping(target = "price", source = "price")
+// @Mapping(target = "cost", source = "cost")
 // @Mapping(target = "stock", source = "stock")
+// @Mapping(target = "unit", source = "unit")
+// @Mapping(target = "quantityPrecision", source = "quantityPrecision")
+// @Mapping(target = "unitConversions", source = "unitConversions")
 // @Mapping(target = "categoryId", source = "categoryId")
+// @Mapping(target = "taxRateId", source = "taxRateId")
 func (m *ProductMapper) ToCreateRequest(dto *dtos.ProductCreateRequestDto) *dtos.ProductCreateRequest {
 	if dto == nil {
 		return nil
@@ -66,20 +210,52 @@ func (m *ProductMapper) ToCreateRequest(dto *dtos.ProductCreateRequestDto) *dtos
 		active = *dto.Active
 	}
 
+	cost := 0.0 // Default value if not provided
+	if dto.Cost != nil {
+		cost = *dto.Cost
+	}
+
+	unit := entities.UnitPiece // Default value if not provided
+	if dto.Unit != nil {
+		unit = *dto.Unit
+	}
+
+	precision := entities.DefaultQuantityPrecision(unit) // Default for the unit if not provided
+	if dto.QuantityPrecision != nil {
+		precision = *dto.QuantityPrecision
+	}
+
 	return &dtos.ProductCreateRequest{
-		Name:       dto.Name,
-		Price:      dto.Price,
-		Stock:      dto.Stock,
-		Active:     active,
-		CategoryID: dto.CategoryID,
+		Name:              dto.Name,
+		SKU:               dto.SKU,
+		PLU:               dto.PLU,
+		Barcodes:          dto.Barcodes,
+		Price:             dto.Price,
+		Cost:              cost,
+		Stock:             dto.Stock,
+		Unit:              unit,
+		QuantityPrecision: precision,
+		UnitConversions:   dto.UnitConversions,
+		Active:            active,
+		TrackLots:         dto.TrackLots,
+		Serialized:        dto.Serialized,
+		CategoryID:        dto.CategoryID,
+		TaxRateID:         dto.TaxRateID,
 	}
 }
 
 // ToUpdateRequest converts ProductUpdateRequestDto to ProductUpdateRequest
 // @Mapping(target = "name", source = "name")
+// @Mapping(target = "sku", source = "sku")
+// @Mapping(target = "plu", source = "plu")
+// @Mapping(target = "barcodes", source = "barcodes")
 // @Mapping(target = "price", source = "price")
 // @Mapping(target = "stock", source = "stock")
+// @Mapping(target = "unit", source = "unit")
+// @Mapping(target = "quantityPrecision", source = "quantityPrecision")
+// @Mapping(target = "unitConversions", source = "unitConversions")
 // @Mapping(target = "categoryId", source = "categoryId")
+// @Mapping(target = "taxRateId", source = "taxRateId")
 func (m *ProductMapper) ToUpdateRequest(dto *dtos.ProductUpdateRequestDto) *dtos.ProductUpdateRequest {
 	if dto == nil {
 		return nil
@@ -91,11 +267,20 @@ func (m *ProductMapper) ToUpdateRequest(dto *dtos.ProductUpdateRequestDto) *dtos
 	}
 
 	return &dtos.ProductUpdateRequest{
-		Name:       dto.Name,
-		Price:      dto.Price,
-		Stock:      dto.Stock,
-		Active:     active,
-		CategoryID: dto.CategoryID,
+		Name:              dto.Name,
+		SKU:               dto.SKU,
+		PLU:               dto.PLU,
+		Barcodes:          dto.Barcodes,
+		Price:             dto.Price,
+		Stock:             dto.Stock,
+		Unit:              dto.Unit,
+		QuantityPrecision: dto.QuantityPrecision,
+		UnitConversions:   dto.UnitConversions,
+		Active:            active,
+		TrackLots:         dto.TrackLots,
+		Serialized:        dto.Serialized,
+		CategoryID:        dto.CategoryID,
+		TaxRateID:         dto.TaxRateID,
 	}
 }
 
@@ -107,13 +292,23 @@ func (m *ProductMapper) ToEntity(request *dtos.ProductCreateRequest) *entities.P
 
 	now := time.Now()
 	return &entities.Product{
-		Name:       request.Name,
-		Price:      request.Price,
-		Stock:      request.Stock,
-		Active:     request.Active,
-		CategoryID: request.CategoryID,
-		CreatedAt:  now,
-		UpdatedAt:  now,
+		Name:              request.Name,
+		SKU:               request.SKU,
+		PLU:               request.PLU,
+		Barcodes:          request.Barcodes,
+		Price:             request.Price,
+		Cost:              request.Cost,
+		Stock:             request.Stock,
+		Unit:              request.Unit,
+		QuantityPrecision: request.QuantityPrecision,
+		UnitConversions:   m.ToUnitConversionEntities(request.UnitConversions),
+		Active:            request.Active,
+		TrackLots:         request.TrackLots,
+		Serialized:        request.Serialized,
+		CategoryID:        request.CategoryID,
+		TaxRateID:         request.TaxRateID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
 	}
 }
 
@@ -124,9 +319,41 @@ func (m *ProductMapper) UpdateEntity(product *entities.Product, request *dtos.Pr
 	}
 
 	product.Name = request.Name
+	if request.SKU != nil { // Keep the current SKU if not provided; an empty SKU clears it
+		product.SKU = request.SKU
+		if *request.SKU == "" {
+			product.SKU = nil
+		}
+	}
+	if request.PLU != nil { // Keep the current PLU if not provided; an empty PLU clears it
+		product.PLU = request.PLU
+		if *request.PLU == "" {
+			product.PLU = nil
+		}
+	}
+	if request.Barcodes != nil { // Keep the current barcodes if not provided
+		product.Barcodes = request.Barcodes
+	}
 	product.Price = request.Price
 	product.Stock = request.Stock
+	if request.Unit != nil && *request.Unit != product.Unit { // Keep the current unit if not provided
+		product.Unit = *request.Unit
+		product.QuantityPrecision = entities.DefaultQuantityPrecision(product.Unit)
+	}
+	if request.QuantityPrecision != nil {
+		product.QuantityPrecision = *request.QuantityPrecision
+	}
+	if request.UnitConversions != nil { // Keep the current conversions if not provided
+		product.UnitConversions = m.ToUnitConversionEntities(request.UnitConversions)
+	}
 	product.Active = request.Active
+	if request.TrackLots != nil { // Keep the current setting if not provided
+		product.TrackLots = *request.TrackLots
+	}
+	if request.Serialized != nil {
+		product.Serialized = *request.Serialized
+	}
 	product.CategoryID = request.CategoryID
+	product.TaxRateID = request.TaxRateID
 	product.UpdatedAt = time.Now()
 }
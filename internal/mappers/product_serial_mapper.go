@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// ProductSerialMapper handles mapping between ProductSerial entity and DTOs
+type ProductSerialMapper struct{}
+
+// ToDto converts ProductSerial entity to ProductSerialDto
+func (m *ProductSerialMapper) ToDto(serial *entities.ProductSerial) *dtos.ProductSerialDto {
+	if serial == nil {
+		return nil
+	}
+
+	return &dtos.ProductSerialDto{
+		ID:              serial.ID,
+		ProductID:       serial.ProductID,
+		ProductName:     serial.ProductName,
+		SerialNumber:    serial.SerialNumber,
+		Status:          serial.Status,
+		ReceivedStoreID: serial.ReceivedStoreID,
+		StoreID:         serial.StoreID,
+		ReceivedAt:      serial.ReceivedAt,
+		SoldAt:          serial.SoldAt,
+		TransactionID:   serial.TransactionID,
+	}
+}
+
+// ToDtoList converts slice of ProductSerial entities to slice of ProductSerialDto
+func (m *ProductSerialMapper) ToDtoList(serials []entities.ProductSerial) []dtos.ProductSerialDto {
+	if serials == nil {
+		return nil
+	}
+
+	result := make([]dtos.ProductSerialDto, len(serials))
+	for i, serial := range serials {
+		dto := m.ToDto(&serial)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
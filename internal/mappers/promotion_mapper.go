@@ -0,0 +1,136 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// PromotionMapper handles mapping between Promotion entity and DTOs
+type PromotionMapper struct {
+	// Location is the time zone promotion days and hours are in, used to tell
+	// whether a promotion is running now
+	Location *time.Location
+}
+
+// ToDto converts Promotion entity to PromotionDto
+func (m *PromotionMapper) ToDto(promotion *entities.Promotion) *dtos.PromotionDto {
+	if promotion == nil {
+		return nil
+	}
+
+	dto := &dtos.PromotionDto{
+		ID:          promotion.ID,
+		Name:        promotion.Name,
+		Type:        promotion.Type,
+		Priority:    promotion.Priority,
+		Stackable:   promotion.Stackable,
+		Active:      promotion.Active,
+		StartsAt:    promotion.StartsAt,
+		EndsAt:      promotion.EndsAt,
+		Days:        promotion.Days,
+		StartTime:   promotion.StartTime,
+		EndTime:     promotion.EndTime,
+		BuyQuantity: promotion.BuyQuantity,
+		GetQuantity: promotion.GetQuantity,
+		CategoryID:  promotion.CategoryID,
+		Percent:     promotion.Percent,
+		BundlePrice: promotion.BundlePrice,
+		Items:       make([]dtos.PromotionItemDto, len(promotion.Items)),
+		RunningNow:  promotion.ActiveAt(time.Now(), m.Location),
+		CreatedAt:   promotion.CreatedAt,
+		UpdatedAt:   promotion.UpdatedAt,
+	}
+
+	if dto.Days == nil {
+		dto.Days = []int{}
+	}
+
+	for i, item := range promotion.Items {
+		dto.Items[i] = dtos.PromotionItemDto{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+		}
+	}
+
+	for _, tier := range promotion.Tiers {
+		dto.Tiers = append(dto.Tiers, dtos.PromotionTierDto{
+			MinQuantity: tier.MinQuantity,
+			UnitPrice:   tier.UnitPrice,
+		})
+	}
+
+	return dto
+}
+
+// ToDtoList converts slice of Promotion entities to slice of PromotionDto
+func (m *PromotionMapper) ToDtoList(promotions []entities.Promotion) []dtos.PromotionDto {
+	if promotions == nil {
+		return nil
+	}
+
+	result := make([]dtos.PromotionDto, len(promotions))
+	for i, promotion := range promotions {
+		dto := m.ToDto(&promotion)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToEntity converts PromotionRequestDto to a new Promotion entity
+func (m *PromotionMapper) ToEntity(dto *dtos.PromotionRequestDto) *entities.Promotion {
+	if dto == nil {
+		return nil
+	}
+
+	promotion := &entities.Promotion{}
+	m.UpdateEntity(promotion, dto)
+	return promotion
+}
+
+// UpdateEntity replaces a Promotion entity's settings with those of a PromotionRequestDto
+func (m *PromotionMapper) UpdateEntity(promotion *entities.Promotion, dto *dtos.PromotionRequestDto) {
+	if promotion == nil || dto == nil {
+		return
+	}
+
+	promotion.Name = dto.Name
+	promotion.Type = dto.Type
+	promotion.Priority = dto.Priority
+	promotion.Stackable = dto.Stackable
+	promotion.Active = dto.Active == nil || *dto.Active
+	promotion.StartsAt = dto.StartsAt
+	promotion.EndsAt = dto.EndsAt
+	promotion.Days = dto.Days
+	promotion.StartTime = dto.StartTime
+	promotion.EndTime = dto.EndTime
+	promotion.BuyQuantity = dto.BuyQuantity
+	promotion.GetQuantity = dto.GetQuantity
+	promotion.CategoryID = dto.CategoryID
+	promotion.Percent = dto.Percent
+	promotion.BundlePrice = dto.BundlePrice
+
+	promotion.Items = nil
+	for _, item := range dto.Items {
+		quantity := item.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		promotion.Items = append(promotion.Items, entities.PromotionItem{
+			ProductID: item.ProductID,
+			Quantity:  quantity,
+		})
+	}
+
+	promotion.Tiers = nil
+	for _, tier := range dto.Tiers {
+		promotion.Tiers = append(promotion.Tiers, entities.PromotionTier{
+			MinQuantity: tier.MinQuantity,
+			UnitPrice:   tier.UnitPrice,
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// StockTransferMapper handles mapping between StockTransfer entity and DTOs
+type StockTransferMapper struct{}
+
+// ToDto converts StockTransfer entity to StockTransferDto
+func (m *StockTransferMapper) ToDto(transfer *entities.StockTransfer) *dtos.StockTransferDto {
+	if transfer == nil {
+		return nil
+	}
+
+	dto := &dtos.StockTransferDto{
+		ID:                 transfer.ID,
+		SourceStoreID:      transfer.SourceStoreID,
+		DestinationStoreID: transfer.DestinationStoreID,
+		Status:             transfer.Status,
+		Note:               transfer.Note,
+		ReceivingNote:      transfer.ReceivingNote,
+		CreatedAt:          transfer.CreatedAt,
+		ShippedAt:          transfer.ShippedAt,
+		ReceivedAt:         transfer.ReceivedAt,
+		CancelledAt:        transfer.CancelledAt,
+	}
+
+	// Map items; discrepancies only exist once the transfer has been received
+	if transfer.Items != nil {
+		dto.Items = make([]dtos.StockTransferItemDto, len(transfer.Items))
+		for i, item := range transfer.Items {
+			discrepancy := 0.0
+			if transfer.Status == entities.StockTransferStatusReceived {
+				discrepancy = item.Discrepancy()
+			}
+			if discrepancy != 0 {
+				dto.HasDiscrepancy = true
+			}
+
+			dto.Items[i] = dtos.StockTransferItemDto{
+				ID:                    item.ID,
+				ProductID:             item.ProductID,
+				ProductName:           item.ProductName,
+				QuantityRequested:     item.QuantityRequested,
+				QuantityShipped:       item.QuantityShipped,
+				QuantityReceived:      item.QuantityReceived,
+				Discrepancy:           discrepancy,
+				SerialNumbers:         item.SerialNumbers,
+				ReceivedSerialNumbers: item.ReceivedSerialNumbers,
+			}
+		}
+	}
+
+	return dto
+}
+
+// ToDtoList converts slice of StockTransfer entities to slice of StockTransferDto
+func (m *StockTransferMapper) ToDtoList(transfers []entities.StockTransfer) []dtos.StockTransferDto {
+	if transfers == nil {
+		return nil
+	}
+
+	result := make([]dtos.StockTransferDto, len(transfers))
+	for i, transfer := range transfers {
+		dto := m.ToDto(&transfer)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToEntity converts StockTransferCreateRequestDto to a requested StockTransfer entity
+func (m *StockTransferMapper) ToEntity(dto *dtos.StockTransferCreateRequestDto) *entities.StockTransfer {
+	if dto == nil {
+		return nil
+	}
+
+	transfer := &entities.StockTransfer{
+		SourceStoreID:      dto.SourceStoreID,
+		DestinationStoreID: dto.DestinationStoreID,
+		Status:             entities.StockTransferStatusRequested,
+		Note:               dto.Note,
+		CreatedAt:          time.Now(),
+	}
+
+	for _, item := range dto.Items {
+		transfer.Items = append(transfer.Items, entities.StockTransferItem{
+			ProductID:         item.ProductID,
+			QuantityRequested: item.Quantity,
+		})
+	}
+
+	return transfer
+}
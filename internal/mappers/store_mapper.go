@@ -0,0 +1,146 @@
+package mappers
+
+import (
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// StoreMapper handles mapping between Store entity and DTOs
+type StoreMapper struct{}
+
+// ToDto converts Store entity to StoreDto
+// @Mapping(target = "id", source = "id")
+// @Mapping(target = "name", source = "name")
+// @Mapping(target = "address", source = "address")
+// @Mapping(target = "taxMode", source = "taxMode")
+// @Mapping(target = "serviceChargeRate", source = "serviceChargeRate")
+// @Mapping(target = "serviceChargeTaxable", source = "serviceChargeTaxable")
+// @Mapping(target = "cashRounding", source = "cashRounding")
+// @Mapping(target = "createdAt", source = "createdAt")
+// @Mapping(target = "updatedAt", source = "updatedAt")
+func (m *StoreMapper) ToDto(store *entities.Store) *dtos.StoreDto {
+	if store == nil {
+		return nil
+	}
+
+	return &dtos.StoreDto{
+		ID:                   store.ID,
+		Name:                 store.Name,
+		Address:              store.Address,
+		TaxMode:              store.TaxMode,
+		ServiceChargeRate:    store.ServiceChargeRate,
+		ServiceChargeTaxable: store.ServiceChargeTaxable,
+		CashRounding:         store.CashRounding,
+		CreatedAt:            store.CreatedAt,
+		UpdatedAt:            store.UpdatedAt,
+	}
+}
+
+// ToDtoList converts slice of Store entities to slice of StoreDto
+func (m *StoreMapper) ToDtoList(stores []entities.Store) []dtos.StoreDto {
+	if stores == nil {
+		return nil
+	}
+
+	result := make([]dtos.StoreDto, len(stores))
+	for i, store := range stores {
+		dto := m.ToDto(&store)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToCreateRequest converts StoreCreateRequestDto to StoreCreateRequest
+// @Mapping(target = "name", source = "name")
+// @Mapping(target = "address", source = "address")
+// @Mapping(target = "taxMode", source = "taxMode")
+// @Mapping(target = "serviceChargeRate", source = "serviceChargeRate")
+// @Mapping(target = "serviceChargeTaxable", source = "serviceChargeTaxable")
+// @Mapping(target = "cashRounding", source = "cashRounding")
+func (m *StoreMapper) ToCreateRequest(dto *dtos.StoreCreateRequestDto) *dtos.StoreCreateRequest {
+	if dto == nil {
+		return nil
+	}
+
+	taxMode := entities.TaxInclusive // Default value if not provided
+	if dto.TaxMode != "" {
+		taxMode = dto.TaxMode
+	}
+
+	return &dtos.StoreCreateRequest{
+		Name:                 dto.Name,
+		Address:              dto.Address,
+		TaxMode:              taxMode,
+		ServiceChargeRate:    dto.ServiceChargeRate,
+		ServiceChargeTaxable: dto.ServiceChargeTaxable,
+		CashRounding:         dto.CashRounding,
+	}
+}
+
+// ToUpdateRequest converts StoreUpdateRequestDto to StoreUpdateRequest
+// @Mapping(target = "name", source = "name")
+// @Mapping(target = "address", source = "address")
+// @Mapping(target = "taxMode", source = "taxMode")
+// @Mapping(target = "serviceChargeRate", source = "serviceChargeRate")
+// @Mapping(target = "serviceChargeTaxable", source = "serviceChargeTaxable")
+// @Mapping(target = "cashRounding", source = "cashRounding")
+func (m *StoreMapper) ToUpdateRequest(dto *dtos.StoreUpdateRequestDto) *dtos.StoreUpdateRequest {
+	if dto == nil {
+		return nil
+	}
+
+	return &dtos.StoreUpdateRequest{
+		Name:                 dto.Name,
+		Address:              dto.Address,
+		TaxMode:              dto.TaxMode,
+		ServiceChargeRate:    dto.ServiceChargeRate,
+		ServiceChargeTaxable: dto.ServiceChargeTaxable,
+		CashRounding:         dto.CashRounding,
+	}
+}
+
+// ToEntity converts StoreCreateRequest to Store entity
+func (m *StoreMapper) ToEntity(request *dtos.StoreCreateRequest) *entities.Store {
+	if request == nil {
+		return nil
+	}
+
+	now := time.Now()
+	return &entities.Store{
+		Name:                 request.Name,
+		Address:              request.Address,
+		TaxMode:              request.TaxMode,
+		ServiceChargeRate:    request.ServiceChargeRate,
+		ServiceChargeTaxable: request.ServiceChargeTaxable,
+		CashRounding:         request.CashRounding,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+}
+
+// UpdateEntity updates existing Store entity with StoreUpdateRequest
+func (m *StoreMapper) UpdateEntity(store *entities.Store, request *dtos.StoreUpdateRequest) {
+	if store == nil || request == nil {
+		return
+	}
+
+	store.Name = request.Name
+	store.Address = request.Address
+	if request.TaxMode != nil { // Keep the current tax mode if not provided
+		store.TaxMode = *request.TaxMode
+	}
+	if request.ServiceChargeRate != nil {
+		store.ServiceChargeRate = *request.ServiceChargeRate
+	}
+	if request.ServiceChargeTaxable != nil {
+		store.ServiceChargeTaxable = *request.ServiceChargeTaxable
+	}
+	if request.CashRounding != nil {
+		store.CashRounding = *request.CashRounding
+	}
+	store.UpdatedAt = time.Now()
+}
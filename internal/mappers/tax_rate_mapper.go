@@ -0,0 +1,65 @@
+package mappers
+
+import (
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// TaxRateMapper handles mapping between TaxRate entity and DTOs
+type TaxRateMapper struct{}
+
+// ToDto converts TaxRate entity to TaxRateDto
+func (m *TaxRateMapper) ToDto(taxRate *entities.TaxRate) *dtos.TaxRateDto {
+	if taxRate == nil {
+		return nil
+	}
+
+	return &dtos.TaxRateDto{
+		ID:        taxRate.ID,
+		Name:      taxRate.Name,
+		Rate:      taxRate.Rate,
+		IsDefault: taxRate.IsDefault,
+		CreatedAt: taxRate.CreatedAt,
+		UpdatedAt: taxRate.UpdatedAt,
+	}
+}
+
+// ToDtoList converts slice of TaxRate entities to slice of TaxRateDto
+func (m *TaxRateMapper) ToDtoList(taxRates []entities.TaxRate) []dtos.TaxRateDto {
+	if taxRates == nil {
+		return nil
+	}
+
+	result := make([]dtos.TaxRateDto, len(taxRates))
+	for i, taxRate := range taxRates {
+		dto := m.ToDto(&taxRate)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToEntity converts TaxRateRequestDto to a new TaxRate entity
+func (m *TaxRateMapper) ToEntity(dto *dtos.TaxRateRequestDto) *entities.TaxRate {
+	if dto == nil {
+		return nil
+	}
+
+	taxRate := &entities.TaxRate{}
+	m.UpdateEntity(taxRate, dto)
+	return taxRate
+}
+
+// UpdateEntity replaces a TaxRate entity's settings with those of a TaxRateRequestDto
+func (m *TaxRateMapper) UpdateEntity(taxRate *entities.TaxRate, dto *dtos.TaxRateRequestDto) {
+	if taxRate == nil || dto == nil {
+		return
+	}
+
+	taxRate.Name = dto.Name
+	if dto.Rate != nil {
+		taxRate.Rate = *dto.Rate
+	}
+	taxRate.IsDefault = dto.IsDefault
+}
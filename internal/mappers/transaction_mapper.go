@@ -14,22 +14,57 @@ func (m *TransactionMapper) ToDto(transaction *entities.Transaction) *dtos.Trans
 	}
 
 	dto := &dtos.TransactionDto{
-		ID:          transaction.ID,
-		TotalAmount: transaction.TotalAmount,
-		CreatedAt:   transaction.CreatedAt,
+		ID:             transaction.ID,
+		StoreID:        transaction.StoreID,
+		CustomerRef:    transaction.CustomerRef,
+		GrossAmount:    transaction.GrossAmount,
+		DiscountAmount: transaction.DiscountAmount,
+		ServiceCharge:  transaction.ServiceCharge,
+		TaxMode:        transaction.TaxMode,
+		TaxAmount:      transaction.TaxAmount,
+		RoundingAmount: transaction.RoundingAmount,
+		TotalAmount:    transaction.TotalAmount,
+		PaidAmount:     transaction.PaidAmount,
+		ChangeAmount:   transaction.ChangeAmount,
+		PaymentMethod:  transaction.PaymentMethod,
+		Status:         transaction.Status,
+		CreatedAt:      transaction.CreatedAt,
+		ExpiresAt:      transaction.ExpiresAt,
+		CompletedAt:    transaction.CompletedAt,
+		FailedAt:       transaction.FailedAt,
+		ExpiredAt:      transaction.ExpiredAt,
+		VoidedAt:       transaction.VoidedAt,
+		RefundedAt:     transaction.RefundedAt,
+		VoidReason:     transaction.VoidReason,
+		RefundReason:   transaction.RefundReason,
+		Discounts:      m.ToAppliedDiscountDtoList(transaction.Discounts),
+		Taxes:          m.ToTransactionTaxDtoList(transaction.Taxes),
+		Payments:       m.ToTransactionPaymentDtoList(transaction.Payments),
 	}
 
 	// Map details
 	if transaction.Details != nil {
 		dto.Details = make([]dtos.TransactionDetailDto, len(transaction.Details))
+		lotMapper := &ProductLotMapper{}
 		for i, detail := range transaction.Details {
 			dto.Details[i] = dtos.TransactionDetailDto{
-				ID:            detail.ID,
-				TransactionID: detail.TransactionID,
-				ProductID:     detail.ProductID,
-				ProductName:   detail.ProductName,
-				Quantity:      detail.Quantity,
-				Subtotal:      detail.Subtotal,
+				ID:             detail.ID,
+				TransactionID:  detail.TransactionID,
+				ProductID:      detail.ProductID,
+				ProductName:    detail.ProductName,
+				Quantity:       detail.Quantity,
+				Unit:           detail.Unit,
+				GrossAmount:    detail.GrossAmount,
+				DiscountAmount: detail.DiscountAmount,
+				Subtotal:       detail.Subtotal,
+				TaxRateID:      detail.TaxRateID,
+				TaxRate:        detail.TaxRate,
+				TaxableAmount:  detail.TaxableAmount,
+				TaxAmount:      detail.TaxAmount,
+				UnitCost:       detail.UnitCost,
+				Lots:           lotMapper.ToAllocationDtoList(detail.Lots),
+				SerialNumbers:  detail.SerialNumbers,
+				Discounts:      m.ToAppliedDiscountDtoList(detail.Discounts),
 			}
 		}
 	}
@@ -52,3 +87,64 @@ func (m *TransactionMapper) ToDtoList(transactions []entities.Transaction) []dto
 	}
 	return result
 }
+
+// ToAppliedDiscountDtoList converts applied discounts to AppliedDiscountDto
+func (m *TransactionMapper) ToAppliedDiscountDtoList(discounts []entities.AppliedDiscount) []dtos.AppliedDiscountDto {
+	if discounts == nil {
+		return nil
+	}
+
+	result := make([]dtos.AppliedDiscountDto, len(discounts))
+	for i, discount := range discounts {
+		result[i] = dtos.AppliedDiscountDto{
+			VoucherID:   discount.VoucherID,
+			Source:      discount.Source,
+			Description: discount.Description,
+			Amount:      discount.Amount,
+		}
+	}
+	return result
+}
+
+// ToTransactionTaxDtoList converts the tax breakdown of a transaction to TransactionTaxDto
+func (m *TransactionMapper) ToTransactionTaxDtoList(taxes []entities.TransactionTax) []dtos.TransactionTaxDto {
+	if taxes == nil {
+		return nil
+	}
+
+	result := make([]dtos.TransactionTaxDto, len(taxes))
+	for i, tax := range taxes {
+		result[i] = dtos.TransactionTaxDto{
+			TaxRateID:     tax.TaxRateID,
+			Name:          tax.Name,
+			Rate:          tax.Rate,
+			TaxableAmount: tax.TaxableAmount,
+			TaxAmount:     tax.TaxAmount,
+		}
+	}
+	return result
+}
+
+// ToTransactionPaymentDtoList converts the payments of a transaction to DTOs
+func (m *TransactionMapper) ToTransactionPaymentDtoList(payments []entities.TransactionPayment) []dtos.TransactionPaymentDto {
+	if payments == nil {
+		return nil
+	}
+
+	result := make([]dtos.TransactionPaymentDto, len(payments))
+	for i, payment := range payments {
+		result[i] = dtos.TransactionPaymentDto{
+			ID:           payment.ID,
+			Method:       payment.Method,
+			Amount:       payment.Amount,
+			ChangeAmount: payment.ChangeAmount,
+			Reference:    payment.Reference,
+			Status:       payment.Status,
+			Provider:     payment.Provider,
+			ProviderRef:  payment.ProviderRef,
+			CheckoutURL:  payment.CheckoutURL,
+			ResolvedAt:   payment.ResolvedAt,
+		}
+	}
+	return result
+}
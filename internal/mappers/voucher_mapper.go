@@ -0,0 +1,103 @@
+package mappers
+
+import (
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// VoucherMapper handles mapping between Voucher entity and DTOs
+type VoucherMapper struct{}
+
+// ToDto converts Voucher entity to VoucherDto
+func (m *VoucherMapper) ToDto(voucher *entities.Voucher) *dtos.VoucherDto {
+	if voucher == nil {
+		return nil
+	}
+
+	dto := &dtos.VoucherDto{
+		ID:               voucher.ID,
+		Code:             voucher.Code,
+		Description:      voucher.Description,
+		Type:             voucher.Type,
+		Value:            voucher.Value,
+		MaxDiscount:      voucher.MaxDiscount,
+		MinSpend:         voucher.MinSpend,
+		UsageLimit:       voucher.UsageLimit,
+		PerCustomerLimit: voucher.PerCustomerLimit,
+		StartsAt:         voucher.StartsAt,
+		ExpiresAt:        voucher.ExpiresAt,
+		Active:           voucher.Active,
+		TimesRedeemed:    voucher.TimesRedeemed,
+		CreatedAt:        voucher.CreatedAt,
+		UpdatedAt:        voucher.UpdatedAt,
+	}
+
+	if voucher.UsageLimit != nil {
+		remaining := max(*voucher.UsageLimit-voucher.TimesRedeemed, 0)
+		dto.RemainingUses = &remaining
+	}
+
+	return dto
+}
+
+// ToDtoList converts slice of Voucher entities to slice of VoucherDto
+func (m *VoucherMapper) ToDtoList(vouchers []entities.Voucher) []dtos.VoucherDto {
+	if vouchers == nil {
+		return nil
+	}
+
+	result := make([]dtos.VoucherDto, len(vouchers))
+	for i, voucher := range vouchers {
+		dto := m.ToDto(&voucher)
+		if dto != nil {
+			result[i] = *dto
+		}
+	}
+	return result
+}
+
+// ToEntity converts VoucherRequestDto to a new Voucher entity
+func (m *VoucherMapper) ToEntity(dto *dtos.VoucherRequestDto) *entities.Voucher {
+	if dto == nil {
+		return nil
+	}
+
+	voucher := &entities.Voucher{}
+	m.UpdateEntity(voucher, dto)
+	return voucher
+}
+
+// UpdateEntity replaces a Voucher entity's settings with those of a VoucherRequestDto
+func (m *VoucherMapper) UpdateEntity(voucher *entities.Voucher, dto *dtos.VoucherRequestDto) {
+	if voucher == nil || dto == nil {
+		return
+	}
+
+	voucher.Code = entities.NormalizeVoucherCode(dto.Code)
+	voucher.Description = dto.Description
+	voucher.Type = dto.Type
+	voucher.Value = dto.Value
+	voucher.MaxDiscount = dto.MaxDiscount
+	voucher.MinSpend = dto.MinSpend
+	voucher.UsageLimit = dto.UsageLimit
+	voucher.PerCustomerLimit = dto.PerCustomerLimit
+	voucher.StartsAt = dto.StartsAt
+	voucher.ExpiresAt = dto.ExpiresAt
+	voucher.Active = dto.Active == nil || *dto.Active
+}
+
+// ToRedemptionDtoList converts voucher redemptions to VoucherRedemptionDto
+func (m *VoucherMapper) ToRedemptionDtoList(redemptions []entities.VoucherRedemption) []dtos.VoucherRedemptionDto {
+	result := make([]dtos.VoucherRedemptionDto, len(redemptions))
+	for i, redemption := range redemptions {
+		result[i] = dtos.VoucherRedemptionDto{
+			ID:            redemption.ID,
+			VoucherID:     redemption.VoucherID,
+			TransactionID: redemption.TransactionID,
+			CustomerRef:   redemption.CustomerRef,
+			Amount:        redemption.Amount,
+			RedeemedAt:    redemption.RedeemedAt,
+		}
+	}
+	return result
+}
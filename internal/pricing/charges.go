@@ -0,0 +1,38 @@
+package pricing
+
+import "math"
+
+// serviceCharge adds the service charge on the cart's net amount, rounded half
+// up to whole rupiah, and taxes it when the cart has a rate for it. Like the
+// lines, the charge includes its tax when prices include tax.
+func serviceCharge(cart *Cart) {
+	cart.ServiceCharge, cart.ServiceChargeTaxable, cart.ServiceChargeTax = 0, 0, 0
+
+	// Work in hundredths of a percent so the result does not depend on float rounding
+	basisPoints := int(math.Round(cart.ServiceChargeRate * 100))
+	net := cart.Net()
+	if basisPoints <= 0 || net <= 0 {
+		return
+	}
+
+	cart.ServiceCharge = (net*basisPoints*2 + 10000) / 20000
+	if cart.ServiceChargeTaxRate != nil {
+		cart.ServiceChargeTaxable, cart.ServiceChargeTax = cart.ServiceChargeTaxRate.Split(cart.ServiceCharge, cart.TaxMode)
+	}
+}
+
+// round rounds the amount due to the nearest multiple of RoundTo, half up, and
+// records the difference
+func round(cart *Cart) {
+	cart.Rounding = 0
+	cart.Rounding = Rounding(cart.Total(), cart.RoundTo)
+}
+
+// Rounding returns what rounding due to the nearest multiple of roundTo, half up,
+// adds to it: negative when rounded down and 0 when roundTo is not positive
+func Rounding(due, roundTo int) int {
+	if roundTo <= 0 {
+		return 0
+	}
+	return (due+roundTo/2)/roundTo*roundTo - due
+}
@@ -0,0 +1,102 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+func TestRounding(t *testing.T) {
+	tests := []struct {
+		due     int
+		roundTo int
+		want    int
+	}{
+		{due: 12345, roundTo: 100, want: -45},
+		{due: 12350, roundTo: 100, want: 50},
+		{due: 12300, roundTo: 100, want: 0},
+		{due: 12345, roundTo: 500, want: 155},
+		{due: 12249, roundTo: 500, want: -249},
+		{due: 12345, roundTo: 0, want: 0},
+		{due: 12345, roundTo: -100, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := Rounding(tt.due, tt.roundTo); got != tt.want {
+			t.Errorf("Rounding(%d, %d) = %d, want %d", tt.due, tt.roundTo, got, tt.want)
+		}
+	}
+}
+
+func TestPriceServiceCharge(t *testing.T) {
+	ppn := &entities.TaxRate{ID: 1, Name: "PPN", Rate: 11}
+
+	tests := []struct {
+		name        string
+		mode        string
+		rate        float64
+		chargeTax   *entities.TaxRate
+		roundTo     int
+		gross       int
+		wantCharge  int
+		wantTax     int
+		wantRounded int
+		wantTotal   int
+	}{
+		{name: "no service charge", mode: entities.TaxExclusive, gross: 10000, wantTax: 1100, wantTotal: 11100},
+		{name: "untaxed service charge", mode: entities.TaxExclusive, rate: 5, gross: 10000, wantCharge: 500, wantTax: 1100, wantTotal: 11600},
+		{name: "taxed service charge on top", mode: entities.TaxExclusive, rate: 5, chargeTax: ppn, gross: 10000, wantCharge: 500, wantTax: 1155, wantTotal: 11655},
+		{name: "taxed service charge with inclusive prices", mode: entities.TaxInclusive, rate: 5, chargeTax: ppn, gross: 11100, wantCharge: 555, wantTax: 1155, wantTotal: 11655},
+		{name: "fractional rate rounds half up", mode: entities.TaxExclusive, rate: 5.5, gross: 1010, wantCharge: 56, wantTax: 111, wantTotal: 1177},
+		{name: "rounded up", mode: entities.TaxExclusive, rate: 5, chargeTax: ppn, roundTo: 100, gross: 10000, wantCharge: 500, wantTax: 1155, wantRounded: 45, wantTotal: 11700},
+		{name: "rounded down", mode: entities.TaxExclusive, roundTo: 500, gross: 10000, wantTax: 1100, wantRounded: -100, wantTotal: 11000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := newLine(1, 1, tt.gross)
+			line.TaxRate = ppn
+			cart := &Cart{
+				Lines:                []*Line{line},
+				At:                   friday,
+				TaxMode:              tt.mode,
+				ServiceChargeRate:    tt.rate,
+				ServiceChargeTaxRate: tt.chargeTax,
+				RoundTo:              tt.roundTo,
+			}
+			if err := Price(cart); err != nil {
+				t.Fatalf("Price() error = %v", err)
+			}
+
+			if cart.ServiceCharge != tt.wantCharge {
+				t.Errorf("ServiceCharge = %d, want %d", cart.ServiceCharge, tt.wantCharge)
+			}
+			if tax := cart.Tax(); tax != tt.wantTax {
+				t.Errorf("Tax() = %d, want %d", tax, tt.wantTax)
+			}
+			if cart.Rounding != tt.wantRounded {
+				t.Errorf("Rounding = %d, want %d", cart.Rounding, tt.wantRounded)
+			}
+			if total := cart.Total(); total != tt.wantTotal {
+				t.Errorf("Total() = %d, want %d", total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestPriceServiceChargeTaxBreakdown(t *testing.T) {
+	ppn := &entities.TaxRate{ID: 1, Name: "PPN", Rate: 11}
+	line := newLine(1, 1, 10000)
+	line.TaxRate = ppn
+	cart := &Cart{Lines: []*Line{line}, At: friday, TaxMode: entities.TaxExclusive, ServiceChargeRate: 5, ServiceChargeTaxRate: ppn}
+
+	if err := Price(cart); err != nil {
+		t.Fatalf("Price() error = %v", err)
+	}
+
+	// The service charge is taxed at the same rate as the line, so they share one entry
+	taxes := cart.Taxes()
+	if len(taxes) != 1 || taxes[0].TaxableAmount != 10500 || taxes[0].TaxAmount != 1155 {
+		t.Errorf("Taxes() = %+v, want one PPN entry of 1155 on 10500", taxes)
+	}
+}
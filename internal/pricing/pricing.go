@@ -0,0 +1,233 @@
+// Package pricing prices a checkout cart. Lines start at their gross amount and
+// an ordered list of rules takes discounts off lines or off the whole cart.
+// Cart-level discounts are then spread over the lines, so that every line ends
+// with the net amount it actually sold for and the lines add up to the total.
+// Tax is then worked out on each line's net amount, the service charge is added
+// and the amount due is rounded.
+package pricing
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// Line is a cart line being priced
+type Line struct {
+	Product  *entities.Product
+	Quantity float64
+	// Gross is the amount before discounts, in whole rupiah
+	Gross int
+	// Discount is the total taken off the line, including its share of cart discounts
+	Discount int
+	// Discounts explains the discounts applied to this line
+	Discounts []entities.AppliedDiscount
+	// TaxRate is the rate the line is taxed at, nil for untaxed lines
+	TaxRate *entities.TaxRate
+	// Taxable and Tax split the net amount into its taxable base and tax once
+	// the cart is priced
+	Taxable int
+	Tax     int
+
+	// promoted is set once a promotion discounts the line, and exclusive once
+	// a promotion that does not stack with others does
+	promoted  bool
+	exclusive bool
+}
+
+// Net returns the amount the line sells for after its discounts
+func (l *Line) Net() int {
+	return l.Gross - l.Discount
+}
+
+// ApplyDiscount takes a discount off the line's current net amount and records
+// why. It returns the amount taken off.
+func (l *Line) ApplyDiscount(discount entities.Discount, source, description string) int {
+	amount := discount.Of(l.Net())
+	if amount == 0 {
+		return 0
+	}
+
+	l.Discount += amount
+	l.Discounts = append(l.Discounts, entities.AppliedDiscount{
+		Source:      source,
+		Description: description,
+		Amount:      amount,
+	})
+	return amount
+}
+
+// Cart is a checkout being priced
+type Cart struct {
+	Lines []*Line
+	// At is the time of sale, which time bound rules are evaluated against
+	At time.Time
+	// Location is the time zone the days and hours of time bound rules are in; nil
+	// takes them in the time zone of At
+	Location *time.Location
+	// TaxMode says whether prices include tax or have it added on top
+	TaxMode string
+	// ServiceChargeRate is the percentage of the net amount added as a service
+	// charge, which is taxed at ServiceChargeTaxRate unless that is nil
+	ServiceChargeRate    float64
+	ServiceChargeTaxRate *entities.TaxRate
+	// RoundTo rounds the amount due to a multiple of this many rupiah, 0 for none
+	RoundTo int
+	// Discount is taken off the whole cart and has not been spread over the lines yet
+	Discount int
+	// Discounts explains the discounts applied to the whole cart
+	Discounts []entities.AppliedDiscount
+	// ServiceCharge, its taxable base and its tax, and the Rounding added to the
+	// amount due are set once the cart is priced
+	ServiceCharge        int
+	ServiceChargeTaxable int
+	ServiceChargeTax     int
+	Rounding             int
+}
+
+// Gross returns the amount of the cart before discounts
+func (c *Cart) Gross() int {
+	gross := 0
+	for _, line := range c.Lines {
+		gross += line.Gross
+	}
+	return gross
+}
+
+// Net returns the amount of the cart after all discounts
+func (c *Cart) Net() int {
+	net := 0
+	for _, line := range c.Lines {
+		net += line.Net()
+	}
+	return net - c.Discount
+}
+
+// ApplyDiscount takes a discount off the cart's current net amount and records
+// why. It returns the amount taken off.
+func (c *Cart) ApplyDiscount(discount entities.Discount, source, description string) int {
+	amount := discount.Of(c.Net())
+	if amount == 0 {
+		return 0
+	}
+
+	c.Discount += amount
+	c.Discounts = append(c.Discounts, entities.AppliedDiscount{
+		Source:      source,
+		Description: description,
+		Amount:      amount,
+	})
+	return amount
+}
+
+// Rule applies discounts to a cart
+type Rule interface {
+	Apply(cart *Cart) error
+}
+
+// Price applies the rules to the cart in order, spreads the cart discount over
+// the lines in proportion to their net amounts, taxes each line, adds the
+// service charge and rounds the amount due
+func Price(cart *Cart, rules ...Rule) error {
+	for _, rule := range rules {
+		if err := rule.Apply(cart); err != nil {
+			return err
+		}
+	}
+
+	allocate(cart)
+	tax(cart)
+	serviceCharge(cart)
+	round(cart)
+	return nil
+}
+
+// allocate spreads the cart discount over the lines in proportion to their net amounts
+func allocate(cart *Cart) {
+	if cart.Discount == 0 {
+		return
+	}
+
+	weights := make([]int, len(cart.Lines))
+	for i, line := range cart.Lines {
+		weights[i] = line.Net()
+	}
+
+	for i, share := range split(cart.Discount, weights) {
+		cart.Lines[i].Discount += share
+	}
+	cart.Discount = 0
+}
+
+// split divides an amount in proportion to the weights. Rupiah left over from
+// rounding go to the largest remainders, earliest first, so the shares add up
+// exactly. No share exceeds its weight as long as the amount does not exceed
+// the total weight.
+func split(amount int, weights []int) []int {
+	shares := make([]int, len(weights))
+	total := 0
+	for _, weight := range weights {
+		total += weight
+	}
+	if amount == 0 || total == 0 {
+		return shares
+	}
+
+	remainders := make([]int, len(weights))
+	allocated := 0
+	for i, weight := range weights {
+		shares[i] = amount * weight / total
+		remainders[i] = amount * weight % total
+		allocated += shares[i]
+	}
+
+	for left := amount - allocated; left > 0; left-- {
+		largest := 0
+		for i := range remainders {
+			if remainders[i] > remainders[largest] {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = -1
+	}
+
+	return shares
+}
+
+// LineDiscount is a discount the cashier gives on one line
+type LineDiscount struct {
+	Line     int
+	Discount entities.Discount
+	Reason   string
+}
+
+// Apply takes the discount off its line
+func (r LineDiscount) Apply(cart *Cart) error {
+	if r.Line < 0 || r.Line >= len(cart.Lines) {
+		return fmt.Errorf("discount refers to line %d, which is not in the cart", r.Line+1)
+	}
+	cart.Lines[r.Line].ApplyDiscount(r.Discount, entities.DiscountSourceManual, describe(r.Discount, r.Reason))
+	return nil
+}
+
+// CartDiscount is a discount the cashier gives on the whole cart
+type CartDiscount struct {
+	Discount entities.Discount
+	Reason   string
+}
+
+// Apply takes the discount off the cart
+func (r CartDiscount) Apply(cart *Cart) error {
+	cart.ApplyDiscount(r.Discount, entities.DiscountSourceManual, describe(r.Discount, r.Reason))
+	return nil
+}
+
+// describe explains a discount, prefixed with the reason given for it if any
+func describe(discount entities.Discount, reason string) string {
+	if reason == "" {
+		return discount.String()
+	}
+	return reason + ": " + discount.String()
+}
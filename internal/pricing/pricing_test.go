@@ -0,0 +1,145 @@
+package pricing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  int
+		weights []int
+		want    []int
+	}{
+		{name: "even", amount: 100, weights: []int{50, 50}, want: []int{50, 50}},
+		{name: "proportional", amount: 1000, weights: []int{30000, 10000}, want: []int{750, 250}},
+		{name: "leftover to the largest remainder", amount: 100, weights: []int{1, 2}, want: []int{33, 67}},
+		{name: "leftover to the earliest on a tie", amount: 100, weights: []int{1, 1, 1}, want: []int{34, 33, 33}},
+		{name: "zero weight gets nothing", amount: 10, weights: []int{0, 5}, want: []int{0, 10}},
+		{name: "nothing to split", amount: 0, weights: []int{5, 5}, want: []int{0, 0}},
+		{name: "no weight", amount: 10, weights: []int{0, 0}, want: []int{0, 0}},
+		{name: "whole weight", amount: 7, weights: []int{3, 4}, want: []int{3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := split(tt.amount, tt.weights); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("split(%d, %v) = %v, want %v", tt.amount, tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriceDiscounts(t *testing.T) {
+	percent := func(value float64) entities.Discount {
+		return entities.Discount{Type: entities.DiscountPercent, Value: value}
+	}
+	amount := func(value float64) entities.Discount {
+		return entities.Discount{Type: entities.DiscountAmount, Value: value}
+	}
+
+	tests := []struct {
+		name  string
+		rules []Rule
+		// wantLines holds each line's discount once cart discounts are spread over the lines
+		wantLines []int
+		wantNet   int
+	}{
+		{
+			name:      "no discounts",
+			wantLines: []int{0, 0},
+			wantNet:   40000,
+		},
+		{
+			name:      "line discount",
+			rules:     []Rule{LineDiscount{Line: 0, Discount: percent(10)}},
+			wantLines: []int{3000, 0},
+			wantNet:   37000,
+		},
+		{
+			name:      "line discount never exceeds the line",
+			rules:     []Rule{LineDiscount{Line: 1, Discount: amount(50000)}},
+			wantLines: []int{0, 10000},
+			wantNet:   30000,
+		},
+		{
+			name:      "cart discount is spread by net amount",
+			rules:     []Rule{CartDiscount{Discount: amount(1000)}},
+			wantLines: []int{750, 250},
+			wantNet:   39000,
+		},
+		{
+			name:      "cart discount applies to the net after line discounts",
+			rules:     []Rule{LineDiscount{Line: 0, Discount: amount(10000)}, CartDiscount{Discount: percent(10)}},
+			wantLines: []int{12000, 1000},
+			wantNet:   27000,
+		},
+		{
+			name:      "cart discount rounding leftover",
+			rules:     []Rule{CartDiscount{Discount: amount(1001)}},
+			wantLines: []int{751, 250},
+			wantNet:   38999,
+		},
+		{
+			name:      "cart discount never exceeds the cart",
+			rules:     []Rule{CartDiscount{Discount: amount(100000)}},
+			wantLines: []int{30000, 10000},
+			wantNet:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := &Cart{Lines: []*Line{newLine(1, 3, 10000), newLine(2, 1, 10000)}, At: friday}
+			if err := Price(cart, tt.rules...); err != nil {
+				t.Fatalf("Price() error = %v", err)
+			}
+
+			got := make([]int, len(cart.Lines))
+			for i, line := range cart.Lines {
+				got[i] = line.Discount
+			}
+			if !reflect.DeepEqual(got, tt.wantLines) {
+				t.Errorf("line discounts = %v, want %v", got, tt.wantLines)
+			}
+			if cart.Discount != 0 {
+				t.Errorf("cart discount left unspread: %d", cart.Discount)
+			}
+			if net := cart.Net(); net != tt.wantNet {
+				t.Errorf("Net() = %d, want %d", net, tt.wantNet)
+			}
+		})
+	}
+}
+
+func TestPriceDescribesDiscounts(t *testing.T) {
+	cart := &Cart{Lines: []*Line{newLine(1, 1, 10000)}, At: friday}
+	rules := []Rule{
+		LineDiscount{Line: 0, Discount: entities.Discount{Type: entities.DiscountPercent, Value: 10}, Reason: "Damaged box"},
+		CartDiscount{Discount: entities.Discount{Type: entities.DiscountAmount, Value: 500}},
+	}
+	if err := Price(cart, rules...); err != nil {
+		t.Fatalf("Price() error = %v", err)
+	}
+
+	line := cart.Lines[0].Discounts
+	if len(line) != 1 || line[0].Description != "Damaged box: 10% off" || line[0].Source != entities.DiscountSourceManual {
+		t.Errorf("line discounts = %+v", line)
+	}
+	if len(cart.Discounts) != 1 || cart.Discounts[0].Description != "Rp 500 off" || cart.Discounts[0].Amount != 500 {
+		t.Errorf("cart discounts = %+v", cart.Discounts)
+	}
+}
+
+func TestLineDiscountOutOfRange(t *testing.T) {
+	cart := &Cart{Lines: []*Line{newLine(1, 1, 10000)}, At: friday}
+	for _, line := range []int{-1, 1} {
+		rule := LineDiscount{Line: line, Discount: entities.Discount{Type: entities.DiscountAmount, Value: 100}}
+		if err := Price(cart, rule); err == nil {
+			t.Errorf("Price() with a discount on line %d error = nil, want an error", line)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package pricing
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// Promotions applies the promotions running at the time of sale. They are
+// applied highest priority first, and by ID among equal priorities, so the
+// same cart always prices the same way.
+type Promotions []entities.Promotion
+
+// Apply takes each running promotion's discounts off the lines it applies to
+func (r Promotions) Apply(cart *Cart) error {
+	promotions := slices.Clone(r)
+	slices.SortStableFunc(promotions, func(a, b entities.Promotion) int {
+		if a.Priority != b.Priority {
+			return cmp.Compare(b.Priority, a.Priority)
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	location := cart.Location
+	if location == nil {
+		location = cart.At.Location()
+	}
+
+	for i := range promotions {
+		promotion := &promotions[i]
+		if !promotion.ActiveAt(cart.At, location) {
+			continue
+		}
+
+		switch promotion.Type {
+		case entities.PromotionBuyXGetY:
+			applyBuyXGetY(cart, promotion)
+		case entities.PromotionQuantityTier:
+			applyQuantityTier(cart, promotion)
+		case entities.PromotionCategoryPercent:
+			applyCategoryPercent(cart, promotion)
+		case entities.PromotionBundlePrice:
+			applyBundlePrice(cart, promotion)
+		default:
+			return fmt.Errorf("promotion %s has unknown type %s", promotion.Name, promotion.Type)
+		}
+	}
+
+	return nil
+}
+
+// eligible returns the indexes of the lines the promotion may discount, in cart order
+func eligible(cart *Cart, promotion *entities.Promotion, matches func(line *Line) bool) []int {
+	var indexes []int
+	for i, line := range cart.Lines {
+		if line.exclusive || (line.promoted && !promotion.Stackable) {
+			continue
+		}
+		if line.Net() <= 0 || !matches(line) {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// listed matches lines selling one of the promotion's products
+func listed(promotion *entities.Promotion) func(line *Line) bool {
+	return func(line *Line) bool {
+		return promotion.HasProduct(line.Product.ID)
+	}
+}
+
+// promote takes a promotion's discount off a line and records that the line was promoted
+func promote(line *Line, promotion *entities.Promotion, amount int, rule string) {
+	discount := entities.Discount{Type: entities.DiscountAmount, Value: float64(amount)}
+	if line.ApplyDiscount(discount, entities.DiscountSourcePromotion, promotion.Name+": "+rule) == 0 {
+		return
+	}
+
+	line.promoted = true
+	if !promotion.Stackable {
+		line.exclusive = true
+	}
+}
+
+// units returns the whole units on a line; weighed items only count whole units
+func units(line *Line) int {
+	return int(math.Floor(entities.RoundQuantity(line.Quantity)))
+}
+
+// unitPrice returns the current net price of one unit on a line
+func unitPrice(line *Line) float64 {
+	return float64(line.Net()) / line.Quantity
+}
+
+// applyBuyXGetY frees get units for every buy + get units of the listed
+// products, counted across lines. The cheapest units go free.
+func applyBuyXGetY(cart *Cart, promotion *entities.Promotion) {
+	indexes := eligible(cart, promotion, listed(promotion))
+
+	total := 0
+	for _, i := range indexes {
+		total += units(cart.Lines[i])
+	}
+	free := total / (promotion.BuyQuantity + promotion.GetQuantity) * promotion.GetQuantity
+	if free == 0 {
+		return
+	}
+
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		return cmp.Compare(unitPrice(cart.Lines[a]), unitPrice(cart.Lines[b]))
+	})
+
+	rule := fmt.Sprintf("buy %d get %d free", promotion.BuyQuantity, promotion.GetQuantity)
+	for _, i := range indexes {
+		if free == 0 {
+			break
+		}
+		line := cart.Lines[i]
+		taken := min(free, units(line))
+		free -= taken
+		promote(line, promotion, int(math.Round(unitPrice(line)*float64(taken))), rule)
+	}
+}
+
+// applyQuantityTier prices each listed product at the unit price of the
+// highest tier its quantity reaches, counting all lines of the product
+func applyQuantityTier(cart *Cart, promotion *entities.Promotion) {
+	indexes := eligible(cart, promotion, listed(promotion))
+
+	quantities := make(map[int]float64)
+	for _, i := range indexes {
+		line := cart.Lines[i]
+		quantities[line.Product.ID] = entities.RoundQuantity(quantities[line.Product.ID] + line.Quantity)
+	}
+
+	for _, i := range indexes {
+		line := cart.Lines[i]
+		tier := promotion.TierFor(quantities[line.Product.ID])
+		if tier == nil {
+			continue
+		}
+
+		tierPrice := int(math.Round(float64(tier.UnitPrice) * line.Quantity))
+		if tierPrice < line.Net() {
+			rule := fmt.Sprintf("%g %s or more at Rp %d each", tier.MinQuantity, line.Product.Unit, tier.UnitPrice)
+			promote(line, promotion, line.Net()-tierPrice, rule)
+		}
+	}
+}
+
+// applyCategoryPercent takes a percentage off lines in the promoted category or its subcategories
+func applyCategoryPercent(cart *Cart, promotion *entities.Promotion) {
+	indexes := eligible(cart, promotion, func(line *Line) bool {
+		return line.Product.CategoryID != nil && slices.Contains(promotion.CategoryIDs, *line.Product.CategoryID)
+	})
+
+	discount := entities.Discount{Type: entities.DiscountPercent, Value: promotion.Percent}
+	for _, i := range indexes {
+		line := cart.Lines[i]
+		promote(line, promotion, discount.Of(line.Net()), discount.String())
+	}
+}
+
+// applyBundlePrice sells as many complete bundles as the cart holds at the
+// bundle price. Units are taken from the lines in cart order and the saving is
+// spread over the lines in proportion to the value of the units they gave.
+func applyBundlePrice(cart *Cart, promotion *entities.Promotion) {
+	indexes := eligible(cart, promotion, listed(promotion))
+
+	available := make(map[int]int)
+	for _, i := range indexes {
+		line := cart.Lines[i]
+		available[line.Product.ID] += units(line)
+	}
+
+	bundles := math.MaxInt
+	for _, item := range promotion.Items {
+		bundles = min(bundles, available[item.ProductID]/item.Quantity)
+	}
+	if bundles == 0 {
+		return
+	}
+
+	needed := make(map[int]int)
+	for _, item := range promotion.Items {
+		needed[item.ProductID] = item.Quantity * bundles
+	}
+
+	// Value the units each line gives to the bundles
+	values := make([]int, len(indexes))
+	regular := 0
+	for j, i := range indexes {
+		line := cart.Lines[i]
+		taken := min(needed[line.Product.ID], units(line))
+		needed[line.Product.ID] -= taken
+		values[j] = int(math.Round(unitPrice(line) * float64(taken)))
+		regular += values[j]
+	}
+
+	saving := regular - bundles*promotion.BundlePrice
+	if saving <= 0 {
+		return
+	}
+
+	rule := fmt.Sprintf("%d bundles for Rp %d", bundles, bundles*promotion.BundlePrice)
+	if bundles == 1 {
+		rule = fmt.Sprintf("bundle for Rp %d", promotion.BundlePrice)
+	}
+	for j, share := range split(saving, values) {
+		promote(cart.Lines[indexes[j]], promotion, share, rule)
+	}
+}
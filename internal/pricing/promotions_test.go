@@ -0,0 +1,233 @@
+package pricing
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// friday is a Friday noon, the default time of sale in these tests
+var friday = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+const snacks = 7
+
+func newLine(id int, quantity float64, unitPrice int) *Line {
+	category := snacks
+	return &Line{
+		Product:  &entities.Product{ID: id, Name: "Product", Unit: "pcs", CategoryID: &category},
+		Quantity: quantity,
+		Gross:    int(float64(unitPrice) * quantity),
+	}
+}
+
+func percentOff(id, priority int, stackable bool, percent float64) entities.Promotion {
+	return entities.Promotion{
+		ID: id, Name: "Promo", Type: entities.PromotionCategoryPercent, Priority: priority,
+		Stackable: stackable, Active: true, CategoryIDs: []int{snacks}, Percent: percent,
+	}
+}
+
+func buyOneGetOne(id, priority int, stackable bool, productID int) entities.Promotion {
+	return entities.Promotion{
+		ID: id, Name: "BOGO", Type: entities.PromotionBuyXGetY, Priority: priority, Stackable: stackable,
+		Active: true, BuyQuantity: 1, GetQuantity: 1, Items: []entities.PromotionItem{{ProductID: productID}},
+	}
+}
+
+func tier(id, priority int, stackable bool, productID int, minQuantity float64, unitPrice int) entities.Promotion {
+	return entities.Promotion{
+		ID: id, Name: "Tier", Type: entities.PromotionQuantityTier, Priority: priority, Stackable: stackable,
+		Active: true, Items: []entities.PromotionItem{{ProductID: productID}},
+		Tiers: []entities.PromotionTier{{MinQuantity: minQuantity, UnitPrice: unitPrice}},
+	}
+}
+
+// discounts returns the discount amounts recorded on each line
+func discounts(cart *Cart) [][]int {
+	amounts := make([][]int, len(cart.Lines))
+	for i, line := range cart.Lines {
+		amounts[i] = []int{}
+		for _, discount := range line.Discounts {
+			amounts[i] = append(amounts[i], discount.Amount)
+		}
+	}
+	return amounts
+}
+
+func TestPromotions(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      []*Line
+		promotions Promotions
+		// want holds the discounts taken off each line, in the order they were applied
+		want [][]int
+	}{
+		{
+			name:       "higher priority applies first",
+			lines:      []*Line{newLine(1, 1, 10000)},
+			promotions: Promotions{percentOff(1, 1, false, 10), percentOff(2, 5, false, 20)},
+			want:       [][]int{{2000}},
+		},
+		{
+			name:       "lower ID breaks a priority tie",
+			lines:      []*Line{newLine(1, 1, 10000)},
+			promotions: Promotions{percentOff(3, 1, false, 10), percentOff(2, 1, false, 20)},
+			want:       [][]int{{2000}},
+		},
+		{
+			name:       "stackable promotions discount the net left by earlier ones",
+			lines:      []*Line{newLine(1, 1, 10000)},
+			promotions: Promotions{percentOff(1, 1, true, 10), percentOff(2, 5, true, 20)},
+			want:       [][]int{{2000, 800}},
+		},
+		{
+			name:       "exclusive promotion skips a line already promoted",
+			lines:      []*Line{newLine(1, 1, 10000)},
+			promotions: Promotions{percentOff(1, 1, false, 10), percentOff(2, 5, true, 20)},
+			want:       [][]int{{2000}},
+		},
+		{
+			name:       "exclusive promotion keeps stackable ones off its lines",
+			lines:      []*Line{newLine(1, 1, 10000)},
+			promotions: Promotions{percentOff(1, 1, true, 10), percentOff(2, 5, false, 20)},
+			want:       [][]int{{2000}},
+		},
+		{
+			name:       "exclusive promotion leaves lines it did not discount to others",
+			lines:      []*Line{newLine(1, 2, 10000), newLine(2, 1, 5000)},
+			promotions: Promotions{buyOneGetOne(1, 5, false, 1), percentOff(2, 1, false, 10)},
+			want:       [][]int{{10000}, {500}},
+		},
+		{
+			name:       "BOGO then tier on the same line",
+			lines:      []*Line{newLine(1, 4, 10000)},
+			promotions: Promotions{buyOneGetOne(1, 5, true, 1), tier(2, 1, true, 1, 4, 8000)},
+			// Two units go free, leaving Rp 5000 a unit, already below the tier price
+			want: [][]int{{20000}},
+		},
+		{
+			name:       "tier then BOGO on the same line",
+			lines:      []*Line{newLine(1, 4, 10000)},
+			promotions: Promotions{buyOneGetOne(1, 1, true, 1), tier(2, 5, true, 1, 4, 8000)},
+			// The tier lowers the unit price to Rp 8000, then two of those go free
+			want: [][]int{{8000, 16000}},
+		},
+		{
+			name:       "exclusive tier keeps BOGO off the line",
+			lines:      []*Line{newLine(1, 4, 10000)},
+			promotions: Promotions{buyOneGetOne(1, 1, true, 1), tier(2, 5, false, 1, 4, 8000)},
+			want:       [][]int{{8000}},
+		},
+		{
+			name:       "tier counts every line of the product",
+			lines:      []*Line{newLine(1, 2, 10000), newLine(1, 2, 10000)},
+			promotions: Promotions{tier(1, 1, false, 1, 4, 8000)},
+			want:       [][]int{{4000}, {4000}},
+		},
+		{
+			name:       "BOGO frees the cheapest units across lines",
+			lines:      []*Line{newLine(1, 1, 10000), newLine(2, 1, 6000)},
+			promotions: Promotions{{ID: 1, Name: "BOGO", Type: entities.PromotionBuyXGetY, Active: true, BuyQuantity: 1, GetQuantity: 1, Items: []entities.PromotionItem{{ProductID: 1}, {ProductID: 2}}}},
+			want:       [][]int{{}, {6000}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := &Cart{Lines: tt.lines, At: friday}
+			if err := tt.promotions.Apply(cart); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got := discounts(cart); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("discounts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromotionsTimeWindow(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+	startsAt, endsAt := at(16, 0, 0), at(17, 0, 0)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		window func(p *entities.Promotion)
+		at     time.Time
+		// location is the time zone of the stores, nil for that of at
+		location *time.Location
+		want     bool
+	}{
+		{name: "inactive", window: func(p *entities.Promotion) { p.Active = false }, at: friday, want: false},
+		{name: "before starts_at", window: func(p *entities.Promotion) { p.StartsAt = &startsAt }, at: at(15, 23, 59), want: false},
+		{name: "at starts_at", window: func(p *entities.Promotion) { p.StartsAt = &startsAt }, at: startsAt, want: true},
+		{name: "before ends_at", window: func(p *entities.Promotion) { p.EndsAt = &endsAt }, at: at(16, 23, 59), want: true},
+		{name: "at ends_at", window: func(p *entities.Promotion) { p.EndsAt = &endsAt }, at: endsAt, want: false},
+		{name: "listed day", window: func(p *entities.Promotion) { p.Days = []int{1, 5} }, at: friday, want: true},
+		{name: "unlisted day", window: func(p *entities.Promotion) { p.Days = []int{1, 2} }, at: friday, want: false},
+		{name: "at start_time", window: hours("10:00", "14:00"), at: at(16, 10, 0), want: true},
+		{name: "before start_time", window: hours("10:00", "14:00"), at: at(16, 9, 59), want: false},
+		{name: "at end_time", window: hours("10:00", "14:00"), at: at(16, 14, 0), want: false},
+		{name: "overnight before midnight", window: hours("22:00", "02:00"), at: at(16, 23, 30), want: true},
+		{name: "overnight after midnight", window: hours("22:00", "02:00"), at: at(17, 1, 59), want: true},
+		{name: "overnight at end_time", window: hours("22:00", "02:00"), at: at(17, 2, 0), want: false},
+		{name: "overnight during the day", window: hours("22:00", "02:00"), at: friday, want: false},
+		{name: "overnight after midnight counts as the day it started", window: overnight(5), at: at(17, 1, 0), want: true},
+		{name: "overnight on its day before midnight", window: overnight(5), at: at(16, 23, 0), want: true},
+		{name: "overnight after midnight of its day", window: overnight(5), at: at(16, 1, 0), want: false},
+		// 16:30 UTC on Friday is 23:30 on Friday in Jakarta and 17:30 UTC is 00:30 on Saturday
+		{name: "store evening before midnight", window: hours("22:00", "02:00"), at: at(16, 16, 30), location: jakarta, want: true},
+		{name: "store evening read as server time", window: hours("22:00", "02:00"), at: at(16, 16, 30), want: false},
+		{name: "store day after midnight", window: func(p *entities.Promotion) { p.Days = []int{6} }, at: at(16, 17, 30), location: jakarta, want: true},
+		{name: "store day before midnight", window: func(p *entities.Promotion) { p.Days = []int{5} }, at: at(16, 17, 30), location: jakarta, want: false},
+		{name: "store overnight after midnight counts as the day it started", window: overnight(5), at: at(16, 17, 30), location: jakarta, want: true},
+		{name: "store overnight after midnight of its day", window: overnight(6), at: at(16, 17, 30), location: jakarta, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promotion := percentOff(1, 1, false, 10)
+			tt.window(&promotion)
+
+			cart := &Cart{Lines: []*Line{newLine(1, 1, 10000)}, At: tt.at, Location: tt.location}
+			if err := (Promotions{promotion}).Apply(cart); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got := cart.Lines[0].Discount > 0; got != tt.want {
+				t.Errorf("promotion applied = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// hours limits a promotion to a time of day
+func hours(start, end string) func(p *entities.Promotion) {
+	return func(p *entities.Promotion) {
+		p.StartTime, p.EndTime = start, end
+	}
+}
+
+// overnight limits a promotion to 22:00 until 02:00 the next morning, starting on the given day
+func overnight(day int) func(p *entities.Promotion) {
+	return func(p *entities.Promotion) {
+		p.Days = []int{day}
+		p.StartTime, p.EndTime = "22:00", "02:00"
+	}
+}
+
+func TestPromotionsUnknownType(t *testing.T) {
+	promotion := percentOff(1, 1, false, 10)
+	promotion.Type = "mystery"
+
+	cart := &Cart{Lines: []*Line{newLine(1, 1, 10000)}, At: friday}
+	if err := (Promotions{promotion}).Apply(cart); err == nil {
+		t.Error("Apply() error = nil, want an error")
+	}
+}
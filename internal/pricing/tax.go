@@ -0,0 +1,65 @@
+package pricing
+
+import "github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+
+// tax splits the net amount of each taxed line into its taxable base and tax.
+// Tax is rounded per line, so the tax of a cart is the sum of its lines.
+func tax(cart *Cart) {
+	for _, line := range cart.Lines {
+		line.Taxable, line.Tax = 0, 0
+		if line.TaxRate != nil {
+			line.Taxable, line.Tax = line.TaxRate.Split(line.Net(), cart.TaxMode)
+		}
+	}
+}
+
+// Tax returns the tax on the cart, including the tax on the service charge
+func (c *Cart) Tax() int {
+	tax := c.ServiceChargeTax
+	for _, line := range c.Lines {
+		tax += line.Tax
+	}
+	return tax
+}
+
+// Total returns the amount the customer pays: the net amount and the service
+// charge, plus the tax when prices exclude it, plus the rounding
+func (c *Cart) Total() int {
+	total := c.Net() + c.ServiceCharge + c.Rounding
+	if c.TaxMode == entities.TaxExclusive {
+		total += c.Tax()
+	}
+	return total
+}
+
+// Taxes returns the taxable base and tax of the cart per rate, in the order the
+// rates first appear in the cart, with the service charge last
+func (c *Cart) Taxes() []entities.TransactionTax {
+	var taxes []entities.TransactionTax
+	index := make(map[int]int)
+	add := func(rate *entities.TaxRate, taxable, tax int) {
+		i, ok := index[rate.ID]
+		if !ok {
+			i = len(taxes)
+			index[rate.ID] = i
+			rateID := rate.ID
+			taxes = append(taxes, entities.TransactionTax{
+				TaxRateID: &rateID,
+				Name:      rate.Name,
+				Rate:      rate.Rate,
+			})
+		}
+		taxes[i].TaxableAmount += taxable
+		taxes[i].TaxAmount += tax
+	}
+
+	for _, line := range c.Lines {
+		if line.TaxRate != nil {
+			add(line.TaxRate, line.Taxable, line.Tax)
+		}
+	}
+	if c.ServiceCharge > 0 && c.ServiceChargeTaxRate != nil {
+		add(c.ServiceChargeTaxRate, c.ServiceChargeTaxable, c.ServiceChargeTax)
+	}
+	return taxes
+}
@@ -0,0 +1,106 @@
+package pricing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+func TestPriceTax(t *testing.T) {
+	ppn := &entities.TaxRate{ID: 1, Name: "PPN", Rate: 11}
+	pb1 := &entities.TaxRate{ID: 2, Name: "PB1", Rate: 10}
+
+	// taxed builds a cart line taxed at rate, nil for an untaxed line
+	taxed := func(id, gross int, rate *entities.TaxRate) *Line {
+		line := newLine(id, 1, gross)
+		line.TaxRate = rate
+		return line
+	}
+
+	tests := []struct {
+		name      string
+		mode      string
+		lines     []*Line
+		wantTax   []int
+		wantTotal int
+		wantTaxes []entities.TransactionTax
+	}{
+		{
+			name:      "exclusive adds tax on top",
+			mode:      entities.TaxExclusive,
+			lines:     []*Line{taxed(1, 10000, ppn), taxed(2, 5000, nil), taxed(3, 3000, ppn)},
+			wantTax:   []int{1100, 0, 330},
+			wantTotal: 19430,
+			wantTaxes: []entities.TransactionTax{{Name: "PPN", Rate: 11, TaxableAmount: 13000, TaxAmount: 1430}},
+		},
+		{
+			name:      "inclusive takes tax out of the price",
+			mode:      entities.TaxInclusive,
+			lines:     []*Line{taxed(1, 11100, ppn), taxed(2, 5000, nil)},
+			wantTax:   []int{1100, 0},
+			wantTotal: 16100,
+			wantTaxes: []entities.TransactionTax{{Name: "PPN", Rate: 11, TaxableAmount: 10000, TaxAmount: 1100}},
+		},
+		{
+			name:      "tax is rounded per line",
+			mode:      entities.TaxExclusive,
+			lines:     []*Line{taxed(1, 50, ppn), taxed(2, 50, ppn)},
+			wantTax:   []int{6, 6},
+			wantTotal: 112,
+			wantTaxes: []entities.TransactionTax{{Name: "PPN", Rate: 11, TaxableAmount: 100, TaxAmount: 12}},
+		},
+		{
+			name:      "rates listed in order of first appearance",
+			mode:      entities.TaxExclusive,
+			lines:     []*Line{taxed(1, 2000, pb1), taxed(2, 1000, ppn), taxed(3, 1000, pb1)},
+			wantTax:   []int{200, 110, 100},
+			wantTotal: 4410,
+			wantTaxes: []entities.TransactionTax{
+				{Name: "PB1", Rate: 10, TaxableAmount: 3000, TaxAmount: 300},
+				{Name: "PPN", Rate: 11, TaxableAmount: 1000, TaxAmount: 110},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cart := &Cart{Lines: tt.lines, At: friday, TaxMode: tt.mode}
+			if err := Price(cart); err != nil {
+				t.Fatalf("Price() error = %v", err)
+			}
+
+			got := make([]int, len(cart.Lines))
+			for i, line := range cart.Lines {
+				got[i] = line.Tax
+			}
+			if !reflect.DeepEqual(got, tt.wantTax) {
+				t.Errorf("line taxes = %v, want %v", got, tt.wantTax)
+			}
+			if total := cart.Total(); total != tt.wantTotal {
+				t.Errorf("Total() = %d, want %d", total, tt.wantTotal)
+			}
+
+			taxes := cart.Taxes()
+			for i := range taxes {
+				taxes[i].TaxRateID = nil
+			}
+			if !reflect.DeepEqual(taxes, tt.wantTaxes) {
+				t.Errorf("Taxes() = %+v, want %+v", taxes, tt.wantTaxes)
+			}
+		})
+	}
+}
+
+func TestPriceTaxesDiscountedNet(t *testing.T) {
+	line := newLine(1, 1, 10000)
+	line.TaxRate = &entities.TaxRate{ID: 1, Name: "PPN", Rate: 11}
+	cart := &Cart{Lines: []*Line{line}, At: friday, TaxMode: entities.TaxExclusive}
+
+	if err := Price(cart, CartDiscount{Discount: entities.Discount{Type: entities.DiscountAmount, Value: 1000}}); err != nil {
+		t.Fatalf("Price() error = %v", err)
+	}
+	if line.Taxable != 9000 || line.Tax != 990 {
+		t.Errorf("taxable, tax = %d, %d, want 9000, 990", line.Taxable, line.Tax)
+	}
+}
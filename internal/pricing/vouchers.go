@@ -0,0 +1,34 @@
+package pricing
+
+import (
+	"fmt"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+// VoucherDiscount redeems a voucher against the whole cart
+type VoucherDiscount struct {
+	Voucher entities.Voucher
+}
+
+// Apply takes the voucher's discount off the cart once its minimum spend is met
+func (r VoucherDiscount) Apply(cart *Cart) error {
+	voucher := r.Voucher
+	if cart.Net() < voucher.MinSpend {
+		return fmt.Errorf("voucher %s requires a minimum spend of Rp %d", voucher.Code, voucher.MinSpend)
+	}
+
+	amount := voucher.Of(cart.Net())
+	if amount == 0 {
+		return fmt.Errorf("voucher %s gives no discount on this cart", voucher.Code)
+	}
+
+	description := fmt.Sprintf("Voucher %s: %s", voucher.Code, voucher.Discount())
+	if voucher.MaxDiscount > 0 {
+		description += fmt.Sprintf(" up to Rp %d", voucher.MaxDiscount)
+	}
+
+	cart.ApplyDiscount(entities.Discount{Type: entities.DiscountAmount, Value: float64(amount)}, entities.DiscountSourceVoucher, description)
+	cart.Discounts[len(cart.Discounts)-1].VoucherID = &voucher.ID
+	return nil
+}
@@ -0,0 +1,246 @@
+// Package qris builds dynamic QRIS payloads, the Indonesian standard for QR code
+// payments based on the EMVCo Merchant-Presented Mode specification, and renders
+// them as QR code images. Payloads are generated locally; no payment service is
+// contacted.
+package qris
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Data object IDs used in a QRIS payload
+const (
+	idPayloadFormat    = "00"
+	idInitiationMethod = "01"
+	idMerchantAccount  = "26"
+	idQRISDomain       = "51"
+	idCategoryCode     = "52"
+	idCurrency         = "53"
+	idAmount           = "54"
+	idCountryCode      = "58"
+	idMerchantName     = "59"
+	idMerchantCity     = "60"
+	idPostalCode       = "61"
+	idAdditionalData   = "62"
+	idCRC              = "63"
+
+	// Sub-IDs of merchant account information and additional data
+	idGloballyUniqueID = "00"
+	idMerchantPAN      = "01"
+	idMerchantID       = "02"
+	idMerchantCriteria = "03"
+	idBillNumber       = "01"
+)
+
+const (
+	// dynamicMethod marks a payload generated for one payment, as opposed to a
+	// static payload reused for any amount
+	dynamicMethod = "12"
+
+	// QRISDomain identifies the national QRIS merchant ID in data object 51
+	QRISDomain = "ID.CO.QRIS.WWW"
+
+	// currencyRupiah and countryIndonesia are the ISO 4217 and ISO 3166 codes
+	currencyRupiah   = "360"
+	countryIndonesia = "ID"
+
+	// maxValueLength is the longest value a data object can hold, as its length
+	// is written in two digits
+	maxValueLength = 99
+)
+
+// Merchant criteria as registered with the national QRIS merchant ID
+const (
+	CriteriaMicro  = "UMI"
+	CriteriaSmall  = "UKE"
+	CriteriaMedium = "UME"
+	CriteriaLarge  = "UBE"
+)
+
+// Merchant is the merchant account a QRIS payment is made to, as issued by the
+// acquiring bank or payment provider
+type Merchant struct {
+	// AcquirerDomain is the reverse domain of the acquirer, such as ID.CO.BANKNAME.WWW
+	AcquirerDomain string
+	// PAN is the merchant's primary account number at the acquirer
+	PAN string
+	// AcquirerMerchantID is the merchant's ID at the acquirer
+	AcquirerMerchantID string
+	// NMID is the national merchant ID, such as ID1020012345678
+	NMID     string
+	Criteria string
+	// CategoryCode is the four digit ISO 18245 merchant category code
+	CategoryCode string
+	Name         string
+	City         string
+	PostalCode   string
+}
+
+// Validate checks that the merchant has what a QRIS payload needs
+func (m Merchant) Validate() error {
+	if m.AcquirerDomain == "" || m.PAN == "" || m.AcquirerMerchantID == "" {
+		return fmt.Errorf("QRIS merchant needs an acquirer domain, PAN and acquirer merchant ID")
+	}
+	if len(m.AcquirerDomain) > 32 {
+		return fmt.Errorf("QRIS acquirer domain can be at most 32 characters")
+	}
+	if len(m.AcquirerMerchantID) > 15 {
+		return fmt.Errorf("QRIS acquirer merchant ID can be at most 15 characters")
+	}
+	if len(m.PAN) > 19 || !isDigits(m.PAN) {
+		return fmt.Errorf("QRIS merchant PAN must be up to 19 digits")
+	}
+	if m.NMID == "" || len(m.NMID) > 15 {
+		return fmt.Errorf("QRIS NMID must be 1 to 15 characters")
+	}
+	switch m.Criteria {
+	case CriteriaMicro, CriteriaSmall, CriteriaMedium, CriteriaLarge:
+	default:
+		return fmt.Errorf("QRIS merchant criteria must be %s, %s, %s or %s", CriteriaMicro, CriteriaSmall, CriteriaMedium, CriteriaLarge)
+	}
+	if len(m.CategoryCode) != 4 || !isDigits(m.CategoryCode) {
+		return fmt.Errorf("QRIS merchant category code must be 4 digits")
+	}
+	if m.Name == "" || len(m.Name) > 25 {
+		return fmt.Errorf("QRIS merchant name must be 1 to 25 characters")
+	}
+	if m.City == "" || len(m.City) > 15 {
+		return fmt.Errorf("QRIS merchant city must be 1 to 15 characters")
+	}
+	if len(m.PostalCode) > 10 {
+		return fmt.Errorf("QRIS postal code can be at most 10 characters")
+	}
+	return nil
+}
+
+// Payload builds the dynamic QRIS payload that asks for a payment of exactly
+// the amount, in whole rupiah, to the merchant. The bill number is shown to the
+// customer and returned by the acquirer, so the payment can be matched to its
+// transaction.
+func Payload(merchant Merchant, amount int, billNumber string) (string, error) {
+	if err := merchant.Validate(); err != nil {
+		return "", err
+	}
+	if amount <= 0 {
+		return "", fmt.Errorf("QRIS amount must be greater than 0")
+	}
+	if len(strconv.Itoa(amount)) > 13 {
+		return "", fmt.Errorf("QRIS amount can be at most 13 digits")
+	}
+	if len(billNumber) > 25 {
+		return "", fmt.Errorf("QRIS bill number can be at most 25 characters")
+	}
+
+	var account, domain, additional, b encoder
+	account.write(idGloballyUniqueID, merchant.AcquirerDomain)
+	account.write(idMerchantPAN, merchant.PAN)
+	account.write(idMerchantID, merchant.AcquirerMerchantID)
+	account.write(idMerchantCriteria, merchant.Criteria)
+	domain.write(idGloballyUniqueID, QRISDomain)
+	domain.write(idMerchantID, merchant.NMID)
+	domain.write(idMerchantCriteria, merchant.Criteria)
+
+	b.write(idPayloadFormat, "01")
+	b.write(idInitiationMethod, dynamicMethod)
+	b.writeTemplate(idMerchantAccount, &account)
+	b.writeTemplate(idQRISDomain, &domain)
+	b.write(idCategoryCode, merchant.CategoryCode)
+	b.write(idCurrency, currencyRupiah)
+	b.write(idAmount, strconv.Itoa(amount))
+	b.write(idCountryCode, countryIndonesia)
+	b.write(idMerchantName, merchant.Name)
+	b.write(idMerchantCity, merchant.City)
+	if merchant.PostalCode != "" {
+		b.write(idPostalCode, merchant.PostalCode)
+	}
+	if billNumber != "" {
+		additional.write(idBillNumber, billNumber)
+		b.writeTemplate(idAdditionalData, &additional)
+	}
+	if b.err != nil {
+		return "", b.err
+	}
+
+	return withCRC(b.String()), nil
+}
+
+// Verify checks that a payload ends with a CRC data object matching its content
+func Verify(payload string) error {
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != idCRC+"04" {
+		return fmt.Errorf("QRIS payload must end with a CRC")
+	}
+	if want := CRC16(payload[:len(payload)-4]); payload[len(payload)-4:] != want {
+		return fmt.Errorf("QRIS payload CRC is %s but should be %s", payload[len(payload)-4:], want)
+	}
+	return nil
+}
+
+// CRC16 returns the CRC-16/CCITT-FALSE checksum of the data (polynomial 0x1021,
+// initial value 0xFFFF) as four upper case hex digits, as EMVCo requires
+func CRC16(data string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// withCRC appends the CRC data object, whose checksum covers the payload up to
+// and including its own ID and length
+func withCRC(payload string) string {
+	payload += idCRC + "04"
+	return payload + CRC16(payload)
+}
+
+// tlv encodes a data object as its ID, two digit length and value. It fails for
+// values too long for their length to fit in two digits.
+func tlv(id, value string) (string, error) {
+	if len(value) > maxValueLength {
+		return "", fmt.Errorf("QRIS data object %s is %d characters long; at most %d fit", id, len(value), maxValueLength)
+	}
+	return fmt.Sprintf("%s%02d%s", id, len(value), value), nil
+}
+
+// encoder writes data objects one after another, keeping the first error
+type encoder struct {
+	strings.Builder
+	err error
+}
+
+func (e *encoder) write(id, value string) {
+	if e.err != nil {
+		return
+	}
+	object, err := tlv(id, value)
+	if err != nil {
+		e.err = err
+		return
+	}
+	e.WriteString(object)
+}
+
+// writeTemplate writes a data object holding the data objects of another encoder
+func (e *encoder) writeTemplate(id string, template *encoder) {
+	if e.err == nil && template.err != nil {
+		e.err = template.err
+	}
+	e.write(id, template.String())
+}
+
+func isDigits(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,183 @@
+package qris
+
+import (
+	"strings"
+	"testing"
+)
+
+// emvcoSample is the sample merchant-presented payload published in the EMVCo QR
+// Code Specification for Payment Systems, Merchant-Presented Mode, which QRIS is
+// based on. Its CRC covers the UTF-8 bytes of the alternate language template.
+const emvcoSample = "00020101021229300012D156000000000510A93FO3230Q31280012D15600000001030812345678" +
+	"520441115802CN5914BEST TRANSPORT6007BEIJING64200002ZH0104最佳运输0202北京" +
+	"540523.7253031565502016233030412340603***0708A60086670902ME91320016A0112233449988770708" +
+	"123456786304A13A"
+
+func testMerchant() Merchant {
+	return Merchant{
+		AcquirerDomain:     "ID.CO.BANKNAME.WWW",
+		PAN:                "9360000812345678901",
+		AcquirerMerchantID: "123456789012345",
+		NMID:               "ID1020012345678",
+		Criteria:           CriteriaMicro,
+		CategoryCode:       "5499",
+		Name:               "Kasir Store",
+		City:               "JAKARTA",
+		PostalCode:         "12190",
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		// The check value of CRC-16/CCITT-FALSE
+		{name: "check value", data: "123456789", want: "29B1"},
+		{name: "empty", data: "", want: "FFFF"},
+		{name: "EMVCo sample", data: emvcoSample[:len(emvcoSample)-4], want: "A13A"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CRC16(tt.data); got != tt.want {
+				t.Errorf("CRC16(%q) = %s, want %s", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr bool
+	}{
+		{name: "EMVCo sample", payload: emvcoSample},
+		{name: "tampered amount", payload: strings.Replace(emvcoSample, "23.72", "23.73", 1), wantErr: true},
+		{name: "wrong CRC", payload: emvcoSample[:len(emvcoSample)-4] + "0000", wantErr: true},
+		{name: "no CRC", payload: "000201", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.payload)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// parse splits a payload into its top-level data objects
+func parse(t *testing.T, payload string) map[string]string {
+	t.Helper()
+
+	objects := make(map[string]string)
+	for len(payload) > 0 {
+		if len(payload) < 4 {
+			t.Fatalf("truncated data object %q", payload)
+		}
+		id, length := payload[:2], payload[2:4]
+		n := 0
+		for _, digit := range length {
+			if digit < '0' || digit > '9' {
+				t.Fatalf("data object %s has length %q", id, length)
+			}
+			n = n*10 + int(digit-'0')
+		}
+		if len(payload) < 4+n {
+			t.Fatalf("data object %s is shorter than its length %d", id, n)
+		}
+		objects[id] = payload[4 : 4+n]
+		payload = payload[4+n:]
+	}
+	return objects
+}
+
+func TestPayload(t *testing.T) {
+	payload, err := Payload(testMerchant(), 125500, "TRX-42")
+	if err != nil {
+		t.Fatalf("Payload() unexpected error: %v", err)
+	}
+
+	if err := Verify(payload); err != nil {
+		t.Fatalf("Payload() produced an invalid CRC: %v", err)
+	}
+
+	objects := parse(t, payload)
+	want := map[string]string{
+		idPayloadFormat:    "01",
+		idInitiationMethod: dynamicMethod,
+		idMerchantAccount:  "0018ID.CO.BANKNAME.WWW011993600008123456789010215123456789012345" + "0303UMI",
+		idQRISDomain:       "0014ID.CO.QRIS.WWW0215ID10200123456780303UMI",
+		idCategoryCode:     "5499",
+		idCurrency:         "360",
+		idAmount:           "125500",
+		idCountryCode:      "ID",
+		idMerchantName:     "Kasir Store",
+		idMerchantCity:     "JAKARTA",
+		idPostalCode:       "12190",
+		idAdditionalData:   "0106TRX-42",
+	}
+	for id, value := range want {
+		if objects[id] != value {
+			t.Errorf("data object %s = %q, want %q", id, objects[id], value)
+		}
+	}
+
+	// The payload starts with its format and ends with its CRC
+	if !strings.HasPrefix(payload, "000201010212") {
+		t.Errorf("payload starts with %q", payload[:12])
+	}
+	if len(objects[idCRC]) != 4 || !strings.HasSuffix(payload, objects[idCRC]) {
+		t.Errorf("payload does not end with its CRC")
+	}
+}
+
+func TestPayloadRejects(t *testing.T) {
+	tests := []struct {
+		name       string
+		merchant   func(m *Merchant)
+		amount     int
+		billNumber string
+		wantErr    string
+	}{
+		{name: "zero amount", amount: 0, wantErr: "amount must be greater than 0"},
+		{name: "amount over 13 digits", amount: 12345678901234, wantErr: "at most 13 digits"},
+		{name: "long bill number", amount: 1000, billNumber: strings.Repeat("9", 26), wantErr: "bill number"},
+		{name: "long acquirer domain", amount: 1000, merchant: func(m *Merchant) { m.AcquirerDomain = strings.Repeat("A", 33) }, wantErr: "acquirer domain"},
+		{name: "long acquirer merchant ID", amount: 1000, merchant: func(m *Merchant) { m.AcquirerMerchantID = strings.Repeat("1", 16) }, wantErr: "acquirer merchant ID"},
+		{name: "PAN with letters", amount: 1000, merchant: func(m *Merchant) { m.PAN = "93600A" }, wantErr: "PAN"},
+		{name: "unknown criteria", amount: 1000, merchant: func(m *Merchant) { m.Criteria = "XXX" }, wantErr: "criteria"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merchant := testMerchant()
+			if tt.merchant != nil {
+				tt.merchant(&merchant)
+			}
+			_, err := Payload(merchant, tt.amount, tt.billNumber)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Payload() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTLV(t *testing.T) {
+	object, err := tlv("59", "Kasir Store")
+	if err != nil || object != "5911Kasir Store" {
+		t.Errorf("tlv() = %q, %v, want %q", object, err, "5911Kasir Store")
+	}
+
+	if _, err := tlv("26", strings.Repeat("A", 99)); err != nil {
+		t.Errorf("tlv() rejected a 99 character value: %v", err)
+	}
+
+	if _, err := tlv("26", strings.Repeat("A", 100)); err == nil {
+		t.Errorf("tlv() accepted a 100 character value")
+	}
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type CartRepository interface {
+	// Create creates a new cart with its items
+	Create(ctx context.Context, cart *entities.Cart) error
+
+	// FindByID retrieves a cart by ID with its items
+	FindByID(ctx context.Context, id int) (*entities.Cart, error)
+
+	// FindAll retrieves the carts of a store with their items, most recently changed
+	// first. An empty status disables the status filter.
+	FindAll(ctx context.Context, storeID int, status string) ([]entities.Cart, error)
+
+	// Update saves a cart without its items. It fails if the cart is no longer in the
+	// given status, which guards against two terminals changing the same cart.
+	Update(ctx context.Context, cart *entities.Cart, from string) error
+
+	// SaveItem adds an item to an open cart, or updates it when it has an ID, and
+	// saves the cart's new expiry
+	SaveItem(ctx context.Context, cart *entities.Cart, item *entities.CartItem) error
+
+	// RemoveItem removes an item from an open cart and saves the cart's new expiry
+	RemoveItem(ctx context.Context, cart *entities.Cart, itemID int) error
+
+	// Delete deletes an open or parked cart with its items
+	Delete(ctx context.Context, id int) error
+
+	// ExpireAll expires the open and parked carts that were due to expire by the given
+	// time and returns how many it expired
+	ExpireAll(ctx context.Context, at time.Time) (int, error)
+}
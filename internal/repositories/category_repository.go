@@ -7,9 +7,21 @@ import (
 )
 
 type CategoryRepository interface {
-	FindAll(ctx context.Context) ([]entities.Category, error)
+	FindAll(ctx context.Context, includeArchived bool) ([]entities.Category, error)
 	FindByID(ctx context.Context, id int) (*entities.Category, error)
 	Create(ctx context.Context, category *entities.Category) error
 	Update(ctx context.Context, category *entities.Category) error
-	Delete(ctx context.Context, id int) error
+
+	// FindStats aggregates the products of every category with the stock held by a
+	// store, keyed by category ID. Categories without products are left out.
+	FindStats(ctx context.Context, storeID int) (map[int]entities.CategoryStats, error)
+
+	// Delete archives a category and, in the same transaction, moves its products
+	// to reassignTo or, with orphan set, leaves them uncategorized. With neither it
+	// fails if the category has any products, archived ones included. Subcategories
+	// move up to the category's parent. It returns the number of products moved.
+	Delete(ctx context.Context, id int, reassignTo *int, orphan bool) (int, error)
+
+	// Restore brings back an archived category, at the top level if its parent is archived
+	Restore(ctx context.Context, id int) error
 }
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type GoodsReceiptRepository interface {
+	// Create records a goods receipt, adds the received quantity to the receiving store's stock
+	// and recalculates the product's weighted average cost. The updated product is returned
+	// as seen by the receiving store.
+	Create(ctx context.Context, receipt *entities.GoodsReceipt) (*entities.Product, error)
+
+	// FindByProductID retrieves all goods receipts for a product, newest first
+	FindByProductID(ctx context.Context, productID int) ([]entities.GoodsReceipt, error)
+}
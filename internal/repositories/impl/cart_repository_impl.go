@@ -0,0 +1,393 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/lib/pq"
+)
+
+const cartSelectQuery = `
+	SELECT id, store_id, status, COALESCE(terminal_id, ''), COALESCE(customer_ref, ''), COALESCE(voucher_codes, '{}'),
+		COALESCE(discount_type, ''), COALESCE(discount_value, 0), COALESCE(discount_reason, ''),
+		COALESCE(note, ''), transaction_id, created_at, updated_at, parked_at, expires_at
+	FROM carts
+`
+
+type cartRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCartRepository(db *sql.DB) repositories.CartRepository {
+	return &cartRepositoryImpl{db: db}
+}
+
+// scanCart scans a row produced by cartSelectQuery
+func scanCart(row interface{ Scan(...interface{}) error }, cart *entities.Cart) error {
+	var discount entities.CartDiscount
+	err := row.Scan(
+		&cart.ID,
+		&cart.StoreID,
+		&cart.Status,
+		&cart.TerminalID,
+		&cart.CustomerRef,
+		pq.Array(&cart.VoucherCodes),
+		&discount.Type,
+		&discount.Value,
+		&discount.Reason,
+		&cart.Note,
+		&cart.TransactionID,
+		&cart.CreatedAt,
+		&cart.UpdatedAt,
+		&cart.ParkedAt,
+		&cart.ExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	cart.Discount = nil
+	if discount.Type != "" {
+		cart.Discount = &discount
+	}
+	return nil
+}
+
+// discountColumns returns the type, value and reason columns of an optional discount
+func discountColumns(discount *entities.CartDiscount) (string, float64, string) {
+	if discount == nil {
+		return "", 0, ""
+	}
+	return discount.Type, discount.Value, discount.Reason
+}
+
+func (r *cartRepositoryImpl) Create(ctx context.Context, cart *entities.Cart) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Insert cart
+	query := `
+		INSERT INTO carts (store_id, status, terminal_id, customer_ref, voucher_codes, discount_type, discount_value, discount_reason, note, created_at, updated_at, expires_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), $5, NULLIF($6, ''), NULLIF($7, 0), NULLIF($8, ''), NULLIF($9, ''), $10, $11, $12)
+		RETURNING id
+	`
+	discountType, discountValue, discountReason := discountColumns(cart.Discount)
+	err = tx.QueryRowContext(ctx, query, cart.StoreID, cart.Status, cart.TerminalID, cart.CustomerRef, pq.Array(cart.VoucherCodes),
+		discountType, discountValue, discountReason, cart.Note, cart.CreatedAt, cart.UpdatedAt, cart.ExpiresAt).Scan(&cart.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create cart: %w", err)
+	}
+
+	// Insert cart items
+	for i := range cart.Items {
+		cart.Items[i].CartID = cart.ID
+		if err := insertCartItem(ctx, tx, &cart.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cartRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Cart, error) {
+	query := cartSelectQuery + ` WHERE id = $1`
+
+	var cart entities.Cart
+	err := scanCart(r.db.QueryRowContext(ctx, query, id), &cart)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cart: %w", err)
+	}
+
+	items, err := r.findItems(ctx, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+	cart.Items = items
+
+	return &cart, nil
+}
+
+func (r *cartRepositoryImpl) FindAll(ctx context.Context, storeID int, status string) ([]entities.Cart, error) {
+	query := cartSelectQuery + ` WHERE store_id = $1`
+	args := []interface{}{storeID}
+
+	// Add status filter
+	if status != "" {
+		query += fmt.Sprintf(" AND status = $%d", len(args)+1)
+		args = append(args, status)
+	}
+
+	query += " ORDER BY updated_at DESC, id DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query carts: %w", err)
+	}
+	defer rows.Close()
+
+	var carts []entities.Cart
+	for rows.Next() {
+		var cart entities.Cart
+		if err := scanCart(rows, &cart); err != nil {
+			return nil, fmt.Errorf("failed to scan cart: %w", err)
+		}
+		carts = append(carts, cart)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating carts: %w", err)
+	}
+
+	// Get items for all carts
+	for i := range carts {
+		items, err := r.findItems(ctx, carts[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		carts[i].Items = items
+	}
+
+	return carts, nil
+}
+
+func (r *cartRepositoryImpl) Update(ctx context.Context, cart *entities.Cart, from string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateCart(ctx, tx, cart, from); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cartRepositoryImpl) SaveItem(ctx context.Context, cart *entities.Cart, item *entities.CartItem) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Saving the cart first locks it and checks it is still open
+	if err := updateCart(ctx, tx, cart, entities.CartStatusOpen); err != nil {
+		return err
+	}
+
+	item.CartID = cart.ID
+	if item.ID == 0 {
+		if err := insertCartItem(ctx, tx, item); err != nil {
+			return err
+		}
+	} else {
+		query := `
+			UPDATE cart_items
+			SET quantity = $1, unit = NULLIF($2, ''), serial_numbers = $3,
+				discount_type = NULLIF($4, ''), discount_value = NULLIF($5, 0), discount_reason = NULLIF($6, '')
+			WHERE id = $7 AND cart_id = $8
+		`
+		discountType, discountValue, discountReason := discountColumns(item.Discount)
+		result, err := tx.ExecContext(ctx, query, item.Quantity, item.Unit, pq.Array(item.SerialNumbers),
+			discountType, discountValue, discountReason, item.ID, item.CartID)
+		if err != nil {
+			return fmt.Errorf("failed to update cart item: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("cart item with id %d not found", item.ID)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cartRepositoryImpl) RemoveItem(ctx context.Context, cart *entities.Cart, itemID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := updateCart(ctx, tx, cart, entities.CartStatusOpen); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM cart_items WHERE id = $1 AND cart_id = $2`, itemID, cart.ID)
+	if err != nil {
+		return fmt.Errorf("failed to remove cart item: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("cart item with id %d not found", itemID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *cartRepositoryImpl) Delete(ctx context.Context, id int) error {
+	// Items are removed with the cart through the foreign key
+	query := `DELETE FROM carts WHERE id = $1 AND status IN ($2, $3)`
+
+	result, err := r.db.ExecContext(ctx, query, id, entities.CartStatusOpen, entities.CartStatusParked)
+	if err != nil {
+		return fmt.Errorf("failed to delete cart: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("cart %d is no longer open or parked", id)
+	}
+
+	return nil
+}
+
+func (r *cartRepositoryImpl) ExpireAll(ctx context.Context, at time.Time) (int, error) {
+	query := `UPDATE carts SET status = $1 WHERE status IN ($2, $3) AND expires_at <= $4`
+
+	result, err := r.db.ExecContext(ctx, query, entities.CartStatusExpired, entities.CartStatusOpen, entities.CartStatusParked, at)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire carts: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// updateCart saves a cart without its items, provided it is still in the given status
+func updateCart(ctx context.Context, tx *sql.Tx, cart *entities.Cart, from string) error {
+	query := `
+		UPDATE carts
+		SET status = $1, terminal_id = NULLIF($2, ''), customer_ref = NULLIF($3, ''), voucher_codes = $4,
+			discount_type = NULLIF($5, ''), discount_value = NULLIF($6, 0), discount_reason = NULLIF($7, ''),
+			note = NULLIF($8, ''), transaction_id = $9, updated_at = $10, parked_at = $11, expires_at = $12
+		WHERE id = $13 AND status = $14
+	`
+	discountType, discountValue, discountReason := discountColumns(cart.Discount)
+	result, err := tx.ExecContext(ctx, query, cart.Status, cart.TerminalID, cart.CustomerRef, pq.Array(cart.VoucherCodes),
+		discountType, discountValue, discountReason, cart.Note, cart.TransactionID, cart.UpdatedAt, cart.ParkedAt, cart.ExpiresAt,
+		cart.ID, from)
+	if err != nil {
+		return fmt.Errorf("failed to update cart: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("cart %d is no longer %s", cart.ID, from)
+	}
+
+	return nil
+}
+
+// insertCartItem inserts a cart item and sets its ID
+func insertCartItem(ctx context.Context, tx *sql.Tx, item *entities.CartItem) error {
+	query := `
+		INSERT INTO cart_items (cart_id, product_id, barcode, quantity, unit, serial_numbers, discount_type, discount_value, discount_reason)
+		VALUES ($1, NULLIF($2, 0), NULLIF($3, ''), $4, NULLIF($5, ''), $6, NULLIF($7, ''), NULLIF($8, 0), NULLIF($9, ''))
+		RETURNING id
+	`
+	discountType, discountValue, discountReason := discountColumns(item.Discount)
+	err := tx.QueryRowContext(ctx, query, item.CartID, item.ProductID, item.Barcode, item.Quantity, item.Unit, pq.Array(item.SerialNumbers),
+		discountType, discountValue, discountReason).Scan(&item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create cart item: %w", err)
+	}
+
+	return nil
+}
+
+// findItems retrieves the items of a cart in the order they were added
+func (r *cartRepositoryImpl) findItems(ctx context.Context, cartID int) ([]entities.CartItem, error) {
+	query := `
+		SELECT id, cart_id, COALESCE(product_id, 0), COALESCE(barcode, ''), quantity, COALESCE(unit, ''), COALESCE(serial_numbers, '{}'),
+			COALESCE(discount_type, ''), COALESCE(discount_value, 0), COALESCE(discount_reason, '')
+		FROM cart_items
+		WHERE cart_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cart items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entities.CartItem
+	for rows.Next() {
+		var item entities.CartItem
+		var discount entities.CartDiscount
+		err := rows.Scan(
+			&item.ID,
+			&item.CartID,
+			&item.ProductID,
+			&item.Barcode,
+			&item.Quantity,
+			&item.Unit,
+			pq.Array(&item.SerialNumbers),
+			&discount.Type,
+			&discount.Value,
+			&discount.Reason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cart item: %w", err)
+		}
+		if discount.Type != "" {
+			item.Discount = &discount
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cart items: %w", err)
+	}
+
+	return items, nil
+}
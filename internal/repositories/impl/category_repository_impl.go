@@ -18,8 +18,12 @@ func NewCategoryRepository(db *sql.DB) repositories.CategoryRepository {
 	return &categoryRepositoryImpl{db: db}
 }
 
-func (r *categoryRepositoryImpl) FindAll(ctx context.Context) ([]entities.Category, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM categories ORDER BY id`
+func (r *categoryRepositoryImpl) FindAll(ctx context.Context, includeArchived bool) ([]entities.Category, error) {
+	query := `SELECT id, name, description, parent_id, tax_rate_id, created_at, updated_at, deleted_at FROM categories`
+	if !includeArchived {
+		query += ` WHERE deleted_at IS NULL`
+	}
+	query += ` ORDER BY id`
 
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
@@ -34,8 +38,11 @@ func (r *categoryRepositoryImpl) FindAll(ctx context.Context) ([]entities.Catego
 			&category.ID,
 			&category.Name,
 			&category.Description,
+			&category.ParentID,
+			&category.TaxRateID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&category.DeletedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan category: %w", err)
@@ -51,15 +58,18 @@ func (r *categoryRepositoryImpl) FindAll(ctx context.Context) ([]entities.Catego
 }
 
 func (r *categoryRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Category, error) {
-	query := `SELECT id, name, description, created_at, updated_at FROM categories WHERE id = $1`
+	query := `SELECT id, name, description, parent_id, tax_rate_id, created_at, updated_at, deleted_at FROM categories WHERE id = $1`
 
 	var category entities.Category
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&category.ID,
 		&category.Name,
 		&category.Description,
+		&category.ParentID,
+		&category.TaxRateID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.DeletedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -74,18 +84,30 @@ func (r *categoryRepositoryImpl) FindByID(ctx context.Context, id int) (*entitie
 }
 
 func (r *categoryRepositoryImpl) Create(ctx context.Context, category *entities.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockParentCategory(ctx, tx, 0, category.ParentID); err != nil {
+		return err
+	}
+
 	query := `
-        INSERT INTO categories (name, description, created_at, updated_at)
-        VALUES ($1, $2, $3, $4)
+        INSERT INTO categories (name, description, parent_id, tax_rate_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
         RETURNING id
     `
 
 	now := time.Now()
-	err := r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		category.Name,
 		category.Description,
+		category.ParentID,
+		category.TaxRateID,
 		now,
 		now,
 	).Scan(&category.ID)
@@ -94,6 +116,10 @@ func (r *categoryRepositoryImpl) Create(ctx context.Context, category *entities.
 		return fmt.Errorf("failed to create category: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	category.CreatedAt = now
 	category.UpdatedAt = now
 
@@ -101,18 +127,30 @@ func (r *categoryRepositoryImpl) Create(ctx context.Context, category *entities.
 }
 
 func (r *categoryRepositoryImpl) Update(ctx context.Context, category *entities.Category) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := lockParentCategory(ctx, tx, category.ID, category.ParentID); err != nil {
+		return err
+	}
+
 	query := `
         UPDATE categories 
-        SET name = $1, description = $2, updated_at = $3
-        WHERE id = $4
+        SET name = $1, description = $2, parent_id = $3, tax_rate_id = $4, updated_at = $5
+        WHERE id = $6
     `
 
 	now := time.Now()
-	result, err := r.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		category.Name,
 		category.Description,
+		category.ParentID,
+		category.TaxRateID,
 		now,
 		category.ID,
 	)
@@ -130,17 +168,178 @@ func (r *categoryRepositoryImpl) Update(ctx context.Context, category *entities.
 		return fmt.Errorf("category not found")
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	category.UpdatedAt = now
 
 	return nil
 }
 
-func (r *categoryRepositoryImpl) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM categories WHERE id = $1`
+// lockParentCategory locks the category that another category or products are
+// placed under until the transaction ends, so it cannot be archived meanwhile.
+// It checks that the category is active and, for an existing category id, not
+// the category itself or one of its subcategories. id is 0 when there is none.
+func lockParentCategory(ctx context.Context, tx *sql.Tx, id int, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+
+	var found int
+	err := tx.QueryRowContext(ctx, `SELECT id FROM categories WHERE id = $1 AND deleted_at IS NULL FOR SHARE`, *parentID).Scan(&found)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("category with id %d is archived", *parentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock parent category: %w", err)
+	}
+
+	if id == 0 {
+		return nil
+	}
+
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)
+	`
+	var cycle bool
+	if err := tx.QueryRowContext(ctx, query, id, *parentID).Scan(&cycle); err != nil {
+		return fmt.Errorf("failed to check category tree: %w", err)
+	}
+	if cycle {
+		return fmt.Errorf("category cannot be nested under itself or one of its subcategories")
+	}
+
+	return nil
+}
+
+func (r *categoryRepositoryImpl) FindStats(ctx context.Context, storeID int) (map[int]entities.CategoryStats, error) {
+	query := `
+		SELECT p.category_id,
+			COUNT(*) FILTER (WHERE p.active),
+			COALESCE(SUM(ps.quantity), 0)::float8,
+			COALESCE(SUM(ps.quantity * COALESCE(ps.price, p.price)), 0)::float8,
+			COALESCE(SUM(ps.quantity * p.cost), 0)::float8
+		FROM products p
+		LEFT JOIN product_stock ps ON ps.product_id = p.id AND ps.store_id = $1
+		WHERE p.category_id IS NOT NULL AND p.deleted_at IS NULL
+		GROUP BY p.category_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[int]entities.CategoryStats)
+	for rows.Next() {
+		var stat entities.CategoryStats
+		err := rows.Scan(
+			&stat.CategoryID,
+			&stat.ActiveProducts,
+			&stat.UnitsInStock,
+			&stat.StockValue,
+			&stat.StockCost,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category stats: %w", err)
+		}
+		stats[stat.CategoryID] = stat
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+func (r *categoryRepositoryImpl) Delete(ctx context.Context, id int, reassignTo *int, orphan bool) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	// Lock the category first. This waits for categories being placed under it
+	// to commit, and keeps new ones out, so the move below sees every subcategory.
+	var name string
+	err = tx.QueryRowContext(ctx, `SELECT name FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&name)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("category not found")
+	}
 	if err != nil {
-		return fmt.Errorf("failed to delete category: %w", err)
+		return 0, fmt.Errorf("failed to lock category: %w", err)
+	}
+
+	// Refuse to strand products unless the caller says where they go. Archived
+	// products count too, since they keep pointing at the category.
+	if reassignTo == nil && !orphan {
+		var count int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM products WHERE category_id = $1`, id).Scan(&count); err != nil {
+			return 0, fmt.Errorf("failed to count category products: %w", err)
+		}
+		if count > 0 {
+			return 0, fmt.Errorf("category %s still has %d products; pass reassign_to or orphan=true", name, count)
+		}
+	}
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, `UPDATE categories SET deleted_at = $1 WHERE id = $2`, now, id); err != nil {
+		return 0, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	// The category the products move to must stay active until they are moved
+	if err := lockParentCategory(ctx, tx, 0, reassignTo); err != nil {
+		return 0, err
+	}
+
+	// Move the products, archived ones included, so none is left pointing at the archived category
+	result, err := tx.ExecContext(ctx, `UPDATE products SET category_id = $1, updated_at = $2 WHERE category_id = $3`, reassignTo, now, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign category products: %w", err)
+	}
+
+	moved, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// Subcategories, archived ones included, move up to the deleted category's
+	// parent, so no active category is left under an archived one
+	_, err = tx.ExecContext(ctx, `
+		UPDATE categories SET parent_id = (SELECT parent_id FROM categories WHERE id = $1), updated_at = $2
+		WHERE parent_id = $1
+	`, id, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to move subcategories: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return int(moved), nil
+}
+
+func (r *categoryRepositoryImpl) Restore(ctx context.Context, id int) error {
+	// A category comes back at the top level when its parent is archived, so no
+	// active category sits under an archived one
+	query := `
+		UPDATE categories c SET deleted_at = NULL, updated_at = $1,
+			parent_id = (SELECT p.id FROM categories p WHERE p.id = c.parent_id AND p.deleted_at IS NULL)
+		WHERE c.id = $2 AND c.deleted_at IS NOT NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore category: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -149,7 +348,7 @@ func (r *categoryRepositoryImpl) Delete(ctx context.Context, id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("category not found")
+		return fmt.Errorf("archived category not found")
 	}
 
 	return nil
@@ -0,0 +1,137 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+type goodsReceiptRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewGoodsReceiptRepository(db *sql.DB) repositories.GoodsReceiptRepository {
+	return &goodsReceiptRepositoryImpl{db: db}
+}
+
+func (r *goodsReceiptRepositoryImpl) Create(ctx context.Context, receipt *entities.GoodsReceipt) (*entities.Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Insert goods receipt
+	query := `INSERT INTO goods_receipts (store_id, product_id, quantity, unit_cost, lot_number, expiry_date, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at`
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, query, receipt.StoreID, receipt.ProductID, receipt.Quantity, receipt.UnitCost, receipt.LotNumber, receipt.ExpiryDate, now).Scan(&receipt.ID, &receipt.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create goods receipt: %w", err)
+	}
+
+	// Recalculate the weighted average cost against stock on hand across all
+	// stores, since cost is shared by the catalog. Negative stock is treated as
+	// zero so that it does not drag the average below the received cost.
+	costQuery := `
+		UPDATE products p
+		SET cost = CASE
+				WHEN s.on_hand + $2 > 0
+				THEN (s.on_hand * p.cost + $2 * $3) / (s.on_hand + $2)
+				ELSE $3
+			END,
+			updated_at = $4
+		FROM (
+			SELECT GREATEST(COALESCE(SUM(quantity), 0), 0) AS on_hand
+			FROM product_stock
+			WHERE product_id = $1
+		) s
+		WHERE p.id = $1
+	`
+	result, err := tx.ExecContext(ctx, costQuery, receipt.ProductID, receipt.Quantity, receipt.UnitCost, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product cost: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return nil, fmt.Errorf("product not found")
+	}
+
+	// Add the received quantity to the receiving store
+	stockQuery := `
+		INSERT INTO product_stock (store_id, product_id, quantity, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (store_id, product_id) DO UPDATE
+		SET quantity = product_stock.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := tx.ExecContext(ctx, stockQuery, receipt.StoreID, receipt.ProductID, receipt.Quantity, now); err != nil {
+		return nil, fmt.Errorf("failed to update store stock: %w", err)
+	}
+
+	if len(receipt.SerialNumbers) > 0 {
+		if err := addSerials(ctx, tx, receipt.StoreID, receipt.ProductID, receipt.ID, receipt.SerialNumbers, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if receipt.LotNumber != nil {
+		if err := addLotStock(ctx, tx, receipt.StoreID, receipt.ProductID, *receipt.LotNumber, receipt.ExpiryDate, receipt.Quantity, now); err != nil {
+			return nil, err
+		}
+	}
+
+	var product entities.Product
+	err = scanProduct(tx.QueryRowContext(ctx, productSelectQuery+` WHERE p.id = $2`, receipt.StoreID, receipt.ProductID), &product)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload product: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *goodsReceiptRepositoryImpl) FindByProductID(ctx context.Context, productID int) ([]entities.GoodsReceipt, error) {
+	query := `SELECT id, store_id, product_id, quantity, unit_cost, lot_number, expiry_date, created_at FROM goods_receipts WHERE product_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goods receipts: %w", err)
+	}
+	defer rows.Close()
+
+	var receipts []entities.GoodsReceipt
+	for rows.Next() {
+		var receipt entities.GoodsReceipt
+		err := rows.Scan(
+			&receipt.ID,
+			&receipt.StoreID,
+			&receipt.ProductID,
+			&receipt.Quantity,
+			&receipt.UnitCost,
+			&receipt.LotNumber,
+			&receipt.ExpiryDate,
+			&receipt.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goods receipt: %w", err)
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goods receipts: %w", err)
+	}
+
+	return receipts, nil
+}
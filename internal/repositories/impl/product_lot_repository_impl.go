@@ -0,0 +1,155 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// lotFEFOOrder orders lots first-expiry-first-out; lots without an expiry date go last
+const lotFEFOOrder = `ORDER BY l.expiry_date ASC NULLS LAST, l.received_at ASC, l.id ASC`
+
+type productLotRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductLotRepository(db *sql.DB) repositories.ProductLotRepository {
+	return &productLotRepositoryImpl{db: db}
+}
+
+func (r *productLotRepositoryImpl) FindByProductID(ctx context.Context, storeID, productID int) ([]entities.ProductLot, error) {
+	query := `
+		SELECT l.id, l.store_id, l.product_id, p.name, l.lot_number, l.expiry_date, l.quantity, l.received_at
+		FROM product_lots l
+		JOIN products p ON l.product_id = p.id
+		WHERE l.store_id = $1 AND l.product_id = $2 AND l.quantity > 0
+	` + lotFEFOOrder
+
+	return r.queryLots(ctx, query, storeID, productID)
+}
+
+func (r *productLotRepositoryImpl) FindExpiring(ctx context.Context, storeID, days int) ([]entities.ProductLot, error) {
+	query := `
+		SELECT l.id, l.store_id, l.product_id, p.name, l.lot_number, l.expiry_date, l.quantity, l.received_at
+		FROM product_lots l
+		JOIN products p ON l.product_id = p.id
+		WHERE l.store_id = $1 AND l.quantity > 0 AND l.expiry_date <= CURRENT_DATE + $2::int
+	` + lotFEFOOrder
+
+	return r.queryLots(ctx, query, storeID, days)
+}
+
+// queryLots runs a lot query and scans all rows
+func (r *productLotRepositoryImpl) queryLots(ctx context.Context, query string, args ...interface{}) ([]entities.ProductLot, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product lots: %w", err)
+	}
+	defer rows.Close()
+
+	var lots []entities.ProductLot
+	for rows.Next() {
+		var lot entities.ProductLot
+		err := rows.Scan(
+			&lot.ID,
+			&lot.StoreID,
+			&lot.ProductID,
+			&lot.ProductName,
+			&lot.LotNumber,
+			&lot.ExpiryDate,
+			&lot.Quantity,
+			&lot.ReceivedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product lots: %w", err)
+	}
+
+	return lots, nil
+}
+
+// addLotStock adds quantity to a store's lot, creating the lot on first receipt.
+// Receiving the same lot number again tops it up and keeps the original expiry date.
+func addLotStock(ctx context.Context, tx *sql.Tx, storeID, productID int, lotNumber string, expiryDate *time.Time, quantity float64, at time.Time) error {
+	query := `
+		INSERT INTO product_lots (store_id, product_id, lot_number, expiry_date, quantity, received_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (store_id, product_id, lot_number) DO UPDATE
+		SET quantity = product_lots.quantity + EXCLUDED.quantity,
+			expiry_date = COALESCE(product_lots.expiry_date, EXCLUDED.expiry_date)
+	`
+
+	if _, err := tx.ExecContext(ctx, query, storeID, productID, lotNumber, expiryDate, quantity, at); err != nil {
+		return fmt.Errorf("failed to add lot stock: %w", err)
+	}
+
+	return nil
+}
+
+// consumeLotsFEFO draws quantity from a store's lots of a product, earliest expiry
+// first, and returns what was taken from each lot. Lots are locked for the rest of
+// the transaction. Products that do not track lots draw nothing; for those that do,
+// all their stock is held in lots, so lots holding less than quantity is an error.
+func consumeLotsFEFO(ctx context.Context, tx *sql.Tx, storeID, productID int, quantity float64) ([]entities.LotAllocation, error) {
+	var name string
+	var trackLots bool
+	if err := tx.QueryRowContext(ctx, `SELECT name, track_lots FROM products WHERE id = $1`, productID).Scan(&name, &trackLots); err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", productID, err)
+	}
+	if !trackLots {
+		return nil, nil
+	}
+
+	query := `
+		SELECT l.id, l.lot_number, l.expiry_date, l.quantity
+		FROM product_lots l
+		WHERE l.store_id = $1 AND l.product_id = $2 AND l.quantity > 0
+	` + lotFEFOOrder + ` FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, storeID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product lots: %w", err)
+	}
+
+	var allocations []entities.LotAllocation
+	remaining := quantity
+	for rows.Next() && remaining > 0 {
+		var allocation entities.LotAllocation
+		var available float64
+		if err := rows.Scan(&allocation.LotID, &allocation.LotNumber, &allocation.ExpiryDate, &available); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan product lot: %w", err)
+		}
+
+		allocation.Quantity = min(available, remaining)
+		remaining = entities.RoundQuantity(remaining - allocation.Quantity)
+		allocations = append(allocations, allocation)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product lots: %w", err)
+	}
+
+	if remaining > 0 {
+		return nil, fmt.Errorf("insufficient stock for product %s: its lots are %v short", name, remaining)
+	}
+
+	updateQuery := `UPDATE product_lots SET quantity = quantity - $1 WHERE id = $2`
+	for _, allocation := range allocations {
+		if _, err := tx.ExecContext(ctx, updateQuery, allocation.Quantity, allocation.LotID); err != nil {
+			return nil, fmt.Errorf("failed to update product lot: %w", err)
+		}
+	}
+
+	return allocations, nil
+}
@@ -8,8 +8,39 @@ import (
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
 	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/lib/pq"
 )
 
+// productSelectQuery selects products as seen by a single store: stock and price
+// override come from that store's product_stock row, and in transit is what has been
+// shipped to the store but not yet received. The store ID is always $1.
+const productSelectQuery = `
+	SELECT p.id, p.name, p.sku, p.plu,
+		ARRAY(SELECT b.barcode FROM product_barcodes b WHERE b.product_id = p.id ORDER BY b.id),
+		p.price, ps.price, p.cost,
+		COALESCE((
+			SELECT FLOOR(MIN(COALESCE(cs.quantity, 0) / bc.quantity))
+			FROM bundle_components bc
+			LEFT JOIN product_stock cs ON cs.product_id = bc.component_id AND cs.store_id = $1
+			WHERE bc.bundle_id = p.id
+		), ps.quantity, 0),
+		COALESCE((
+			SELECT SUM(ti.quantity_shipped)
+			FROM stock_transfer_items ti
+			JOIN stock_transfers t ON t.id = ti.transfer_id
+			WHERE t.status = 'shipped' AND t.destination_store_id = $1 AND ti.product_id = p.id
+		), 0),
+		p.unit, p.quantity_precision,
+		ARRAY(SELECT uc.unit FROM product_unit_conversions uc WHERE uc.product_id = p.id ORDER BY uc.id),
+		ARRAY(SELECT uc.factor::float8 FROM product_unit_conversions uc WHERE uc.product_id = p.id ORDER BY uc.id),
+		p.active, p.track_lots, p.serialized, p.category_id, p.tax_rate_id, p.parent_id, p.option_values,
+		EXISTS(SELECT 1 FROM products v WHERE v.parent_id = p.id AND v.deleted_at IS NULL),
+		EXISTS(SELECT 1 FROM bundle_components bc WHERE bc.bundle_id = p.id),
+		p.created_at, p.updated_at, p.deleted_at
+	FROM products p
+	LEFT JOIN product_stock ps ON ps.product_id = p.id AND ps.store_id = $1
+`
+
 type productRepositoryImpl struct {
 	db *sql.DB
 }
@@ -18,10 +49,57 @@ func NewProductRepository(db *sql.DB) repositories.ProductRepository {
 	return &productRepositoryImpl{db: db}
 }
 
-func (r *productRepositoryImpl) FindAll(ctx context.Context) ([]entities.Product, error) {
-	query := `SELECT id, name, price, stock, active, category_id, created_at, updated_at FROM products ORDER BY id`
+// scanProduct scans a row produced by productSelectQuery
+func scanProduct(row interface{ Scan(...interface{}) error }, product *entities.Product) error {
+	var conversionUnits []string
+	var conversionFactors []float64
+	err := row.Scan(
+		&product.ID,
+		&product.Name,
+		&product.SKU,
+		&product.PLU,
+		pq.Array(&product.Barcodes),
+		&product.Price,
+		&product.StorePrice,
+		&product.Cost,
+		&product.Stock,
+		&product.InTransit,
+		&product.Unit,
+		&product.QuantityPrecision,
+		pq.Array(&conversionUnits),
+		pq.Array(&conversionFactors),
+		&product.Active,
+		&product.TrackLots,
+		&product.Serialized,
+		&product.CategoryID,
+		&product.TaxRateID,
+		&product.ParentID,
+		pq.Array(&product.OptionValues),
+		&product.HasVariants,
+		&product.IsBundle,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+		&product.DeletedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	product.UnitConversions = nil
+	for i, unit := range conversionUnits {
+		product.UnitConversions = append(product.UnitConversions, entities.UnitConversion{
+			ProductID: product.ID,
+			Unit:      unit,
+			Factor:    conversionFactors[i],
+		})
+	}
+
+	return nil
+}
 
-	rows, err := r.db.QueryContext(ctx, query)
+// queryProducts runs a productSelectQuery based query and scans all rows
+func (r *productRepositoryImpl) queryProducts(ctx context.Context, query string, args ...interface{}) ([]entities.Product, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query products: %w", err)
 	}
@@ -30,17 +108,7 @@ func (r *productRepositoryImpl) FindAll(ctx context.Context) ([]entities.Product
 	var products []entities.Product
 	for rows.Next() {
 		var product entities.Product
-		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Price,
-			&product.Stock,
-			&product.Active,
-			&product.CategoryID,
-			&product.CreatedAt,
-			&product.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanProduct(rows, &product); err != nil {
 			return nil, fmt.Errorf("failed to scan product: %w", err)
 		}
 		products = append(products, product)
@@ -53,20 +121,21 @@ func (r *productRepositoryImpl) FindAll(ctx context.Context) ([]entities.Product
 	return products, nil
 }
 
-func (r *productRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Product, error) {
-	query := `SELECT id, name, price, stock, active, category_id, created_at, updated_at FROM products WHERE id = $1`
+func (r *productRepositoryImpl) FindAll(ctx context.Context, storeID int, includeArchived bool) ([]entities.Product, error) {
+	query := productSelectQuery + ` WHERE 1=1`
+	if !includeArchived {
+		query += ` AND p.deleted_at IS NULL`
+	}
+	query += ` ORDER BY p.id`
+
+	return r.queryProducts(ctx, query, storeID)
+}
+
+func (r *productRepositoryImpl) FindByID(ctx context.Context, storeID, id int) (*entities.Product, error) {
+	query := productSelectQuery + ` WHERE p.id = $2`
 
 	var product entities.Product
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&product.ID,
-		&product.Name,
-		&product.Price,
-		&product.Stock,
-		&product.Active,
-		&product.CategoryID,
-		&product.CreatedAt,
-		&product.UpdatedAt,
-	)
+	err := scanProduct(r.db.QueryRowContext(ctx, query, storeID, id), &product)
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -78,107 +147,307 @@ func (r *productRepositoryImpl) FindByID(ctx context.Context, id int) (*entities
 	return &product, nil
 }
 
-func (r *productRepositoryImpl) FindByCategoryID(ctx context.Context, categoryID int) ([]entities.Product, error) {
-	query := `SELECT id, name, price, stock, active, category_id, created_at, updated_at FROM products WHERE category_id = $1 ORDER BY id`
+func (r *productRepositoryImpl) FindByBarcode(ctx context.Context, storeID int, barcode string) (*entities.Product, error) {
+	query := productSelectQuery + ` WHERE p.id = (SELECT product_id FROM product_barcodes WHERE barcode = $2)`
 
-	rows, err := r.db.QueryContext(ctx, query, categoryID)
+	var product entities.Product
+	err := scanProduct(r.db.QueryRowContext(ctx, query, storeID, barcode), &product)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to query products by category: %w", err)
+		return nil, fmt.Errorf("failed to find product by barcode: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *productRepositoryImpl) FindBySKU(ctx context.Context, storeID int, sku string) (*entities.Product, error) {
+	query := productSelectQuery + ` WHERE p.sku = $2`
+
+	var product entities.Product
+	err := scanProduct(r.db.QueryRowContext(ctx, query, storeID, sku), &product)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by sku: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *productRepositoryImpl) FindByPLU(ctx context.Context, storeID int, plu string) (*entities.Product, error) {
+	query := productSelectQuery + ` WHERE p.plu = $2`
+
+	var product entities.Product
+	err := scanProduct(r.db.QueryRowContext(ctx, query, storeID, plu), &product)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by plu: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *productRepositoryImpl) FindVariants(ctx context.Context, storeID, parentID int, includeArchived bool) ([]entities.Product, error) {
+	query := productSelectQuery + ` WHERE p.parent_id = $2`
+	if !includeArchived {
+		query += ` AND p.deleted_at IS NULL`
+	}
+	query += ` ORDER BY p.id`
+
+	products, err := r.queryProducts(ctx, query, storeID, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product variants: %w", err)
+	}
+
+	return products, nil
+}
+
+func (r *productRepositoryImpl) FindOptions(ctx context.Context, productID int) ([]entities.ProductOption, error) {
+	query := `SELECT id, product_id, name, values, position FROM product_options WHERE product_id = $1 ORDER BY position`
+
+	rows, err := r.db.QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product options: %w", err)
 	}
 	defer rows.Close()
 
-	var products []entities.Product
+	var options []entities.ProductOption
 	for rows.Next() {
-		var product entities.Product
+		var option entities.ProductOption
 		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Price,
-			&product.Stock,
-			&product.Active,
-			&product.CategoryID,
-			&product.CreatedAt,
-			&product.UpdatedAt,
+			&option.ID,
+			&option.ProductID,
+			&option.Name,
+			pq.Array(&option.Values),
+			&option.Position,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan product: %w", err)
+			return nil, fmt.Errorf("failed to scan product option: %w", err)
 		}
-		products = append(products, product)
+		options = append(options, option)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating products: %w", err)
+		return nil, fmt.Errorf("error iterating product options: %w", err)
 	}
 
-	return products, nil
+	return options, nil
 }
 
-func (r *productRepositoryImpl) FindByFilters(ctx context.Context, name string, active *bool) ([]entities.Product, error) {
-	query := `SELECT id, name, price, stock, active, category_id, created_at, updated_at FROM products WHERE 1=1`
-	args := []interface{}{}
+func (r *productRepositoryImpl) ReplaceOptions(ctx context.Context, productID int, options []entities.ProductOption) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
 
-	// Add name filter with ILIKE for case-insensitive partial matching
-	if name != "" {
-		query += fmt.Sprintf(" AND name ILIKE $%d", len(args)+1)
-		args = append(args, "%"+name+"%")
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_options WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("failed to clear product options: %w", err)
 	}
 
-	// Add active filter
-	if active != nil {
-		query += fmt.Sprintf(" AND active = $%d", len(args)+1)
-		args = append(args, *active)
+	query := `INSERT INTO product_options (product_id, name, values, position) VALUES ($1, $2, $3, $4) RETURNING id`
+	for i := range options {
+		option := &options[i]
+		option.ProductID = productID
+		option.Position = i + 1
+		err := tx.QueryRowContext(ctx, query, productID, option.Name, pq.Array(option.Values), option.Position).Scan(&option.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create product option: %w", err)
+		}
 	}
 
-	query += " ORDER BY id"
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	return nil
+}
+
+func (r *productRepositoryImpl) FindComponents(ctx context.Context, storeID, bundleID int) ([]entities.BundleComponent, error) {
+	query := `
+		SELECT bc.id, bc.bundle_id, bc.component_id, p.name, bc.quantity, p.cost, COALESCE(ps.quantity, 0)
+		FROM bundle_components bc
+		JOIN products p ON p.id = bc.component_id
+		LEFT JOIN product_stock ps ON ps.product_id = bc.component_id AND ps.store_id = $1
+		WHERE bc.bundle_id = $2
+		ORDER BY bc.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, storeID, bundleID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query products by filters: %w", err)
+		return nil, fmt.Errorf("failed to query bundle components: %w", err)
 	}
 	defer rows.Close()
 
-	var products []entities.Product
+	var components []entities.BundleComponent
 	for rows.Next() {
-		var product entities.Product
+		var component entities.BundleComponent
 		err := rows.Scan(
-			&product.ID,
-			&product.Name,
-			&product.Price,
-			&product.Stock,
-			&product.Active,
-			&product.CategoryID,
-			&product.CreatedAt,
-			&product.UpdatedAt,
+			&component.ID,
+			&component.BundleID,
+			&component.ComponentID,
+			&component.ComponentName,
+			&component.Quantity,
+			&component.Cost,
+			&component.Stock,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan product: %w", err)
+			return nil, fmt.Errorf("failed to scan bundle component: %w", err)
 		}
-		products = append(products, product)
+		components = append(components, component)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating products: %w", err)
+		return nil, fmt.Errorf("error iterating bundle components: %w", err)
+	}
+
+	return components, nil
+}
+
+func (r *productRepositoryImpl) ReplaceComponents(ctx context.Context, bundleID int, components []entities.BundleComponent) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Stock held by the product itself, at any store or on its way to one, would no
+	// longer be sellable once it is a bundle. The product row is locked so the check
+	// and the new components are saved together.
+	var name, unit string
+	var isBundle bool
+	lockQuery := `SELECT name, unit, EXISTS (SELECT 1 FROM bundle_components WHERE bundle_id = $1) FROM products WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, lockQuery, bundleID).Scan(&name, &unit, &isBundle); err != nil {
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	if len(components) > 0 && !isBundle {
+		stock, inTransit, err := stockAcrossStores(ctx, tx, bundleID)
+		if err != nil {
+			return err
+		}
+		if stock != 0 || inTransit != 0 {
+			return fmt.Errorf("product %s still has %g %s in stock and %g %s in transit across its stores; clear its stock before making it a bundle", name, stock, unit, inTransit, unit)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM bundle_components WHERE bundle_id = $1`, bundleID); err != nil {
+		return fmt.Errorf("failed to clear bundle components: %w", err)
+	}
+
+	query := `INSERT INTO bundle_components (bundle_id, component_id, quantity) VALUES ($1, $2, $3) RETURNING id`
+	for i := range components {
+		component := &components[i]
+		component.BundleID = bundleID
+		err := tx.QueryRowContext(ctx, query, bundleID, component.ComponentID, component.Quantity).Scan(&component.ID)
+		if err != nil {
+			return fmt.Errorf("failed to add bundle component: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *productRepositoryImpl) FindByCategoryID(ctx context.Context, storeID, categoryID int, includeDescendants, includeArchived bool) ([]entities.Product, error) {
+	query := productSelectQuery + ` WHERE p.category_id = $2`
+	if includeDescendants {
+		query = productSelectQuery + `
+	WHERE p.category_id IN (
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = $2
+			UNION
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree
+	)`
+	}
+	if !includeArchived {
+		query += ` AND p.deleted_at IS NULL`
+	}
+	query += ` ORDER BY p.id`
+
+	products, err := r.queryProducts(ctx, query, storeID, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products by category: %w", err)
 	}
 
 	return products, nil
 }
 
-func (r *productRepositoryImpl) Create(ctx context.Context, product *entities.Product) error {
+func (r *productRepositoryImpl) FindByFilters(ctx context.Context, storeID int, name string, active *bool, includeArchived bool) ([]entities.Product, error) {
+	query := productSelectQuery + ` WHERE 1=1`
+	args := []interface{}{storeID}
+
+	// Add name filter with ILIKE for case-insensitive partial matching
+	if name != "" {
+		query += fmt.Sprintf(" AND p.name ILIKE $%d", len(args)+1)
+		args = append(args, "%"+name+"%")
+	}
+
+	// Add active filter
+	if active != nil {
+		query += fmt.Sprintf(" AND p.active = $%d", len(args)+1)
+		args = append(args, *active)
+	}
+
+	// Hide archived products unless asked for
+	if !includeArchived {
+		query += " AND p.deleted_at IS NULL"
+	}
+
+	query += " ORDER BY p.id"
+
+	products, err := r.queryProducts(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query products by filters: %w", err)
+	}
+
+	return products, nil
+}
+
+func (r *productRepositoryImpl) Create(ctx context.Context, storeID int, product *entities.Product) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
-        INSERT INTO products (name, price, stock, active, category_id, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        INSERT INTO products (name, sku, plu, price, cost, unit, quantity_precision, active, track_lots, serialized, category_id, tax_rate_id, parent_id, option_values, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
         RETURNING id
     `
 
 	now := time.Now()
-	err := r.db.QueryRowContext(
+	err = tx.QueryRowContext(
 		ctx,
 		query,
 		product.Name,
+		product.SKU,
+		product.PLU,
 		product.Price,
-		product.Stock,
+		product.Cost,
+		product.Unit,
+		product.QuantityPrecision,
 		product.Active,
+		product.TrackLots,
+		product.Serialized,
 		product.CategoryID,
+		product.TaxRateID,
+		product.ParentID,
+		pq.Array(product.OptionValues),
 		now,
 		now,
 	).Scan(&product.ID)
@@ -187,28 +456,80 @@ func (r *productRepositoryImpl) Create(ctx context.Context, product *entities.Pr
 		return fmt.Errorf("failed to create product: %w", err)
 	}
 
+	// Initial stock is held by the store that created the product
+	stockQuery := `INSERT INTO product_stock (store_id, product_id, quantity, updated_at) VALUES ($1, $2, $3, $4)`
+	if _, err := tx.ExecContext(ctx, stockQuery, storeID, product.ID, product.Stock, now); err != nil {
+		return fmt.Errorf("failed to create product stock: %w", err)
+	}
+
+	if err := replaceBarcodes(ctx, tx, product.ID, product.Barcodes); err != nil {
+		return err
+	}
+
+	if err := replaceUnitConversions(ctx, tx, product.ID, product.UnitConversions); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	product.CreatedAt = now
 	product.UpdatedAt = now
 
 	return nil
 }
 
-func (r *productRepositoryImpl) Update(ctx context.Context, product *entities.Product) error {
+func (r *productRepositoryImpl) Update(ctx context.Context, storeID int, product *entities.Product, stockDelta float64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Stock is counted in the product's unit, so the unit can only change while no
+	// store holds or awaits any of it. The product row is locked so the check and the
+	// new unit are saved together.
+	var unit string
+	err = tx.QueryRowContext(ctx, `SELECT unit FROM products WHERE id = $1 FOR UPDATE`, product.ID).Scan(&unit)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("product not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	if unit != product.Unit {
+		stock, inTransit, err := stockAcrossStores(ctx, tx, product.ID)
+		if err != nil {
+			return err
+		}
+		if stock != 0 || inTransit != 0 {
+			return fmt.Errorf("product %s still has %g %s in stock and %g %s in transit across its stores; clear its stock before changing its unit", product.Name, stock, unit, inTransit, unit)
+		}
+	}
+
 	query := `
         UPDATE products 
-        SET name = $1, price = $2, stock = $3, active = $4, category_id = $5, updated_at = $6
-        WHERE id = $7
+        SET name = $1, sku = $2, plu = $3, price = $4, unit = $5, quantity_precision = $6, active = $7, track_lots = $8, serialized = $9, category_id = $10, tax_rate_id = $11, updated_at = $12
+        WHERE id = $13
     `
 
 	now := time.Now()
-	result, err := r.db.ExecContext(
+	result, err := tx.ExecContext(
 		ctx,
 		query,
 		product.Name,
+		product.SKU,
+		product.PLU,
 		product.Price,
-		product.Stock,
+		product.Unit,
+		product.QuantityPrecision,
 		product.Active,
+		product.TrackLots,
+		product.Serialized,
 		product.CategoryID,
+		product.TaxRateID,
 		now,
 		product.ID,
 	)
@@ -226,15 +547,49 @@ func (r *productRepositoryImpl) Update(ctx context.Context, product *entities.Pr
 		return fmt.Errorf("product not found")
 	}
 
+	// Adjust the caller's store stock by the change made to it, on top of any sale
+	// made meanwhile, and only when it changed
+	if stockDelta != 0 {
+		stockQuery := `
+			INSERT INTO product_stock (store_id, product_id, quantity, updated_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (store_id, product_id) DO UPDATE
+			SET quantity = product_stock.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+			RETURNING quantity
+		`
+		var stock float64
+		if err := tx.QueryRowContext(ctx, stockQuery, storeID, product.ID, stockDelta, now).Scan(&stock); err != nil {
+			return fmt.Errorf("failed to update product stock: %w", err)
+		}
+		if stock < 0 {
+			return fmt.Errorf("stock of product %s changed meanwhile and cannot go below zero", product.Name)
+		}
+		product.Stock = stock
+	}
+
+	if err := replaceBarcodes(ctx, tx, product.ID, product.Barcodes); err != nil {
+		return err
+	}
+
+	if err := replaceUnitConversions(ctx, tx, product.ID, product.UnitConversions); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	product.UpdatedAt = now
 
 	return nil
 }
 
 func (r *productRepositoryImpl) Delete(ctx context.Context, id int) error {
-	query := `DELETE FROM products WHERE id = $1`
+	// Variants of a parent product are archived with it, at the same time so that
+	// restoring the parent brings them back
+	query := `UPDATE products SET deleted_at = $1 WHERE (id = $2 OR parent_id = $2) AND deleted_at IS NULL`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
 	}
@@ -250,3 +605,100 @@ func (r *productRepositoryImpl) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (r *productRepositoryImpl) Restore(ctx context.Context, id int) error {
+	// Restore the product and the variants archived together with it
+	query := `
+		UPDATE products SET deleted_at = NULL, updated_at = $1
+		WHERE (id = $2 OR parent_id = $2)
+		AND deleted_at = (SELECT deleted_at FROM products WHERE id = $2)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore product: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("archived product not found")
+	}
+
+	return nil
+}
+
+func (r *productRepositoryImpl) UpdateStoreStock(ctx context.Context, storeID, productID int, quantity float64, price *float64) error {
+	query := `
+		INSERT INTO product_stock (store_id, product_id, quantity, price, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (store_id, product_id) DO UPDATE
+		SET quantity = EXCLUDED.quantity, price = EXCLUDED.price, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query, storeID, productID, quantity, price, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to update store stock: %w", err)
+	}
+
+	return nil
+}
+
+// replaceBarcodes replaces the barcodes of a product. Barcodes are unique across
+// the catalog, so a barcode already used by another product is rejected.
+func replaceBarcodes(ctx context.Context, tx *sql.Tx, productID int, barcodes []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_barcodes WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("failed to clear product barcodes: %w", err)
+	}
+
+	query := `INSERT INTO product_barcodes (product_id, barcode) VALUES ($1, $2)`
+	for _, barcode := range barcodes {
+		if _, err := tx.ExecContext(ctx, query, productID, barcode); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+				return fmt.Errorf("barcode %s is already assigned to another product", barcode)
+			}
+			return fmt.Errorf("failed to add product barcode: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// replaceUnitConversions replaces the purchasing and selling units of a product
+func replaceUnitConversions(ctx context.Context, tx *sql.Tx, productID int, conversions []entities.UnitConversion) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM product_unit_conversions WHERE product_id = $1`, productID); err != nil {
+		return fmt.Errorf("failed to clear product unit conversions: %w", err)
+	}
+
+	query := `INSERT INTO product_unit_conversions (product_id, unit, factor) VALUES ($1, $2, $3)`
+	for _, conversion := range conversions {
+		if _, err := tx.ExecContext(ctx, query, productID, conversion.Unit, conversion.Factor); err != nil {
+			return fmt.Errorf("failed to add product unit conversion: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// stockAcrossStores returns the stock of a product held by all stores together and
+// the stock shipped to them that has not been received yet
+func stockAcrossStores(ctx context.Context, tx *sql.Tx, productID int) (float64, float64, error) {
+	query := `
+		SELECT
+			COALESCE((SELECT SUM(quantity) FROM product_stock WHERE product_id = $1), 0),
+			COALESCE((
+				SELECT SUM(ti.quantity_shipped)
+				FROM stock_transfer_items ti
+				JOIN stock_transfers t ON t.id = ti.transfer_id
+				WHERE ti.product_id = $1 AND t.status = $2
+			), 0)
+	`
+	var stock, inTransit float64
+	if err := tx.QueryRowContext(ctx, query, productID, entities.StockTransferStatusShipped).Scan(&stock, &inTransit); err != nil {
+		return 0, 0, fmt.Errorf("failed to get product stock: %w", err)
+	}
+	return stock, inTransit, nil
+}
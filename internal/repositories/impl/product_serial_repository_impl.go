@@ -0,0 +1,249 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// productSerialSelectQuery selects serials with their product name and sale transaction
+const productSerialSelectQuery = `
+	SELECT s.id, s.product_id, p.name, s.serial_number, s.status, s.received_store_id, s.store_id,
+		s.goods_receipt_id, s.transaction_detail_id, td.transaction_id, s.received_at, s.sold_at
+	FROM product_serials s
+	JOIN products p ON s.product_id = p.id
+	LEFT JOIN transaction_details td ON s.transaction_detail_id = td.id
+`
+
+type productSerialRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewProductSerialRepository(db *sql.DB) repositories.ProductSerialRepository {
+	return &productSerialRepositoryImpl{db: db}
+}
+
+// scanProductSerial scans a row selected with productSerialSelectQuery
+func scanProductSerial(row interface{ Scan(...interface{}) error }, serial *entities.ProductSerial) error {
+	return row.Scan(
+		&serial.ID,
+		&serial.ProductID,
+		&serial.ProductName,
+		&serial.SerialNumber,
+		&serial.Status,
+		&serial.ReceivedStoreID,
+		&serial.StoreID,
+		&serial.GoodsReceiptID,
+		&serial.TransactionDetailID,
+		&serial.TransactionID,
+		&serial.ReceivedAt,
+		&serial.SoldAt,
+	)
+}
+
+func (r *productSerialRepositoryImpl) FindBySerialNumber(ctx context.Context, serialNumber string) (*entities.ProductSerial, error) {
+	var serial entities.ProductSerial
+	err := scanProductSerial(r.db.QueryRowContext(ctx, productSerialSelectQuery+` WHERE s.serial_number = $1`, serialNumber), &serial)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product serial: %w", err)
+	}
+
+	return &serial, nil
+}
+
+func (r *productSerialRepositoryImpl) FindInStockByProductID(ctx context.Context, storeID, productID int) ([]entities.ProductSerial, error) {
+	query := productSerialSelectQuery + ` WHERE s.store_id = $1 AND s.product_id = $2 AND s.status = $3 ORDER BY s.received_at, s.id`
+
+	rows, err := r.db.QueryContext(ctx, query, storeID, productID, entities.SerialStatusInStock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product serials: %w", err)
+	}
+	defer rows.Close()
+
+	var serials []entities.ProductSerial
+	for rows.Next() {
+		var serial entities.ProductSerial
+		if err := scanProductSerial(rows, &serial); err != nil {
+			return nil, fmt.Errorf("failed to scan product serial: %w", err)
+		}
+		serials = append(serials, serial)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product serials: %w", err)
+	}
+
+	return serials, nil
+}
+
+// addSerials registers received serial numbers as in stock. Serial numbers are
+// unique across the catalog, so receiving a number twice fails.
+func addSerials(ctx context.Context, tx *sql.Tx, storeID, productID, goodsReceiptID int, serialNumbers []string, at time.Time) error {
+	query := `
+		INSERT INTO product_serials (product_id, serial_number, status, received_store_id, store_id, goods_receipt_id, received_at)
+		VALUES ($1, $2, $3, $4, $4, $5, $6)
+		ON CONFLICT (serial_number) DO NOTHING
+	`
+
+	for _, serialNumber := range serialNumbers {
+		result, err := tx.ExecContext(ctx, query, productID, serialNumber, entities.SerialStatusInStock, storeID, goodsReceiptID, at)
+		if err != nil {
+			return fmt.Errorf("failed to add serial number: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("serial number %s already exists", serialNumber)
+		}
+	}
+
+	return nil
+}
+
+// sellSerials marks serial numbers of a product held by a store as sold on a
+// transaction detail. The update is conditional so a serial can only be sold
+// once, and only by the store holding it.
+func sellSerials(ctx context.Context, tx *sql.Tx, storeID, transactionDetailID, productID int, serialNumbers []string, at time.Time) error {
+	query := `
+		UPDATE product_serials
+		SET status = $1, transaction_detail_id = $2, sold_at = $3
+		WHERE product_id = $4 AND serial_number = $5 AND status = $6 AND store_id = $7
+	`
+
+	for _, serialNumber := range serialNumbers {
+		result, err := tx.ExecContext(ctx, query, entities.SerialStatusSold, transactionDetailID, at, productID, serialNumber, entities.SerialStatusInStock, storeID)
+		if err != nil {
+			return fmt.Errorf("failed to sell serial number: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("serial number %s is not in stock for this product at this store", serialNumber)
+		}
+	}
+
+	return nil
+}
+
+// shipSerials takes the serials of a serialized product that a store received
+// earliest out of the store for a stock transfer item and records them on the
+// item. Shipped serials belong to no store until they are received. Products
+// that are not serialized have no serials to ship.
+func shipSerials(ctx context.Context, tx *sql.Tx, storeID int, item entities.StockTransferItem) error {
+	var serialized bool
+	if err := tx.QueryRowContext(ctx, `SELECT serialized FROM products WHERE id = $1`, item.ProductID).Scan(&serialized); err != nil {
+		return fmt.Errorf("failed to find product: %w", err)
+	}
+	if !serialized {
+		return nil
+	}
+
+	query := `
+		WITH shipped AS (
+			UPDATE product_serials SET store_id = NULL
+			WHERE id IN (
+				SELECT id FROM product_serials
+				WHERE store_id = $1 AND product_id = $2 AND status = $3
+				ORDER BY received_at, id
+				LIMIT $4
+				FOR UPDATE
+			)
+			RETURNING id
+		)
+		INSERT INTO stock_transfer_item_serials (transfer_item_id, serial_id)
+		SELECT $5, id FROM shipped
+	`
+	result, err := tx.ExecContext(ctx, query, storeID, item.ProductID, entities.SerialStatusInStock, int(item.QuantityShipped), item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to ship serial numbers: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected < int64(item.QuantityShipped) {
+		return fmt.Errorf("insufficient serial numbers for product %s at source store", item.ProductName)
+	}
+
+	return nil
+}
+
+// receiveSerials adds the serials shipped on a stock transfer item to the
+// destination store. When the item names the serial numbers that arrived only
+// those are received; the rest stay in transit so no store can sell them.
+func receiveSerials(ctx context.Context, tx *sql.Tx, storeID int, item entities.StockTransferItem) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT ps.id, ps.serial_number
+		FROM stock_transfer_item_serials tis
+		JOIN product_serials ps ON tis.serial_id = ps.id
+		WHERE tis.transfer_item_id = $1
+	`, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query shipped serial numbers: %w", err)
+	}
+
+	shipped := make(map[string]int)
+	for rows.Next() {
+		var id int
+		var serialNumber string
+		if err := rows.Scan(&id, &serialNumber); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan shipped serial number: %w", err)
+		}
+		shipped[serialNumber] = id
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating shipped serial numbers: %w", err)
+	}
+
+	if len(shipped) == 0 {
+		return nil
+	}
+
+	received := item.ReceivedSerialNumbers
+	if received == nil && item.QuantityReceived > 0 {
+		if int(item.QuantityReceived) != len(shipped) {
+			return fmt.Errorf("product %s is serialized; list the serial_numbers that arrived", item.ProductName)
+		}
+		for serialNumber := range shipped {
+			received = append(received, serialNumber)
+		}
+	}
+
+	serialQuery := `UPDATE product_serials SET store_id = $1 WHERE id = $2`
+	itemQuery := `UPDATE stock_transfer_item_serials SET received = TRUE WHERE transfer_item_id = $1 AND serial_id = $2`
+	for _, serialNumber := range received {
+		id, ok := shipped[serialNumber]
+		if !ok {
+			return fmt.Errorf("serial number %s was not shipped for product %s", serialNumber, item.ProductName)
+		}
+
+		if _, err := tx.ExecContext(ctx, serialQuery, storeID, id); err != nil {
+			return fmt.Errorf("failed to receive serial number: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, itemQuery, item.ID, id); err != nil {
+			return fmt.Errorf("failed to receive serial number: %w", err)
+		}
+	}
+
+	return nil
+}
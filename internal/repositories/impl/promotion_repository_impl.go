@@ -0,0 +1,384 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/lib/pq"
+)
+
+const promotionSelectQuery = `
+	SELECT id, name, type, priority, stackable, active, starts_at, ends_at, days_of_week,
+		COALESCE(TO_CHAR(start_time, 'HH24:MI'), ''), COALESCE(TO_CHAR(end_time, 'HH24:MI'), ''),
+		buy_quantity, get_quantity, category_id, percent::float8, bundle_price, created_at, updated_at
+	FROM promotions
+`
+
+type promotionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewPromotionRepository(db *sql.DB) repositories.PromotionRepository {
+	return &promotionRepositoryImpl{db: db}
+}
+
+// scanPromotion scans a row produced by promotionSelectQuery
+func scanPromotion(row interface{ Scan(...interface{}) error }, promotion *entities.Promotion) error {
+	var days []int64
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Type,
+		&promotion.Priority,
+		&promotion.Stackable,
+		&promotion.Active,
+		&promotion.StartsAt,
+		&promotion.EndsAt,
+		pq.Array(&days),
+		&promotion.StartTime,
+		&promotion.EndTime,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		&promotion.CategoryID,
+		&promotion.Percent,
+		&promotion.BundlePrice,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	promotion.Days = make([]int, len(days))
+	for i, day := range days {
+		promotion.Days[i] = int(day)
+	}
+	return nil
+}
+
+// daysOfWeek converts promotion days to a database array, empty rather than NULL when unset
+func daysOfWeek(days []int) pq.Int64Array {
+	array := pq.Int64Array{}
+	for _, day := range days {
+		array = append(array, int64(day))
+	}
+	return array
+}
+
+func (r *promotionRepositoryImpl) Create(ctx context.Context, promotion *entities.Promotion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO promotions (name, type, priority, stackable, active, starts_at, ends_at, days_of_week, start_time, end_time,
+			buy_quantity, get_quantity, category_id, percent, bundle_price, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, '')::time, NULLIF($10, '')::time, $11, $12, $13, $14, $15, $16, $16)
+		RETURNING id, created_at, updated_at
+	`
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, query,
+		promotion.Name,
+		promotion.Type,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.Active,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		daysOfWeek(promotion.Days),
+		promotion.StartTime,
+		promotion.EndTime,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.CategoryID,
+		promotion.Percent,
+		promotion.BundlePrice,
+		now,
+	).Scan(&promotion.ID, &promotion.CreatedAt, &promotion.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	if err := insertPromotionRules(ctx, tx, promotion); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *promotionRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Promotion, error) {
+	query := promotionSelectQuery + ` WHERE id = $1`
+
+	var promotion entities.Promotion
+	err := scanPromotion(r.db.QueryRowContext(ctx, query, id), &promotion)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promotion: %w", err)
+	}
+
+	if err := r.attachRules(ctx, &promotion); err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (r *promotionRepositoryImpl) FindAll(ctx context.Context) ([]entities.Promotion, error) {
+	return r.findPromotions(ctx, promotionSelectQuery+` ORDER BY priority DESC, id`)
+}
+
+func (r *promotionRepositoryImpl) FindActive(ctx context.Context, at time.Time) ([]entities.Promotion, error) {
+	query := promotionSelectQuery + `
+		WHERE active AND (starts_at IS NULL OR starts_at <= $1) AND (ends_at IS NULL OR ends_at > $1)
+		ORDER BY priority DESC, id
+	`
+	promotions, err := r.findPromotions(ctx, query, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// Category promotions cover the whole subtree of their category
+	subtreeQuery := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree ORDER BY id
+	`
+	for i := range promotions {
+		promotion := &promotions[i]
+		if promotion.CategoryID == nil {
+			continue
+		}
+
+		rows, err := r.db.QueryContext(ctx, subtreeQuery, *promotion.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query promotion categories: %w", err)
+		}
+
+		for rows.Next() {
+			var categoryID int
+			if err := rows.Scan(&categoryID); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan promotion category: %w", err)
+			}
+			promotion.CategoryIDs = append(promotion.CategoryIDs, categoryID)
+		}
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return nil, fmt.Errorf("error iterating promotion categories: %w", err)
+		}
+	}
+
+	return promotions, nil
+}
+
+// findPromotions runs a query built on promotionSelectQuery and loads each promotion's items and tiers
+func (r *promotionRepositoryImpl) findPromotions(ctx context.Context, query string, args ...interface{}) ([]entities.Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotions: %w", err)
+	}
+	defer rows.Close()
+
+	var promotions []entities.Promotion
+	for rows.Next() {
+		var promotion entities.Promotion
+		if err := scanPromotion(rows, &promotion); err != nil {
+			return nil, fmt.Errorf("failed to scan promotion: %w", err)
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promotions: %w", err)
+	}
+
+	for i := range promotions {
+		if err := r.attachRules(ctx, &promotions[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return promotions, nil
+}
+
+// attachRules loads the items and tiers of a promotion
+func (r *promotionRepositoryImpl) attachRules(ctx context.Context, promotion *entities.Promotion) error {
+	itemQuery := `
+		SELECT pi.id, pi.promotion_id, pi.product_id, COALESCE(p.name, ''), pi.quantity
+		FROM promotion_items pi
+		LEFT JOIN products p ON pi.product_id = p.id
+		WHERE pi.promotion_id = $1
+		ORDER BY pi.id
+	`
+	rows, err := r.db.QueryContext(ctx, itemQuery, promotion.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query promotion items: %w", err)
+	}
+	defer rows.Close()
+
+	promotion.Items = nil
+	for rows.Next() {
+		var item entities.PromotionItem
+		if err := rows.Scan(&item.ID, &item.PromotionID, &item.ProductID, &item.ProductName, &item.Quantity); err != nil {
+			return fmt.Errorf("failed to scan promotion item: %w", err)
+		}
+		promotion.Items = append(promotion.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating promotion items: %w", err)
+	}
+
+	tierQuery := `
+		SELECT id, promotion_id, min_quantity::float8, unit_price
+		FROM promotion_tiers
+		WHERE promotion_id = $1
+		ORDER BY min_quantity
+	`
+	tierRows, err := r.db.QueryContext(ctx, tierQuery, promotion.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query promotion tiers: %w", err)
+	}
+	defer tierRows.Close()
+
+	promotion.Tiers = nil
+	for tierRows.Next() {
+		var tier entities.PromotionTier
+		if err := tierRows.Scan(&tier.ID, &tier.PromotionID, &tier.MinQuantity, &tier.UnitPrice); err != nil {
+			return fmt.Errorf("failed to scan promotion tier: %w", err)
+		}
+		promotion.Tiers = append(promotion.Tiers, tier)
+	}
+
+	if err = tierRows.Err(); err != nil {
+		return fmt.Errorf("error iterating promotion tiers: %w", err)
+	}
+
+	return nil
+}
+
+// insertPromotionRules inserts the items and tiers of a promotion
+func insertPromotionRules(ctx context.Context, tx *sql.Tx, promotion *entities.Promotion) error {
+	itemQuery := `INSERT INTO promotion_items (promotion_id, product_id, quantity) VALUES ($1, $2, $3) RETURNING id`
+	for i := range promotion.Items {
+		item := &promotion.Items[i]
+		item.PromotionID = promotion.ID
+		if err := tx.QueryRowContext(ctx, itemQuery, item.PromotionID, item.ProductID, item.Quantity).Scan(&item.ID); err != nil {
+			return fmt.Errorf("failed to create promotion item: %w", err)
+		}
+	}
+
+	tierQuery := `INSERT INTO promotion_tiers (promotion_id, min_quantity, unit_price) VALUES ($1, $2, $3) RETURNING id`
+	for i := range promotion.Tiers {
+		tier := &promotion.Tiers[i]
+		tier.PromotionID = promotion.ID
+		if err := tx.QueryRowContext(ctx, tierQuery, tier.PromotionID, tier.MinQuantity, tier.UnitPrice).Scan(&tier.ID); err != nil {
+			return fmt.Errorf("failed to create promotion tier: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *promotionRepositoryImpl) Update(ctx context.Context, promotion *entities.Promotion) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE promotions
+		SET name = $1, type = $2, priority = $3, stackable = $4, active = $5, starts_at = $6, ends_at = $7, days_of_week = $8,
+			start_time = NULLIF($9, '')::time, end_time = NULLIF($10, '')::time, buy_quantity = $11, get_quantity = $12,
+			category_id = $13, percent = $14, bundle_price = $15, updated_at = $16
+		WHERE id = $17
+	`
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, query,
+		promotion.Name,
+		promotion.Type,
+		promotion.Priority,
+		promotion.Stackable,
+		promotion.Active,
+		promotion.StartsAt,
+		promotion.EndsAt,
+		daysOfWeek(promotion.Days),
+		promotion.StartTime,
+		promotion.EndTime,
+		promotion.BuyQuantity,
+		promotion.GetQuantity,
+		promotion.CategoryID,
+		promotion.Percent,
+		promotion.BundlePrice,
+		now,
+		promotion.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("promotion not found")
+	}
+
+	// Replace items and tiers
+	if _, err := tx.ExecContext(ctx, `DELETE FROM promotion_items WHERE promotion_id = $1`, promotion.ID); err != nil {
+		return fmt.Errorf("failed to delete promotion items: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM promotion_tiers WHERE promotion_id = $1`, promotion.ID); err != nil {
+		return fmt.Errorf("failed to delete promotion tiers: %w", err)
+	}
+
+	if err := insertPromotionRules(ctx, tx, promotion); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	promotion.UpdatedAt = now
+	return nil
+}
+
+func (r *promotionRepositoryImpl) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM promotions WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("promotion not found")
+	}
+
+	return nil
+}
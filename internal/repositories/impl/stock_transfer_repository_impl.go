@@ -0,0 +1,407 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/lib/pq"
+)
+
+const stockTransferSelectQuery = `
+	SELECT id, source_store_id, destination_store_id, status, note, receiving_note, created_at, shipped_at, received_at, cancelled_at
+	FROM stock_transfers
+`
+
+type stockTransferRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewStockTransferRepository(db *sql.DB) repositories.StockTransferRepository {
+	return &stockTransferRepositoryImpl{db: db}
+}
+
+// scanStockTransfer scans a row produced by stockTransferSelectQuery
+func scanStockTransfer(row interface{ Scan(...interface{}) error }, transfer *entities.StockTransfer) error {
+	return row.Scan(
+		&transfer.ID,
+		&transfer.SourceStoreID,
+		&transfer.DestinationStoreID,
+		&transfer.Status,
+		&transfer.Note,
+		&transfer.ReceivingNote,
+		&transfer.CreatedAt,
+		&transfer.ShippedAt,
+		&transfer.ReceivedAt,
+		&transfer.CancelledAt,
+	)
+}
+
+func (r *stockTransferRepositoryImpl) Create(ctx context.Context, transfer *entities.StockTransfer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Insert transfer
+	query := `
+		INSERT INTO stock_transfers (source_store_id, destination_store_id, status, note, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, query, transfer.SourceStoreID, transfer.DestinationStoreID, transfer.Status, transfer.Note, now).
+		Scan(&transfer.ID, &transfer.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create stock transfer: %w", err)
+	}
+
+	// Insert transfer items
+	itemQuery := `INSERT INTO stock_transfer_items (transfer_id, product_id, quantity_requested) VALUES ($1, $2, $3) RETURNING id`
+	for i := range transfer.Items {
+		item := &transfer.Items[i]
+		item.TransferID = transfer.ID
+		err = tx.QueryRowContext(ctx, itemQuery, item.TransferID, item.ProductID, item.QuantityRequested).Scan(&item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create stock transfer item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *stockTransferRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.StockTransfer, error) {
+	query := stockTransferSelectQuery + ` WHERE id = $1`
+
+	var transfer entities.StockTransfer
+	err := scanStockTransfer(r.db.QueryRowContext(ctx, query, id), &transfer)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stock transfer: %w", err)
+	}
+
+	items, err := r.findItems(ctx, transfer.ID)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Items = items
+
+	return &transfer, nil
+}
+
+func (r *stockTransferRepositoryImpl) FindAll(ctx context.Context, status string, storeID int) ([]entities.StockTransfer, error) {
+	query := stockTransferSelectQuery + ` WHERE 1=1`
+	args := []interface{}{}
+
+	// Add status filter
+	if status != "" {
+		query += fmt.Sprintf(" AND status = $%d", len(args)+1)
+		args = append(args, status)
+	}
+
+	// Add store filter on either side of the transfer
+	if storeID != 0 {
+		query += fmt.Sprintf(" AND (source_store_id = $%d OR destination_store_id = $%d)", len(args)+1, len(args)+1)
+		args = append(args, storeID)
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock transfers: %w", err)
+	}
+	defer rows.Close()
+
+	var transfers []entities.StockTransfer
+	for rows.Next() {
+		var transfer entities.StockTransfer
+		if err := scanStockTransfer(rows, &transfer); err != nil {
+			return nil, fmt.Errorf("failed to scan stock transfer: %w", err)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stock transfers: %w", err)
+	}
+
+	// Get items for all transfers
+	for i := range transfers {
+		items, err := r.findItems(ctx, transfers[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		transfers[i].Items = items
+	}
+
+	return transfers, nil
+}
+
+func (r *stockTransferRepositoryImpl) Ship(ctx context.Context, transfer *entities.StockTransfer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := updateStockTransferStatus(ctx, tx, transfer.ID, entities.StockTransferStatusRequested, entities.StockTransferStatusShipped, "shipped_at", now); err != nil {
+		return err
+	}
+
+	// Take shipped quantities out of the source store; the update is conditional
+	// so a transfer cannot ship more than the source has on hand
+	stockQuery := `UPDATE product_stock SET quantity = quantity - $1, updated_at = $2 WHERE store_id = $3 AND product_id = $4 AND quantity >= $1`
+	itemQuery := `UPDATE stock_transfer_items SET quantity_shipped = $1 WHERE id = $2`
+	lotQuery := `INSERT INTO stock_transfer_item_lots (transfer_item_id, lot_number, expiry_date, quantity) VALUES ($1, $2, $3, $4)`
+	for _, item := range transfer.Items {
+		result, err := tx.ExecContext(ctx, stockQuery, item.QuantityShipped, now, transfer.SourceStoreID, item.ProductID)
+		if err != nil {
+			return fmt.Errorf("failed to update source store stock: %w", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rowsAffected == 0 {
+			return fmt.Errorf("insufficient stock for product %s at source store", item.ProductName)
+		}
+
+		if _, err := tx.ExecContext(ctx, itemQuery, item.QuantityShipped, item.ID); err != nil {
+			return fmt.Errorf("failed to update stock transfer item: %w", err)
+		}
+
+		// Ship the earliest expiring lots and remember them so the destination
+		// receives the same lot numbers and expiry dates
+		allocations, err := consumeLotsFEFO(ctx, tx, transfer.SourceStoreID, item.ProductID, item.QuantityShipped)
+		if err != nil {
+			return err
+		}
+
+		for _, allocation := range allocations {
+			if _, err := tx.ExecContext(ctx, lotQuery, item.ID, allocation.LotNumber, allocation.ExpiryDate, allocation.Quantity); err != nil {
+				return fmt.Errorf("failed to record shipped lot: %w", err)
+			}
+		}
+
+		// Serialized units leave the source store and travel with the transfer
+		if err := shipSerials(ctx, tx, transfer.SourceStoreID, item); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	transfer.Status = entities.StockTransferStatusShipped
+	transfer.ShippedAt = &now
+
+	return nil
+}
+
+func (r *stockTransferRepositoryImpl) Receive(ctx context.Context, transfer *entities.StockTransfer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := updateStockTransferStatus(ctx, tx, transfer.ID, entities.StockTransferStatusShipped, entities.StockTransferStatusReceived, "received_at", now); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_transfers SET receiving_note = $1 WHERE id = $2`, transfer.ReceivingNote, transfer.ID); err != nil {
+		return fmt.Errorf("failed to update stock transfer: %w", err)
+	}
+
+	// Add received quantities to the destination store
+	stockQuery := `
+		INSERT INTO product_stock (store_id, product_id, quantity, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (store_id, product_id) DO UPDATE
+		SET quantity = product_stock.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at
+	`
+	itemQuery := `UPDATE stock_transfer_items SET quantity_received = $1 WHERE id = $2`
+	for _, item := range transfer.Items {
+		if _, err := tx.ExecContext(ctx, stockQuery, transfer.DestinationStoreID, item.ProductID, item.QuantityReceived, now); err != nil {
+			return fmt.Errorf("failed to update destination store stock: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, itemQuery, item.QuantityReceived, item.ID); err != nil {
+			return fmt.Errorf("failed to update stock transfer item: %w", err)
+		}
+
+		if err := receiveShippedLots(ctx, tx, transfer.DestinationStoreID, item, now); err != nil {
+			return err
+		}
+
+		if err := receiveSerials(ctx, tx, transfer.DestinationStoreID, item); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	transfer.Status = entities.StockTransferStatusReceived
+	transfer.ReceivedAt = &now
+
+	return nil
+}
+
+func (r *stockTransferRepositoryImpl) Cancel(ctx context.Context, transfer *entities.StockTransfer) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := updateStockTransferStatus(ctx, tx, transfer.ID, entities.StockTransferStatusRequested, entities.StockTransferStatusCancelled, "cancelled_at", now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	transfer.Status = entities.StockTransferStatusCancelled
+	transfer.CancelledAt = &now
+
+	return nil
+}
+
+// updateStockTransferStatus moves a transfer from one status to the next and stamps
+// the matching timestamp column. It fails if the transfer is no longer in the expected
+// status, which guards against two clerks shipping or receiving the same transfer.
+func updateStockTransferStatus(ctx context.Context, tx *sql.Tx, id int, from, to, timestampColumn string, at time.Time) error {
+	query := fmt.Sprintf(`UPDATE stock_transfers SET status = $1, %s = $2 WHERE id = $3 AND status = $4`, timestampColumn)
+
+	result, err := tx.ExecContext(ctx, query, to, at, id, from)
+	if err != nil {
+		return fmt.Errorf("failed to update stock transfer status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("stock transfer %d is no longer %s", id, from)
+	}
+
+	return nil
+}
+
+// receiveShippedLots adds the lots shipped for a transfer item to the destination
+// store. When less arrived than was shipped, the shortfall is taken from the lots
+// expiring last.
+func receiveShippedLots(ctx context.Context, tx *sql.Tx, storeID int, item entities.StockTransferItem, at time.Time) error {
+	query := `
+		SELECT lot_number, expiry_date, quantity
+		FROM stock_transfer_item_lots
+		WHERE transfer_item_id = $1
+		ORDER BY expiry_date ASC NULLS LAST, id ASC
+	`
+	rows, err := tx.QueryContext(ctx, query, item.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query shipped lots: %w", err)
+	}
+
+	var lots []entities.LotAllocation
+	for rows.Next() {
+		var lot entities.LotAllocation
+		if err := rows.Scan(&lot.LotNumber, &lot.ExpiryDate, &lot.Quantity); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan shipped lot: %w", err)
+		}
+		lots = append(lots, lot)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating shipped lots: %w", err)
+	}
+
+	remaining := item.QuantityReceived
+	for _, lot := range lots {
+		if remaining <= 0 {
+			break
+		}
+
+		quantity := min(lot.Quantity, remaining)
+		remaining = entities.RoundQuantity(remaining - quantity)
+		if err := addLotStock(ctx, tx, storeID, item.ProductID, lot.LotNumber, lot.ExpiryDate, quantity, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findItems retrieves the items of a stock transfer with product names
+func (r *stockTransferRepositoryImpl) findItems(ctx context.Context, transferID int) ([]entities.StockTransferItem, error) {
+	query := `
+		SELECT ti.id, ti.transfer_id, ti.product_id, p.name, ti.quantity_requested, ti.quantity_shipped, ti.quantity_received,
+			ARRAY(
+				SELECT ps.serial_number FROM stock_transfer_item_serials tis JOIN product_serials ps ON tis.serial_id = ps.id
+				WHERE tis.transfer_item_id = ti.id ORDER BY ps.serial_number
+			),
+			ARRAY(
+				SELECT ps.serial_number FROM stock_transfer_item_serials tis JOIN product_serials ps ON tis.serial_id = ps.id
+				WHERE tis.transfer_item_id = ti.id AND tis.received ORDER BY ps.serial_number
+			)
+		FROM stock_transfer_items ti
+		LEFT JOIN products p ON ti.product_id = p.id
+		WHERE ti.transfer_id = $1
+		ORDER BY ti.id
+	`
+	rows, err := r.db.QueryContext(ctx, query, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stock transfer items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entities.StockTransferItem
+	for rows.Next() {
+		var item entities.StockTransferItem
+		err := rows.Scan(
+			&item.ID,
+			&item.TransferID,
+			&item.ProductID,
+			&item.ProductName,
+			&item.QuantityRequested,
+			&item.QuantityShipped,
+			&item.QuantityReceived,
+			pq.Array(&item.SerialNumbers),
+			pq.Array(&item.ReceivedSerialNumbers),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock transfer item: %w", err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stock transfer items: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,172 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+type storeRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewStoreRepository(db *sql.DB) repositories.StoreRepository {
+	return &storeRepositoryImpl{db: db}
+}
+
+func (r *storeRepositoryImpl) FindAll(ctx context.Context) ([]entities.Store, error) {
+	query := `SELECT id, name, address, tax_mode, service_charge_rate::float8, service_charge_taxable, cash_rounding, created_at, updated_at FROM stores ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stores: %w", err)
+	}
+	defer rows.Close()
+
+	var stores []entities.Store
+	for rows.Next() {
+		var store entities.Store
+		err := rows.Scan(
+			&store.ID,
+			&store.Name,
+			&store.Address,
+			&store.TaxMode,
+			&store.ServiceChargeRate,
+			&store.ServiceChargeTaxable,
+			&store.CashRounding,
+			&store.CreatedAt,
+			&store.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan store: %w", err)
+		}
+		stores = append(stores, store)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stores: %w", err)
+	}
+
+	return stores, nil
+}
+
+func (r *storeRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Store, error) {
+	query := `SELECT id, name, address, tax_mode, service_charge_rate::float8, service_charge_taxable, cash_rounding, created_at, updated_at FROM stores WHERE id = $1`
+
+	var store entities.Store
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&store.ID,
+		&store.Name,
+		&store.Address,
+		&store.TaxMode,
+		&store.ServiceChargeRate,
+		&store.ServiceChargeTaxable,
+		&store.CashRounding,
+		&store.CreatedAt,
+		&store.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store: %w", err)
+	}
+
+	return &store, nil
+}
+
+func (r *storeRepositoryImpl) Create(ctx context.Context, store *entities.Store) error {
+	query := `
+        INSERT INTO stores (name, address, tax_mode, service_charge_rate, service_charge_taxable, cash_rounding, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id
+    `
+
+	now := time.Now()
+	err := r.db.QueryRowContext(
+		ctx,
+		query,
+		store.Name,
+		store.Address,
+		store.TaxMode,
+		store.ServiceChargeRate,
+		store.ServiceChargeTaxable,
+		store.CashRounding,
+		now,
+		now,
+	).Scan(&store.ID)
+
+	if err != nil {
+		return fmt.Errorf("failed to create store: %w", err)
+	}
+
+	store.CreatedAt = now
+	store.UpdatedAt = now
+
+	return nil
+}
+
+func (r *storeRepositoryImpl) Update(ctx context.Context, store *entities.Store) error {
+	query := `
+        UPDATE stores 
+        SET name = $1, address = $2, tax_mode = $3, service_charge_rate = $4, service_charge_taxable = $5, cash_rounding = $6, updated_at = $7
+        WHERE id = $8
+    `
+
+	now := time.Now()
+	result, err := r.db.ExecContext(
+		ctx,
+		query,
+		store.Name,
+		store.Address,
+		store.TaxMode,
+		store.ServiceChargeRate,
+		store.ServiceChargeTaxable,
+		store.CashRounding,
+		now,
+		store.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update store: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("store not found")
+	}
+
+	store.UpdatedAt = now
+
+	return nil
+}
+
+func (r *storeRepositoryImpl) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM stores WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete store: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("store not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/lib/pq"
+)
+
+const taxRateSelectQuery = `SELECT id, name, rate::float8, is_default, created_at, updated_at FROM tax_rates`
+
+type taxRateRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTaxRateRepository(db *sql.DB) repositories.TaxRateRepository {
+	return &taxRateRepositoryImpl{db: db}
+}
+
+// scanTaxRate scans a row produced by taxRateSelectQuery
+func scanTaxRate(row interface{ Scan(...interface{}) error }, taxRate *entities.TaxRate) error {
+	return row.Scan(
+		&taxRate.ID,
+		&taxRate.Name,
+		&taxRate.Rate,
+		&taxRate.IsDefault,
+		&taxRate.CreatedAt,
+		&taxRate.UpdatedAt,
+	)
+}
+
+func (r *taxRateRepositoryImpl) Create(ctx context.Context, taxRate *entities.TaxRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := clearDefaultTaxRate(ctx, tx, taxRate); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO tax_rates (name, rate, is_default, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING id
+	`
+	now := time.Now()
+	err = tx.QueryRowContext(ctx, query, taxRate.Name, taxRate.Rate, taxRate.IsDefault, now).Scan(&taxRate.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("tax rate %s already exists", taxRate.Name)
+		}
+		return fmt.Errorf("failed to create tax rate: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	taxRate.CreatedAt = now
+	taxRate.UpdatedAt = now
+	return nil
+}
+
+func (r *taxRateRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.TaxRate, error) {
+	var taxRate entities.TaxRate
+	err := scanTaxRate(r.db.QueryRowContext(ctx, taxRateSelectQuery+` WHERE id = $1`, id), &taxRate)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tax rate: %w", err)
+	}
+
+	return &taxRate, nil
+}
+
+func (r *taxRateRepositoryImpl) FindDefault(ctx context.Context) (*entities.TaxRate, error) {
+	var taxRate entities.TaxRate
+	err := scanTaxRate(r.db.QueryRowContext(ctx, taxRateSelectQuery+` WHERE is_default`), &taxRate)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find default tax rate: %w", err)
+	}
+
+	return &taxRate, nil
+}
+
+func (r *taxRateRepositoryImpl) FindAll(ctx context.Context) ([]entities.TaxRate, error) {
+	rows, err := r.db.QueryContext(ctx, taxRateSelectQuery+` ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax rates: %w", err)
+	}
+	defer rows.Close()
+
+	var taxRates []entities.TaxRate
+	for rows.Next() {
+		var taxRate entities.TaxRate
+		if err := scanTaxRate(rows, &taxRate); err != nil {
+			return nil, fmt.Errorf("failed to scan tax rate: %w", err)
+		}
+		taxRates = append(taxRates, taxRate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tax rates: %w", err)
+	}
+
+	return taxRates, nil
+}
+
+func (r *taxRateRepositoryImpl) Update(ctx context.Context, taxRate *entities.TaxRate) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := clearDefaultTaxRate(ctx, tx, taxRate); err != nil {
+		return err
+	}
+
+	query := `UPDATE tax_rates SET name = $1, rate = $2, is_default = $3, updated_at = $4 WHERE id = $5`
+	now := time.Now()
+	result, err := tx.ExecContext(ctx, query, taxRate.Name, taxRate.Rate, taxRate.IsDefault, now, taxRate.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("tax rate %s already exists", taxRate.Name)
+		}
+		return fmt.Errorf("failed to update tax rate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("tax rate not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	taxRate.UpdatedAt = now
+	return nil
+}
+
+// clearDefaultTaxRate unsets the current default rate when another rate becomes the default
+func clearDefaultTaxRate(ctx context.Context, tx *sql.Tx, taxRate *entities.TaxRate) error {
+	if !taxRate.IsDefault {
+		return nil
+	}
+
+	query := `UPDATE tax_rates SET is_default = FALSE, updated_at = $1 WHERE is_default AND id <> $2`
+	if _, err := tx.ExecContext(ctx, query, time.Now(), taxRate.ID); err != nil {
+		return fmt.Errorf("failed to clear default tax rate: %w", err)
+	}
+	return nil
+}
+
+func (r *taxRateRepositoryImpl) Delete(ctx context.Context, id int) error {
+	query := `DELETE FROM tax_rates WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tax rate: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("tax rate not found")
+	}
+
+	return nil
+}
+
+func (r *taxRateRepositoryImpl) CountAssignments(ctx context.Context, id int) (int, int, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM products WHERE tax_rate_id = $1 AND deleted_at IS NULL),
+			(SELECT COUNT(*) FROM categories WHERE tax_rate_id = $1 AND deleted_at IS NULL)
+	`
+	var products, categories int
+	if err := r.db.QueryRowContext(ctx, query, id).Scan(&products, &categories); err != nil {
+		return 0, 0, fmt.Errorf("failed to count tax rate assignments: %w", err)
+	}
+
+	return products, categories, nil
+}
+
+func (r *taxRateRepositoryImpl) FindForProducts(ctx context.Context, productIDs []int) (map[int]entities.TaxRate, error) {
+	// Walk up from each product through its categories, nearest first, and take
+	// the first rate found; products without one fall back to the default rate
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT p.id AS product_id, p.category_id, p.tax_rate_id, 0 AS depth
+			FROM products p
+			WHERE p.id = ANY($1)
+			UNION ALL
+			SELECT chain.product_id, c.parent_id, c.tax_rate_id, chain.depth + 1
+			FROM chain
+			JOIN categories c ON c.id = chain.category_id
+			WHERE chain.depth < 100
+		), assigned AS (
+			SELECT DISTINCT ON (product_id) product_id, tax_rate_id
+			FROM chain
+			WHERE tax_rate_id IS NOT NULL
+			ORDER BY product_id, depth
+		)
+		SELECT p.id, t.id, t.name, t.rate::float8, t.is_default, t.created_at, t.updated_at
+		FROM products p
+		LEFT JOIN assigned ON assigned.product_id = p.id
+		JOIN tax_rates t ON t.id = COALESCE(assigned.tax_rate_id, (SELECT id FROM tax_rates WHERE is_default))
+		WHERE p.id = ANY($1)
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query product tax rates: %w", err)
+	}
+	defer rows.Close()
+
+	taxRates := make(map[int]entities.TaxRate)
+	for rows.Next() {
+		var productID int
+		var taxRate entities.TaxRate
+		if err := rows.Scan(&productID, &taxRate.ID, &taxRate.Name, &taxRate.Rate, &taxRate.IsDefault, &taxRate.CreatedAt, &taxRate.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan product tax rate: %w", err)
+		}
+		taxRates[productID] = taxRate
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating product tax rates: %w", err)
+	}
+
+	return taxRates, nil
+}
@@ -26,22 +26,55 @@ func (r *transactionRepositoryImpl) Create(ctx context.Context, transaction *ent
 	defer tx.Rollback()
 
 	// Insert transaction
-	query := `INSERT INTO transactions (total_amount, created_at) VALUES ($1, $2) RETURNING id, created_at`
 	now := time.Now()
-	err = tx.QueryRowContext(ctx, query, transaction.TotalAmount, now).Scan(&transaction.ID, &transaction.CreatedAt)
+	if transaction.Status == entities.TransactionStatusCompleted {
+		transaction.CompletedAt = &now
+	}
+	query := `INSERT INTO transactions (store_id, customer_ref, gross_amount, discount_amount, service_charge, tax_mode, tax_amount, rounding_amount, total_amount, paid_amount, change_amount, payment_method, status, created_at, expires_at, completed_at) VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), $13, $14, $15, $16) RETURNING id, created_at`
+	err = tx.QueryRowContext(ctx, query, transaction.StoreID, transaction.CustomerRef, transaction.GrossAmount, transaction.DiscountAmount, transaction.ServiceCharge, transaction.TaxMode, transaction.TaxAmount, transaction.RoundingAmount, transaction.TotalAmount, transaction.PaidAmount, transaction.ChangeAmount, transaction.PaymentMethod, transaction.Status, now, transaction.ExpiresAt, transaction.CompletedAt).Scan(&transaction.ID, &transaction.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	if err := insertTaxes(ctx, tx, transaction.ID, transaction.Taxes); err != nil {
+		return err
+	}
+
+	if err := insertPayments(ctx, tx, transaction.ID, transaction.Payments); err != nil {
+		return err
+	}
+
+	if err := insertDiscounts(ctx, tx, transaction.ID, nil, transaction.Discounts); err != nil {
+		return err
+	}
+
 	// Insert transaction details
-	detailQuery := `INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES ($1, $2, $3, $4) RETURNING id`
+	detailQuery := `
+		INSERT INTO transaction_details (transaction_id, product_id, quantity, gross_amount, discount_amount, subtotal, tax_rate_id, tax_rate, taxable_amount, tax_amount, unit_cost)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id
+	`
 	for i := range transaction.Details {
 		detail := &transaction.Details[i]
 		detail.TransactionID = transaction.ID
-		err = tx.QueryRowContext(ctx, detailQuery, detail.TransactionID, detail.ProductID, detail.Quantity, detail.Subtotal).Scan(&detail.ID)
+		err = tx.QueryRowContext(ctx, detailQuery, detail.TransactionID, detail.ProductID, detail.Quantity, detail.GrossAmount, detail.DiscountAmount, detail.Subtotal,
+			detail.TaxRateID, detail.TaxRate, detail.TaxableAmount, detail.TaxAmount, detail.UnitCost).Scan(&detail.ID)
 		if err != nil {
 			return fmt.Errorf("failed to create transaction detail: %w", err)
 		}
+
+		if err := insertDiscounts(ctx, tx, transaction.ID, &detail.ID, detail.Discounts); err != nil {
+			return err
+		}
+	}
+
+	// Drafts take their stock and redeem their vouchers when they are completed
+	if transaction.Status != entities.TransactionStatusDraft {
+		if err := redeemVouchers(ctx, tx, transaction, now); err != nil {
+			return err
+		}
+		if err := takeStock(ctx, tx, transaction, now); err != nil {
+			return err
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -51,14 +84,121 @@ func (r *transactionRepositoryImpl) Create(ctx context.Context, transaction *ent
 	return nil
 }
 
+// redeemVouchers redeems the vouchers applied to a transaction; a voucher used up
+// by a concurrent checkout fails the whole transaction
+func redeemVouchers(ctx context.Context, tx *sql.Tx, transaction *entities.Transaction, at time.Time) error {
+	for _, discount := range transaction.Discounts {
+		if discount.VoucherID == nil {
+			continue
+		}
+		if err := redeemVoucher(ctx, tx, *discount.VoucherID, transaction.ID, transaction.CustomerRef, discount.Amount, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// takeStock takes the quantity sold on each recorded detail of a transaction out
+// of the store's stock, drawing from lots and selling serial numbers. The stock
+// update is conditional so concurrent checkouts cannot oversell.
+func takeStock(ctx context.Context, tx *sql.Tx, transaction *entities.Transaction, at time.Time) error {
+	stockQuery := `UPDATE product_stock SET quantity = quantity - $1, updated_at = $2 WHERE store_id = $3 AND product_id = $4 AND quantity >= $1`
+	lotQuery := `INSERT INTO transaction_detail_lots (transaction_detail_id, lot_id, lot_number, expiry_date, quantity) VALUES ($1, $2, $3, $4, $5)`
+	componentQuery := `INSERT INTO transaction_detail_components (transaction_detail_id, component_id, quantity) VALUES ($1, $2, $3)`
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+
+		// A bundle holds no stock of its own; selling it takes its components out of stock
+		stockLines := []entities.BundleComponent{{ComponentID: detail.ProductID, ComponentName: detail.ProductName, Quantity: 1}}
+		if len(detail.Components) > 0 {
+			stockLines = detail.Components
+		}
+
+		detail.Lots = nil
+		for _, line := range stockLines {
+			quantity := line.Quantity * detail.Quantity
+			result, err := tx.ExecContext(ctx, stockQuery, quantity, at, transaction.StoreID, line.ComponentID)
+			if err != nil {
+				return fmt.Errorf("failed to update product stock: %w", err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get rows affected: %w", err)
+			}
+
+			if rowsAffected == 0 {
+				return fmt.Errorf("insufficient stock for product %s", line.ComponentName)
+			}
+
+			// Draw the sold quantity from the earliest expiring lots and record them on the detail
+			lots, err := consumeLotsFEFO(ctx, tx, transaction.StoreID, line.ComponentID, quantity)
+			if err != nil {
+				return err
+			}
+			detail.Lots = append(detail.Lots, lots...)
+
+			// Remember what a bundle took, so putting it back does not depend on
+			// the bundle's components at that time
+			if len(detail.Components) > 0 {
+				if _, err := tx.ExecContext(ctx, componentQuery, detail.ID, line.ComponentID, quantity); err != nil {
+					return fmt.Errorf("failed to record transaction detail component: %w", err)
+				}
+			}
+		}
+
+		for _, lot := range detail.Lots {
+			if _, err := tx.ExecContext(ctx, lotQuery, detail.ID, lot.LotID, lot.LotNumber, lot.ExpiryDate, lot.Quantity); err != nil {
+				return fmt.Errorf("failed to record transaction detail lot: %w", err)
+			}
+		}
+
+		if err := sellSerials(ctx, tx, transaction.StoreID, detail.ID, detail.ProductID, detail.SerialNumbers, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *transactionRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Transaction, error) {
+	return r.find(ctx, `id = $1`, id)
+}
+
+func (r *transactionRepositoryImpl) FindByStoreAndID(ctx context.Context, storeID, id int) (*entities.Transaction, error) {
+	return r.find(ctx, `id = $1 AND store_id = $2`, id, storeID)
+}
+
+// find retrieves the transaction matching the filter with its details, or nil if none does
+func (r *transactionRepositoryImpl) find(ctx context.Context, filter string, args ...interface{}) (*entities.Transaction, error) {
 	// Get transaction
-	query := `SELECT id, total_amount, created_at FROM transactions WHERE id = $1`
+	query := `SELECT id, store_id, COALESCE(customer_ref, ''), gross_amount, discount_amount, service_charge, tax_mode, tax_amount, rounding_amount, total_amount, paid_amount, change_amount, COALESCE(payment_method, ''), status, created_at, expires_at,
+		completed_at, failed_at, expired_at, voided_at, refunded_at, COALESCE(void_reason, ''), COALESCE(refund_reason, '') FROM transactions WHERE ` + filter
 	var transaction entities.Transaction
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&transaction.ID,
+		&transaction.StoreID,
+		&transaction.CustomerRef,
+		&transaction.GrossAmount,
+		&transaction.DiscountAmount,
+		&transaction.ServiceCharge,
+		&transaction.TaxMode,
+		&transaction.TaxAmount,
+		&transaction.RoundingAmount,
 		&transaction.TotalAmount,
+		&transaction.PaidAmount,
+		&transaction.ChangeAmount,
+		&transaction.PaymentMethod,
+		&transaction.Status,
 		&transaction.CreatedAt,
+		&transaction.ExpiresAt,
+		&transaction.CompletedAt,
+		&transaction.FailedAt,
+		&transaction.ExpiredAt,
+		&transaction.VoidedAt,
+		&transaction.RefundedAt,
+		&transaction.VoidReason,
+		&transaction.RefundReason,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -69,13 +209,14 @@ func (r *transactionRepositoryImpl) FindByID(ctx context.Context, id int) (*enti
 
 	// Get transaction details with product names
 	detailQuery := `
-		SELECT td.id, td.transaction_id, td.product_id, p.name, td.quantity, td.subtotal
+		SELECT td.id, td.transaction_id, td.product_id, p.name, td.quantity, COALESCE(p.unit, 'pcs'), td.gross_amount, td.discount_amount, td.subtotal,
+			td.tax_rate_id, td.tax_rate::float8, td.taxable_amount, td.tax_amount, td.unit_cost
 		FROM transaction_details td
 		LEFT JOIN products p ON td.product_id = p.id
 		WHERE td.transaction_id = $1
 		ORDER BY td.id
 	`
-	rows, err := r.db.QueryContext(ctx, detailQuery, id)
+	rows, err := r.db.QueryContext(ctx, detailQuery, transaction.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transaction details: %w", err)
 	}
@@ -90,7 +231,15 @@ func (r *transactionRepositoryImpl) FindByID(ctx context.Context, id int) (*enti
 			&detail.ProductID,
 			&detail.ProductName,
 			&detail.Quantity,
+			&detail.Unit,
+			&detail.GrossAmount,
+			&detail.DiscountAmount,
 			&detail.Subtotal,
+			&detail.TaxRateID,
+			&detail.TaxRate,
+			&detail.TaxableAmount,
+			&detail.TaxAmount,
+			&detail.UnitCost,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction detail: %w", err)
@@ -102,13 +251,34 @@ func (r *transactionRepositoryImpl) FindByID(ctx context.Context, id int) (*enti
 		return nil, fmt.Errorf("error iterating transaction details: %w", err)
 	}
 
+	if err := r.attachDetailLots(ctx, transaction.ID, details); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachDetailSerials(ctx, transaction.ID, details); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachDiscounts(ctx, &transaction, details); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachTaxes(ctx, &transaction); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachPayments(ctx, &transaction); err != nil {
+		return nil, err
+	}
+
 	transaction.Details = details
 	return &transaction, nil
 }
 
 func (r *transactionRepositoryImpl) FindAll(ctx context.Context) ([]entities.Transaction, error) {
 	// Get all transactions
-	query := `SELECT id, total_amount, created_at FROM transactions ORDER BY created_at DESC`
+	query := `SELECT id, store_id, COALESCE(customer_ref, ''), gross_amount, discount_amount, service_charge, tax_mode, tax_amount, rounding_amount, total_amount, paid_amount, change_amount, COALESCE(payment_method, ''), status, created_at, expires_at,
+		completed_at, failed_at, expired_at, voided_at, refunded_at, COALESCE(void_reason, ''), COALESCE(refund_reason, '') FROM transactions ORDER BY created_at DESC`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query transactions: %w", err)
@@ -120,8 +290,28 @@ func (r *transactionRepositoryImpl) FindAll(ctx context.Context) ([]entities.Tra
 		var transaction entities.Transaction
 		err := rows.Scan(
 			&transaction.ID,
+			&transaction.StoreID,
+			&transaction.CustomerRef,
+			&transaction.GrossAmount,
+			&transaction.DiscountAmount,
+			&transaction.ServiceCharge,
+			&transaction.TaxMode,
+			&transaction.TaxAmount,
+			&transaction.RoundingAmount,
 			&transaction.TotalAmount,
+			&transaction.PaidAmount,
+			&transaction.ChangeAmount,
+			&transaction.PaymentMethod,
+			&transaction.Status,
 			&transaction.CreatedAt,
+			&transaction.ExpiresAt,
+			&transaction.CompletedAt,
+			&transaction.FailedAt,
+			&transaction.ExpiredAt,
+			&transaction.VoidedAt,
+			&transaction.RefundedAt,
+			&transaction.VoidReason,
+			&transaction.RefundReason,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
@@ -136,7 +326,8 @@ func (r *transactionRepositoryImpl) FindAll(ctx context.Context) ([]entities.Tra
 	// Get details for all transactions
 	for i := range transactions {
 		detailQuery := `
-			SELECT td.id, td.transaction_id, td.product_id, p.name, td.quantity, td.subtotal
+			SELECT td.id, td.transaction_id, td.product_id, p.name, td.quantity, COALESCE(p.unit, 'pcs'), td.gross_amount, td.discount_amount, td.subtotal,
+				td.tax_rate_id, td.tax_rate::float8, td.taxable_amount, td.tax_amount, td.unit_cost
 			FROM transaction_details td
 			LEFT JOIN products p ON td.product_id = p.id
 			WHERE td.transaction_id = $1
@@ -156,7 +347,15 @@ func (r *transactionRepositoryImpl) FindAll(ctx context.Context) ([]entities.Tra
 				&detail.ProductID,
 				&detail.ProductName,
 				&detail.Quantity,
+				&detail.Unit,
+				&detail.GrossAmount,
+				&detail.DiscountAmount,
 				&detail.Subtotal,
+				&detail.TaxRateID,
+				&detail.TaxRate,
+				&detail.TaxableAmount,
+				&detail.TaxAmount,
+				&detail.UnitCost,
 			)
 			if err != nil {
 				detailRows.Close()
@@ -170,63 +369,720 @@ func (r *transactionRepositoryImpl) FindAll(ctx context.Context) ([]entities.Tra
 			return nil, fmt.Errorf("error iterating transaction details: %w", err)
 		}
 
+		if err := r.attachDetailLots(ctx, transactions[i].ID, details); err != nil {
+			return nil, err
+		}
+
+		if err := r.attachDetailSerials(ctx, transactions[i].ID, details); err != nil {
+			return nil, err
+		}
+
+		if err := r.attachDiscounts(ctx, &transactions[i], details); err != nil {
+			return nil, err
+		}
+
+		if err := r.attachTaxes(ctx, &transactions[i]); err != nil {
+			return nil, err
+		}
+
+		if err := r.attachPayments(ctx, &transactions[i]); err != nil {
+			return nil, err
+		}
+
 		transactions[i].Details = details
 	}
 
 	return transactions, nil
 }
 
+// attachDetailLots loads the lots each detail of a transaction drew from
+func (r *transactionRepositoryImpl) attachDetailLots(ctx context.Context, transactionID int, details []entities.TransactionDetail) error {
+	query := `
+		SELECT tdl.transaction_detail_id, COALESCE(tdl.lot_id, 0), tdl.lot_number, tdl.expiry_date, tdl.quantity
+		FROM transaction_detail_lots tdl
+		JOIN transaction_details td ON tdl.transaction_detail_id = td.id
+		WHERE td.transaction_id = $1
+		ORDER BY tdl.id
+	`
+	rows, err := r.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to query transaction detail lots: %w", err)
+	}
+	defer rows.Close()
+
+	lotsByDetail := make(map[int][]entities.LotAllocation)
+	for rows.Next() {
+		var detailID int
+		var lot entities.LotAllocation
+		if err := rows.Scan(&detailID, &lot.LotID, &lot.LotNumber, &lot.ExpiryDate, &lot.Quantity); err != nil {
+			return fmt.Errorf("failed to scan transaction detail lot: %w", err)
+		}
+		lotsByDetail[detailID] = append(lotsByDetail[detailID], lot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transaction detail lots: %w", err)
+	}
+
+	for i := range details {
+		details[i].Lots = lotsByDetail[details[i].ID]
+	}
+
+	return nil
+}
+
+// attachDetailSerials loads the serial numbers sold on each detail of a transaction
+func (r *transactionRepositoryImpl) attachDetailSerials(ctx context.Context, transactionID int, details []entities.TransactionDetail) error {
+	query := `
+		SELECT s.transaction_detail_id, s.serial_number
+		FROM product_serials s
+		JOIN transaction_details td ON s.transaction_detail_id = td.id
+		WHERE td.transaction_id = $1
+		ORDER BY s.serial_number
+	`
+	rows, err := r.db.QueryContext(ctx, query, transactionID)
+	if err != nil {
+		return fmt.Errorf("failed to query transaction detail serials: %w", err)
+	}
+	defer rows.Close()
+
+	serialsByDetail := make(map[int][]string)
+	for rows.Next() {
+		var detailID int
+		var serialNumber string
+		if err := rows.Scan(&detailID, &serialNumber); err != nil {
+			return fmt.Errorf("failed to scan transaction detail serial: %w", err)
+		}
+		serialsByDetail[detailID] = append(serialsByDetail[detailID], serialNumber)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transaction detail serials: %w", err)
+	}
+
+	for i := range details {
+		details[i].SerialNumbers = serialsByDetail[details[i].ID]
+	}
+
+	return nil
+}
+
+// attachDiscounts loads the discounts applied to a transaction, putting line
+// discounts on their details and cart discounts on the transaction
+func (r *transactionRepositoryImpl) attachDiscounts(ctx context.Context, transaction *entities.Transaction, details []entities.TransactionDetail) error {
+	query := `
+		SELECT id, transaction_id, transaction_detail_id, voucher_id, source, description, amount
+		FROM transaction_discounts
+		WHERE transaction_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, transaction.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query transaction discounts: %w", err)
+	}
+	defer rows.Close()
+
+	discountsByDetail := make(map[int][]entities.AppliedDiscount)
+	transaction.Discounts = nil
+	for rows.Next() {
+		var discount entities.AppliedDiscount
+		if err := rows.Scan(&discount.ID, &discount.TransactionID, &discount.TransactionDetailID, &discount.VoucherID, &discount.Source, &discount.Description, &discount.Amount); err != nil {
+			return fmt.Errorf("failed to scan transaction discount: %w", err)
+		}
+		if discount.TransactionDetailID == nil {
+			transaction.Discounts = append(transaction.Discounts, discount)
+			continue
+		}
+		discountsByDetail[*discount.TransactionDetailID] = append(discountsByDetail[*discount.TransactionDetailID], discount)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transaction discounts: %w", err)
+	}
+
+	for i := range details {
+		details[i].Discounts = discountsByDetail[details[i].ID]
+	}
+
+	return nil
+}
+
+// insertDiscounts records the discounts applied to a transaction, or to one of
+// its details when detailID is set
+func insertDiscounts(ctx context.Context, tx *sql.Tx, transactionID int, detailID *int, discounts []entities.AppliedDiscount) error {
+	query := `INSERT INTO transaction_discounts (transaction_id, transaction_detail_id, voucher_id, source, description, amount) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	for i := range discounts {
+		discount := &discounts[i]
+		discount.TransactionID = transactionID
+		discount.TransactionDetailID = detailID
+		err := tx.QueryRowContext(ctx, query, discount.TransactionID, discount.TransactionDetailID, discount.VoucherID, discount.Source, discount.Description, discount.Amount).Scan(&discount.ID)
+		if err != nil {
+			return fmt.Errorf("failed to record transaction discount: %w", err)
+		}
+	}
+	return nil
+}
+
+// attachTaxes loads the tax breakdown of a transaction
+func (r *transactionRepositoryImpl) attachTaxes(ctx context.Context, transaction *entities.Transaction) error {
+	query := `
+		SELECT id, transaction_id, tax_rate_id, name, rate::float8, taxable_amount, tax_amount
+		FROM transaction_taxes
+		WHERE transaction_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, transaction.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query transaction taxes: %w", err)
+	}
+	defer rows.Close()
+
+	transaction.Taxes = nil
+	for rows.Next() {
+		var tax entities.TransactionTax
+		if err := rows.Scan(&tax.ID, &tax.TransactionID, &tax.TaxRateID, &tax.Name, &tax.Rate, &tax.TaxableAmount, &tax.TaxAmount); err != nil {
+			return fmt.Errorf("failed to scan transaction tax: %w", err)
+		}
+		transaction.Taxes = append(transaction.Taxes, tax)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transaction taxes: %w", err)
+	}
+
+	return nil
+}
+
+// insertTaxes records the tax charged per rate on a transaction
+func insertTaxes(ctx context.Context, tx *sql.Tx, transactionID int, taxes []entities.TransactionTax) error {
+	query := `INSERT INTO transaction_taxes (transaction_id, tax_rate_id, name, rate, taxable_amount, tax_amount) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	for i := range taxes {
+		tax := &taxes[i]
+		tax.TransactionID = transactionID
+		err := tx.QueryRowContext(ctx, query, tax.TransactionID, tax.TaxRateID, tax.Name, tax.Rate, tax.TaxableAmount, tax.TaxAmount).Scan(&tax.ID)
+		if err != nil {
+			return fmt.Errorf("failed to record transaction tax: %w", err)
+		}
+	}
+	return nil
+}
+
+// attachPayments loads the payments of a transaction
+func (r *transactionRepositoryImpl) attachPayments(ctx context.Context, transaction *entities.Transaction) error {
+	query := `
+		SELECT id, transaction_id, method, amount, change_amount, COALESCE(reference, ''), status,
+			COALESCE(provider, ''), COALESCE(provider_ref, ''), COALESCE(checkout_url, ''), resolved_at
+		FROM transaction_payments
+		WHERE transaction_id = $1
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, transaction.ID)
+	if err != nil {
+		return fmt.Errorf("failed to query transaction payments: %w", err)
+	}
+	defer rows.Close()
+
+	transaction.Payments = nil
+	for rows.Next() {
+		var payment entities.TransactionPayment
+		if err := rows.Scan(&payment.ID, &payment.TransactionID, &payment.Method, &payment.Amount, &payment.ChangeAmount, &payment.Reference, &payment.Status,
+			&payment.Provider, &payment.ProviderRef, &payment.CheckoutURL, &payment.ResolvedAt); err != nil {
+			return fmt.Errorf("failed to scan transaction payment: %w", err)
+		}
+		transaction.Payments = append(transaction.Payments, payment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating transaction payments: %w", err)
+	}
+
+	return nil
+}
+
+// insertPayments records the payments of a transaction
+func insertPayments(ctx context.Context, tx *sql.Tx, transactionID int, payments []entities.TransactionPayment) error {
+	query := `INSERT INTO transaction_payments (transaction_id, method, amount, change_amount, reference, status, provider) VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, '')) RETURNING id`
+	for i := range payments {
+		payment := &payments[i]
+		payment.TransactionID = transactionID
+		err := tx.QueryRowContext(ctx, query, payment.TransactionID, payment.Method, payment.Amount, payment.ChangeAmount, payment.Reference, payment.Status, payment.Provider).Scan(&payment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to record transaction payment: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *transactionRepositoryImpl) SetPaymentCharge(ctx context.Context, paymentID int, providerRef, checkoutURL string) error {
+	query := `UPDATE transaction_payments SET provider_ref = $1, checkout_url = NULLIF($2, '') WHERE id = $3 AND status = $4`
+	result, err := r.db.ExecContext(ctx, query, providerRef, checkoutURL, paymentID, entities.PaymentStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to record payment charge: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("pending payment with id %d not found", paymentID)
+	}
+
+	return nil
+}
+
+func (r *transactionRepositoryImpl) FindPaymentByProviderRef(ctx context.Context, provider, providerRef string) (*entities.TransactionPayment, error) {
+	var payment entities.TransactionPayment
+	query := `SELECT id, transaction_id, method, amount, status FROM transaction_payments WHERE provider = $1 AND provider_ref = $2`
+	err := r.db.QueryRowContext(ctx, query, provider, providerRef).Scan(&payment.ID, &payment.TransactionID, &payment.Method, &payment.Amount, &payment.Status)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (r *transactionRepositoryImpl) ResolvePayment(ctx context.Context, provider, providerRef string, paid bool, at time.Time) (*entities.TransactionPayment, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var payment entities.TransactionPayment
+	query := `SELECT id, transaction_id, method, amount, status FROM transaction_payments WHERE provider = $1 AND provider_ref = $2`
+	err = tx.QueryRowContext(ctx, query, provider, providerRef).Scan(&payment.ID, &payment.TransactionID, &payment.Method, &payment.Amount, &payment.Status)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+
+	// Lock the transaction so a payment resolved at the same time, or the transaction
+	// expiring, cannot complete or release it twice
+	var status string
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM transactions WHERE id = $1 FOR UPDATE`, payment.TransactionID).Scan(&status); err != nil {
+		return nil, fmt.Errorf("failed to lock transaction: %w", err)
+	}
+
+	// Gateways may deliver a webhook more than once; only the first one counts
+	if err := tx.QueryRowContext(ctx, `SELECT status FROM transaction_payments WHERE id = $1`, payment.ID).Scan(&payment.Status); err != nil {
+		return nil, fmt.Errorf("failed to find payment: %w", err)
+	}
+	if payment.Status != entities.PaymentStatusPending && payment.Status != entities.PaymentStatusExpired && payment.Status != entities.PaymentStatusCancelled {
+		return &payment, nil
+	}
+
+	// A payment that arrives after its transaction expired or was voided is still
+	// recorded as paid, so it can be refunded through the gateway
+	payment.Status = entities.PaymentStatusFailed
+	if paid {
+		payment.Status = entities.PaymentStatusPaid
+	}
+	payment.ResolvedAt = &at
+	updateQuery := `UPDATE transaction_payments SET status = $1, resolved_at = $2 WHERE id = $3`
+	if _, err := tx.ExecContext(ctx, updateQuery, payment.Status, at, payment.ID); err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	if status == entities.TransactionStatusPending {
+		if !paid {
+			if err := releasePending(ctx, tx, payment.TransactionID, entities.TransactionStatusFailed, at); err != nil {
+				return nil, err
+			}
+		} else {
+			// The transaction completes once none of its payments is pending any more
+			completeQuery := `
+				UPDATE transactions SET status = $1, completed_at = $2, expires_at = NULL
+				WHERE id = $3 AND NOT EXISTS (SELECT 1 FROM transaction_payments WHERE transaction_id = $3 AND status = $4)
+			`
+			if _, err := tx.ExecContext(ctx, completeQuery, entities.TransactionStatusCompleted, at, payment.TransactionID, entities.PaymentStatusPending); err != nil {
+				return nil, fmt.Errorf("failed to complete transaction: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &payment, nil
+}
+
+func (r *transactionRepositoryImpl) ReleasePending(ctx context.Context, id int, status string, at time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM transactions WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+	if err == sql.ErrNoRows {
+		return false, fmt.Errorf("transaction with id %d not found", id)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to lock transaction: %w", err)
+	}
+
+	// A webhook may have completed or failed the transaction in the meantime
+	if current != entities.TransactionStatusPending {
+		return false, nil
+	}
+
+	if err := releasePending(ctx, tx, id, status, at); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *transactionRepositoryImpl) Complete(ctx context.Context, transaction *entities.Transaction) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if err := updateTransactionStatus(ctx, tx, transaction.ID, entities.TransactionStatusDraft, transaction.Status, now); err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE transactions SET rounding_amount = $1, total_amount = $2, paid_amount = $3, change_amount = $4, payment_method = $5, expires_at = $6
+		WHERE id = $7
+	`
+	_, err = tx.ExecContext(ctx, query, transaction.RoundingAmount, transaction.TotalAmount, transaction.PaidAmount, transaction.ChangeAmount, transaction.PaymentMethod,
+		transaction.ExpiresAt, transaction.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	if err := insertPayments(ctx, tx, transaction.ID, transaction.Payments); err != nil {
+		return err
+	}
+
+	if err := redeemVouchers(ctx, tx, transaction, now); err != nil {
+		return err
+	}
+
+	if err := takeStock(ctx, tx, transaction, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if transaction.Status == entities.TransactionStatusCompleted {
+		transaction.CompletedAt = &now
+	}
+
+	return nil
+}
+
+func (r *transactionRepositoryImpl) Void(ctx context.Context, id int, reason string) error {
+	return r.cancel(ctx, id, entities.TransactionStatusVoided, "void_reason", reason)
+}
+
+func (r *transactionRepositoryImpl) Refund(ctx context.Context, id int, reason string) error {
+	return r.cancel(ctx, id, entities.TransactionStatusRefunded, "refund_reason", reason)
+}
+
+// cancel voids or refunds a transaction: it puts back the stock the transaction
+// took, refunds its paid payments, cancels its pending ones and records why
+func (r *transactionRepositoryImpl) cancel(ctx context.Context, id int, status, reasonColumn, reason string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the transaction and check the transition against its current status
+	transaction := entities.Transaction{ID: id}
+	err = tx.QueryRowContext(ctx, `SELECT status, created_at FROM transactions WHERE id = $1 FOR UPDATE`, id).Scan(&transaction.Status, &transaction.CreatedAt)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("transaction with id %d not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock transaction: %w", err)
+	}
+
+	now := time.Now()
+	if err := transaction.CheckTransition(status, now); err != nil {
+		return err
+	}
+
+	if transaction.HoldsStock() {
+		if err := restock(ctx, tx, id, now); err != nil {
+			return err
+		}
+	}
+
+	paymentQuery := `
+		UPDATE transaction_payments SET status = CASE status WHEN $1 THEN $2 ELSE $3 END, resolved_at = $4
+		WHERE transaction_id = $5 AND status IN ($1, $6)
+	`
+	_, err = tx.ExecContext(ctx, paymentQuery, entities.PaymentStatusPaid, entities.PaymentStatusRefunded, entities.PaymentStatusCancelled, now, id, entities.PaymentStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction payments: %w", err)
+	}
+
+	if err := updateTransactionStatus(ctx, tx, id, transaction.Status, status, now); err != nil {
+		return err
+	}
+
+	reasonQuery := fmt.Sprintf(`UPDATE transactions SET %s = $1, expires_at = NULL WHERE id = $2`, reasonColumn)
+	if _, err := tx.ExecContext(ctx, reasonQuery, reason, id); err != nil {
+		return fmt.Errorf("failed to record %s: %w", reasonColumn, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// transactionStatusTimestamps names the column stamped when a transaction enters each status
+var transactionStatusTimestamps = map[string]string{
+	entities.TransactionStatusCompleted: "completed_at",
+	entities.TransactionStatusFailed:    "failed_at",
+	entities.TransactionStatusExpired:   "expired_at",
+	entities.TransactionStatusVoided:    "voided_at",
+	entities.TransactionStatusRefunded:  "refunded_at",
+}
+
+// updateTransactionStatus moves a transaction from one status to the next and stamps
+// the matching timestamp column. It fails if the move is not allowed or the transaction
+// is no longer in the expected status, such as when a webhook got to it first.
+func updateTransactionStatus(ctx context.Context, tx *sql.Tx, id int, from, to string, at time.Time) error {
+	if !entities.CanTransition(from, to) {
+		return fmt.Errorf("transaction %d is %s and cannot become %s", id, from, to)
+	}
+
+	// A draft becoming pending keeps its created_at; every other move is stamped
+	query := `UPDATE transactions SET status = $1 WHERE id = $2 AND status = $3`
+	args := []interface{}{to, id, from}
+	if column, ok := transactionStatusTimestamps[to]; ok {
+		query = fmt.Sprintf(`UPDATE transactions SET status = $1, %s = $4 WHERE id = $2 AND status = $3`, column)
+		args = append(args, at)
+	}
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update transaction status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction %d is no longer %s", id, from)
+	}
+
+	return nil
+}
+
+func (r *transactionRepositoryImpl) FindExpiredPending(ctx context.Context, at time.Time) ([]int, error) {
+	query := `SELECT id FROM transactions WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at`
+	rows, err := r.db.QueryContext(ctx, query, entities.TransactionStatusPending, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired pending transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan transaction id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expired pending transactions: %w", err)
+	}
+
+	return ids, nil
+}
+
+// releasePending ends a pending transaction that will not be paid with the given
+// status, failed or expired, marks its outstanding payments the same way and
+// puts back everything its checkout took: the stock, the lots drawn from, the
+// serial numbers sold and the vouchers redeemed. The transaction must be locked.
+func releasePending(ctx context.Context, tx *sql.Tx, transactionID int, status string, at time.Time) error {
+	if err := updateTransactionStatus(ctx, tx, transactionID, entities.TransactionStatusPending, status, at); err != nil {
+		return err
+	}
+
+	paymentStatus := entities.PaymentStatusFailed
+	if status == entities.TransactionStatusExpired {
+		paymentStatus = entities.PaymentStatusExpired
+	}
+	paymentQuery := `UPDATE transaction_payments SET status = $1, resolved_at = $2 WHERE transaction_id = $3 AND status = $4`
+	if _, err := tx.ExecContext(ctx, paymentQuery, paymentStatus, at, transactionID, entities.PaymentStatusPending); err != nil {
+		return fmt.Errorf("failed to update transaction payments: %w", err)
+	}
+
+	return restock(ctx, tx, transactionID, at)
+}
+
+// restock puts the stock taken by a transaction back into its store. Bundles
+// are put back as the components recorded when they were sold.
+func restock(ctx context.Context, tx *sql.Tx, transactionID int, at time.Time) error {
+	stockQuery := `
+		UPDATE product_stock ps SET quantity = ps.quantity + s.quantity, updated_at = $2
+		FROM (
+			SELECT t.store_id, COALESCE(tdc.component_id, td.product_id) AS product_id, SUM(COALESCE(tdc.quantity, td.quantity)) AS quantity
+			FROM transaction_details td
+			JOIN transactions t ON td.transaction_id = t.id
+			LEFT JOIN transaction_detail_components tdc ON tdc.transaction_detail_id = td.id
+			WHERE td.transaction_id = $1
+			GROUP BY t.store_id, COALESCE(tdc.component_id, td.product_id)
+		) s
+		WHERE ps.store_id = s.store_id AND ps.product_id = s.product_id
+	`
+	if _, err := tx.ExecContext(ctx, stockQuery, transactionID, at); err != nil {
+		return fmt.Errorf("failed to restore product stock: %w", err)
+	}
+
+	lotQuery := `
+		UPDATE product_lots l SET quantity = l.quantity + s.quantity
+		FROM (
+			SELECT tdl.lot_id, SUM(tdl.quantity) AS quantity
+			FROM transaction_detail_lots tdl
+			JOIN transaction_details td ON tdl.transaction_detail_id = td.id
+			WHERE td.transaction_id = $1 AND tdl.lot_id IS NOT NULL
+			GROUP BY tdl.lot_id
+		) s
+		WHERE l.id = s.lot_id
+	`
+	if _, err := tx.ExecContext(ctx, lotQuery, transactionID); err != nil {
+		return fmt.Errorf("failed to restore product lots: %w", err)
+	}
+
+	serialQuery := `
+		UPDATE product_serials SET status = $1, transaction_detail_id = NULL, sold_at = NULL
+		WHERE transaction_detail_id IN (SELECT id FROM transaction_details WHERE transaction_id = $2)
+	`
+	if _, err := tx.ExecContext(ctx, serialQuery, entities.SerialStatusInStock, transactionID); err != nil {
+		return fmt.Errorf("failed to restore serial numbers: %w", err)
+	}
+
+	voucherQuery := `
+		UPDATE vouchers v SET times_redeemed = v.times_redeemed - r.uses
+		FROM (SELECT voucher_id, COUNT(*) AS uses FROM voucher_redemptions WHERE transaction_id = $1 GROUP BY voucher_id) r
+		WHERE v.id = r.voucher_id
+	`
+	if _, err := tx.ExecContext(ctx, voucherQuery, transactionID); err != nil {
+		return fmt.Errorf("failed to restore vouchers: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM voucher_redemptions WHERE transaction_id = $1`, transactionID); err != nil {
+		return fmt.Errorf("failed to remove voucher redemptions: %w", err)
+	}
+
+	return nil
+}
+
 func (r *transactionRepositoryImpl) CreateDetail(ctx context.Context, detail *entities.TransactionDetail) error {
-	query := `INSERT INTO transaction_details (transaction_id, product_id, quantity, subtotal) VALUES ($1, $2, $3, $4) RETURNING id`
-	err := r.db.QueryRowContext(ctx, query, detail.TransactionID, detail.ProductID, detail.Quantity, detail.Subtotal).Scan(&detail.ID)
+	query := `
+		INSERT INTO transaction_details (transaction_id, product_id, quantity, gross_amount, discount_amount, subtotal, tax_rate_id, tax_rate, taxable_amount, tax_amount, unit_cost)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id
+	`
+	err := r.db.QueryRowContext(ctx, query, detail.TransactionID, detail.ProductID, detail.Quantity, detail.GrossAmount, detail.DiscountAmount, detail.Subtotal,
+		detail.TaxRateID, detail.TaxRate, detail.TaxableAmount, detail.TaxAmount, detail.UnitCost).Scan(&detail.ID)
 	if err != nil {
 		return fmt.Errorf("failed to create transaction detail: %w", err)
 	}
 	return nil
 }
 
-func (r *transactionRepositoryImpl) GetTodayRevenue(ctx context.Context) (int, error) {
+// salesLedger books every sale on the day it completed and takes a refunded
+// sale off again on the day it was refunded, as the rows t with the sign of
+// the entry and the booked_at time it counts at. Reports filter on booked_at, so
+// a refund never changes the figures of a period already reported. Drafts,
+// pending, failed, expired and voided transactions are never booked.
+const salesLedger = `(
+		SELECT transactions.*, 1 AS sign, completed_at AS booked_at
+		FROM transactions WHERE status IN ('completed', 'refunded') AND completed_at IS NOT NULL
+		UNION ALL
+		SELECT transactions.*, -1 AS sign, refunded_at AS booked_at
+		FROM transactions WHERE status = 'refunded'
+	) t`
+
+func (r *transactionRepositoryImpl) GetTodayRevenue(ctx context.Context, storeID int) (int, error) {
 	query := `
-		SELECT COALESCE(SUM(total_amount), 0) 
-		FROM transactions 
-		WHERE DATE(created_at) = CURRENT_DATE
+		SELECT COALESCE(SUM(t.sign * t.total_amount), 0)
+		FROM ` + salesLedger + `
+		WHERE DATE(t.booked_at) = CURRENT_DATE AND t.store_id = $1
 	`
 	var totalRevenue int
-	err := r.db.QueryRowContext(ctx, query).Scan(&totalRevenue)
+	err := r.db.QueryRowContext(ctx, query, storeID).Scan(&totalRevenue)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get today's revenue: %w", err)
 	}
 	return totalRevenue, nil
 }
 
-func (r *transactionRepositoryImpl) GetTodayTransactionCount(ctx context.Context) (int, error) {
+func (r *transactionRepositoryImpl) GetTodayTransactionCount(ctx context.Context, storeID int) (int, error) {
 	query := `
-		SELECT COUNT(*) 
-		FROM transactions 
-		WHERE DATE(created_at) = CURRENT_DATE
+		SELECT COALESCE(SUM(t.sign), 0)
+		FROM ` + salesLedger + `
+		WHERE DATE(t.booked_at) = CURRENT_DATE AND t.store_id = $1
 	`
 	var count int
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, storeID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get today's transaction count: %w", err)
 	}
 	return count, nil
 }
 
-func (r *transactionRepositoryImpl) GetTodayBestSellingProduct(ctx context.Context) (string, int, error) {
+func (r *transactionRepositoryImpl) GetTodayCostOfGoodsSold(ctx context.Context, storeID int) (int, error) {
+	query := `
+		SELECT COALESCE(ROUND(SUM(t.sign * td.unit_cost * td.quantity)), 0)
+		FROM transaction_details td
+		JOIN ` + salesLedger + ` ON td.transaction_id = t.id
+		WHERE DATE(t.booked_at) = CURRENT_DATE AND t.store_id = $1
+	`
+	var totalCost int
+	err := r.db.QueryRowContext(ctx, query, storeID).Scan(&totalCost)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get today's cost of goods sold: %w", err)
+	}
+	return totalCost, nil
+}
+
+func (r *transactionRepositoryImpl) GetTodayBestSellingProduct(ctx context.Context, storeID int) (string, float64, error) {
 	query := `
-		SELECT p.name, SUM(td.quantity) as total_qty
+		SELECT p.name, SUM(t.sign * td.quantity) as total_qty
 		FROM transaction_details td
-		JOIN transactions t ON td.transaction_id = t.id
+		JOIN ` + salesLedger + ` ON td.transaction_id = t.id
 		JOIN products p ON td.product_id = p.id
-		WHERE DATE(t.created_at) = CURRENT_DATE
+		WHERE DATE(t.booked_at) = CURRENT_DATE AND t.store_id = $1
 		GROUP BY p.id, p.name
+		HAVING SUM(t.sign * td.quantity) > 0
 		ORDER BY total_qty DESC
 		LIMIT 1
 	`
 	var productName string
-	var qtySold int
-	err := r.db.QueryRowContext(ctx, query).Scan(&productName, &qtySold)
+	var qtySold float64
+	err := r.db.QueryRowContext(ctx, query, storeID).Scan(&productName, &qtySold)
 	if err == sql.ErrNoRows {
 		return "", 0, nil
 	}
@@ -236,48 +1092,64 @@ func (r *transactionRepositoryImpl) GetTodayBestSellingProduct(ctx context.Conte
 	return productName, qtySold, nil
 }
 
-func (r *transactionRepositoryImpl) GetDateRangeRevenue(ctx context.Context, startDate, endDate string) (int, error) {
+func (r *transactionRepositoryImpl) GetDateRangeRevenue(ctx context.Context, storeID int, startDate, endDate string) (int, error) {
 	query := `
-		SELECT COALESCE(SUM(total_amount), 0) 
-		FROM transactions 
-		WHERE DATE(created_at) >= $1 AND DATE(created_at) <= $2
+		SELECT COALESCE(SUM(t.sign * t.total_amount), 0)
+		FROM ` + salesLedger + `
+		WHERE DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3
 	`
 	var totalRevenue int
-	err := r.db.QueryRowContext(ctx, query, startDate, endDate).Scan(&totalRevenue)
+	err := r.db.QueryRowContext(ctx, query, startDate, endDate, storeID).Scan(&totalRevenue)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get date range revenue: %w", err)
 	}
 	return totalRevenue, nil
 }
 
-func (r *transactionRepositoryImpl) GetDateRangeTransactionCount(ctx context.Context, startDate, endDate string) (int, error) {
+func (r *transactionRepositoryImpl) GetDateRangeTransactionCount(ctx context.Context, storeID int, startDate, endDate string) (int, error) {
 	query := `
-		SELECT COUNT(*) 
-		FROM transactions 
-		WHERE DATE(created_at) >= $1 AND DATE(created_at) <= $2
+		SELECT COALESCE(SUM(t.sign), 0)
+		FROM ` + salesLedger + `
+		WHERE DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3
 	`
 	var count int
-	err := r.db.QueryRowContext(ctx, query, startDate, endDate).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, startDate, endDate, storeID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get date range transaction count: %w", err)
 	}
 	return count, nil
 }
 
-func (r *transactionRepositoryImpl) GetDateRangeBestSellingProduct(ctx context.Context, startDate, endDate string) (string, int, error) {
+func (r *transactionRepositoryImpl) GetDateRangeCostOfGoodsSold(ctx context.Context, storeID int, startDate, endDate string) (int, error) {
+	query := `
+		SELECT COALESCE(ROUND(SUM(t.sign * td.unit_cost * td.quantity)), 0)
+		FROM transaction_details td
+		JOIN ` + salesLedger + ` ON td.transaction_id = t.id
+		WHERE DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3
+	`
+	var totalCost int
+	err := r.db.QueryRowContext(ctx, query, startDate, endDate, storeID).Scan(&totalCost)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get date range cost of goods sold: %w", err)
+	}
+	return totalCost, nil
+}
+
+func (r *transactionRepositoryImpl) GetDateRangeBestSellingProduct(ctx context.Context, storeID int, startDate, endDate string) (string, float64, error) {
 	query := `
-		SELECT p.name, SUM(td.quantity) as total_qty
+		SELECT p.name, SUM(t.sign * td.quantity) as total_qty
 		FROM transaction_details td
-		JOIN transactions t ON td.transaction_id = t.id
+		JOIN ` + salesLedger + ` ON td.transaction_id = t.id
 		JOIN products p ON td.product_id = p.id
-		WHERE DATE(t.created_at) >= $1 AND DATE(t.created_at) <= $2
+		WHERE DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3
 		GROUP BY p.id, p.name
+		HAVING SUM(t.sign * td.quantity) > 0
 		ORDER BY total_qty DESC
 		LIMIT 1
 	`
 	var productName string
-	var qtySold int
-	err := r.db.QueryRowContext(ctx, query, startDate, endDate).Scan(&productName, &qtySold)
+	var qtySold float64
+	err := r.db.QueryRowContext(ctx, query, startDate, endDate, storeID).Scan(&productName, &qtySold)
 	if err == sql.ErrNoRows {
 		return "", 0, nil
 	}
@@ -286,3 +1158,177 @@ func (r *transactionRepositoryImpl) GetDateRangeBestSellingProduct(ctx context.C
 	}
 	return productName, qtySold, nil
 }
+
+func (r *transactionRepositoryImpl) GetTodayCategorySales(ctx context.Context, storeID int) ([]entities.CategorySales, error) {
+	sales, err := r.queryCategorySales(ctx, `DATE(t.booked_at) = CURRENT_DATE AND t.store_id = $1`, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get today's category sales: %w", err)
+	}
+	return sales, nil
+}
+
+func (r *transactionRepositoryImpl) GetDateRangeCategorySales(ctx context.Context, storeID int, startDate, endDate string) ([]entities.CategorySales, error) {
+	sales, err := r.queryCategorySales(ctx, `DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3`, startDate, endDate, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range category sales: %w", err)
+	}
+	return sales, nil
+}
+
+// queryCategorySales sums the transaction details booked in the sales ledger t matching the filter by the
+// top-level category of each product, found by walking down the category tree
+// from its roots. Uncategorized products are grouped under a nil category.
+func (r *transactionRepositoryImpl) queryCategorySales(ctx context.Context, filter string, args ...interface{}) ([]entities.CategorySales, error) {
+	query := `
+		WITH RECURSIVE roots AS (
+			SELECT id, id AS root_id FROM categories WHERE parent_id IS NULL
+			UNION
+			SELECT c.id, roots.root_id FROM categories c JOIN roots ON c.parent_id = roots.id
+		)
+		SELECT roots.root_id, COALESCE(rc.name, 'Uncategorized'),
+			COALESCE(SUM(t.sign * td.subtotal), 0), COALESCE(ROUND(SUM(t.sign * td.unit_cost * td.quantity)), 0), COALESCE(SUM(t.sign * td.quantity), 0)
+		FROM transaction_details td
+		JOIN ` + salesLedger + ` ON td.transaction_id = t.id
+		JOIN products p ON td.product_id = p.id
+		LEFT JOIN roots ON roots.id = p.category_id
+		LEFT JOIN categories rc ON rc.id = roots.root_id
+		WHERE ` + filter + `
+		GROUP BY roots.root_id, rc.name
+		ORDER BY 3 DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sales []entities.CategorySales
+	for rows.Next() {
+		var category entities.CategorySales
+		if err := rows.Scan(&category.CategoryID, &category.CategoryName, &category.Revenue, &category.Cost, &category.QtySold); err != nil {
+			return nil, fmt.Errorf("failed to scan category sales: %w", err)
+		}
+		sales = append(sales, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category sales: %w", err)
+	}
+
+	return sales, nil
+}
+
+func (r *transactionRepositoryImpl) GetTodaySalesTotals(ctx context.Context, storeID int) (*entities.SalesTotals, error) {
+	totals, err := r.querySalesTotals(ctx, `DATE(t.booked_at) = CURRENT_DATE AND t.store_id = $1`, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get today's sales totals: %w", err)
+	}
+	return totals, nil
+}
+
+func (r *transactionRepositoryImpl) GetDateRangeSalesTotals(ctx context.Context, storeID int, startDate, endDate string) (*entities.SalesTotals, error) {
+	totals, err := r.querySalesTotals(ctx, `DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3`, startDate, endDate, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range sales totals: %w", err)
+	}
+	return totals, nil
+}
+
+// querySalesTotals sums the amount components of the sales ledger entries t
+// matching the filter, splitting the tax into tax included in prices and tax added on top
+func (r *transactionRepositoryImpl) querySalesTotals(ctx context.Context, filter string, args ...interface{}) (*entities.SalesTotals, error) {
+	query := `
+		SELECT
+			COALESCE(SUM(t.sign * t.gross_amount), 0),
+			COALESCE(SUM(t.sign * t.discount_amount), 0),
+			COALESCE(SUM(t.sign * t.service_charge), 0),
+			COALESCE(SUM(t.sign * t.tax_amount) FILTER (WHERE t.tax_mode = 'inclusive'), 0),
+			COALESCE(SUM(t.sign * t.tax_amount) FILTER (WHERE t.tax_mode = 'exclusive'), 0),
+			COALESCE(SUM(t.sign * t.rounding_amount), 0),
+			COALESCE(SUM(t.sign * t.total_amount), 0)
+		FROM ` + salesLedger + `
+		WHERE ` + filter
+
+	var totals entities.SalesTotals
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&totals.GrossAmount,
+		&totals.DiscountAmount,
+		&totals.ServiceCharge,
+		&totals.TaxIncluded,
+		&totals.TaxAdded,
+		&totals.RoundingAmount,
+		&totals.TotalAmount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &totals, nil
+}
+
+func (r *transactionRepositoryImpl) GetDateRangeTaxSummary(ctx context.Context, storeID int, startDate, endDate string) ([]entities.TaxSummary, error) {
+	// Group by the name and rate charged, so a rate changed within the range is reported at each rate
+	query := `
+		SELECT tt.tax_rate_id, tt.name, tt.rate::float8,
+			COUNT(DISTINCT tt.transaction_id) FILTER (WHERE t.sign > 0) - COUNT(DISTINCT tt.transaction_id) FILTER (WHERE t.sign < 0),
+			SUM(t.sign * tt.taxable_amount), SUM(t.sign * tt.tax_amount)
+		FROM transaction_taxes tt
+		JOIN ` + salesLedger + ` ON tt.transaction_id = t.id
+		WHERE DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3
+		GROUP BY tt.tax_rate_id, tt.name, tt.rate
+		ORDER BY tt.rate DESC, tt.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, startDate, endDate, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range tax summary: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []entities.TaxSummary
+	for rows.Next() {
+		var summary entities.TaxSummary
+		if err := rows.Scan(&summary.TaxRateID, &summary.Name, &summary.Rate, &summary.TransactionCount, &summary.TaxableAmount, &summary.TaxAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan tax summary: %w", err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tax summary: %w", err)
+	}
+
+	return summaries, nil
+}
+
+func (r *transactionRepositoryImpl) GetDateRangePaymentSummary(ctx context.Context, storeID int, startDate, endDate string) ([]entities.PaymentSummary, error) {
+	query := `
+		SELECT tp.method, SUM(t.sign),
+			COUNT(DISTINCT tp.transaction_id) FILTER (WHERE t.sign > 0) - COUNT(DISTINCT tp.transaction_id) FILTER (WHERE t.sign < 0),
+			SUM(t.sign * tp.amount), SUM(t.sign * tp.change_amount)
+		FROM transaction_payments tp
+		JOIN ` + salesLedger + ` ON tp.transaction_id = t.id
+		WHERE DATE(t.booked_at) >= $1 AND DATE(t.booked_at) <= $2 AND t.store_id = $3
+		GROUP BY tp.method
+		ORDER BY SUM(t.sign * (tp.amount - tp.change_amount)) DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, startDate, endDate, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range payment summary: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []entities.PaymentSummary
+	for rows.Next() {
+		var summary entities.PaymentSummary
+		if err := rows.Scan(&summary.Method, &summary.PaymentCount, &summary.TransactionCount, &summary.Amount, &summary.ChangeAmount); err != nil {
+			return nil, fmt.Errorf("failed to scan payment summary: %w", err)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment summary: %w", err)
+	}
+
+	return summaries, nil
+}
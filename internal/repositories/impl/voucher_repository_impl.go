@@ -0,0 +1,281 @@
+package impl
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/lib/pq"
+)
+
+const voucherSelectQuery = `
+	SELECT id, code, description, type, value::float8, max_discount, min_spend, usage_limit, per_customer_limit,
+		starts_at, expires_at, active, times_redeemed, created_at, updated_at
+	FROM vouchers
+`
+
+type voucherRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewVoucherRepository(db *sql.DB) repositories.VoucherRepository {
+	return &voucherRepositoryImpl{db: db}
+}
+
+// scanVoucher scans a row produced by voucherSelectQuery
+func scanVoucher(row interface{ Scan(...interface{}) error }, voucher *entities.Voucher) error {
+	return row.Scan(
+		&voucher.ID,
+		&voucher.Code,
+		&voucher.Description,
+		&voucher.Type,
+		&voucher.Value,
+		&voucher.MaxDiscount,
+		&voucher.MinSpend,
+		&voucher.UsageLimit,
+		&voucher.PerCustomerLimit,
+		&voucher.StartsAt,
+		&voucher.ExpiresAt,
+		&voucher.Active,
+		&voucher.TimesRedeemed,
+		&voucher.CreatedAt,
+		&voucher.UpdatedAt,
+	)
+}
+
+func (r *voucherRepositoryImpl) Create(ctx context.Context, voucher *entities.Voucher) error {
+	query := `
+		INSERT INTO vouchers (code, description, type, value, max_discount, min_spend, usage_limit, per_customer_limit,
+			starts_at, expires_at, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		RETURNING id, created_at, updated_at
+	`
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query,
+		voucher.Code,
+		voucher.Description,
+		voucher.Type,
+		voucher.Value,
+		voucher.MaxDiscount,
+		voucher.MinSpend,
+		voucher.UsageLimit,
+		voucher.PerCustomerLimit,
+		voucher.StartsAt,
+		voucher.ExpiresAt,
+		voucher.Active,
+		now,
+	).Scan(&voucher.ID, &voucher.CreatedAt, &voucher.UpdatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("voucher code %s is already in use", voucher.Code)
+		}
+		return fmt.Errorf("failed to create voucher: %w", err)
+	}
+
+	return nil
+}
+
+func (r *voucherRepositoryImpl) FindByID(ctx context.Context, id int) (*entities.Voucher, error) {
+	return r.findOne(ctx, voucherSelectQuery+` WHERE id = $1`, id)
+}
+
+func (r *voucherRepositoryImpl) FindByCode(ctx context.Context, code string) (*entities.Voucher, error) {
+	return r.findOne(ctx, voucherSelectQuery+` WHERE code = $1`, code)
+}
+
+// findOne runs a query built on voucherSelectQuery that matches at most one voucher
+func (r *voucherRepositoryImpl) findOne(ctx context.Context, query string, args ...interface{}) (*entities.Voucher, error) {
+	var voucher entities.Voucher
+	err := scanVoucher(r.db.QueryRowContext(ctx, query, args...), &voucher)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find voucher: %w", err)
+	}
+
+	return &voucher, nil
+}
+
+func (r *voucherRepositoryImpl) FindAll(ctx context.Context) ([]entities.Voucher, error) {
+	rows, err := r.db.QueryContext(ctx, voucherSelectQuery+` ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vouchers: %w", err)
+	}
+	defer rows.Close()
+
+	var vouchers []entities.Voucher
+	for rows.Next() {
+		var voucher entities.Voucher
+		if err := scanVoucher(rows, &voucher); err != nil {
+			return nil, fmt.Errorf("failed to scan voucher: %w", err)
+		}
+		vouchers = append(vouchers, voucher)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating vouchers: %w", err)
+	}
+
+	return vouchers, nil
+}
+
+func (r *voucherRepositoryImpl) Update(ctx context.Context, voucher *entities.Voucher) error {
+	query := `
+		UPDATE vouchers
+		SET code = $1, description = $2, type = $3, value = $4, max_discount = $5, min_spend = $6, usage_limit = $7,
+			per_customer_limit = $8, starts_at = $9, expires_at = $10, active = $11, updated_at = $12
+		WHERE id = $13
+	`
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, query,
+		voucher.Code,
+		voucher.Description,
+		voucher.Type,
+		voucher.Value,
+		voucher.MaxDiscount,
+		voucher.MinSpend,
+		voucher.UsageLimit,
+		voucher.PerCustomerLimit,
+		voucher.StartsAt,
+		voucher.ExpiresAt,
+		voucher.Active,
+		now,
+		voucher.ID,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return fmt.Errorf("voucher code %s is already in use", voucher.Code)
+		}
+		return fmt.Errorf("failed to update voucher: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("voucher not found")
+	}
+
+	voucher.UpdatedAt = now
+	return nil
+}
+
+func (r *voucherRepositoryImpl) Delete(ctx context.Context, id int) error {
+	// Redeemed vouchers are kept so their redemptions stay traceable
+	query := `DELETE FROM vouchers WHERE id = $1 AND times_redeemed = 0`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete voucher: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("voucher has been redeemed and cannot be deleted; deactivate it instead")
+	}
+
+	return nil
+}
+
+func (r *voucherRepositoryImpl) CountRedemptions(ctx context.Context, voucherID int, customerRef string) (int, error) {
+	query := `SELECT COUNT(*) FROM voucher_redemptions WHERE voucher_id = $1 AND customer_ref = $2`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, voucherID, customerRef).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count voucher redemptions: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *voucherRepositoryImpl) FindRedemptions(ctx context.Context, voucherID int) ([]entities.VoucherRedemption, error) {
+	query := `
+		SELECT id, voucher_id, transaction_id, COALESCE(customer_ref, ''), amount, redeemed_at
+		FROM voucher_redemptions
+		WHERE voucher_id = $1
+		ORDER BY redeemed_at DESC, id DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, voucherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query voucher redemptions: %w", err)
+	}
+	defer rows.Close()
+
+	redemptions := []entities.VoucherRedemption{}
+	for rows.Next() {
+		var redemption entities.VoucherRedemption
+		if err := rows.Scan(&redemption.ID, &redemption.VoucherID, &redemption.TransactionID, &redemption.CustomerRef, &redemption.Amount, &redemption.RedeemedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan voucher redemption: %w", err)
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating voucher redemptions: %w", err)
+	}
+
+	return redemptions, nil
+}
+
+// redeemVoucher counts a voucher redemption on a transaction. The usage count is
+// raised with a conditional update, which also locks the voucher until the
+// transaction commits, so concurrent checkouts cannot redeem it past its usage
+// limit or past a customer's limit.
+func redeemVoucher(ctx context.Context, tx *sql.Tx, voucherID, transactionID int, customerRef string, amount int, at time.Time) error {
+	query := `
+		UPDATE vouchers SET times_redeemed = times_redeemed + 1
+		WHERE id = $1 AND active
+			AND (usage_limit IS NULL OR times_redeemed < usage_limit)
+			AND (starts_at IS NULL OR starts_at <= $2)
+			AND (expires_at IS NULL OR expires_at > $2)
+		RETURNING code, per_customer_limit
+	`
+	var code string
+	var perCustomerLimit *int
+	err := tx.QueryRowContext(ctx, query, voucherID, at).Scan(&code, &perCustomerLimit)
+	if err == sql.ErrNoRows {
+		// Explain why, such as another checkout having used up the voucher first
+		var voucher entities.Voucher
+		if err := scanVoucher(tx.QueryRowContext(ctx, voucherSelectQuery+` WHERE id = $1`, voucherID), &voucher); err != nil {
+			return fmt.Errorf("voucher %d can no longer be redeemed", voucherID)
+		}
+		if err := voucher.CheckRedeemable(at); err != nil {
+			return err
+		}
+		return fmt.Errorf("voucher %s can no longer be redeemed", voucher.Code)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to redeem voucher: %w", err)
+	}
+
+	if perCustomerLimit != nil {
+		var count int
+		countQuery := `SELECT COUNT(*) FROM voucher_redemptions WHERE voucher_id = $1 AND customer_ref = $2`
+		if err := tx.QueryRowContext(ctx, countQuery, voucherID, customerRef).Scan(&count); err != nil {
+			return fmt.Errorf("failed to count voucher redemptions: %w", err)
+		}
+		if count >= *perCustomerLimit {
+			return fmt.Errorf("voucher %s has reached its limit of %d uses per customer", code, *perCustomerLimit)
+		}
+	}
+
+	insertQuery := `
+		INSERT INTO voucher_redemptions (voucher_id, transaction_id, customer_ref, amount, redeemed_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5)
+	`
+	if _, err := tx.ExecContext(ctx, insertQuery, voucherID, transactionID, customerRef, amount, at); err != nil {
+		return fmt.Errorf("failed to record voucher redemption: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type ProductLotRepository interface {
+	// FindByProductID retrieves the lots of a product held by a store that still have stock,
+	// in first-expiry-first-out order
+	FindByProductID(ctx context.Context, storeID, productID int) ([]entities.ProductLot, error)
+
+	// FindExpiring retrieves lots held by a store that still have stock and expire within
+	// the given number of days (already expired lots included), soonest first
+	FindExpiring(ctx context.Context, storeID, days int) ([]entities.ProductLot, error)
+}
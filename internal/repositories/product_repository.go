@@ -6,12 +6,45 @@ import (
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
 )
 
+// ProductRepository reads products as seen by a store: Stock is the stock held by
+// that store and StorePrice its price override, if any. Listings leave out
+// archived products unless includeArchived is set; lookups by ID, SKU, barcode
+// and PLU return them so their identifiers stay reserved.
 type ProductRepository interface {
-	FindAll(ctx context.Context) ([]entities.Product, error)
-	FindByID(ctx context.Context, id int) (*entities.Product, error)
-	FindByCategoryID(ctx context.Context, categoryID int) ([]entities.Product, error)
-	FindByFilters(ctx context.Context, name string, active *bool) ([]entities.Product, error)
-	Create(ctx context.Context, product *entities.Product) error
-	Update(ctx context.Context, product *entities.Product) error
+	FindAll(ctx context.Context, storeID int, includeArchived bool) ([]entities.Product, error)
+	FindByID(ctx context.Context, storeID, id int) (*entities.Product, error)
+	FindByBarcode(ctx context.Context, storeID int, barcode string) (*entities.Product, error)
+	FindBySKU(ctx context.Context, storeID int, sku string) (*entities.Product, error)
+	FindByPLU(ctx context.Context, storeID int, plu string) (*entities.Product, error)
+	FindByCategoryID(ctx context.Context, storeID, categoryID int, includeDescendants, includeArchived bool) ([]entities.Product, error)
+	FindByFilters(ctx context.Context, storeID int, name string, active *bool, includeArchived bool) ([]entities.Product, error)
+	Create(ctx context.Context, storeID int, product *entities.Product) error
+	// Update updates a product and adds stockDelta to the stock of the store, so a
+	// sale made since the product was read is not overwritten. A zero stockDelta
+	// leaves the stock untouched.
+	Update(ctx context.Context, storeID int, product *entities.Product, stockDelta float64) error
+	// Delete archives a product; it stays readable by ID but is hidden from listings
 	Delete(ctx context.Context, id int) error
-}
\ No newline at end of file
+
+	// Restore brings back an archived product
+	Restore(ctx context.Context, id int) error
+
+	// FindComponents retrieves the components of a bundle with their stock at the given store
+	FindComponents(ctx context.Context, storeID, bundleID int) ([]entities.BundleComponent, error)
+
+	// ReplaceComponents replaces the components of a bundle. A product only becomes a
+	// bundle while no store holds or awaits stock of it.
+	ReplaceComponents(ctx context.Context, bundleID int, components []entities.BundleComponent) error
+
+	// FindVariants retrieves the variants of a parent product, archived ones only when includeArchived is set
+	FindVariants(ctx context.Context, storeID, parentID int, includeArchived bool) ([]entities.Product, error)
+
+	// FindOptions retrieves the option axes of a parent product in order
+	FindOptions(ctx context.Context, productID int) ([]entities.ProductOption, error)
+
+	// ReplaceOptions replaces the option axes of a parent product
+	ReplaceOptions(ctx context.Context, productID int, options []entities.ProductOption) error
+
+	// UpdateStoreStock sets a store's stock level and price override (nil for catalog price) for a product
+	UpdateStoreStock(ctx context.Context, storeID, productID int, quantity float64, price *float64) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type ProductSerialRepository interface {
+	// FindBySerialNumber retrieves a serial with the transaction it was sold in, if any
+	FindBySerialNumber(ctx context.Context, serialNumber string) (*entities.ProductSerial, error)
+
+	// FindInStockByProductID retrieves the unsold serials of a product held by a store, oldest first
+	FindInStockByProductID(ctx context.Context, storeID, productID int) ([]entities.ProductSerial, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type PromotionRepository interface {
+	// Create creates a new promotion with its items and tiers
+	Create(ctx context.Context, promotion *entities.Promotion) error
+
+	// FindByID retrieves a promotion by ID with its items and tiers
+	FindByID(ctx context.Context, id int) (*entities.Promotion, error)
+
+	// FindAll retrieves all promotions with their items and tiers, highest priority first
+	FindAll(ctx context.Context) ([]entities.Promotion, error)
+
+	// FindActive retrieves the active promotions whose dates include the given time,
+	// with the subcategories of category promotions. Days and hours are not checked.
+	FindActive(ctx context.Context, at time.Time) ([]entities.Promotion, error)
+
+	// Update updates a promotion and replaces its items and tiers
+	Update(ctx context.Context, promotion *entities.Promotion) error
+
+	// Delete deletes a promotion by ID
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type StockTransferRepository interface {
+	// Create creates a new requested stock transfer with its items
+	Create(ctx context.Context, transfer *entities.StockTransfer) error
+
+	// FindByID retrieves a stock transfer by ID with its items
+	FindByID(ctx context.Context, id int) (*entities.StockTransfer, error)
+
+	// FindAll retrieves stock transfers with their items, newest first. An empty status
+	// and a zero store ID disable the respective filter; the store filter matches
+	// either side of the transfer.
+	FindAll(ctx context.Context, status string, storeID int) ([]entities.StockTransfer, error)
+
+	// Ship marks a requested transfer as shipped and deducts each item's shipped
+	// quantity from the source store's stock
+	Ship(ctx context.Context, transfer *entities.StockTransfer) error
+
+	// Receive marks a shipped transfer as received and adds each item's received
+	// quantity to the destination store's stock
+	Receive(ctx context.Context, transfer *entities.StockTransfer) error
+
+	// Cancel marks a requested transfer as cancelled
+	Cancel(ctx context.Context, transfer *entities.StockTransfer) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type StoreRepository interface {
+	FindAll(ctx context.Context) ([]entities.Store, error)
+	FindByID(ctx context.Context, id int) (*entities.Store, error)
+	Create(ctx context.Context, store *entities.Store) error
+	Update(ctx context.Context, store *entities.Store) error
+	Delete(ctx context.Context, id int) error
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type TaxRateRepository interface {
+	// Create creates a new tax rate, making it the only default rate when it is the default
+	Create(ctx context.Context, taxRate *entities.TaxRate) error
+
+	// FindByID retrieves a tax rate by ID
+	FindByID(ctx context.Context, id int) (*entities.TaxRate, error)
+
+	// FindDefault retrieves the default tax rate, or nil when there is none
+	FindDefault(ctx context.Context) (*entities.TaxRate, error)
+
+	// FindAll retrieves all tax rates
+	FindAll(ctx context.Context) ([]entities.TaxRate, error)
+
+	// Update updates a tax rate, making it the only default rate when it is the default
+	Update(ctx context.Context, taxRate *entities.TaxRate) error
+
+	// Delete deletes a tax rate; past transactions keep the tax they were charged
+	Delete(ctx context.Context, id int) error
+
+	// CountAssignments counts the products and categories a tax rate is assigned to
+	CountAssignments(ctx context.Context, id int) (products, categories int, err error)
+
+	// FindForProducts resolves the tax rate of each product: its own rate, else the
+	// rate of its nearest category up the tree that has one, else the default rate.
+	// Products that resolve to no rate are left out of the map.
+	FindForProducts(ctx context.Context, productIDs []int) (map[int]entities.TaxRate, error)
+}
@@ -2,38 +2,100 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
 )
 
 type TransactionRepository interface {
-	// Create creates a new transaction with details
+	// Create creates a new transaction with details and deducts the sold quantities from the store's stock
 	Create(ctx context.Context, transaction *entities.Transaction) error
 	
 	// FindByID retrieves a transaction by ID with its details
 	FindByID(ctx context.Context, id int) (*entities.Transaction, error)
 	
+	// FindByStoreAndID retrieves a transaction of a store by ID with its details. It returns
+	// nil when the store has no transaction with the ID.
+	FindByStoreAndID(ctx context.Context, storeID, id int) (*entities.Transaction, error)
+	
 	// FindAll retrieves all transactions with their details
 	FindAll(ctx context.Context) ([]entities.Transaction, error)
 	
+	// Complete completes a draft with its payments, becoming completed or pending as its status
+	// says, and takes its stock and redeems its vouchers. The details must carry bundle components.
+	Complete(ctx context.Context, transaction *entities.Transaction) error
+	
+	// Void voids a transaction, putting back any stock it took and refunding or cancelling its payments
+	Void(ctx context.Context, id int, reason string) error
+	
+	// Refund refunds a completed transaction, putting back its stock and refunding its payments
+	Refund(ctx context.Context, id int, reason string) error
+	
+	// SetPaymentCharge records the charge a payment gateway created for a pending payment
+	SetPaymentCharge(ctx context.Context, paymentID int, providerRef, checkoutURL string) error
+	
+	// FindPaymentByProviderRef retrieves a gateway payment by the provider's reference. It
+	// returns nil when the provider has no such payment.
+	FindPaymentByProviderRef(ctx context.Context, provider, providerRef string) (*entities.TransactionPayment, error)
+	
+	// ResolvePayment records the outcome of a gateway payment, completing its transaction once
+	// no payment is pending or failing it and putting its stock back. It returns nil when the
+	// provider has no such payment. Outcomes after the first are ignored.
+	ResolvePayment(ctx context.Context, provider, providerRef string, paid bool, at time.Time) (*entities.TransactionPayment, error)
+	
+	// ReleasePending ends a pending transaction with the given status, failed or expired, and puts
+	// its stock back. It reports false when the transaction is no longer pending.
+	ReleasePending(ctx context.Context, id int, status string, at time.Time) (bool, error)
+	
+	// FindExpiredPending returns the IDs of the pending transactions whose payments were due by the given time
+	FindExpiredPending(ctx context.Context, at time.Time) ([]int, error)
+	
 	// CreateDetail creates a transaction detail
 	CreateDetail(ctx context.Context, detail *entities.TransactionDetail) error
 	
-	// GetTodayRevenue returns the total revenue from today's transactions
-	GetTodayRevenue(ctx context.Context) (int, error)
+	// The report methods below count sales on the day they completed and take refunds off on the
+	// day they were refunded, so the figures of a past period never change. Drafts, pending
+	// transactions and failed, expired or voided sales are left out.
+	
+	// GetTodayRevenue returns the total revenue from today's transactions of a store
+	GetTodayRevenue(ctx context.Context, storeID int) (int, error)
+	
+	// GetTodayTransactionCount returns the count of transactions made today at a store
+	GetTodayTransactionCount(ctx context.Context, storeID int) (int, error)
+	
+	// GetTodayCostOfGoodsSold returns the total cost of goods sold in today's transactions of a store
+	GetTodayCostOfGoodsSold(ctx context.Context, storeID int) (int, error)
+	
+	// GetTodayBestSellingProduct returns the product name and quantity sold for today's best selling product of a store
+	GetTodayBestSellingProduct(ctx context.Context, storeID int) (productName string, qtySold float64, err error)
+	
+	// GetDateRangeRevenue returns the total revenue from transactions of a store within a date range
+	GetDateRangeRevenue(ctx context.Context, storeID int, startDate, endDate string) (int, error)
+	
+	// GetDateRangeTransactionCount returns the count of transactions of a store within a date range
+	GetDateRangeTransactionCount(ctx context.Context, storeID int, startDate, endDate string) (int, error)
+	
+	// GetDateRangeCostOfGoodsSold returns the total cost of goods sold in transactions of a store within a date range
+	GetDateRangeCostOfGoodsSold(ctx context.Context, storeID int, startDate, endDate string) (int, error)
+	
+	// GetDateRangeBestSellingProduct returns the product name and quantity sold for best selling product of a store within a date range
+	GetDateRangeBestSellingProduct(ctx context.Context, storeID int, startDate, endDate string) (productName string, qtySold float64, err error)
+	
+	// GetTodayCategorySales returns today's sales of a store rolled up by top-level category
+	GetTodayCategorySales(ctx context.Context, storeID int) ([]entities.CategorySales, error)
 	
-	// GetTodayTransactionCount returns the count of transactions made today
-	GetTodayTransactionCount(ctx context.Context) (int, error)
+	// GetDateRangeCategorySales returns the sales of a store within a date range rolled up by top-level category
+	GetDateRangeCategorySales(ctx context.Context, storeID int, startDate, endDate string) ([]entities.CategorySales, error)
 	
-	// GetTodayBestSellingProduct returns the product name and quantity sold for today's best selling product
-	GetTodayBestSellingProduct(ctx context.Context) (productName string, qtySold int, err error)
+	// GetTodaySalesTotals returns the components of the amount of today's transactions of a store
+	GetTodaySalesTotals(ctx context.Context, storeID int) (*entities.SalesTotals, error)
 	
-	// GetDateRangeRevenue returns the total revenue from transactions within a date range
-	GetDateRangeRevenue(ctx context.Context, startDate, endDate string) (int, error)
+	// GetDateRangeSalesTotals returns the components of the amount of transactions of a store within a date range
+	GetDateRangeSalesTotals(ctx context.Context, storeID int, startDate, endDate string) (*entities.SalesTotals, error)
 	
-	// GetDateRangeTransactionCount returns the count of transactions within a date range
-	GetDateRangeTransactionCount(ctx context.Context, startDate, endDate string) (int, error)
+	// GetDateRangeTaxSummary returns the tax charged per rate in transactions of a store within a date range
+	GetDateRangeTaxSummary(ctx context.Context, storeID int, startDate, endDate string) ([]entities.TaxSummary, error)
 	
-	// GetDateRangeBestSellingProduct returns the product name and quantity sold for best selling product within a date range
-	GetDateRangeBestSellingProduct(ctx context.Context, startDate, endDate string) (productName string, qtySold int, err error)
+	// GetDateRangePaymentSummary returns the payments taken per method in transactions of a store within a date range
+	GetDateRangePaymentSummary(ctx context.Context, storeID int, startDate, endDate string) ([]entities.PaymentSummary, error)
 }
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+)
+
+type VoucherRepository interface {
+	// Create creates a new voucher
+	Create(ctx context.Context, voucher *entities.Voucher) error
+
+	// FindByID retrieves a voucher by ID
+	FindByID(ctx context.Context, id int) (*entities.Voucher, error)
+
+	// FindByCode retrieves a voucher by its normalized code
+	FindByCode(ctx context.Context, code string) (*entities.Voucher, error)
+
+	// FindAll retrieves all vouchers, newest first
+	FindAll(ctx context.Context) ([]entities.Voucher, error)
+
+	// Update updates a voucher's settings
+	Update(ctx context.Context, voucher *entities.Voucher) error
+
+	// Delete deletes a voucher that has never been redeemed
+	Delete(ctx context.Context, id int) error
+
+	// CountRedemptions counts a customer's redemptions of a voucher
+	CountRedemptions(ctx context.Context, voucherID int, customerRef string) (int, error)
+
+	// FindRedemptions retrieves a voucher's redemptions, newest first
+	FindRedemptions(ctx context.Context, voucherID int) ([]entities.VoucherRedemption, error)
+}
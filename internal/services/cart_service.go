@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type CartService interface {
+	// GetAll retrieves the carts of a store, optionally filtered by status
+	GetAll(ctx context.Context, storeID int, status string) ([]dtos.CartDto, error)
+
+	// GetByID retrieves a cart by ID with its live totals
+	GetByID(ctx context.Context, storeID, id int) (*dtos.CartDto, error)
+
+	// Create opens a cart at the given store
+	Create(ctx context.Context, storeID int, dto *dtos.CartCreateRequestDto) (*dtos.CartDto, error)
+
+	// Update replaces the customer, vouchers and cart discount of an open cart
+	Update(ctx context.Context, storeID, id int, dto *dtos.CartUpdateRequestDto) (*dtos.CartDto, error)
+
+	// Delete discards an open or parked cart
+	Delete(ctx context.Context, storeID, id int) error
+
+	// AddItem adds a line to an open cart
+	AddItem(ctx context.Context, storeID, id int, dto *dtos.CheckoutItemDto) (*dtos.CartDto, error)
+
+	// UpdateItem changes the quantity, serial numbers and discount of a line of an open cart
+	UpdateItem(ctx context.Context, storeID, id, itemID int, dto *dtos.CartItemUpdateRequestDto) (*dtos.CartDto, error)
+
+	// RemoveItem removes a line from an open cart
+	RemoveItem(ctx context.Context, storeID, id, itemID int) (*dtos.CartDto, error)
+
+	// Park sets an open cart aside so the terminal can serve the next customer
+	Park(ctx context.Context, storeID, id int, dto *dtos.CartParkRequestDto) (*dtos.CartDto, error)
+
+	// Resume reopens a parked cart at a terminal of the given store
+	Resume(ctx context.Context, storeID, id int, dto *dtos.CartResumeRequestDto) (*dtos.CartDto, error)
+
+	// Checkout checks out an open cart into a transaction
+	Checkout(ctx context.Context, storeID, id int, dto *dtos.CartCheckoutRequestDto) (*dtos.TransactionDto, error)
+
+	// ExpireCarts expires the open and parked carts left untouched for too long and
+	// returns how many expired
+	ExpireCarts(ctx context.Context) (int, error)
+}
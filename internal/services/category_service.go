@@ -7,8 +7,12 @@ import (
 )
 
 type CategoryService interface {
-	// GetAll retrieves all categories
-	GetAll(ctx context.Context) ([]dtos.CategoryDto, error)
+	// GetAll retrieves all categories, archived ones only when includeArchived is set. With
+	// withStats each category carries its product count and the stock value held by the store.
+	GetAll(ctx context.Context, storeID int, includeArchived, withStats bool) ([]dtos.CategoryDto, error)
+
+	// GetTree retrieves all categories nested under their parents
+	GetTree(ctx context.Context, includeArchived bool) ([]dtos.CategoryDto, error)
 
 	// GetByID retrieves a category by ID
 	GetByID(ctx context.Context, id int) (*dtos.CategoryDto, error)
@@ -19,6 +23,10 @@ type CategoryService interface {
 	// Update updates an existing category
 	Update(ctx context.Context, id int, dto *dtos.CategoryUpdateRequestDto) (*dtos.CategoryDto, error)
 
-	// Delete deletes a category by ID
-	Delete(ctx context.Context, id int) error
+	// Delete archives a category by ID. A category with products is only deleted when
+	// they are moved to reassignTo or, with orphan set, left uncategorized.
+	Delete(ctx context.Context, id int, reassignTo *int, orphan bool) (*dtos.CategoryDeleteResultDto, error)
+
+	// Restore brings back an archived category
+	Restore(ctx context.Context, id int) (*dtos.CategoryDto, error)
 }
@@ -0,0 +1,464 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type cartServiceImpl struct {
+	repository         repositories.CartRepository
+	storeRepository    repositories.StoreRepository
+	transactionService services.TransactionService
+	expiry             time.Duration
+	mapper             *mappers.CartMapper
+}
+
+// NewCartService creates a new instance of CartService. Carts expire once they
+// are left untouched for the given expiry.
+func NewCartService(
+	repository repositories.CartRepository,
+	storeRepository repositories.StoreRepository,
+	transactionService services.TransactionService,
+	expiry time.Duration,
+) services.CartService {
+	return &cartServiceImpl{
+		repository:         repository,
+		storeRepository:    storeRepository,
+		transactionService: transactionService,
+		expiry:             expiry,
+		mapper:             &mappers.CartMapper{},
+	}
+}
+
+// GetAll retrieves the carts of a store, optionally filtered by status
+func (s *cartServiceImpl) GetAll(ctx context.Context, storeID int, status string) ([]dtos.CartDto, error) {
+	if status != "" && !isCartStatus(status) {
+		return nil, fmt.Errorf("invalid cart status %q", status)
+	}
+
+	carts, err := s.repository.FindAll(ctx, storeID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get carts: %w", err)
+	}
+
+	// Carts past their expiry are shown as expired before they are swept
+	now := time.Now()
+	for i := range carts {
+		expireIfDue(&carts[i], now)
+	}
+
+	return s.mapper.ToDtoList(carts), nil
+}
+
+// GetByID retrieves a cart by ID with its live totals
+func (s *cartServiceImpl) GetByID(ctx context.Context, storeID, id int) (*dtos.CartDto, error) {
+	cart, err := s.findCart(ctx, storeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toDto(ctx, cart), nil
+}
+
+// Create opens a cart at the given store
+func (s *cartServiceImpl) Create(ctx context.Context, storeID int, dto *dtos.CartCreateRequestDto) (*dtos.CartDto, error) {
+	if dto == nil {
+		dto = &dtos.CartCreateRequestDto{}
+	}
+
+	// Validate the store
+	store, err := s.storeRepository.FindByID(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store with id %d: %w", storeID, err)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("store with id %d not found", storeID)
+	}
+
+	terminalID, err := normalizeTerminalID(dto.TerminalID)
+	if err != nil {
+		return nil, err
+	}
+	customerRef, err := normalizeCustomerRef(dto.CustomerRef)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cart := &entities.Cart{
+		StoreID:     storeID,
+		Status:      entities.CartStatusOpen,
+		TerminalID:  terminalID,
+		CustomerRef: customerRef,
+		CreatedAt:   now,
+	}
+	cart.Touch(now, s.expiry)
+	for i := range dto.Items {
+		cart.Items = append(cart.Items, *s.mapper.ToItemEntity(&dto.Items[i]))
+	}
+
+	// Only a cart that can be priced is saved
+	if _, err := s.quote(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Create(ctx, cart); err != nil {
+		return nil, fmt.Errorf("failed to create cart: %w", err)
+	}
+
+	return s.toDto(ctx, cart), nil
+}
+
+// Update replaces the customer, vouchers and cart discount of an open cart
+func (s *cartServiceImpl) Update(ctx context.Context, storeID, id int, dto *dtos.CartUpdateRequestDto) (*dtos.CartDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("update request dto cannot be nil")
+	}
+
+	customerRef, err := normalizeCustomerRef(dto.CustomerRef)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.findOpenCart(ctx, storeID, id, "edited")
+	if err != nil {
+		return nil, err
+	}
+
+	cart.CustomerRef = customerRef
+	cart.VoucherCodes = nil
+	for _, code := range dto.VoucherCodes {
+		cart.VoucherCodes = append(cart.VoucherCodes, entities.NormalizeVoucherCode(code))
+	}
+	cart.Discount = s.mapper.ToDiscountEntity(dto.Discount)
+	cart.Touch(time.Now(), s.expiry)
+
+	if _, err := s.quote(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, cart, entities.CartStatusOpen); err != nil {
+		return nil, fmt.Errorf("failed to update cart: %w", err)
+	}
+
+	return s.toDto(ctx, cart), nil
+}
+
+// Delete discards an open or parked cart
+func (s *cartServiceImpl) Delete(ctx context.Context, storeID, id int) error {
+	cart, err := s.findCart(ctx, storeID, id)
+	if err != nil {
+		return err
+	}
+
+	if !cart.IsActive() {
+		return fmt.Errorf("cart %d cannot be deleted because it is %s", id, cart.Status)
+	}
+
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete cart: %w", err)
+	}
+
+	return nil
+}
+
+// AddItem adds a line to an open cart
+func (s *cartServiceImpl) AddItem(ctx context.Context, storeID, id int, dto *dtos.CheckoutItemDto) (*dtos.CartDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("cart item cannot be nil")
+	}
+
+	cart, err := s.findOpenCart(ctx, storeID, id, "edited")
+	if err != nil {
+		return nil, err
+	}
+
+	item := s.mapper.ToItemEntity(dto)
+	cart.Items = append(cart.Items, *item)
+	cart.Touch(time.Now(), s.expiry)
+
+	if _, err := s.quote(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.SaveItem(ctx, cart, item); err != nil {
+		return nil, fmt.Errorf("failed to add cart item: %w", err)
+	}
+	cart.Items[len(cart.Items)-1] = *item
+
+	return s.toDto(ctx, cart), nil
+}
+
+// UpdateItem changes the quantity, serial numbers and discount of a line of an open cart
+func (s *cartServiceImpl) UpdateItem(ctx context.Context, storeID, id, itemID int, dto *dtos.CartItemUpdateRequestDto) (*dtos.CartDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("update request dto cannot be nil")
+	}
+
+	cart, err := s.findOpenCart(ctx, storeID, id, "edited")
+	if err != nil {
+		return nil, err
+	}
+
+	item := cart.FindItem(itemID)
+	if item == nil {
+		return nil, fmt.Errorf("cart item with id %d not found in cart %d", itemID, id)
+	}
+	item.Quantity = dto.Quantity
+	item.Unit = dto.Unit
+	item.SerialNumbers = dto.SerialNumbers
+	item.Discount = s.mapper.ToDiscountEntity(dto.Discount)
+	cart.Touch(time.Now(), s.expiry)
+
+	if _, err := s.quote(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.SaveItem(ctx, cart, item); err != nil {
+		return nil, fmt.Errorf("failed to update cart item: %w", err)
+	}
+
+	return s.toDto(ctx, cart), nil
+}
+
+// RemoveItem removes a line from an open cart. Removing a line is never refused
+// for pricing reasons; the cart shows why it cannot be priced instead.
+func (s *cartServiceImpl) RemoveItem(ctx context.Context, storeID, id, itemID int) (*dtos.CartDto, error) {
+	cart, err := s.findOpenCart(ctx, storeID, id, "edited")
+	if err != nil {
+		return nil, err
+	}
+
+	if cart.FindItem(itemID) == nil {
+		return nil, fmt.Errorf("cart item with id %d not found in cart %d", itemID, id)
+	}
+	cart.Touch(time.Now(), s.expiry)
+
+	if err := s.repository.RemoveItem(ctx, cart, itemID); err != nil {
+		return nil, fmt.Errorf("failed to remove cart item: %w", err)
+	}
+
+	items := cart.Items[:0]
+	for _, item := range cart.Items {
+		if item.ID != itemID {
+			items = append(items, item)
+		}
+	}
+	cart.Items = items
+
+	return s.toDto(ctx, cart), nil
+}
+
+// Park sets an open cart aside so the terminal can serve the next customer
+func (s *cartServiceImpl) Park(ctx context.Context, storeID, id int, dto *dtos.CartParkRequestDto) (*dtos.CartDto, error) {
+	if dto == nil {
+		dto = &dtos.CartParkRequestDto{}
+	}
+
+	note := strings.TrimSpace(dto.Note)
+	if len(note) > 255 {
+		return nil, fmt.Errorf("note cannot be longer than 255 characters")
+	}
+
+	cart, err := s.findOpenCart(ctx, storeID, id, "parked")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cart.Status = entities.CartStatusParked
+	cart.Note = note
+	cart.ParkedAt = &now
+	cart.Touch(now, s.expiry)
+
+	if err := s.repository.Update(ctx, cart, entities.CartStatusOpen); err != nil {
+		return nil, fmt.Errorf("failed to park cart: %w", err)
+	}
+
+	return s.toDto(ctx, cart), nil
+}
+
+// Resume reopens a parked cart at a terminal of the given store
+func (s *cartServiceImpl) Resume(ctx context.Context, storeID, id int, dto *dtos.CartResumeRequestDto) (*dtos.CartDto, error) {
+	if dto == nil {
+		dto = &dtos.CartResumeRequestDto{}
+	}
+
+	terminalID, err := normalizeTerminalID(dto.TerminalID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := s.findCart(ctx, storeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart.Status != entities.CartStatusParked {
+		return nil, fmt.Errorf("cart %d cannot be resumed because it is %s", id, cart.Status)
+	}
+
+	cart.Status = entities.CartStatusOpen
+	cart.TerminalID = terminalID
+	cart.Touch(time.Now(), s.expiry)
+
+	if err := s.repository.Update(ctx, cart, entities.CartStatusParked); err != nil {
+		return nil, fmt.Errorf("failed to resume cart: %w", err)
+	}
+
+	return s.toDto(ctx, cart), nil
+}
+
+// Checkout checks out an open cart into a transaction. The cart is claimed before
+// the transaction is recorded so two terminals cannot check it out twice, and is
+// reopened if the checkout fails.
+func (s *cartServiceImpl) Checkout(ctx context.Context, storeID, id int, dto *dtos.CartCheckoutRequestDto) (*dtos.TransactionDto, error) {
+	if dto == nil {
+		dto = &dtos.CartCheckoutRequestDto{}
+	}
+
+	cart, err := s.findOpenCart(ctx, storeID, id, "checked out")
+	if err != nil {
+		return nil, err
+	}
+
+	request := s.mapper.ToCheckoutRequest(cart)
+	request.Payments = dto.Payments
+	request.Draft = dto.Draft
+
+	cart.Status = entities.CartStatusCheckedOut
+	cart.UpdatedAt = time.Now()
+	if err := s.repository.Update(ctx, cart, entities.CartStatusOpen); err != nil {
+		return nil, fmt.Errorf("failed to check out cart: %w", err)
+	}
+
+	transaction, err := s.transactionService.Checkout(ctx, cart.StoreID, request)
+	if err != nil {
+		cart.Status = entities.CartStatusOpen
+		if reopenErr := s.repository.Update(ctx, cart, entities.CartStatusCheckedOut); reopenErr != nil {
+			return nil, fmt.Errorf("%w; failed to reopen cart %d: %v", err, id, reopenErr)
+		}
+		return nil, err
+	}
+
+	cart.TransactionID = &transaction.ID
+	if err := s.repository.Update(ctx, cart, entities.CartStatusCheckedOut); err != nil {
+		return nil, fmt.Errorf("failed to record transaction %d on cart %d: %w", transaction.ID, id, err)
+	}
+
+	return transaction, nil
+}
+
+// ExpireCarts expires the open and parked carts left untouched for too long
+func (s *cartServiceImpl) ExpireCarts(ctx context.Context) (int, error) {
+	expired, err := s.repository.ExpireAll(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
+
+// findCart loads a cart of the given store and reports a not found error if it does
+// not exist there, so a parked cart can be resumed at any terminal but only in its own
+// store. A cart past its expiry is reported as expired even before it is swept.
+func (s *cartServiceImpl) findCart(ctx context.Context, storeID, id int) (*entities.Cart, error) {
+	cart, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart by id %d: %w", id, err)
+	}
+
+	if cart == nil || cart.StoreID != storeID {
+		return nil, fmt.Errorf("cart with id %d not found at store %d", id, storeID)
+	}
+
+	expireIfDue(cart, time.Now())
+
+	return cart, nil
+}
+
+// findOpenCart loads a cart that must be open for the given action
+func (s *cartServiceImpl) findOpenCart(ctx context.Context, storeID, id int, action string) (*entities.Cart, error) {
+	cart, err := s.findCart(ctx, storeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart.Status != entities.CartStatusOpen {
+		return nil, fmt.Errorf("cart %d cannot be %s because it is %s", id, action, cart.Status)
+	}
+
+	return cart, nil
+}
+
+// quote prices a cart as it would be checked out now. An empty cart has no quote.
+func (s *cartServiceImpl) quote(ctx context.Context, cart *entities.Cart) (*dtos.TransactionDto, error) {
+	if len(cart.Items) == 0 {
+		return nil, nil
+	}
+
+	return s.transactionService.Quote(ctx, cart.StoreID, s.mapper.ToCheckoutRequest(cart))
+}
+
+// toDto converts a cart to its DTO with live totals while it is open or parked
+func (s *cartServiceImpl) toDto(ctx context.Context, cart *entities.Cart) *dtos.CartDto {
+	dto := s.mapper.ToDto(cart)
+	if !cart.IsActive() {
+		return dto
+	}
+
+	// Prices, promotions and stock may have changed since the cart was last edited
+	quote, err := s.quote(ctx, cart)
+	if err != nil {
+		dto.PricingError = err.Error()
+		return dto
+	}
+	dto.Quote = quote
+
+	return dto
+}
+
+// normalizeTerminalID trims a terminal ID and checks its length
+func normalizeTerminalID(terminalID string) (string, error) {
+	terminalID = strings.TrimSpace(terminalID)
+	if len(terminalID) > 50 {
+		return "", fmt.Errorf("terminal_id cannot be longer than 50 characters")
+	}
+	return terminalID, nil
+}
+
+// normalizeCustomerRef trims a customer reference and checks its length
+func normalizeCustomerRef(customerRef string) (string, error) {
+	customerRef = strings.TrimSpace(customerRef)
+	if len(customerRef) > 100 {
+		return "", fmt.Errorf("customer_ref cannot be longer than 100 characters")
+	}
+	return customerRef, nil
+}
+
+// expireIfDue marks an open or parked cart past its expiry as expired
+func expireIfDue(cart *entities.Cart, at time.Time) {
+	if cart.IsActive() && !at.Before(cart.ExpiresAt) {
+		cart.Status = entities.CartStatusExpired
+	}
+}
+
+// isCartStatus reports whether status is a known cart status
+func isCartStatus(status string) bool {
+	switch status {
+	case entities.CartStatusOpen,
+		entities.CartStatusParked,
+		entities.CartStatusCheckedOut,
+		entities.CartStatusExpired:
+		return true
+	}
+	return false
+}
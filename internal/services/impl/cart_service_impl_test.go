@@ -0,0 +1,324 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+// cartRepositoryStub keeps carts in memory and refuses to save a cart that has
+// left the status it was read in, as the database does
+type cartRepositoryStub struct {
+	repositories.CartRepository
+	carts map[int]*entities.Cart
+}
+
+func (r *cartRepositoryStub) Create(ctx context.Context, cart *entities.Cart) error {
+	cart.ID = len(r.carts) + 1
+	stored := *cart
+	r.carts[cart.ID] = &stored
+	return nil
+}
+
+func (r *cartRepositoryStub) FindByID(ctx context.Context, id int) (*entities.Cart, error) {
+	cart, ok := r.carts[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *cart
+	found.Items = append([]entities.CartItem(nil), cart.Items...)
+	return &found, nil
+}
+
+func (r *cartRepositoryStub) Update(ctx context.Context, cart *entities.Cart, from string) error {
+	if r.carts[cart.ID].Status != from {
+		return fmt.Errorf("cart %d is no longer %s", cart.ID, from)
+	}
+	stored := *cart
+	r.carts[cart.ID] = &stored
+	return nil
+}
+
+func (r *cartRepositoryStub) SaveItem(ctx context.Context, cart *entities.Cart, item *entities.CartItem) error {
+	if item.ID == 0 {
+		item.ID = len(cart.Items)
+	}
+	return r.Update(ctx, cart, entities.CartStatusOpen)
+}
+
+func (r *cartRepositoryStub) Delete(ctx context.Context, id int) error {
+	if !r.carts[id].IsActive() {
+		return fmt.Errorf("cart %d is no longer open or parked", id)
+	}
+	delete(r.carts, id)
+	return nil
+}
+
+// cartCheckoutStub prices every cart and records the carts checked out. Checkout
+// fails with checkoutErr when it is set.
+type cartCheckoutStub struct {
+	services.TransactionService
+	checkoutErr error
+	checkedOut  []*dtos.TransactionCreateRequestDto
+}
+
+func (s *cartCheckoutStub) Quote(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error) {
+	return &dtos.TransactionDto{StoreID: storeID}, nil
+}
+
+func (s *cartCheckoutStub) Checkout(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error) {
+	if s.checkoutErr != nil {
+		return nil, s.checkoutErr
+	}
+	s.checkedOut = append(s.checkedOut, dto)
+	return &dtos.TransactionDto{ID: len(s.checkedOut), StoreID: storeID}, nil
+}
+
+func TestCartLifecycle(t *testing.T) {
+	ctx := context.Background()
+	item := &dtos.CheckoutItemDto{ProductID: 1, Quantity: 2}
+
+	// Each step acts on cart 1, which starts open at store 1, from store 1 unless
+	// the step names another
+	type step struct {
+		action     string
+		storeID    int
+		wantErr    string
+		wantStatus string
+	}
+
+	tests := []struct {
+		name string
+		// expired makes the cart overdue before the first step
+		expired     bool
+		checkoutErr error
+		steps       []step
+	}{
+		{
+			name: "park, resume elsewhere in the store and check out",
+			steps: []step{
+				{action: "add", wantStatus: entities.CartStatusOpen},
+				{action: "park", wantStatus: entities.CartStatusParked},
+				{action: "resume", wantStatus: entities.CartStatusOpen},
+				{action: "checkout", wantStatus: entities.CartStatusCheckedOut},
+			},
+		},
+		{
+			name: "parked cart cannot be edited or checked out",
+			steps: []step{
+				{action: "park", wantStatus: entities.CartStatusParked},
+				{action: "add", wantErr: "cannot be edited because it is parked", wantStatus: entities.CartStatusParked},
+				{action: "checkout", wantErr: "cannot be checked out because it is parked", wantStatus: entities.CartStatusParked},
+				{action: "park", wantErr: "cannot be parked because it is parked", wantStatus: entities.CartStatusParked},
+			},
+		},
+		{
+			name: "open cart cannot be resumed",
+			steps: []step{
+				{action: "resume", wantErr: "cannot be resumed because it is open", wantStatus: entities.CartStatusOpen},
+			},
+		},
+		{
+			name: "another store cannot see the cart",
+			steps: []step{
+				{action: "add", storeID: 2, wantErr: "cart with id 1 not found at store 2", wantStatus: entities.CartStatusOpen},
+				{action: "park", wantStatus: entities.CartStatusParked},
+				{action: "resume", storeID: 2, wantErr: "cart with id 1 not found at store 2", wantStatus: entities.CartStatusParked},
+			},
+		},
+		{
+			name:        "failed checkout reopens the cart",
+			checkoutErr: fmt.Errorf("insufficient stock for product Kopi"),
+			steps: []step{
+				{action: "add", wantStatus: entities.CartStatusOpen},
+				{action: "checkout", wantErr: "insufficient stock for product Kopi", wantStatus: entities.CartStatusOpen},
+			},
+		},
+		{
+			name: "checked out cart is final",
+			steps: []step{
+				{action: "add", wantStatus: entities.CartStatusOpen},
+				{action: "checkout", wantStatus: entities.CartStatusCheckedOut},
+				{action: "checkout", wantErr: "cannot be checked out because it is checked_out", wantStatus: entities.CartStatusCheckedOut},
+				{action: "resume", wantErr: "cannot be resumed because it is checked_out", wantStatus: entities.CartStatusCheckedOut},
+			},
+		},
+		{
+			name:    "overdue cart counts as expired before it is swept",
+			expired: true,
+			steps: []step{
+				{action: "add", wantErr: "cannot be edited because it is expired", wantStatus: entities.CartStatusOpen},
+				{action: "park", wantErr: "cannot be parked because it is expired", wantStatus: entities.CartStatusOpen},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carts := &cartRepositoryStub{carts: make(map[int]*entities.Cart)}
+			transactions := &cartCheckoutStub{checkoutErr: tt.checkoutErr}
+			service := NewCartService(carts, newStoreRepositoryStub(1, 2), transactions, time.Hour).(*cartServiceImpl)
+
+			if _, err := service.Create(ctx, 1, &dtos.CartCreateRequestDto{TerminalID: "POS-01"}); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			if tt.expired {
+				carts.carts[1].ExpiresAt = time.Now().Add(-time.Minute)
+			}
+
+			for i, step := range tt.steps {
+				storeID := step.storeID
+				if storeID == 0 {
+					storeID = 1
+				}
+
+				var err error
+				switch step.action {
+				case "add":
+					_, err = service.AddItem(ctx, storeID, 1, item)
+				case "park":
+					_, err = service.Park(ctx, storeID, 1, &dtos.CartParkRequestDto{Note: "Customer fetching wallet"})
+				case "resume":
+					_, err = service.Resume(ctx, storeID, 1, &dtos.CartResumeRequestDto{TerminalID: "POS-02"})
+				case "checkout":
+					_, err = service.Checkout(ctx, storeID, 1, nil)
+				}
+
+				if step.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), step.wantErr) {
+						t.Fatalf("step %d (%s): error = %v, want %q", i, step.action, err, step.wantErr)
+					}
+				} else if err != nil {
+					t.Fatalf("step %d (%s): error = %v", i, step.action, err)
+				}
+
+				if status := carts.carts[1].Status; status != step.wantStatus {
+					t.Fatalf("step %d (%s): status = %s, want %s", i, step.action, status, step.wantStatus)
+				}
+			}
+
+			cart := carts.carts[1]
+			if cart.Status == entities.CartStatusCheckedOut {
+				if cart.TransactionID == nil || *cart.TransactionID != 1 || len(transactions.checkedOut) != 1 {
+					t.Errorf("transaction = %v after %d checkouts, want transaction 1 once", cart.TransactionID, len(transactions.checkedOut))
+				}
+			} else if cart.TransactionID != nil {
+				t.Errorf("transaction = %d, want none", *cart.TransactionID)
+			}
+		})
+	}
+}
+
+func TestCartDelete(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		// expiresIn is how long the cart has left before it expires
+		expiresIn time.Duration
+		wantErr   string
+	}{
+		{name: "open", status: entities.CartStatusOpen, expiresIn: time.Hour},
+		{name: "parked", status: entities.CartStatusParked, expiresIn: time.Hour},
+		{name: "checked out", status: entities.CartStatusCheckedOut, expiresIn: time.Hour, wantErr: "cart 1 cannot be deleted because it is checked_out"},
+		{name: "expired", status: entities.CartStatusExpired, expiresIn: time.Hour, wantErr: "cart 1 cannot be deleted because it is expired"},
+		{name: "overdue before it is swept", status: entities.CartStatusParked, expiresIn: -time.Minute, wantErr: "cart 1 cannot be deleted because it is expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carts := &cartRepositoryStub{carts: map[int]*entities.Cart{
+				1: {ID: 1, StoreID: 1, Status: tt.status, ExpiresAt: time.Now().Add(tt.expiresIn)},
+			}}
+			service := NewCartService(carts, newStoreRepositoryStub(1), &cartCheckoutStub{}, time.Hour).(*cartServiceImpl)
+
+			err := service.Delete(context.Background(), 1, 1)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Delete() error = %v, want %q", err, tt.wantErr)
+				}
+				if carts.carts[1] == nil {
+					t.Error("cart was deleted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if carts.carts[1] != nil {
+				t.Error("cart was not deleted")
+			}
+		})
+	}
+}
+
+func TestCartCustomerRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		customerRef string
+		want        string
+		wantErr     string
+	}{
+		{name: "trimmed", customerRef: "  0812345678 ", want: "0812345678"},
+		{name: "fits its column", customerRef: strings.Repeat("a", 100), want: strings.Repeat("a", 100)},
+		{name: "too long", customerRef: strings.Repeat("a", 101), wantErr: "customer_ref cannot be longer than 100 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("create", func(t *testing.T) {
+				carts := &cartRepositoryStub{carts: make(map[int]*entities.Cart)}
+				service := NewCartService(carts, newStoreRepositoryStub(1), &cartCheckoutStub{}, time.Hour)
+
+				cart, err := service.Create(ctx, 1, &dtos.CartCreateRequestDto{CustomerRef: tt.customerRef})
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("Create() error = %v, want %q", err, tt.wantErr)
+					}
+					if len(carts.carts) != 0 {
+						t.Error("cart was created")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				if cart.CustomerRef != tt.want {
+					t.Errorf("customer_ref = %q, want %q", cart.CustomerRef, tt.want)
+				}
+			})
+
+			t.Run("update", func(t *testing.T) {
+				carts := &cartRepositoryStub{carts: map[int]*entities.Cart{
+					1: {ID: 1, StoreID: 1, Status: entities.CartStatusOpen, CustomerRef: "0800", ExpiresAt: time.Now().Add(time.Hour)},
+				}}
+				service := NewCartService(carts, newStoreRepositoryStub(1), &cartCheckoutStub{}, time.Hour)
+
+				cart, err := service.Update(ctx, 1, 1, &dtos.CartUpdateRequestDto{CustomerRef: tt.customerRef})
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("Update() error = %v, want %q", err, tt.wantErr)
+					}
+					if carts.carts[1].CustomerRef != "0800" {
+						t.Errorf("customer_ref = %q, want it unchanged", carts.carts[1].CustomerRef)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Update() error = %v", err)
+				}
+				if cart.CustomerRef != tt.want {
+					t.Errorf("customer_ref = %q, want %q", cart.CustomerRef, tt.want)
+				}
+			})
+		})
+	}
+}
@@ -11,28 +11,54 @@ import (
 )
 
 type categoryServiceImpl struct {
-	repository repositories.CategoryRepository
-	mapper     *mappers.CategoryMapper
+	repository        repositories.CategoryRepository
+	taxRateRepository repositories.TaxRateRepository
+	mapper            *mappers.CategoryMapper
 }
 
 // NewCategoryService creates a new instance of CategoryService
-func NewCategoryService(repository repositories.CategoryRepository) services.CategoryService {
+func NewCategoryService(repository repositories.CategoryRepository, taxRateRepository repositories.TaxRateRepository) services.CategoryService {
 	return &categoryServiceImpl{
-		repository: repository,
-		mapper:     &mappers.CategoryMapper{},
+		repository:        repository,
+		taxRateRepository: taxRateRepository,
+		mapper:            &mappers.CategoryMapper{},
 	}
 }
 
 // GetAll retrieves all categories
-func (s *categoryServiceImpl) GetAll(ctx context.Context) ([]dtos.CategoryDto, error) {
-	categories, err := s.repository.FindAll(ctx)
+func (s *categoryServiceImpl) GetAll(ctx context.Context, storeID int, includeArchived, withStats bool) ([]dtos.CategoryDto, error) {
+	categories, err := s.repository.FindAll(ctx, includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all categories: %w", err)
 	}
 
+	if withStats {
+		stats, err := s.repository.FindStats(ctx, storeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category stats: %w", err)
+		}
+
+		// Categories without products get zero stats
+		for i := range categories {
+			stat := stats[categories[i].ID]
+			stat.CategoryID = categories[i].ID
+			categories[i].Stats = &stat
+		}
+	}
+
 	return s.mapper.ToDtoList(categories), nil
 }
 
+// GetTree retrieves all categories nested under their parents
+func (s *categoryServiceImpl) GetTree(ctx context.Context, includeArchived bool) ([]dtos.CategoryDto, error) {
+	categories, err := s.repository.FindAll(ctx, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all categories: %w", err)
+	}
+
+	return s.mapper.ToTree(categories), nil
+}
+
 // GetByID retrieves a category by ID
 func (s *categoryServiceImpl) GetByID(ctx context.Context, id int) (*dtos.CategoryDto, error) {
 	category, err := s.repository.FindByID(ctx, id)
@@ -59,6 +85,14 @@ func (s *categoryServiceImpl) Create(ctx context.Context, dto *dtos.CategoryCrea
 	// Convert request to entity
 	category := s.mapper.ToEntity(request)
 
+	if err := s.validateParent(ctx, 0, category.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := validateTaxRateID(ctx, s.taxRateRepository, category.TaxRateID); err != nil {
+		return nil, err
+	}
+
 	// Save to repository
 	err := s.repository.Create(ctx, category)
 	if err != nil {
@@ -85,6 +119,10 @@ func (s *categoryServiceImpl) Update(ctx context.Context, id int, dto *dtos.Cate
 		return nil, fmt.Errorf("category with id %d not found", id)
 	}
 
+	if existingCategory.IsArchived() {
+		return nil, fmt.Errorf("category %s is archived; restore it before editing", existingCategory.Name)
+	}
+
 	// Convert DTO to request
 	request := s.mapper.ToUpdateRequest(dto)
 
@@ -92,6 +130,14 @@ func (s *categoryServiceImpl) Update(ctx context.Context, id int, dto *dtos.Cate
 	s.mapper.UpdateEntity(existingCategory, request)
 	existingCategory.ID = id // Ensure ID is preserved
 
+	if err := s.validateParent(ctx, id, existingCategory.ParentID); err != nil {
+		return nil, err
+	}
+
+	if err := validateTaxRateID(ctx, s.taxRateRepository, existingCategory.TaxRateID); err != nil {
+		return nil, err
+	}
+
 	// Save updated entity
 	err = s.repository.Update(ctx, existingCategory)
 	if err != nil {
@@ -102,22 +148,116 @@ func (s *categoryServiceImpl) Update(ctx context.Context, id int, dto *dtos.Cate
 	return s.mapper.ToDto(existingCategory), nil
 }
 
-// Delete deletes a category by ID
-func (s *categoryServiceImpl) Delete(ctx context.Context, id int) error {
+// Delete archives a category by ID. Its products must be moved to another category
+// or, with orphan set, left uncategorized; both happen in one transaction.
+func (s *categoryServiceImpl) Delete(ctx context.Context, id int, reassignTo *int, orphan bool) (*dtos.CategoryDeleteResultDto, error) {
 	// Check if category exists
 	existingCategory, err := s.repository.FindByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to find category by id %d: %w", id, err)
+		return nil, fmt.Errorf("failed to find category by id %d: %w", id, err)
+	}
+
+	if existingCategory == nil {
+		return nil, fmt.Errorf("category with id %d not found", id)
+	}
+
+	if existingCategory.IsArchived() {
+		return nil, fmt.Errorf("category %s is already archived", existingCategory.Name)
+	}
+
+	if reassignTo != nil && orphan {
+		return nil, fmt.Errorf("reassign_to and orphan cannot be used together")
+	}
+
+	// Validate the category the products move to
+	if reassignTo != nil {
+		if *reassignTo == id {
+			return nil, fmt.Errorf("products cannot be reassigned to the category being deleted")
+		}
+
+		target, err := s.repository.FindByID(ctx, *reassignTo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find category by id %d: %w", *reassignTo, err)
+		}
+		if target == nil {
+			return nil, fmt.Errorf("category with id %d not found", *reassignTo)
+		}
+		if target.IsArchived() {
+			return nil, fmt.Errorf("category %s is archived", target.Name)
+		}
+	}
+
+	// Archive category and move its products; the repository refuses to strand
+	// products when neither option is given
+	moved, err := s.repository.Delete(ctx, id, reassignTo, orphan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return &dtos.CategoryDeleteResultDto{
+		ID:            id,
+		ProductsMoved: moved,
+		ReassignedTo:  reassignTo,
+	}, nil
+}
+
+// Restore brings back an archived category
+func (s *categoryServiceImpl) Restore(ctx context.Context, id int) (*dtos.CategoryDto, error) {
+	existingCategory, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category by id %d: %w", id, err)
 	}
 
 	if existingCategory == nil {
-		return fmt.Errorf("category with id %d not found", id)
+		return nil, fmt.Errorf("category with id %d not found", id)
 	}
 
-	// Delete category
-	err = s.repository.Delete(ctx, id)
+	if !existingCategory.IsArchived() {
+		return nil, fmt.Errorf("category %s is not archived", existingCategory.Name)
+	}
+
+	err = s.repository.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore category: %w", err)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// validateParent checks that a category's parent exists, is not archived and is
+// not the category itself or one of its subcategories. id is 0 for a new category.
+func (s *categoryServiceImpl) validateParent(ctx context.Context, id int, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := s.repository.FindByID(ctx, *parentID)
 	if err != nil {
-		return fmt.Errorf("failed to delete category: %w", err)
+		return fmt.Errorf("failed to find category by id %d: %w", *parentID, err)
+	}
+	if parent == nil {
+		return fmt.Errorf("parent category with id %d not found", *parentID)
+	}
+	if parent.IsArchived() {
+		return fmt.Errorf("parent category %s is archived", parent.Name)
+	}
+
+	// Walk up from the new parent; reaching the category itself would close a cycle
+	visited := make(map[int]bool)
+	for ancestor := parent; ancestor != nil; {
+		if ancestor.ID == id {
+			return fmt.Errorf("category cannot be nested under itself or one of its subcategories")
+		}
+		if visited[ancestor.ID] || ancestor.ParentID == nil {
+			break
+		}
+		visited[ancestor.ID] = true
+
+		nextID := *ancestor.ParentID
+		ancestor, err = s.repository.FindByID(ctx, nextID)
+		if err != nil {
+			return fmt.Errorf("failed to find category by id %d: %w", nextID, err)
+		}
 	}
 
 	return nil
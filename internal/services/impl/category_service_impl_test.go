@@ -0,0 +1,442 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// categoryRepositoryStub keeps categories in memory. products maps the ID of
+// each product, archived ones included, to its category; stats holds the stats of each store.
+type categoryRepositoryStub struct {
+	repositories.CategoryRepository
+	categories map[int]*entities.Category
+	products   map[int]int
+	stats      map[int]map[int]entities.CategoryStats
+}
+
+func newCategoryRepositoryStub(categories ...entities.Category) *categoryRepositoryStub {
+	repository := &categoryRepositoryStub{
+		categories: make(map[int]*entities.Category),
+		products:   make(map[int]int),
+		stats:      make(map[int]map[int]entities.CategoryStats),
+	}
+	for i := range categories {
+		repository.categories[categories[i].ID] = &categories[i]
+	}
+	return repository
+}
+
+func (r *categoryRepositoryStub) FindAll(ctx context.Context, includeArchived bool) ([]entities.Category, error) {
+	var categories []entities.Category
+	for id := 1; id <= len(r.categories); id++ {
+		if category := r.categories[id]; includeArchived || !category.IsArchived() {
+			categories = append(categories, *category)
+		}
+	}
+	return categories, nil
+}
+
+func (r *categoryRepositoryStub) FindStats(ctx context.Context, storeID int) (map[int]entities.CategoryStats, error) {
+	return r.stats[storeID], nil
+}
+
+func (r *categoryRepositoryStub) FindByID(ctx context.Context, id int) (*entities.Category, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *category
+	return &found, nil
+}
+
+func (r *categoryRepositoryStub) Create(ctx context.Context, category *entities.Category) error {
+	category.ID = len(r.categories) + 1
+	stored := *category
+	r.categories[category.ID] = &stored
+	return nil
+}
+
+func (r *categoryRepositoryStub) Update(ctx context.Context, category *entities.Category) error {
+	stored := *category
+	r.categories[category.ID] = &stored
+	return nil
+}
+
+// Delete archives a category and moves its products, with 0 standing for
+// uncategorized. It refuses to strand products when neither option is given.
+func (r *categoryRepositoryStub) Delete(ctx context.Context, id int, reassignTo *int, orphan bool) (int, error) {
+	if reassignTo == nil && !orphan {
+		count := 0
+		for _, categoryID := range r.products {
+			if categoryID == id {
+				count++
+			}
+		}
+		if count > 0 {
+			return 0, fmt.Errorf("category %s still has %d products; pass reassign_to or orphan=true", r.categories[id].Name, count)
+		}
+	}
+
+	deletedAt := time.Now()
+	r.categories[id].DeletedAt = &deletedAt
+
+	moved := 0
+	for productID, categoryID := range r.products {
+		if categoryID != id {
+			continue
+		}
+		r.products[productID] = 0
+		if reassignTo != nil {
+			r.products[productID] = *reassignTo
+		}
+		moved++
+	}
+	return moved, nil
+}
+
+func (r *categoryRepositoryStub) Restore(ctx context.Context, id int) error {
+	r.categories[id].DeletedAt = nil
+	return nil
+}
+
+func TestArchiveCategory(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		archived     bool
+		action       func(s *categoryServiceImpl) error
+		wantErr      string
+		wantArchived bool
+	}{
+		{
+			name: "archive",
+			action: func(s *categoryServiceImpl) error {
+				_, err := s.Delete(ctx, 1, nil, false)
+				return err
+			},
+			wantArchived: true,
+		},
+		{
+			name:     "archived twice",
+			archived: true,
+			action: func(s *categoryServiceImpl) error {
+				_, err := s.Delete(ctx, 1, nil, false)
+				return err
+			},
+			wantErr:      "category Minuman is already archived",
+			wantArchived: true,
+		},
+		{
+			name:     "restore",
+			archived: true,
+			action: func(s *categoryServiceImpl) error {
+				_, err := s.Restore(ctx, 1)
+				return err
+			},
+		},
+		{
+			name: "restore a category that is not archived",
+			action: func(s *categoryServiceImpl) error {
+				_, err := s.Restore(ctx, 1)
+				return err
+			},
+			wantErr: "category Minuman is not archived",
+		},
+		{
+			name:     "edit an archived category",
+			archived: true,
+			action: func(s *categoryServiceImpl) error {
+				_, err := s.Update(ctx, 1, &dtos.CategoryUpdateRequestDto{Name: "Minuman Dingin"})
+				return err
+			},
+			wantErr:      "category Minuman is archived; restore it before editing",
+			wantArchived: true,
+		},
+		{
+			name: "unknown category",
+			action: func(s *categoryServiceImpl) error {
+				_, err := s.Delete(ctx, 2, nil, false)
+				return err
+			},
+			wantErr: "category with id 2 not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category := entities.Category{ID: 1, Name: "Minuman"}
+			if tt.archived {
+				deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+				category.DeletedAt = &deletedAt
+			}
+			repository := newCategoryRepositoryStub(category)
+			service := NewCategoryService(repository, nil).(*categoryServiceImpl)
+
+			err := tt.action(service)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if archived := repository.categories[1].IsArchived(); archived != tt.wantArchived {
+				t.Errorf("archived = %v, want %v", archived, tt.wantArchived)
+			}
+		})
+	}
+}
+
+func TestDeleteCategoryWithProducts(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	food, archived := 2, 3
+	self, unknown := 1, 9
+
+	tests := []struct {
+		name       string
+		reassignTo *int
+		orphan     bool
+		wantErr    string
+		wantMoved  int
+		// wantCategory is where the products end up, 0 for uncategorized
+		wantCategory int
+	}{
+		{name: "reassign", reassignTo: &food, wantMoved: 2, wantCategory: food},
+		{name: "orphan", orphan: true, wantMoved: 2},
+		{name: "products need a destination", wantErr: "category Minuman still has 2 products; pass reassign_to or orphan=true", wantCategory: 1},
+		{name: "reassign and orphan", reassignTo: &food, orphan: true, wantErr: "cannot be used together", wantCategory: 1},
+		{name: "reassign to itself", reassignTo: &self, wantErr: "cannot be reassigned to the category being deleted", wantCategory: 1},
+		{name: "reassign to an archived category", reassignTo: &archived, wantErr: "category Snack is archived", wantCategory: 1},
+		{name: "reassign to an unknown category", reassignTo: &unknown, wantErr: "category with id 9 not found", wantCategory: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newCategoryRepositoryStub(
+				entities.Category{ID: 1, Name: "Minuman"},
+				entities.Category{ID: food, Name: "Makanan"},
+				entities.Category{ID: archived, Name: "Snack", DeletedAt: &deletedAt},
+			)
+			repository.products[10] = 1
+			repository.products[11] = 1
+			repository.products[12] = food
+			service := NewCategoryService(repository, nil).(*categoryServiceImpl)
+
+			result, err := service.Delete(context.Background(), 1, tt.reassignTo, tt.orphan)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Delete() error = %v, want %q", err, tt.wantErr)
+				}
+				if repository.categories[1].IsArchived() {
+					t.Errorf("category was archived")
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+				if result.ProductsMoved != tt.wantMoved {
+					t.Errorf("products moved = %d, want %d", result.ProductsMoved, tt.wantMoved)
+				}
+			}
+
+			for _, productID := range []int{10, 11} {
+				if categoryID := repository.products[productID]; categoryID != tt.wantCategory {
+					t.Errorf("product %d is in category %d, want %d", productID, categoryID, tt.wantCategory)
+				}
+			}
+		})
+	}
+}
+
+func TestProductInArchivedCategory(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	archived, active := 1, 2
+
+	tests := []struct {
+		name       string
+		categoryID int
+		wantErr    string
+	}{
+		{name: "active category", categoryID: active},
+		{name: "archived category", categoryID: archived, wantErr: "category Minuman is archived"},
+		{name: "unknown category", categoryID: 3, wantErr: "category with id 3 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, _ := newProductService(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+			service.categoryRepository = newCategoryRepositoryStub(
+				entities.Category{ID: archived, Name: "Minuman", DeletedAt: &deletedAt},
+				entities.Category{ID: active, Name: "Makanan"},
+			)
+			categoryID := tt.categoryID
+
+			_, err := service.Update(context.Background(), entities.DefaultStoreID, 1, &dtos.ProductUpdateRequestDto{Name: "Kopi", Price: 5000, Stock: 10, CategoryID: &categoryID})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Update() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestCategoryParent(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	drinks, coffee, espresso, archived := 1, 2, 3, 4
+	unknown, none := 9, 0
+
+	tests := []struct {
+		name string
+		// id is the category moved, 0 to create one
+		id       int
+		parentID *int
+		wantErr  string
+	}{
+		{name: "new subcategory", parentID: &espresso},
+		{name: "new top-level category"},
+		{name: "move under another branch", id: espresso, parentID: &drinks},
+		{name: "move to the top level", id: coffee, parentID: &none},
+		{name: "under itself", id: coffee, parentID: &coffee, wantErr: "cannot be nested under itself or one of its subcategories"},
+		{name: "under its child", id: drinks, parentID: &coffee, wantErr: "cannot be nested under itself or one of its subcategories"},
+		{name: "under its grandchild", id: drinks, parentID: &espresso, wantErr: "cannot be nested under itself or one of its subcategories"},
+		{name: "under an archived category", id: coffee, parentID: &archived, wantErr: "parent category Teh is archived"},
+		{name: "under an unknown category", parentID: &unknown, wantErr: "parent category with id 9 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newCategoryRepositoryStub(
+				entities.Category{ID: drinks, Name: "Minuman"},
+				entities.Category{ID: coffee, Name: "Kopi", ParentID: &drinks},
+				entities.Category{ID: espresso, Name: "Espresso", ParentID: &coffee},
+				entities.Category{ID: archived, Name: "Teh", ParentID: &drinks, DeletedAt: &deletedAt},
+			)
+			service := NewCategoryService(repository, nil).(*categoryServiceImpl)
+
+			var category *dtos.CategoryDto
+			var err error
+			if tt.id == 0 {
+				category, err = service.Create(context.Background(), &dtos.CategoryCreateRequestDto{Name: "Baru", ParentID: tt.parentID})
+			} else {
+				category, err = service.Update(context.Background(), tt.id, &dtos.CategoryUpdateRequestDto{Name: repository.categories[tt.id].Name, ParentID: tt.parentID})
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				if len(repository.categories) != 4 {
+					t.Errorf("category was created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			want := tt.parentID
+			if want != nil && *want == 0 {
+				want = nil
+			}
+			if (category.ParentID == nil) != (want == nil) || want != nil && *category.ParentID != *want {
+				t.Errorf("parent = %v, want %v", category.ParentID, want)
+			}
+		})
+	}
+}
+
+func TestCategoryStats(t *testing.T) {
+	deletedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	drinks := entities.CategoryStats{CategoryID: 1, ActiveProducts: 2, UnitsInStock: 30, StockValue: 150000, StockCost: 90000}
+	branchDrinks := entities.CategoryStats{CategoryID: 1, ActiveProducts: 2, UnitsInStock: 4, StockValue: 18000, StockCost: 12000}
+	tea := entities.CategoryStats{CategoryID: 3, ActiveProducts: 1, UnitsInStock: 5, StockValue: 20000, StockCost: 10000}
+
+	tests := []struct {
+		name            string
+		storeID         int
+		includeArchived bool
+		withStats       bool
+		// want holds the stats of each category listed, by ID
+		want map[int]*dtos.CategoryStatsDto
+	}{
+		{
+			name:    "without stats",
+			storeID: 1,
+			want:    map[int]*dtos.CategoryStatsDto{1: nil, 2: nil},
+		},
+		{
+			name:      "category without products has zero stats",
+			storeID:   1,
+			withStats: true,
+			want: map[int]*dtos.CategoryStatsDto{
+				1: {ActiveProducts: 2, UnitsInStock: 30, StockValue: 150000, StockCost: 90000},
+				2: {},
+			},
+		},
+		{
+			name:      "stock of the given store",
+			storeID:   2,
+			withStats: true,
+			want: map[int]*dtos.CategoryStatsDto{
+				1: {ActiveProducts: 2, UnitsInStock: 4, StockValue: 18000, StockCost: 12000},
+				2: {},
+			},
+		},
+		{
+			name:            "archived category",
+			storeID:         1,
+			includeArchived: true,
+			withStats:       true,
+			want: map[int]*dtos.CategoryStatsDto{
+				1: {ActiveProducts: 2, UnitsInStock: 30, StockValue: 150000, StockCost: 90000},
+				2: {},
+				3: {ActiveProducts: 1, UnitsInStock: 5, StockValue: 20000, StockCost: 10000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newCategoryRepositoryStub(
+				entities.Category{ID: 1, Name: "Minuman"},
+				entities.Category{ID: 2, Name: "Makanan"},
+				entities.Category{ID: 3, Name: "Teh", DeletedAt: &deletedAt},
+			)
+			repository.stats[1] = map[int]entities.CategoryStats{1: drinks, 3: tea}
+			repository.stats[2] = map[int]entities.CategoryStats{1: branchDrinks}
+			service := NewCategoryService(repository, nil).(*categoryServiceImpl)
+
+			categories, err := service.GetAll(context.Background(), tt.storeID, tt.includeArchived, tt.withStats)
+			if err != nil {
+				t.Fatalf("GetAll() error = %v", err)
+			}
+
+			if len(categories) != len(tt.want) {
+				t.Fatalf("got %d categories, want %d", len(categories), len(tt.want))
+			}
+			for _, category := range categories {
+				want, ok := tt.want[category.ID]
+				if !ok {
+					t.Errorf("category %d should not be listed", category.ID)
+					continue
+				}
+				if (category.Stats == nil) != (want == nil) || want != nil && *category.Stats != *want {
+					t.Errorf("category %d stats = %+v, want %+v", category.ID, category.Stats, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/labels"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+// maxLabels caps the labels rendered by one request
+const maxLabels = 1000
+
+type labelServiceImpl struct {
+	productRepository repositories.ProductRepository
+}
+
+// NewLabelService creates a new instance of LabelService
+func NewLabelService(productRepository repositories.ProductRepository) services.LabelService {
+	return &labelServiceImpl{
+		productRepository: productRepository,
+	}
+}
+
+// Generate renders shelf labels for products with the prices of the given store
+func (s *labelServiceImpl) Generate(ctx context.Context, storeID int, dto *dtos.LabelRequestDto) (*dtos.LabelFileDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("label request dto cannot be nil")
+	}
+
+	if len(dto.ProductIDs) == 0 {
+		return nil, fmt.Errorf("product_ids cannot be empty")
+	}
+
+	format := dto.Format
+	if format == "" {
+		format = "pdf"
+	}
+	if format != "pdf" && format != "png" {
+		return nil, fmt.Errorf("format must be png or pdf")
+	}
+
+	symbology := dto.Symbology
+	if symbology == "" {
+		symbology = labels.SymbologyCode128
+	}
+
+	copies := dto.Copies
+	if copies == 0 {
+		copies = 1
+	}
+	if copies < 0 || len(dto.ProductIDs)*copies > maxLabels {
+		return nil, fmt.Errorf("at most %d labels can be generated at once", maxLabels)
+	}
+
+	layout := toLabelLayout(dto.Layout)
+	if err := layout.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Build one label per product and copy
+	var sheet []labels.Label
+	for _, id := range dto.ProductIDs {
+		product, err := s.productRepository.FindByID(ctx, storeID, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product with id %d not found", id)
+		}
+
+		content, err := labelContent(product, symbology)
+		if err != nil {
+			return nil, err
+		}
+
+		label := labels.Label{
+			Name:      product.Name,
+			Price:     formatRupiah(product.SellingPrice()),
+			Symbology: symbology,
+			Content:   content,
+		}
+		for i := 0; i < copies; i++ {
+			sheet = append(sheet, label)
+		}
+	}
+
+	result := &dtos.LabelFileDto{Pages: layout.Pages(len(sheet))}
+
+	if format == "png" {
+		page := dto.Page
+		if page == 0 {
+			page = 1
+		}
+		dpi := dto.DPI
+		if dpi == 0 {
+			dpi = labels.DefaultDPI
+		}
+
+		data, err := labels.RenderPNG(sheet, layout, page, dpi)
+		if err != nil {
+			return nil, err
+		}
+		result.ContentType = "image/png"
+		result.FileName = fmt.Sprintf("labels-%d.png", page)
+		result.Data = data
+		return result, nil
+	}
+
+	data, err := labels.RenderPDF(sheet, layout)
+	if err != nil {
+		return nil, err
+	}
+	result.ContentType = "application/pdf"
+	result.FileName = "labels.pdf"
+	result.Data = data
+	return result, nil
+}
+
+// toLabelLayout applies the requested layout over the default sheet
+func toLabelLayout(dto *dtos.LabelLayoutDto) labels.Layout {
+	layout := labels.DefaultLayout()
+	if dto == nil {
+		return layout
+	}
+
+	if dto.Columns != 0 {
+		layout.Columns = dto.Columns
+	}
+	if dto.Rows != 0 {
+		layout.Rows = dto.Rows
+	}
+	if dto.LabelWidth != 0 {
+		layout.LabelWidth = dto.LabelWidth
+	}
+	if dto.LabelHeight != 0 {
+		layout.LabelHeight = dto.LabelHeight
+	}
+	if dto.Margin != nil {
+		layout.Margin = *dto.Margin
+	}
+	if dto.Gap != nil {
+		layout.Gap = *dto.Gap
+	}
+	return layout
+}
+
+// labelContent picks what the barcode of a product's label encodes: EAN-13 uses
+// the product's first barcode, Code128 its SKU or, without one, its first barcode
+func labelContent(product *entities.Product, symbology string) (string, error) {
+	switch symbology {
+	case labels.SymbologyEAN13:
+		if len(product.Barcodes) == 0 {
+			return "", fmt.Errorf("product %s has no EAN-13 barcode", product.Name)
+		}
+		return product.Barcodes[0], nil
+	case labels.SymbologyCode128:
+		if product.SKU != nil && *product.SKU != "" {
+			return *product.SKU, nil
+		}
+		if len(product.Barcodes) > 0 {
+			return product.Barcodes[0], nil
+		}
+		return "", fmt.Errorf("product %s has no SKU or barcode to print", product.Name)
+	default:
+		return "", fmt.Errorf("symbology must be code128 or ean13")
+	}
+}
+
+// formatRupiah formats a price as Indonesian Rupiah, e.g. Rp 15.000
+func formatRupiah(price float64) string {
+	digits := strconv.FormatInt(int64(math.Abs(math.Round(price))), 10)
+
+	// Group thousands with dots
+	var grouped []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped = append(grouped, '.')
+		}
+		grouped = append(grouped, digits[i])
+	}
+
+	if price < 0 {
+		return "-Rp " + string(grouped)
+	}
+	return "Rp " + string(grouped)
+}
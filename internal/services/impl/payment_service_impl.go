@@ -0,0 +1,163 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/gateway"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/qris"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type paymentServiceImpl struct {
+	transactionRepository repositories.TransactionRepository
+	merchant              *qris.Merchant
+	provider              gateway.PaymentProvider
+	mapper                *mappers.TransactionMapper
+}
+
+// NewPaymentService creates a new instance of PaymentService. merchant is the
+// account QRIS payments are made to; without it QRIS codes are not available.
+// provider is the payment gateway; without it webhooks are not accepted.
+func NewPaymentService(transactionRepository repositories.TransactionRepository, merchant *qris.Merchant, provider gateway.PaymentProvider) services.PaymentService {
+	return &paymentServiceImpl{
+		transactionRepository: transactionRepository,
+		merchant:              merchant,
+		provider:              provider,
+		mapper:                &mappers.TransactionMapper{},
+	}
+}
+
+// GetQRIS builds the dynamic QRIS code for the pending QRIS payment of a pending transaction
+func (s *paymentServiceImpl) GetQRIS(ctx context.Context, transactionID int) (*dtos.QRISDto, error) {
+	if s.merchant == nil {
+		return nil, fmt.Errorf("QRIS is not configured; set the QRIS_* environment variables")
+	}
+
+	transaction, err := s.transactionRepository.FindByID(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by id %d: %w", transactionID, err)
+	}
+
+	if transaction == nil {
+		return nil, fmt.Errorf("transaction with id %d not found", transactionID)
+	}
+
+	// A code is only shown while the customer still has to pay, so completed,
+	// voided, expired and refunded transactions cannot be paid again
+	if transaction.Status != entities.TransactionStatusPending {
+		return nil, fmt.Errorf("transaction %d is %s; QRIS codes are only available for pending transactions", transactionID, transaction.Status)
+	}
+
+	// The code asks for exactly what the customer still pays by QRIS, which is all
+	// of a transaction paid by QRIS alone or its QRIS share of a split payment
+	amount := 0
+	for _, payment := range transaction.Payments {
+		if payment.Method == entities.PaymentQRIS && payment.Status == entities.PaymentStatusPending {
+			amount += payment.Amount
+		}
+	}
+	if amount == 0 {
+		return nil, fmt.Errorf("transaction %d has no pending QRIS payment", transactionID)
+	}
+
+	billNumber := fmt.Sprintf("TRX-%d", transaction.ID)
+	payload, err := qris.Payload(*s.merchant, amount, billNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build QRIS payload: %w", err)
+	}
+
+	return &dtos.QRISDto{
+		TransactionID: transaction.ID,
+		Amount:        amount,
+		BillNumber:    billNumber,
+		Payload:       payload,
+	}, nil
+}
+
+// RenderQRIS renders the QRIS code of a transaction as a PNG image
+func (s *paymentServiceImpl) RenderQRIS(ctx context.Context, transactionID, size int) ([]byte, error) {
+	code, err := s.GetQRIS(ctx, transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return qris.RenderPNG(code.Payload, size)
+}
+
+// HandleWebhook verifies a webhook from the payment gateway and records the payment outcome it reports
+func (s *paymentServiceImpl) HandleWebhook(ctx context.Context, header http.Header, body []byte) (*dtos.TransactionDto, error) {
+	if s.provider == nil {
+		return nil, fmt.Errorf("no payment gateway is configured; set PAYMENT_PROVIDER")
+	}
+
+	event, err := s.provider.ParseWebhook(header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.transactionRepository.FindPaymentByProviderRef(ctx, s.provider.Name(), event.ProviderRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment %s: %w", event.ProviderRef, err)
+	}
+
+	if payment == nil {
+		return nil, fmt.Errorf("payment %s not found", event.ProviderRef)
+	}
+
+	// A gateway charging a different amount than the payment asked for must not
+	// complete the sale, so the payment stays pending for a person to check. A
+	// failed charge took no money, so it fails the payment whatever its amount.
+	paid := event.Status == gateway.EventPaid
+	if paid && event.Amount != payment.Amount {
+		return nil, fmt.Errorf("webhook for payment %s reports an amount of %d but the payment is %d", event.ProviderRef, event.Amount, payment.Amount)
+	}
+
+	payment, err = s.transactionRepository.ResolvePayment(ctx, s.provider.Name(), event.ProviderRef, paid, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve payment %s: %w", event.ProviderRef, err)
+	}
+
+	if payment == nil {
+		return nil, fmt.Errorf("payment %s not found", event.ProviderRef)
+	}
+
+	transaction, err := s.transactionRepository.FindByID(ctx, payment.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by id %d: %w", payment.TransactionID, err)
+	}
+
+	if transaction == nil {
+		return nil, fmt.Errorf("transaction with id %d not found", payment.TransactionID)
+	}
+
+	return s.mapper.ToDto(transaction), nil
+}
+
+// ExpirePending expires the pending transactions whose payments were not confirmed in time
+func (s *paymentServiceImpl) ExpirePending(ctx context.Context) (int, error) {
+	now := time.Now()
+	ids, err := s.transactionRepository.FindExpiredPending(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, id := range ids {
+		released, err := s.transactionRepository.ReleasePending(ctx, id, entities.TransactionStatusExpired, now)
+		if err != nil {
+			return expired, fmt.Errorf("failed to expire transaction %d: %w", id, err)
+		}
+		if released {
+			expired++
+		}
+	}
+
+	return expired, nil
+}
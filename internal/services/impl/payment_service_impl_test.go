@@ -0,0 +1,235 @@
+package impl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/gateway"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// webhookRepository keeps gateway payments and their transactions in memory,
+// resolving payments the way the database does: only the first outcome counts
+type webhookRepository struct {
+	repositories.TransactionRepository
+	payments     map[string]*entities.TransactionPayment
+	transactions map[int]*entities.Transaction
+	resolved     int
+}
+
+func newWebhookRepository() *webhookRepository {
+	return &webhookRepository{
+		payments: map[string]*entities.TransactionPayment{
+			"fake_1": {ID: 1, TransactionID: 10, Method: entities.PaymentCredit, Amount: 50000, Status: entities.PaymentStatusPending, Provider: "fake", ProviderRef: "fake_1"},
+		},
+		transactions: map[int]*entities.Transaction{
+			10: {ID: 10, TotalAmount: 50000, Status: entities.TransactionStatusPending},
+		},
+	}
+}
+
+func (r *webhookRepository) FindPaymentByProviderRef(ctx context.Context, provider, providerRef string) (*entities.TransactionPayment, error) {
+	payment, ok := r.payments[providerRef]
+	if !ok || payment.Provider != provider {
+		return nil, nil
+	}
+	found := *payment
+	return &found, nil
+}
+
+func (r *webhookRepository) ResolvePayment(ctx context.Context, provider, providerRef string, paid bool, at time.Time) (*entities.TransactionPayment, error) {
+	payment, ok := r.payments[providerRef]
+	if !ok || payment.Provider != provider {
+		return nil, nil
+	}
+	if payment.Status != entities.PaymentStatusPending {
+		return payment, nil
+	}
+
+	r.resolved++
+	transaction := r.transactions[payment.TransactionID]
+	payment.ResolvedAt = &at
+	if paid {
+		payment.Status = entities.PaymentStatusPaid
+		transaction.Status = entities.TransactionStatusCompleted
+		transaction.CompletedAt = &at
+	} else {
+		payment.Status = entities.PaymentStatusFailed
+		transaction.Status = entities.TransactionStatusFailed
+		transaction.FailedAt = &at
+	}
+	return payment, nil
+}
+
+func (r *webhookRepository) FindByID(ctx context.Context, id int) (*entities.Transaction, error) {
+	return r.transactions[id], nil
+}
+
+func newWebhookService(t *testing.T) (*paymentServiceImpl, *webhookRepository, *gateway.FakeProvider) {
+	t.Helper()
+	provider, err := gateway.NewFakeProvider("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	repository := newWebhookRepository()
+	service := NewPaymentService(repository, nil, provider).(*paymentServiceImpl)
+	return service, repository, provider
+}
+
+func signedWebhook(t *testing.T, provider *gateway.FakeProvider, event gateway.Event) ([]byte, http.Header) {
+	t.Helper()
+	body, header, err := provider.Webhook(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body, header
+}
+
+func TestHandleWebhook(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      gateway.Event
+		wantStatus string
+	}{
+		{name: "paid completes the transaction", event: gateway.Event{ProviderRef: "fake_1", Status: gateway.EventPaid, Amount: 50000}, wantStatus: entities.TransactionStatusCompleted},
+		{name: "failed fails the transaction", event: gateway.Event{ProviderRef: "fake_1", Status: gateway.EventFailed, Amount: 50000}, wantStatus: entities.TransactionStatusFailed},
+		{name: "failed with another amount fails the transaction", event: gateway.Event{ProviderRef: "fake_1", Status: gateway.EventFailed, Amount: 1}, wantStatus: entities.TransactionStatusFailed},
+		{name: "failed without an amount fails the transaction", event: gateway.Event{ProviderRef: "fake_1", Status: gateway.EventFailed}, wantStatus: entities.TransactionStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository, provider := newWebhookService(t)
+			body, header := signedWebhook(t, provider, tt.event)
+
+			transaction, err := service.HandleWebhook(context.Background(), header, body)
+			if err != nil {
+				t.Fatalf("HandleWebhook() error = %v", err)
+			}
+			if transaction.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", transaction.Status, tt.wantStatus)
+			}
+			if repository.resolved != 1 {
+				t.Errorf("resolved %d payments, want 1", repository.resolved)
+			}
+		})
+	}
+}
+
+func TestHandleWebhookSignature(t *testing.T) {
+	event := gateway.Event{ProviderRef: "fake_1", Status: gateway.EventPaid, Amount: 50000}
+
+	tests := []struct {
+		name   string
+		tamper func(body []byte, header http.Header) ([]byte, http.Header)
+	}{
+		{
+			name: "missing signature",
+			tamper: func(body []byte, header http.Header) ([]byte, http.Header) {
+				return body, http.Header{}
+			},
+		},
+		{
+			name: "signature from another secret",
+			tamper: func(body []byte, header http.Header) ([]byte, http.Header) {
+				other, _ := gateway.NewFakeProvider("other")
+				_, otherHeader, _ := other.Webhook(event)
+				return body, otherHeader
+			},
+		},
+		{
+			name: "body changed after signing",
+			tamper: func(body []byte, header http.Header) ([]byte, http.Header) {
+				return []byte(strings.Replace(string(body), "50000", "5", 1)), header
+			},
+		},
+		{
+			name: "signature not hex",
+			tamper: func(body []byte, header http.Header) ([]byte, http.Header) {
+				header.Set(gateway.FakeSignatureHeader, "not-hex")
+				return body, header
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository, provider := newWebhookService(t)
+			body, header := tt.tamper(signedWebhook(t, provider, event))
+
+			_, err := service.HandleWebhook(context.Background(), header, body)
+			if !errors.Is(err, gateway.ErrInvalidSignature) {
+				t.Fatalf("HandleWebhook() error = %v, want %v", err, gateway.ErrInvalidSignature)
+			}
+			if repository.resolved != 0 {
+				t.Errorf("resolved %d payments, want 0", repository.resolved)
+			}
+		})
+	}
+}
+
+func TestHandleWebhookDuplicate(t *testing.T) {
+	service, repository, provider := newWebhookService(t)
+
+	paid, paidHeader := signedWebhook(t, provider, gateway.Event{ProviderRef: "fake_1", Status: gateway.EventPaid, Amount: 50000})
+	failed, failedHeader := signedWebhook(t, provider, gateway.Event{ProviderRef: "fake_1", Status: gateway.EventFailed, Amount: 50000})
+
+	// The gateway retries the paid event and then reports a late failure; neither
+	// may change the outcome already recorded
+	deliveries := []struct {
+		body   []byte
+		header http.Header
+	}{{paid, paidHeader}, {paid, paidHeader}, {failed, failedHeader}}
+
+	for i, delivery := range deliveries {
+		transaction, err := service.HandleWebhook(context.Background(), delivery.header, delivery.body)
+		if err != nil {
+			t.Fatalf("delivery %d: HandleWebhook() error = %v", i, err)
+		}
+		if transaction.Status != entities.TransactionStatusCompleted {
+			t.Errorf("delivery %d: status = %s, want %s", i, transaction.Status, entities.TransactionStatusCompleted)
+		}
+	}
+
+	if repository.resolved != 1 {
+		t.Errorf("resolved %d payments, want 1", repository.resolved)
+	}
+	if status := repository.payments["fake_1"].Status; status != entities.PaymentStatusPaid {
+		t.Errorf("payment status = %s, want %s", status, entities.PaymentStatusPaid)
+	}
+}
+
+func TestHandleWebhookRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   gateway.Event
+		wantErr string
+	}{
+		{name: "amount below the payment", event: gateway.Event{ProviderRef: "fake_1", Status: gateway.EventPaid, Amount: 100}, wantErr: "reports an amount of 100 but the payment is 50000"},
+		{name: "amount above the payment", event: gateway.Event{ProviderRef: "fake_1", Status: gateway.EventPaid, Amount: 60000}, wantErr: "reports an amount of 60000 but the payment is 50000"},
+		{name: "unknown payment", event: gateway.Event{ProviderRef: "fake_2", Status: gateway.EventPaid, Amount: 50000}, wantErr: "payment fake_2 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository, provider := newWebhookService(t)
+			body, header := signedWebhook(t, provider, tt.event)
+
+			_, err := service.HandleWebhook(context.Background(), header, body)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("HandleWebhook() error = %v, want %q", err, tt.wantErr)
+			}
+			if repository.resolved != 0 {
+				t.Errorf("resolved %d payments, want 0", repository.resolved)
+			}
+			if status := repository.payments["fake_1"].Status; status != entities.PaymentStatusPending {
+				t.Errorf("payment status = %s, want %s", status, entities.PaymentStatusPending)
+			}
+		})
+	}
+}
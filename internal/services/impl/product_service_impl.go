@@ -3,34 +3,60 @@ package impl
 import (
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
 	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
 	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
 	"github.com/gustionusamba24/kasir-api-go/internal/services"
 )
 
 type productServiceImpl struct {
-	repository         repositories.ProductRepository
-	categoryRepository repositories.CategoryRepository
-	mapper             *mappers.ProductMapper
+	repository             repositories.ProductRepository
+	categoryRepository     repositories.CategoryRepository
+	taxRateRepository      repositories.TaxRateRepository
+	goodsReceiptRepository repositories.GoodsReceiptRepository
+	lotRepository          repositories.ProductLotRepository
+	serialRepository       repositories.ProductSerialRepository
+	mapper                 *mappers.ProductMapper
+	receiptMapper          *mappers.GoodsReceiptMapper
+	lotMapper              *mappers.ProductLotMapper
+	serialMapper           *mappers.ProductSerialMapper
+	scaleFormat            entities.ScaleBarcodeFormat
 }
 
 // NewProductService creates a new instance of ProductService
 func NewProductService(
 	repository repositories.ProductRepository,
 	categoryRepository repositories.CategoryRepository,
+	taxRateRepository repositories.TaxRateRepository,
+	goodsReceiptRepository repositories.GoodsReceiptRepository,
+	lotRepository repositories.ProductLotRepository,
+	serialRepository repositories.ProductSerialRepository,
+	scaleFormat entities.ScaleBarcodeFormat,
 ) services.ProductService {
 	return &productServiceImpl{
-		repository:         repository,
-		categoryRepository: categoryRepository,
-		mapper:             &mappers.ProductMapper{},
+		repository:             repository,
+		categoryRepository:     categoryRepository,
+		taxRateRepository:      taxRateRepository,
+		goodsReceiptRepository: goodsReceiptRepository,
+		lotRepository:          lotRepository,
+		serialRepository:       serialRepository,
+		mapper:                 &mappers.ProductMapper{},
+		receiptMapper:          &mappers.GoodsReceiptMapper{},
+		lotMapper:              &mappers.ProductLotMapper{},
+		serialMapper:           &mappers.ProductSerialMapper{},
+		scaleFormat:            scaleFormat,
 	}
 }
 
 // GetAll retrieves all products
-func (s *productServiceImpl) GetAll(ctx context.Context) ([]dtos.ProductDto, error) {
-	products, err := s.repository.FindAll(ctx)
+func (s *productServiceImpl) GetAll(ctx context.Context, storeID int, includeArchived bool) ([]dtos.ProductDto, error) {
+	products, err := s.repository.FindAll(ctx, storeID, includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get all products: %w", err)
 	}
@@ -39,8 +65,8 @@ func (s *productServiceImpl) GetAll(ctx context.Context) ([]dtos.ProductDto, err
 }
 
 // GetByID retrieves a product by ID
-func (s *productServiceImpl) GetByID(ctx context.Context, id int) (*dtos.ProductDto, error) {
-	product, err := s.repository.FindByID(ctx, id)
+func (s *productServiceImpl) GetByID(ctx context.Context, storeID, id int) (*dtos.ProductDto, error) {
+	product, err := s.repository.FindByID(ctx, storeID, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product by id %d: %w", id, err)
 	}
@@ -49,11 +75,25 @@ func (s *productServiceImpl) GetByID(ctx context.Context, id int) (*dtos.Product
 		return nil, fmt.Errorf("product with id %d not found", id)
 	}
 
+	if product.IsBundle {
+		product.Components, err = s.repository.FindComponents(ctx, storeID, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get components of product %d: %w", id, err)
+		}
+	}
+
+	// Parents carry their option axes and variant matrix
+	if product.ParentID == nil {
+		if err := s.loadVariants(ctx, storeID, product, false); err != nil {
+			return nil, err
+		}
+	}
+
 	return s.mapper.ToDto(product), nil
 }
 
 // GetByCategoryID retrieves all products by category ID
-func (s *productServiceImpl) GetByCategoryID(ctx context.Context, categoryID int) ([]dtos.ProductDto, error) {
+func (s *productServiceImpl) GetByCategoryID(ctx context.Context, storeID, categoryID int, includeDescendants, includeArchived bool) ([]dtos.ProductDto, error) {
 	// Validate category exists
 	category, err := s.categoryRepository.FindByID(ctx, categoryID)
 	if err != nil {
@@ -64,7 +104,7 @@ func (s *productServiceImpl) GetByCategoryID(ctx context.Context, categoryID int
 		return nil, fmt.Errorf("category with id %d not found", categoryID)
 	}
 
-	products, err := s.repository.FindByCategoryID(ctx, categoryID)
+	products, err := s.repository.FindByCategoryID(ctx, storeID, categoryID, includeDescendants, includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get products by category id %d: %w", categoryID, err)
 	}
@@ -73,8 +113,8 @@ func (s *productServiceImpl) GetByCategoryID(ctx context.Context, categoryID int
 }
 
 // Search searches products by name and active status
-func (s *productServiceImpl) Search(ctx context.Context, name string, active *bool) ([]dtos.ProductDto, error) {
-	products, err := s.repository.FindByFilters(ctx, name, active)
+func (s *productServiceImpl) Search(ctx context.Context, storeID int, name string, active *bool, includeArchived bool) ([]dtos.ProductDto, error) {
+	products, err := s.repository.FindByFilters(ctx, storeID, name, active, includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search products: %w", err)
 	}
@@ -83,11 +123,46 @@ func (s *productServiceImpl) Search(ctx context.Context, name string, active *bo
 }
 
 // Create creates a new product
-func (s *productServiceImpl) Create(ctx context.Context, dto *dtos.ProductCreateRequestDto) (*dtos.ProductDto, error) {
+func (s *productServiceImpl) Create(ctx context.Context, storeID int, dto *dtos.ProductCreateRequestDto) (*dtos.ProductDto, error) {
 	if dto == nil {
 		return nil, fmt.Errorf("create request dto cannot be nil")
 	}
 
+	// Serialized stock needs a serial number per unit, which only goods receipts record
+	if dto.Serialized && dto.Stock > 0 {
+		return nil, fmt.Errorf("serialized products must start with zero stock and be stocked through goods receipts")
+	}
+
+	// Lot-tracked stock is held in lots, which only goods receipts record
+	if dto.TrackLots && dto.Stock > 0 {
+		return nil, fmt.Errorf("lot-tracked products must start with zero stock and be stocked through goods receipts")
+	}
+
+	// Validate SKU and barcodes are well formed and not used by another product
+	sku, barcodes, err := s.validateIdentifiers(ctx, 0, dto.SKU, dto.Barcodes)
+	if err != nil {
+		return nil, err
+	}
+	if sku != nil && *sku == "" {
+		sku = nil
+	}
+	dto.SKU, dto.Barcodes = sku, barcodes
+
+	// Validate the PLU scale labels refer to the product by
+	dto.PLU, err = s.validatePLU(ctx, 0, dto.PLU)
+	if err != nil {
+		return nil, err
+	}
+	if dto.PLU != nil && *dto.PLU == "" {
+		dto.PLU = nil
+	}
+
+	// Validate the unit of measure and purchasing units
+	dto.Unit, dto.UnitConversions, err = normalizeUnits(dto.Unit, dto.UnitConversions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate category exists if provided
 	if dto.CategoryID != nil {
 		category, err := s.categoryRepository.FindByID(ctx, *dto.CategoryID)
@@ -97,6 +172,14 @@ func (s *productServiceImpl) Create(ctx context.Context, dto *dtos.ProductCreate
 		if category == nil {
 			return nil, fmt.Errorf("category with id %d not found", *dto.CategoryID)
 		}
+		if category.IsArchived() {
+			return nil, fmt.Errorf("category %s is archived", category.Name)
+		}
+	}
+
+	// Validate tax rate exists if provided
+	if err := validateTaxRateID(ctx, s.taxRateRepository, dto.TaxRateID); err != nil {
+		return nil, err
 	}
 
 	// Convert DTO to request
@@ -105,8 +188,12 @@ func (s *productServiceImpl) Create(ctx context.Context, dto *dtos.ProductCreate
 	// Convert request to entity
 	product := s.mapper.ToEntity(request)
 
+	if err := validateQuantitySettings(product); err != nil {
+		return nil, err
+	}
+
 	// Save to repository
-	err := s.repository.Create(ctx, product)
+	err = s.repository.Create(ctx, storeID, product)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
@@ -116,13 +203,13 @@ func (s *productServiceImpl) Create(ctx context.Context, dto *dtos.ProductCreate
 }
 
 // Update updates an existing product
-func (s *productServiceImpl) Update(ctx context.Context, id int, dto *dtos.ProductUpdateRequestDto) (*dtos.ProductDto, error) {
+func (s *productServiceImpl) Update(ctx context.Context, storeID, id int, dto *dtos.ProductUpdateRequestDto) (*dtos.ProductDto, error) {
 	if dto == nil {
 		return nil, fmt.Errorf("update request dto cannot be nil")
 	}
 
 	// Check if product exists
-	existingProduct, err := s.repository.FindByID(ctx, id)
+	existingProduct, err := s.repository.FindByID(ctx, storeID, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
 	}
@@ -131,6 +218,10 @@ func (s *productServiceImpl) Update(ctx context.Context, id int, dto *dtos.Produ
 		return nil, fmt.Errorf("product with id %d not found", id)
 	}
 
+	if existingProduct.IsArchived() {
+		return nil, fmt.Errorf("product %s is archived; restore it before editing", existingProduct.Name)
+	}
+
 	// Validate category exists if provided
 	if dto.CategoryID != nil {
 		category, err := s.categoryRepository.FindByID(ctx, *dto.CategoryID)
@@ -140,6 +231,47 @@ func (s *productServiceImpl) Update(ctx context.Context, id int, dto *dtos.Produ
 		if category == nil {
 			return nil, fmt.Errorf("category with id %d not found", *dto.CategoryID)
 		}
+		if category.IsArchived() {
+			return nil, fmt.Errorf("category %s is archived", category.Name)
+		}
+	}
+
+	// Validate tax rate exists if provided
+	if err := validateTaxRateID(ctx, s.taxRateRepository, dto.TaxRateID); err != nil {
+		return nil, err
+	}
+
+	// Validate SKU and barcodes are well formed and not used by another product
+	sku, barcodes, err := s.validateIdentifiers(ctx, id, dto.SKU, dto.Barcodes)
+	if err != nil {
+		return nil, err
+	}
+	dto.SKU, dto.Barcodes = sku, barcodes
+
+	// Validate the PLU scale labels refer to the product by
+	dto.PLU, err = s.validatePLU(ctx, id, dto.PLU)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the unit of measure and purchasing units
+	dto.Unit, dto.UnitConversions, err = normalizeUnits(dto.Unit, dto.UnitConversions)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stock is saved as the change from the stock read above, so a sale made
+	// meanwhile is not lost. A bundle's stock is derived from its components, so
+	// its own stock stays at zero.
+	available := existingProduct.Stock
+	stockDelta := entities.RoundQuantity(dto.Stock - available)
+	if existingProduct.IsBundle {
+		stockDelta = 0
+	}
+
+	// Validate the stock may be edited this way
+	if err := s.validateStockEdit(ctx, storeID, existingProduct, dto, stockDelta); err != nil {
+		return nil, err
 	}
 
 	// Convert DTO to request
@@ -148,9 +280,16 @@ func (s *productServiceImpl) Update(ctx context.Context, id int, dto *dtos.Produ
 	// Update entity with request data
 	s.mapper.UpdateEntity(existingProduct, request)
 	existingProduct.ID = id // Ensure ID is preserved
+	if existingProduct.IsBundle {
+		existingProduct.Stock = available
+	}
+
+	if err := validateQuantitySettings(existingProduct); err != nil {
+		return nil, err
+	}
 
 	// Save updated entity
-	err = s.repository.Update(ctx, existingProduct)
+	err = s.repository.Update(ctx, storeID, existingProduct, stockDelta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update product: %w", err)
 	}
@@ -159,10 +298,42 @@ func (s *productServiceImpl) Update(ctx context.Context, id int, dto *dtos.Produ
 	return s.mapper.ToDto(existingProduct), nil
 }
 
-// Delete deletes a product by ID
+// validateStockEdit checks a product update changes the stock and the way it is
+// tracked only where the stock recorded elsewhere stays right. The stock of a
+// serialized product follows its serial numbers, so it changes only by receiving
+// and selling them, and the product can start or stop being serialized only while
+// the store holds none of it. The stock of a lot-tracked product is held in its
+// lots the same way, and whether a product tracks lots cannot change once it exists.
+func (s *productServiceImpl) validateStockEdit(ctx context.Context, storeID int, product *entities.Product, dto *dtos.ProductUpdateRequestDto, stockDelta float64) error {
+	if product.Serialized && stockDelta != 0 {
+		return fmt.Errorf("stock of serialized product %s cannot be edited; receive or sell its serial numbers instead", product.Name)
+	}
+
+	if dto.Serialized != nil && *dto.Serialized != product.Serialized {
+		serials, err := s.serialRepository.FindInStockByProductID(ctx, storeID, product.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get serials for product id %d: %w", product.ID, err)
+		}
+		if product.Stock != 0 || len(serials) > 0 {
+			return fmt.Errorf("product %s can only start or stop being serialized while it has no stock", product.Name)
+		}
+	}
+
+	if product.TrackLots && stockDelta != 0 {
+		return fmt.Errorf("stock of lot-tracked product %s cannot be edited; receive goods into a lot or sell from its lots instead", product.Name)
+	}
+
+	if dto.TrackLots != nil && *dto.TrackLots != product.TrackLots {
+		return fmt.Errorf("lot tracking of product %s cannot be changed", product.Name)
+	}
+
+	return nil
+}
+
+// Delete archives a product by ID. Variants of a parent product are archived with it.
 func (s *productServiceImpl) Delete(ctx context.Context, id int) error {
-	// Check if product exists
-	existingProduct, err := s.repository.FindByID(ctx, id)
+	// Check if product exists in the shared catalog
+	existingProduct, err := s.repository.FindByID(ctx, entities.DefaultStoreID, id)
 	if err != nil {
 		return fmt.Errorf("failed to find product by id %d: %w", id, err)
 	}
@@ -171,7 +342,11 @@ func (s *productServiceImpl) Delete(ctx context.Context, id int) error {
 		return fmt.Errorf("product with id %d not found", id)
 	}
 
-	// Delete product
+	if existingProduct.IsArchived() {
+		return fmt.Errorf("product %s is already archived", existingProduct.Name)
+	}
+
+	// Archive product
 	err = s.repository.Delete(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete product: %w", err)
@@ -179,3 +354,798 @@ func (s *productServiceImpl) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// Restore brings back an archived product together with the variants archived with it
+func (s *productServiceImpl) Restore(ctx context.Context, storeID, id int) (*dtos.ProductDto, error) {
+	existingProduct, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if existingProduct == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	if !existingProduct.IsArchived() {
+		return nil, fmt.Errorf("product %s is not archived", existingProduct.Name)
+	}
+
+	// A variant cannot come back without its parent
+	if existingProduct.ParentID != nil {
+		parent, err := s.repository.FindByID(ctx, storeID, *existingProduct.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product by id %d: %w", *existingProduct.ParentID, err)
+		}
+		if parent != nil && parent.IsArchived() {
+			return nil, fmt.Errorf("parent product %s is archived; restore it first", parent.Name)
+		}
+	}
+
+	err = s.repository.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore product: %w", err)
+	}
+
+	return s.GetByID(ctx, storeID, id)
+}
+
+// ReceiveGoods records incoming stock for a product at a store and updates its weighted average cost
+func (s *productServiceImpl) ReceiveGoods(ctx context.Context, storeID, id int, dto *dtos.GoodsReceiptCreateRequestDto) (*dtos.GoodsReceiptDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("goods receipt request dto cannot be nil")
+	}
+
+	if dto.Quantity <= 0 {
+		return nil, fmt.Errorf("quantity must be greater than 0")
+	}
+
+	if dto.UnitCost < 0 {
+		return nil, fmt.Errorf("unit cost cannot be negative")
+	}
+
+	// Check if product exists
+	existingProduct, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if existingProduct == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	if existingProduct.IsArchived() {
+		return nil, fmt.Errorf("product %s is archived", existingProduct.Name)
+	}
+
+	if existingProduct.IsBundle {
+		return nil, fmt.Errorf("product %s is a bundle; receive its components instead", existingProduct.Name)
+	}
+
+	// Goods bought in a purchasing unit, such as a box of 24, are received in the stock unit
+	if dto.Unit != nil {
+		received, err := existingProduct.ConvertQuantity(dto.Quantity, *dto.Unit)
+		if err != nil {
+			return nil, err
+		}
+		dto.UnitCost = dto.UnitCost * dto.Quantity / received
+		dto.Quantity = received
+		dto.Unit = nil
+	}
+
+	if err := existingProduct.ValidateQuantity(dto.Quantity); err != nil {
+		return nil, err
+	}
+
+	// Lot-tracked products must say which lot arrived; other products cannot
+	if dto.LotNumber != nil {
+		trimmed := strings.TrimSpace(*dto.LotNumber)
+		dto.LotNumber = &trimmed
+		if trimmed == "" {
+			dto.LotNumber = nil
+		}
+	}
+
+	if existingProduct.TrackLots && dto.LotNumber == nil {
+		return nil, fmt.Errorf("lot number is required for products that track lots")
+	}
+
+	if !existingProduct.TrackLots && (dto.LotNumber != nil || dto.ExpiryDate != nil) {
+		return nil, fmt.Errorf("product with id %d does not track lots", id)
+	}
+
+	if dto.ExpiryDate != nil {
+		if _, err := time.Parse("2006-01-02", *dto.ExpiryDate); err != nil {
+			return nil, fmt.Errorf("invalid expiry date, expected YYYY-MM-DD")
+		}
+	}
+
+	// Serialized products must list the serial number of every unit received
+	if existingProduct.Serialized {
+		serialNumbers, err := normalizeSerialNumbers(dto.SerialNumbers, int(dto.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		dto.SerialNumbers = serialNumbers
+	} else if len(dto.SerialNumbers) > 0 {
+		return nil, fmt.Errorf("product with id %d is not serialized", id)
+	}
+
+	// Save receipt and update stock and average cost
+	receipt := s.receiptMapper.ToEntity(storeID, id, dto)
+	product, err := s.goodsReceiptRepository.Create(ctx, receipt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive goods: %w", err)
+	}
+
+	result := s.receiptMapper.ToDto(receipt)
+	result.StockAfter = product.Stock
+	result.AverageCost = product.Cost
+
+	return result, nil
+}
+
+// GetReceipts retrieves the goods receipt history of a product
+func (s *productServiceImpl) GetReceipts(ctx context.Context, id int) ([]dtos.GoodsReceiptDto, error) {
+	// Check if product exists in the shared catalog
+	existingProduct, err := s.repository.FindByID(ctx, entities.DefaultStoreID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if existingProduct == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	receipts, err := s.goodsReceiptRepository.FindByProductID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goods receipts for product id %d: %w", id, err)
+	}
+
+	return s.receiptMapper.ToDtoList(receipts), nil
+}
+
+// GetLots retrieves the lots of a product held by a store, earliest expiry first
+func (s *productServiceImpl) GetLots(ctx context.Context, storeID, id int) ([]dtos.ProductLotDto, error) {
+	// Check if product exists
+	existingProduct, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if existingProduct == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	lots, err := s.lotRepository.FindByProductID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lots for product id %d: %w", id, err)
+	}
+
+	return s.lotMapper.ToDtoList(lots), nil
+}
+
+// GetSerials retrieves the unsold serial numbers of a product held by a store
+func (s *productServiceImpl) GetSerials(ctx context.Context, storeID, id int) ([]dtos.ProductSerialDto, error) {
+	// Check if product exists in the shared catalog
+	existingProduct, err := s.repository.FindByID(ctx, entities.DefaultStoreID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if existingProduct == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	serials, err := s.serialRepository.FindInStockByProductID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get serials for product id %d: %w", id, err)
+	}
+
+	return s.serialMapper.ToDtoList(serials), nil
+}
+
+// Lookup finds the product a scanned barcode or SKU belongs to
+func (s *productServiceImpl) Lookup(ctx context.Context, storeID int, barcode, sku string) (*dtos.ProductDto, error) {
+	var product *entities.Product
+	var err error
+
+	switch {
+	case barcode != "":
+		var label *entities.ScaleBarcode
+		product, label, err = findProductByBarcode(ctx, s.repository, s.scaleFormat, storeID, barcode)
+		if err != nil {
+			return nil, err
+		}
+
+		// Scale labels also tell the quantity and price of the weighed item
+		if label != nil {
+			quantity, err := label.Quantity(product)
+			if err != nil {
+				return nil, err
+			}
+
+			price := math.Round(product.SellingPrice() * quantity)
+			if label.Price != nil {
+				price = *label.Price
+			}
+
+			result := s.mapper.ToDto(product)
+			result.ScaleLabel = &dtos.ScaleLabelDto{
+				Barcode:  label.Barcode,
+				PLU:      label.PLU,
+				Quantity: quantity,
+				Unit:     product.Unit,
+				Price:    price,
+			}
+			return result, nil
+		}
+	case sku != "":
+		product, err = s.repository.FindBySKU(ctx, storeID, sku)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product by sku %s: %w", sku, err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product with sku %s not found", sku)
+		}
+		if product.IsArchived() {
+			return nil, fmt.Errorf("product %s is archived", product.Name)
+		}
+	default:
+		return nil, fmt.Errorf("barcode or sku is required")
+	}
+
+	return s.mapper.ToDto(product), nil
+}
+
+// SetComponents replaces the components of a bundle
+func (s *productServiceImpl) SetComponents(ctx context.Context, storeID, id int, dto *dtos.BundleComponentsUpdateRequestDto) (*dtos.ProductDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("components request dto cannot be nil")
+	}
+
+	product, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if product == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	if product.IsArchived() {
+		return nil, fmt.Errorf("product %s is archived", product.Name)
+	}
+
+	if len(dto.Components) > 0 {
+		if product.HasVariants {
+			return nil, fmt.Errorf("product %s has variants; make a variant the bundle instead", product.Name)
+		}
+		if product.Serialized {
+			return nil, fmt.Errorf("serialized products cannot be bundles")
+		}
+	}
+
+	seen := make(map[int]bool, len(dto.Components))
+	for _, item := range dto.Components {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("component quantity must be greater than 0")
+		}
+		if item.ProductID == id {
+			return nil, fmt.Errorf("a bundle cannot contain itself")
+		}
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("component with id %d is listed more than once", item.ProductID)
+		}
+		seen[item.ProductID] = true
+
+		component, err := s.repository.FindByID(ctx, storeID, item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product by id %d: %w", item.ProductID, err)
+		}
+		if component == nil {
+			return nil, fmt.Errorf("component with id %d not found", item.ProductID)
+		}
+
+		// Components must hold stock of their own and need no serial number at checkout
+		switch {
+		case component.IsArchived():
+			return nil, fmt.Errorf("component %s is archived", component.Name)
+		case component.IsBundle:
+			return nil, fmt.Errorf("component %s is a bundle; bundles cannot be nested", component.Name)
+		case component.HasVariants:
+			return nil, fmt.Errorf("component %s has variants; add a variant instead", component.Name)
+		case component.Serialized:
+			return nil, fmt.Errorf("component %s is serialized and cannot be part of a bundle", component.Name)
+		}
+
+		if err := component.ValidateQuantity(item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	components := s.mapper.ToComponentEntities(id, dto)
+	err = s.repository.ReplaceComponents(ctx, id, components)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set bundle components: %w", err)
+	}
+
+	return s.GetByID(ctx, storeID, id)
+}
+
+// GetComponents retrieves the components of a bundle
+func (s *productServiceImpl) GetComponents(ctx context.Context, storeID, id int) ([]dtos.BundleComponentDto, error) {
+	product, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if product == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	components, err := s.repository.FindComponents(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get components of product %d: %w", id, err)
+	}
+
+	return s.mapper.ToComponentDtoList(components), nil
+}
+
+// maxProductOptions is the number of option axes a product can have
+const maxProductOptions = 3
+
+// SetOptions replaces the option axes of a parent product
+func (s *productServiceImpl) SetOptions(ctx context.Context, storeID, id int, dto *dtos.ProductOptionsUpdateRequestDto) (*dtos.ProductDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("options request dto cannot be nil")
+	}
+
+	product, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if product == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	if product.IsArchived() {
+		return nil, fmt.Errorf("product %s is archived", product.Name)
+	}
+
+	if product.ParentID != nil {
+		return nil, fmt.Errorf("product %s is a variant and cannot have options of its own", product.Name)
+	}
+
+	if len(dto.Options) == 0 || len(dto.Options) > maxProductOptions {
+		return nil, fmt.Errorf("a product must have between 1 and %d options", maxProductOptions)
+	}
+
+	// Trim names and values and reject duplicates
+	names := make(map[string]bool, len(dto.Options))
+	for i := range dto.Options {
+		option := &dto.Options[i]
+		option.Name = strings.TrimSpace(option.Name)
+		if option.Name == "" {
+			return nil, fmt.Errorf("option name cannot be empty")
+		}
+		if names[strings.ToLower(option.Name)] {
+			return nil, fmt.Errorf("option %s is listed more than once", option.Name)
+		}
+		names[strings.ToLower(option.Name)] = true
+
+		if len(option.Values) == 0 {
+			return nil, fmt.Errorf("option %s must have at least one value", option.Name)
+		}
+
+		values := make(map[string]bool, len(option.Values))
+		for j := range option.Values {
+			option.Values[j] = strings.TrimSpace(option.Values[j])
+			value := strings.ToLower(option.Values[j])
+			if value == "" {
+				return nil, fmt.Errorf("option %s has an empty value", option.Name)
+			}
+			if values[value] {
+				return nil, fmt.Errorf("option %s lists value %s more than once", option.Name, option.Values[j])
+			}
+			values[value] = true
+		}
+	}
+
+	options := s.mapper.ToOptionEntities(id, dto)
+
+	// Existing variants, archived ones included, must still fit the new axes
+	variants, err := s.repository.FindVariants(ctx, storeID, id, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants of product %d: %w", id, err)
+	}
+	for _, variant := range variants {
+		if _, err := matchOptionValues(options, variant.OptionValues); err != nil {
+			return nil, fmt.Errorf("variant %s no longer matches the options: %w", variant.Name, err)
+		}
+	}
+
+	err = s.repository.ReplaceOptions(ctx, id, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set product options: %w", err)
+	}
+
+	product.Options = options
+	product.Variants = slices.DeleteFunc(variants, func(variant entities.Product) bool {
+		return variant.IsArchived()
+	})
+	return s.mapper.ToDto(product), nil
+}
+
+// GetVariants retrieves the variants of a parent product
+func (s *productServiceImpl) GetVariants(ctx context.Context, storeID, id int) ([]dtos.ProductDto, error) {
+	product, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if product == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	variants, err := s.repository.FindVariants(ctx, storeID, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants of product %d: %w", id, err)
+	}
+
+	return s.mapper.ToDtoList(variants), nil
+}
+
+// CreateVariant creates a variant of a parent product. The variant is a product of
+// its own with its own price, stock, SKU and barcodes, and inherits the parent's
+// category and tracking settings.
+func (s *productServiceImpl) CreateVariant(ctx context.Context, storeID, id int, dto *dtos.ProductVariantCreateRequestDto) (*dtos.ProductDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("variant request dto cannot be nil")
+	}
+
+	parent, err := s.repository.FindByID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", id, err)
+	}
+
+	if parent == nil {
+		return nil, fmt.Errorf("product with id %d not found", id)
+	}
+
+	if parent.IsArchived() {
+		return nil, fmt.Errorf("product %s is archived", parent.Name)
+	}
+
+	if parent.ParentID != nil {
+		return nil, fmt.Errorf("product %s is a variant and cannot have variants of its own", parent.Name)
+	}
+
+	// Archived variants still hold their combination of option values
+	if err := s.loadVariants(ctx, storeID, parent, true); err != nil {
+		return nil, err
+	}
+
+	if len(parent.Options) == 0 {
+		return nil, fmt.Errorf("product %s has no options; set its options before adding variants", parent.Name)
+	}
+
+	values, err := matchOptionValues(parent.Options, dto.OptionValues)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variant := range parent.Variants {
+		if slices.Equal(variant.OptionValues, values) {
+			if variant.IsArchived() {
+				return nil, fmt.Errorf("variant %s already exists and is archived; restore it instead", variant.Name)
+			}
+			return nil, fmt.Errorf("variant %s already exists", variant.Name)
+		}
+	}
+
+	// Serialized stock needs a serial number per unit, which only goods receipts record
+	if parent.Serialized && dto.Stock > 0 {
+		return nil, fmt.Errorf("serialized products must start with zero stock and be stocked through goods receipts")
+	}
+
+	// Lot-tracked stock is held in lots, which only goods receipts record
+	if parent.TrackLots && dto.Stock > 0 {
+		return nil, fmt.Errorf("lot-tracked products must start with zero stock and be stocked through goods receipts")
+	}
+
+	// Validate SKU and barcodes are well formed and not used by another product
+	sku, barcodes, err := s.validateIdentifiers(ctx, 0, dto.SKU, dto.Barcodes)
+	if err != nil {
+		return nil, err
+	}
+	if sku != nil && *sku == "" {
+		sku = nil
+	}
+
+	price := parent.Price // Default to the parent's price if not provided
+	if dto.Price != nil {
+		price = *dto.Price
+	}
+
+	cost := parent.Cost // Default to the parent's cost if not provided
+	if dto.Cost != nil {
+		cost = *dto.Cost
+	}
+
+	active := true // Default value if not provided
+	if dto.Active != nil {
+		active = *dto.Active
+	}
+
+	now := time.Now()
+	variant := &entities.Product{
+		Name:              fmt.Sprintf("%s (%s)", parent.Name, strings.Join(values, " / ")),
+		SKU:               sku,
+		Barcodes:          barcodes,
+		Price:             price,
+		Cost:              cost,
+		Stock:             dto.Stock,
+		Unit:              parent.Unit,
+		QuantityPrecision: parent.QuantityPrecision,
+		UnitConversions:   parent.UnitConversions,
+		Active:            active,
+		TrackLots:         parent.TrackLots,
+		Serialized:        parent.Serialized,
+		CategoryID:        parent.CategoryID,
+		TaxRateID:         parent.TaxRateID,
+		ParentID:          &parent.ID,
+		OptionValues:      values,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := validateQuantitySettings(variant); err != nil {
+		return nil, err
+	}
+
+	err = s.repository.Create(ctx, storeID, variant)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variant: %w", err)
+	}
+
+	return s.mapper.ToDto(variant), nil
+}
+
+// loadVariants attaches the option axes and variants of a parent product
+func (s *productServiceImpl) loadVariants(ctx context.Context, storeID int, product *entities.Product, includeArchived bool) error {
+	options, err := s.repository.FindOptions(ctx, product.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get options of product %d: %w", product.ID, err)
+	}
+	product.Options = options
+
+	if len(options) == 0 && !product.HasVariants {
+		return nil
+	}
+
+	variants, err := s.repository.FindVariants(ctx, storeID, product.ID, includeArchived)
+	if err != nil {
+		return fmt.Errorf("failed to get variants of product %d: %w", product.ID, err)
+	}
+	product.Variants = variants
+
+	return nil
+}
+
+// matchOptionValues checks that values holds one valid value per option axis, in
+// axis order, and returns them spelled as defined on the axes
+func matchOptionValues(options []entities.ProductOption, values []string) ([]string, error) {
+	if len(values) != len(options) {
+		return nil, fmt.Errorf("expected %d option values, got %d", len(options), len(values))
+	}
+
+	matched := make([]string, len(values))
+	for i, option := range options {
+		value := strings.TrimSpace(values[i])
+		for _, allowed := range option.Values {
+			if strings.EqualFold(allowed, value) {
+				matched[i] = allowed
+				break
+			}
+		}
+		if matched[i] == "" {
+			return nil, fmt.Errorf("%s is not a value of option %s", value, option.Name)
+		}
+	}
+
+	return matched, nil
+}
+
+// findProductByBarcode resolves a scanned barcode to a product. A barcode assigned
+// to a product wins; otherwise a scale label is decoded and resolved to the
+// product with its PLU, and the decoded label is returned with it. Archived
+// products are not sold, so scanning one is an error.
+func findProductByBarcode(ctx context.Context, repository repositories.ProductRepository, format entities.ScaleBarcodeFormat, storeID int, barcode string) (*entities.Product, *entities.ScaleBarcode, error) {
+	code, err := entities.NormalizeBarcode(barcode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	product, err := repository.FindByBarcode(ctx, storeID, code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find product by barcode %s: %w", code, err)
+	}
+	if product != nil {
+		if product.IsArchived() {
+			return nil, nil, fmt.Errorf("product %s is archived", product.Name)
+		}
+		return product, nil, nil
+	}
+
+	label, ok := format.Parse(code)
+	if !ok {
+		return nil, nil, fmt.Errorf("product with barcode %s not found", code)
+	}
+
+	product, err = repository.FindByPLU(ctx, storeID, label.PLU)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find product by plu %s: %w", label.PLU, err)
+	}
+	if product == nil {
+		return nil, nil, fmt.Errorf("product with plu %s not found", label.PLU)
+	}
+	if product.IsArchived() {
+		return nil, nil, fmt.Errorf("product %s is archived", product.Name)
+	}
+
+	return product, label, nil
+}
+
+// validatePLU normalizes a product's PLU and checks that it fits the item code
+// of scale labels and that no other product uses it. productID is 0 for a new
+// product. A nil PLU is returned as is so that updates keep the current value.
+func (s *productServiceImpl) validatePLU(ctx context.Context, productID int, plu *string) (*string, error) {
+	if plu == nil {
+		return nil, nil
+	}
+
+	trimmed := strings.TrimSpace(*plu)
+	if trimmed == "" {
+		return &trimmed, nil
+	}
+
+	if strings.Trim(trimmed, "0123456789") != "" {
+		return nil, fmt.Errorf("plu %s must contain digits only", trimmed)
+	}
+
+	normalized := entities.NormalizePLU(trimmed)
+	if len(normalized) > s.scaleFormat.ItemDigits {
+		return nil, fmt.Errorf("plu %s is longer than the %d digit item code of scale labels", normalized, s.scaleFormat.ItemDigits)
+	}
+
+	existing, err := s.repository.FindByPLU(ctx, entities.DefaultStoreID, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by plu %s: %w", normalized, err)
+	}
+	if existing != nil && existing.ID != productID {
+		return nil, fmt.Errorf("plu %s is already assigned to product %s", normalized, existing.Name)
+	}
+
+	return &normalized, nil
+}
+
+// validateIdentifiers normalizes a product's SKU and barcodes and checks that no
+// other product uses them. productID is 0 for a new product. A nil SKU or nil
+// barcodes are returned as is so that updates keep the current values.
+func (s *productServiceImpl) validateIdentifiers(ctx context.Context, productID int, sku *string, barcodes []string) (*string, []string, error) {
+	if sku != nil {
+		trimmed := strings.TrimSpace(*sku)
+		sku = &trimmed
+
+		if trimmed != "" {
+			existing, err := s.repository.FindBySKU(ctx, entities.DefaultStoreID, trimmed)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to find product by sku %s: %w", trimmed, err)
+			}
+			if existing != nil && existing.ID != productID {
+				return nil, nil, fmt.Errorf("sku %s is already assigned to product %s", trimmed, existing.Name)
+			}
+		}
+	}
+
+	if barcodes == nil {
+		return sku, nil, nil
+	}
+
+	normalized := make([]string, 0, len(barcodes))
+	seen := make(map[string]bool, len(barcodes))
+	for _, barcode := range barcodes {
+		code, err := entities.NormalizeBarcode(barcode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		existing, err := s.repository.FindByBarcode(ctx, entities.DefaultStoreID, code)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find product by barcode %s: %w", code, err)
+		}
+		if existing != nil && existing.ID != productID {
+			return nil, nil, fmt.Errorf("barcode %s is already assigned to product %s", code, existing.Name)
+		}
+		normalized = append(normalized, code)
+	}
+
+	return sku, normalized, nil
+}
+
+// normalizeUnits checks a product's unit of measure and purchasing units and
+// returns them in standard spelling. Nil values are returned as is so that
+// updates keep the current values.
+func normalizeUnits(unit *string, conversions []dtos.UnitConversionDto) (*string, []dtos.UnitConversionDto, error) {
+	if unit != nil {
+		normalized, ok := entities.NormalizeUnit(*unit)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown unit %s; use pcs, kg, g, L or ml", *unit)
+		}
+		unit = &normalized
+	}
+
+	seen := make(map[string]bool, len(conversions))
+	for i := range conversions {
+		conversion := &conversions[i]
+		conversion.Unit = strings.TrimSpace(conversion.Unit)
+		if conversion.Unit == "" {
+			return nil, nil, fmt.Errorf("unit conversion name cannot be empty")
+		}
+		if _, ok := entities.NormalizeUnit(conversion.Unit); ok {
+			return nil, nil, fmt.Errorf("%s is a standard unit and converts automatically", conversion.Unit)
+		}
+		if conversion.Factor <= 0 {
+			return nil, nil, fmt.Errorf("factor of unit %s must be greater than 0", conversion.Unit)
+		}
+		if seen[strings.ToLower(conversion.Unit)] {
+			return nil, nil, fmt.Errorf("unit %s is listed more than once", conversion.Unit)
+		}
+		seen[strings.ToLower(conversion.Unit)] = true
+	}
+
+	return unit, conversions, nil
+}
+
+// validateQuantitySettings checks a product's quantity precision and that its
+// stock fits it. Serialized products are counted in whole units.
+func validateQuantitySettings(product *entities.Product) error {
+	if product.QuantityPrecision < 0 || product.QuantityPrecision > entities.MaxQuantityPrecision {
+		return fmt.Errorf("quantity precision must be between 0 and %d", entities.MaxQuantityPrecision)
+	}
+
+	if product.Serialized && product.QuantityPrecision != 0 {
+		return fmt.Errorf("serialized products must be counted in whole units")
+	}
+
+	return product.ValidateStock(product.Stock)
+}
+
+// normalizeSerialNumbers trims serial numbers and checks that there is exactly one
+// distinct serial number per unit
+func normalizeSerialNumbers(serialNumbers []string, quantity int) ([]string, error) {
+	if len(serialNumbers) != quantity {
+		return nil, fmt.Errorf("expected %d serial numbers, got %d", quantity, len(serialNumbers))
+	}
+
+	result := make([]string, len(serialNumbers))
+	seen := make(map[string]bool, len(serialNumbers))
+	for i, serialNumber := range serialNumbers {
+		serialNumber = strings.TrimSpace(serialNumber)
+		if serialNumber == "" {
+			return nil, fmt.Errorf("serial numbers cannot be empty")
+		}
+		if seen[serialNumber] {
+			return nil, fmt.Errorf("serial number %s is listed more than once", serialNumber)
+		}
+		seen[serialNumber] = true
+		result[i] = serialNumber
+	}
+
+	return result, nil
+}
@@ -0,0 +1,890 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// storeProduct identifies a product's stock level at a store
+type storeProduct struct {
+	storeID   int
+	productID int
+}
+
+// productRepositoryStub keeps the catalog in memory. Product Stock is the main
+// store's stock; other stores hold what stock and prices says. transit holds
+// what has been shipped to a store but not received yet. A product with
+// components is a bundle whose stock is derived the way the database does it.
+type productRepositoryStub struct {
+	repositories.ProductRepository
+	products   map[int]*entities.Product
+	stock      map[storeProduct]float64
+	prices     map[storeProduct]float64
+	transit    map[storeProduct]float64
+	options    map[int][]entities.ProductOption
+	components map[int][]entities.BundleComponent
+}
+
+func newProductRepositoryStub(products ...entities.Product) *productRepositoryStub {
+	repository := &productRepositoryStub{
+		products:   make(map[int]*entities.Product),
+		stock:      make(map[storeProduct]float64),
+		prices:     make(map[storeProduct]float64),
+		transit:    make(map[storeProduct]float64),
+		options:    make(map[int][]entities.ProductOption),
+		components: make(map[int][]entities.BundleComponent),
+	}
+	for i := range products {
+		// Products are counted in pieces unless they say otherwise, as in the database
+		if products[i].Unit == "" {
+			products[i].Unit = entities.UnitPiece
+		}
+		repository.products[products[i].ID] = &products[i]
+	}
+	return repository
+}
+
+func (r *productRepositoryStub) FindByID(ctx context.Context, storeID, id int) (*entities.Product, error) {
+	product, ok := r.products[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *product
+	if storeID != entities.DefaultStoreID {
+		found.Stock = r.stock[storeProduct{storeID, id}]
+	}
+	if price, ok := r.prices[storeProduct{storeID, id}]; ok {
+		found.StorePrice = &price
+	}
+	found.InTransit = r.transit[storeProduct{storeID, id}]
+	for _, other := range r.products {
+		if other.ParentID != nil && *other.ParentID == id && !other.IsArchived() {
+			found.HasVariants = true
+		}
+	}
+	if components := r.components[id]; len(components) > 0 {
+		found.IsBundle = true
+		found.Stock = -1
+		for _, component := range components {
+			if stock := math.Floor(r.storeStock(storeID, component.ComponentID) / component.Quantity); found.Stock < 0 || stock < found.Stock {
+				found.Stock = stock
+			}
+		}
+	}
+	return &found, nil
+}
+
+func (r *productRepositoryStub) FindComponents(ctx context.Context, storeID, bundleID int) ([]entities.BundleComponent, error) {
+	var components []entities.BundleComponent
+	for _, component := range r.components[bundleID] {
+		product := r.products[component.ComponentID]
+		component.ComponentName = product.Name
+		component.Cost = product.Cost
+		component.Stock = r.storeStock(storeID, component.ComponentID)
+		components = append(components, component)
+	}
+	return components, nil
+}
+
+// ReplaceComponents refuses to make a product held or awaited at any store a bundle
+func (r *productRepositoryStub) ReplaceComponents(ctx context.Context, bundleID int, components []entities.BundleComponent) error {
+	product := r.products[bundleID]
+	if len(components) > 0 && len(r.components[bundleID]) == 0 {
+		stock, inTransit := r.stockAcrossStores(bundleID)
+		if stock != 0 || inTransit != 0 {
+			return fmt.Errorf("product %s still has %g %s in stock and %g %s in transit across its stores; clear its stock before making it a bundle", product.Name, stock, product.Unit, inTransit, product.Unit)
+		}
+	}
+	r.components[bundleID] = components
+	return nil
+}
+
+// stockAcrossStores returns the stock of a product at all stores and in transit to them
+func (r *productRepositoryStub) stockAcrossStores(productID int) (float64, float64) {
+	stock, inTransit := r.products[productID].Stock, 0.0
+	for key, quantity := range r.stock {
+		if key.productID == productID {
+			stock += quantity
+		}
+	}
+	for key, quantity := range r.transit {
+		if key.productID == productID {
+			inTransit += quantity
+		}
+	}
+	return stock, inTransit
+}
+
+func (r *productRepositoryStub) Create(ctx context.Context, storeID int, product *entities.Product) error {
+	product.ID = len(r.products) + 1
+	for r.products[product.ID] != nil {
+		product.ID++
+	}
+	stored := *product
+	r.products[product.ID] = &stored
+	return nil
+}
+
+func (r *productRepositoryStub) FindVariants(ctx context.Context, storeID, parentID int, includeArchived bool) ([]entities.Product, error) {
+	var variants []entities.Product
+	for id := 1; id <= len(r.products); id++ {
+		product, ok := r.products[id]
+		if ok && product.ParentID != nil && *product.ParentID == parentID && (includeArchived || !product.IsArchived()) {
+			found, _ := r.FindByID(ctx, storeID, id)
+			variants = append(variants, *found)
+		}
+	}
+	return variants, nil
+}
+
+// Update refuses to change the unit of a product held or awaited at any store
+func (r *productRepositoryStub) Update(ctx context.Context, storeID int, product *entities.Product, stockDelta float64) error {
+	if unit := r.products[product.ID].Unit; unit != product.Unit {
+		stock, inTransit := r.stockAcrossStores(product.ID)
+		if stock != 0 || inTransit != 0 {
+			return fmt.Errorf("product %s still has %g %s in stock and %g %s in transit across its stores; clear its stock before changing its unit", product.Name, stock, unit, inTransit, unit)
+		}
+	}
+	stored := *product
+	stored.Stock = r.products[product.ID].Stock
+	r.products[product.ID] = &stored
+	if stockDelta != 0 {
+		r.setStoreStock(storeProduct{storeID, product.ID}, r.storeStock(storeID, product.ID)+stockDelta)
+	}
+	return nil
+}
+
+// Delete archives a product together with its variants, at the same time
+func (r *productRepositoryStub) Delete(ctx context.Context, id int) error {
+	deletedAt := time.Now()
+	for _, product := range r.products {
+		if (product.ID == id || product.ParentID != nil && *product.ParentID == id) && !product.IsArchived() {
+			product.DeletedAt = &deletedAt
+		}
+	}
+	return nil
+}
+
+// Restore brings back a product and the variants archived with it
+func (r *productRepositoryStub) Restore(ctx context.Context, id int) error {
+	deletedAt := *r.products[id].DeletedAt
+	for _, product := range r.products {
+		if (product.ID == id || product.ParentID != nil && *product.ParentID == id) && product.IsArchived() && product.DeletedAt.Equal(deletedAt) {
+			product.DeletedAt = nil
+		}
+	}
+	return nil
+}
+
+func (r *productRepositoryStub) FindOptions(ctx context.Context, productID int) ([]entities.ProductOption, error) {
+	return r.options[productID], nil
+}
+
+func (r *productRepositoryStub) ReplaceOptions(ctx context.Context, productID int, options []entities.ProductOption) error {
+	r.options[productID] = options
+	return nil
+}
+
+func (r *productRepositoryStub) UpdateStoreStock(ctx context.Context, storeID, productID int, quantity float64, price *float64) error {
+	if storeID == entities.DefaultStoreID {
+		r.products[productID].Stock = quantity
+	} else {
+		r.stock[storeProduct{storeID, productID}] = quantity
+	}
+	delete(r.prices, storeProduct{storeID, productID})
+	if price != nil {
+		r.prices[storeProduct{storeID, productID}] = *price
+	}
+	return nil
+}
+
+// goodsReceiptRepositoryStub adds received goods to the stock and averages the cost the way the database does
+type goodsReceiptRepositoryStub struct {
+	repositories.GoodsReceiptRepository
+	products *productRepositoryStub
+	receipts []entities.GoodsReceipt
+}
+
+func (r *goodsReceiptRepositoryStub) Create(ctx context.Context, receipt *entities.GoodsReceipt) (*entities.Product, error) {
+	product := r.products.products[receipt.ProductID]
+	stock := product.Stock + receipt.Quantity
+	product.Cost = (product.Cost*float64(product.Stock) + receipt.UnitCost*float64(receipt.Quantity)) / float64(stock)
+	product.Stock = stock
+
+	receipt.ID = len(r.receipts) + 1
+	r.receipts = append(r.receipts, *receipt)
+	updated := *product
+	return &updated, nil
+}
+
+func newProductService(products ...entities.Product) (*productServiceImpl, *productRepositoryStub, *goodsReceiptRepositoryStub) {
+	repository := newProductRepositoryStub(products...)
+	receipts := &goodsReceiptRepositoryStub{products: repository}
+	service := NewProductService(repository, nil, nil, receipts, nil, nil, entities.DefaultScaleBarcodeFormat()).(*productServiceImpl)
+	return service, repository, receipts
+}
+
+func TestReceiveGoods(t *testing.T) {
+	tests := []struct {
+		name        string
+		productID   int
+		dto         dtos.GoodsReceiptCreateRequestDto
+		wantErr     string
+		wantStock   float64
+		wantAverage float64
+	}{
+		{name: "averages the cost over old and new stock", productID: 1, dto: dtos.GoodsReceiptCreateRequestDto{Quantity: 10, UnitCost: 4000}, wantStock: 20, wantAverage: 3500},
+		{name: "free goods lower the average", productID: 1, dto: dtos.GoodsReceiptCreateRequestDto{Quantity: 30, UnitCost: 0}, wantStock: 40, wantAverage: 750},
+		{name: "no quantity", productID: 1, dto: dtos.GoodsReceiptCreateRequestDto{Quantity: 0, UnitCost: 4000}, wantErr: "quantity must be greater than 0"},
+		{name: "negative cost", productID: 1, dto: dtos.GoodsReceiptCreateRequestDto{Quantity: 1, UnitCost: -1}, wantErr: "unit cost cannot be negative"},
+		{name: "unknown product", productID: 2, dto: dtos.GoodsReceiptCreateRequestDto{Quantity: 1, UnitCost: 4000}, wantErr: "product with id 2 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, receipts := newProductService(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Cost: 3000, Stock: 10})
+
+			dto := tt.dto
+			receipt, err := service.ReceiveGoods(context.Background(), entities.DefaultStoreID, tt.productID, &dto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReceiveGoods() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(receipts.receipts) != 0 {
+					t.Errorf("recorded %d receipts, want 0", len(receipts.receipts))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReceiveGoods() error = %v", err)
+			}
+
+			if receipt.StockAfter != tt.wantStock || receipt.AverageCost != tt.wantAverage {
+				t.Errorf("stock after, average cost = %v, %v, want %v, %v", receipt.StockAfter, receipt.AverageCost, tt.wantStock, tt.wantAverage)
+			}
+			if receipt.Quantity != tt.dto.Quantity || receipt.UnitCost != tt.dto.UnitCost {
+				t.Errorf("receipt = %+v, want the received quantity and cost", receipt)
+			}
+		})
+	}
+}
+
+func TestMatchOptionValues(t *testing.T) {
+	options := []entities.ProductOption{
+		{Name: "Size", Values: []string{"S", "M", "L"}},
+		{Name: "Color", Values: []string{"Red", "Blue"}},
+	}
+
+	tests := []struct {
+		name    string
+		values  []string
+		want    []string
+		wantErr string
+	}{
+		{name: "exact", values: []string{"M", "Red"}, want: []string{"M", "Red"}},
+		{name: "spelled as on the axis", values: []string{" m ", "BLUE"}, want: []string{"M", "Blue"}},
+		{name: "too few values", values: []string{"M"}, wantErr: "expected 2 option values, got 1"},
+		{name: "axes out of order", values: []string{"Red", "M"}, wantErr: "Red is not a value of option Size"},
+		{name: "unknown value", values: []string{"XL", "Red"}, wantErr: "XL is not a value of option Size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchOptionValues(options, tt.values)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("matchOptionValues(%v) error = %v, want %q", tt.values, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(got, tt.want) {
+				t.Errorf("matchOptionValues(%v) = %v, %v, want %v", tt.values, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	option := func(name string, values ...string) dtos.ProductOptionDto {
+		return dtos.ProductOptionDto{Name: name, Values: values}
+	}
+	parentID := 1
+
+	tests := []struct {
+		name      string
+		productID int
+		options   []dtos.ProductOptionDto
+		// variant is an existing variant of the parent, if any
+		variant []string
+		wantErr string
+	}{
+		{name: "two axes", productID: 1, options: []dtos.ProductOptionDto{option("Size", "S", "M"), option("Color", "Red")}},
+		{name: "axes still fit the variants", productID: 1, options: []dtos.ProductOptionDto{option("Size", "S", "M", "L")}, variant: []string{"M"}},
+		{name: "axes no longer fit a variant", productID: 1, options: []dtos.ProductOptionDto{option("Size", "S", "L")}, variant: []string{"M"}, wantErr: "no longer matches the options"},
+		{name: "no axes", productID: 1, wantErr: "between 1 and 3 options"},
+		{name: "too many axes", productID: 1, options: []dtos.ProductOptionDto{option("A", "1"), option("B", "1"), option("C", "1"), option("D", "1")}, wantErr: "between 1 and 3 options"},
+		{name: "blank name", productID: 1, options: []dtos.ProductOptionDto{option("  ", "S")}, wantErr: "option name cannot be empty"},
+		{name: "same axis twice", productID: 1, options: []dtos.ProductOptionDto{option("Size", "S"), option("size", "M")}, wantErr: "option size is listed more than once"},
+		{name: "axis without values", productID: 1, options: []dtos.ProductOptionDto{option("Size")}, wantErr: "must have at least one value"},
+		{name: "blank value", productID: 1, options: []dtos.ProductOptionDto{option("Size", "S", " ")}, wantErr: "has an empty value"},
+		{name: "same value twice", productID: 1, options: []dtos.ProductOptionDto{option("Size", "S", "s")}, wantErr: "lists value s more than once"},
+		{name: "options on a variant", productID: 2, options: []dtos.ProductOptionDto{option("Size", "S")}, variant: []string{"S"}, wantErr: "is a variant and cannot have options"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := []entities.Product{{ID: 1, Name: "Kaos", Price: 50000, Active: true}}
+			if tt.variant != nil {
+				products = append(products, entities.Product{ID: 2, Name: "Kaos (M)", Price: 50000, Active: true, ParentID: &parentID, OptionValues: tt.variant})
+			}
+			service, repository, _ := newProductService(products...)
+
+			_, err := service.SetOptions(context.Background(), entities.DefaultStoreID, tt.productID, &dtos.ProductOptionsUpdateRequestDto{Options: tt.options})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetOptions() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(repository.options[tt.productID]) != 0 {
+					t.Errorf("options were saved: %+v", repository.options[tt.productID])
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetOptions() error = %v", err)
+			}
+			if saved := repository.options[tt.productID]; len(saved) != len(tt.options) || saved[0].Position != 1 {
+				t.Errorf("saved options = %+v", saved)
+			}
+		})
+	}
+}
+
+func TestCreateVariant(t *testing.T) {
+	category := 3
+	price := 65000.0
+	parentID := 1
+
+	tests := []struct {
+		name      string
+		productID int
+		// options are the parent's axes
+		options   []entities.ProductOption
+		dto       dtos.ProductVariantCreateRequestDto
+		wantErr   string
+		wantName  string
+		wantPrice float64
+	}{
+		{
+			name:      "inherits the parent's price and category",
+			productID: 1,
+			options:   []entities.ProductOption{{Name: "Size", Values: []string{"S", "M"}}, {Name: "Color", Values: []string{"Red"}}},
+			dto:       dtos.ProductVariantCreateRequestDto{OptionValues: []string{"m", "red"}, Stock: 5},
+			wantName:  "Kaos (M / Red)",
+			wantPrice: 50000,
+		},
+		{
+			name:      "own price",
+			productID: 1,
+			options:   []entities.ProductOption{{Name: "Size", Values: []string{"S", "XXL"}}},
+			dto:       dtos.ProductVariantCreateRequestDto{OptionValues: []string{"XXL"}, Price: &price},
+			wantName:  "Kaos (XXL)",
+			wantPrice: 65000,
+		},
+		{
+			name:      "parent without options",
+			productID: 1,
+			dto:       dtos.ProductVariantCreateRequestDto{OptionValues: []string{"M"}},
+			wantErr:   "has no options",
+		},
+		{
+			name:      "combination already exists",
+			productID: 1,
+			options:   []entities.ProductOption{{Name: "Size", Values: []string{"S", "M"}}},
+			dto:       dtos.ProductVariantCreateRequestDto{OptionValues: []string{"S"}},
+			wantErr:   "variant Kaos (S) already exists",
+		},
+		{
+			name:      "value off the axes",
+			productID: 1,
+			options:   []entities.ProductOption{{Name: "Size", Values: []string{"S", "M"}}},
+			dto:       dtos.ProductVariantCreateRequestDto{OptionValues: []string{"L"}},
+			wantErr:   "L is not a value of option Size",
+		},
+		{
+			name:      "variant of a variant",
+			productID: 2,
+			options:   []entities.ProductOption{{Name: "Size", Values: []string{"S", "M"}}},
+			dto:       dtos.ProductVariantCreateRequestDto{OptionValues: []string{"M"}},
+			wantErr:   "is a variant and cannot have variants",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository, _ := newProductService(
+				entities.Product{ID: 1, Name: "Kaos", Price: 50000, Cost: 30000, Active: true, CategoryID: &category},
+				entities.Product{ID: 2, Name: "Kaos (S)", Price: 50000, Active: true, ParentID: &parentID, OptionValues: []string{"S"}},
+			)
+			repository.options[1] = tt.options
+
+			dto := tt.dto
+			variant, err := service.CreateVariant(context.Background(), entities.DefaultStoreID, tt.productID, &dto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("CreateVariant() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(repository.products) != 2 {
+					t.Errorf("catalog has %d products, want 2", len(repository.products))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateVariant() error = %v", err)
+			}
+
+			if variant.Name != tt.wantName || variant.Price != tt.wantPrice {
+				t.Errorf("name, price = %s, %v, want %s, %v", variant.Name, variant.Price, tt.wantName, tt.wantPrice)
+			}
+			if variant.ParentID == nil || *variant.ParentID != 1 || variant.CategoryID == nil || *variant.CategoryID != category {
+				t.Errorf("parent, category = %v, %v, want 1, %d", variant.ParentID, variant.CategoryID, category)
+			}
+		})
+	}
+}
+
+func TestSetComponents(t *testing.T) {
+	component := func(productID int, quantity float64) dtos.BundleComponentRequestDto {
+		return dtos.BundleComponentRequestDto{ProductID: productID, Quantity: quantity}
+	}
+	parentID := 6
+
+	tests := []struct {
+		name       string
+		bundleID   int
+		components []dtos.BundleComponentRequestDto
+		// branchStock and branchTransit are held and awaited by store 2
+		branchStock   float64
+		branchTransit float64
+		wantErr       string
+		wantStock     float64
+	}{
+		{name: "stock of the scarcest component", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(2, 1), component(3, 2)}, wantStock: 4},
+		{name: "components of a bundle can change", bundleID: 4, components: []dtos.BundleComponentRequestDto{component(2, 5)}, wantStock: 2},
+		{name: "no components turns a bundle back into a product", bundleID: 4},
+		{name: "product still holds stock", bundleID: 5, components: []dtos.BundleComponentRequestDto{component(2, 1)}, wantErr: "still has 3 pcs in stock and 0 pcs in transit"},
+		{name: "product held at another store", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(2, 1)}, branchStock: 2, wantErr: "still has 2 pcs in stock and 0 pcs in transit"},
+		{name: "product on its way to another store", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(2, 1)}, branchTransit: 4, wantErr: "still has 0 pcs in stock and 4 pcs in transit"},
+		{name: "parent of variants", bundleID: 6, components: []dtos.BundleComponentRequestDto{component(2, 1)}, wantErr: "make a variant the bundle instead"},
+		{name: "serialized bundle", bundleID: 8, components: []dtos.BundleComponentRequestDto{component(2, 1)}, wantErr: "serialized products cannot be bundles"},
+		{name: "no quantity", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(2, 0)}, wantErr: "quantity must be greater than 0"},
+		{name: "bundle contains itself", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(1, 1)}, wantErr: "cannot contain itself"},
+		{name: "same component twice", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(2, 1), component(2, 1)}, wantErr: "component with id 2 is listed more than once"},
+		{name: "unknown component", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(99, 1)}, wantErr: "component with id 99 not found"},
+		{name: "nested bundle", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(4, 1)}, wantErr: "bundles cannot be nested"},
+		{name: "component with variants", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(6, 1)}, wantErr: "component Kaos has variants"},
+		{name: "serialized component", bundleID: 1, components: []dtos.BundleComponentRequestDto{component(8, 1)}, wantErr: "component Ponsel is serialized"},
+		{name: "unknown bundle", bundleID: 99, components: []dtos.BundleComponentRequestDto{component(2, 1)}, wantErr: "product with id 99 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repository, _ := newProductService(
+				entities.Product{ID: 1, Name: "Paket Sarapan", Price: 12000, Active: true},
+				entities.Product{ID: 2, Name: "Kopi", Price: 5000, Cost: 3000, Stock: 10, Active: true},
+				entities.Product{ID: 3, Name: "Roti", Price: 8000, Cost: 4000, Stock: 9, Active: true},
+				entities.Product{ID: 4, Name: "Paket Kopi", Price: 9000, Active: true},
+				entities.Product{ID: 5, Name: "Paket Teh", Price: 9000, Stock: 3, Active: true},
+				entities.Product{ID: 6, Name: "Kaos", Price: 50000, Active: true},
+				entities.Product{ID: 7, Name: "Kaos (M)", Price: 50000, Active: true, ParentID: &parentID},
+				entities.Product{ID: 8, Name: "Ponsel", Price: 2000000, Active: true, Serialized: true},
+			)
+			repository.components[4] = []entities.BundleComponent{{BundleID: 4, ComponentID: 2, Quantity: 2}}
+			repository.stock[storeProduct{2, tt.bundleID}] = tt.branchStock
+			repository.transit[storeProduct{2, tt.bundleID}] = tt.branchTransit
+
+			bundle, err := service.SetComponents(context.Background(), entities.DefaultStoreID, tt.bundleID, &dtos.BundleComponentsUpdateRequestDto{Components: tt.components})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SetComponents() error = %v, want %q", err, tt.wantErr)
+				}
+				if _, ok := repository.components[tt.bundleID]; ok && tt.bundleID != 4 {
+					t.Errorf("components were saved: %+v", repository.components[tt.bundleID])
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetComponents() error = %v", err)
+			}
+
+			if bundle.IsBundle != (len(tt.components) > 0) || len(bundle.Components) != len(tt.components) {
+				t.Errorf("is bundle, components = %v, %+v, want %d components", bundle.IsBundle, bundle.Components, len(tt.components))
+			}
+			if bundle.Stock != tt.wantStock {
+				t.Errorf("stock = %v, want %v", bundle.Stock, tt.wantStock)
+			}
+		})
+	}
+}
+
+func TestArchiveProduct(t *testing.T) {
+	ctx := context.Background()
+	store := entities.DefaultStoreID
+
+	tests := []struct {
+		name string
+		// archived are archived one after the other before the action
+		archived []int
+		action   func(s *productServiceImpl) error
+		wantErr  string
+		// wantArchived are the products archived after the action
+		wantArchived []int
+	}{
+		{
+			name:         "parent takes its variants along",
+			action:       func(s *productServiceImpl) error { return s.Delete(ctx, 1) },
+			wantArchived: []int{1, 2, 3},
+		},
+		{
+			name:         "variant alone",
+			action:       func(s *productServiceImpl) error { return s.Delete(ctx, 2) },
+			wantArchived: []int{2},
+		},
+		{
+			name:         "archived twice",
+			archived:     []int{4},
+			action:       func(s *productServiceImpl) error { return s.Delete(ctx, 4) },
+			wantErr:      "product Gula is already archived",
+			wantArchived: []int{4},
+		},
+		{
+			name:     "restore brings the variants archived with the parent back",
+			archived: []int{1},
+			action: func(s *productServiceImpl) error {
+				_, err := s.Restore(ctx, store, 1)
+				return err
+			},
+		},
+		{
+			name:     "variant archived before its parent stays archived",
+			archived: []int{2, 1},
+			action: func(s *productServiceImpl) error {
+				_, err := s.Restore(ctx, store, 1)
+				return err
+			},
+			wantArchived: []int{2},
+		},
+		{
+			name:     "variant of an archived parent",
+			archived: []int{2, 1},
+			action: func(s *productServiceImpl) error {
+				_, err := s.Restore(ctx, store, 2)
+				return err
+			},
+			wantErr:      "parent product Kaos is archived; restore it first",
+			wantArchived: []int{1, 2, 3},
+		},
+		{
+			name: "restore a product that is not archived",
+			action: func(s *productServiceImpl) error {
+				_, err := s.Restore(ctx, store, 4)
+				return err
+			},
+			wantErr: "product Gula is not archived",
+		},
+		{
+			name:     "edit an archived product",
+			archived: []int{4},
+			action: func(s *productServiceImpl) error {
+				_, err := s.Update(ctx, store, 4, &dtos.ProductUpdateRequestDto{Name: "Gula Pasir", Price: 15000, Stock: 5})
+				return err
+			},
+			wantErr:      "product Gula is archived; restore it before editing",
+			wantArchived: []int{4},
+		},
+		{
+			name:     "receive an archived product",
+			archived: []int{4},
+			action: func(s *productServiceImpl) error {
+				_, err := s.ReceiveGoods(ctx, store, 4, &dtos.GoodsReceiptCreateRequestDto{Quantity: 1, UnitCost: 12000})
+				return err
+			},
+			wantErr:      "product Gula is archived",
+			wantArchived: []int{4},
+		},
+		{
+			name:     "archived variant holds its option values",
+			archived: []int{2},
+			action: func(s *productServiceImpl) error {
+				_, err := s.CreateVariant(ctx, store, 1, &dtos.ProductVariantCreateRequestDto{OptionValues: []string{"S"}})
+				return err
+			},
+			wantErr:      "variant Kaos (S) already exists and is archived; restore it instead",
+			wantArchived: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentID := 1
+			service, repository, _ := newProductService(
+				entities.Product{ID: 1, Name: "Kaos", Price: 50000, Active: true},
+				entities.Product{ID: 2, Name: "Kaos (S)", Price: 50000, Active: true, ParentID: &parentID, OptionValues: []string{"S"}},
+				entities.Product{ID: 3, Name: "Kaos (M)", Price: 50000, Active: true, ParentID: &parentID, OptionValues: []string{"M"}},
+				entities.Product{ID: 4, Name: "Gula", Price: 15000, Stock: 5, Active: true},
+			)
+			repository.options[1] = []entities.ProductOption{{Name: "Size", Values: []string{"S", "M"}}}
+			for i, id := range tt.archived {
+				deletedAt := time.Date(2026, 1, 1, 0, 0, i, 0, time.UTC)
+				for _, product := range repository.products {
+					if (product.ID == id || product.ParentID != nil && *product.ParentID == id) && !product.IsArchived() {
+						product.DeletedAt = &deletedAt
+					}
+				}
+			}
+
+			err := tt.action(service)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			var archived []int
+			for id := 1; id <= len(repository.products); id++ {
+				if repository.products[id].IsArchived() {
+					archived = append(archived, id)
+				}
+			}
+			if !slices.Equal(archived, tt.wantArchived) {
+				t.Errorf("archived = %v, want %v", archived, tt.wantArchived)
+			}
+		})
+	}
+}
+
+// saleMeanwhileStub sells some stock right after the product is read, as a
+// checkout running alongside an edit would
+type saleMeanwhileStub struct {
+	*productRepositoryStub
+	sold float64
+}
+
+func (r *saleMeanwhileStub) FindByID(ctx context.Context, storeID, id int) (*entities.Product, error) {
+	product, err := r.productRepositoryStub.FindByID(ctx, storeID, id)
+	if product != nil && r.sold > 0 {
+		r.setStoreStock(storeProduct{storeID, id}, r.storeStock(storeID, id)-r.sold)
+		r.sold = 0
+	}
+	return product, err
+}
+
+func TestUpdateProductStock(t *testing.T) {
+	tests := []struct {
+		name      string
+		stock     float64
+		sold      float64
+		wantStock float64
+	}{
+		{name: "unchanged stock", stock: 10, wantStock: 10},
+		{name: "stock counted up", stock: 15, wantStock: 15},
+		{name: "unchanged stock keeps a sale made meanwhile", stock: 10, sold: 2, wantStock: 8},
+		{name: "stock counted up on top of a sale made meanwhile", stock: 15, sold: 2, wantStock: 13},
+		{name: "stock counted down on top of a sale made meanwhile", stock: 4, sold: 2, wantStock: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+			repository := &saleMeanwhileStub{productRepositoryStub: products, sold: tt.sold}
+			service := NewProductService(repository, nil, nil, nil, nil, nil, entities.DefaultScaleBarcodeFormat())
+
+			_, err := service.Update(context.Background(), entities.DefaultStoreID, 1, &dtos.ProductUpdateRequestDto{Name: "Kopi", Price: 5000, Stock: tt.stock})
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if stock := products.storeStock(entities.DefaultStoreID, 1); stock != tt.wantStock {
+				t.Errorf("stock = %v, want %v", stock, tt.wantStock)
+			}
+		})
+	}
+}
+
+// productSerialRepositoryStub holds the unsold serials of every store
+type productSerialRepositoryStub struct {
+	repositories.ProductSerialRepository
+	serials []entities.ProductSerial
+}
+
+func (r *productSerialRepositoryStub) FindInStockByProductID(ctx context.Context, storeID, productID int) ([]entities.ProductSerial, error) {
+	var found []entities.ProductSerial
+	for _, serial := range r.serials {
+		if serial.ProductID == productID && serial.StoreID != nil && *serial.StoreID == storeID && serial.Status == entities.SerialStatusInStock {
+			found = append(found, serial)
+		}
+	}
+	return found, nil
+}
+
+func TestUpdateProductTracking(t *testing.T) {
+	yes, no := true, false
+	store := entities.DefaultStoreID
+	serial := func(number string) entities.ProductSerial {
+		return entities.ProductSerial{ProductID: 1, SerialNumber: number, Status: entities.SerialStatusInStock, StoreID: &store}
+	}
+
+	tests := []struct {
+		name    string
+		product entities.Product
+		serials []entities.ProductSerial
+		dto     dtos.ProductUpdateRequestDto
+		wantErr string
+	}{
+		{
+			name:    "serialized product keeps its stock",
+			product: entities.Product{Stock: 2, Serialized: true},
+			serials: []entities.ProductSerial{serial("SN-1"), serial("SN-2")},
+			dto:     dtos.ProductUpdateRequestDto{Stock: 2},
+		},
+		{
+			name:    "stock of a serialized product",
+			product: entities.Product{Stock: 2, Serialized: true},
+			serials: []entities.ProductSerial{serial("SN-1"), serial("SN-2")},
+			dto:     dtos.ProductUpdateRequestDto{Stock: 3},
+			wantErr: "stock of serialized product Laptop cannot be edited",
+		},
+		{
+			name:    "serialized without stock",
+			product: entities.Product{},
+			dto:     dtos.ProductUpdateRequestDto{Serialized: &yes},
+		},
+		{
+			name:    "serialized with stock",
+			product: entities.Product{Stock: 2},
+			dto:     dtos.ProductUpdateRequestDto{Stock: 2, Serialized: &yes},
+			wantErr: "can only start or stop being serialized while it has no stock",
+		},
+		{
+			name:    "no longer serialized with serials in stock",
+			product: entities.Product{Serialized: true},
+			serials: []entities.ProductSerial{serial("SN-1")},
+			dto:     dtos.ProductUpdateRequestDto{Serialized: &no},
+			wantErr: "can only start or stop being serialized while it has no stock",
+		},
+		{
+			name:    "no longer serialized without stock",
+			product: entities.Product{Serialized: true},
+			dto:     dtos.ProductUpdateRequestDto{Serialized: &no},
+		},
+		{
+			name:    "lot-tracked product keeps its stock",
+			product: entities.Product{Stock: 5, TrackLots: true},
+			dto:     dtos.ProductUpdateRequestDto{Stock: 5, TrackLots: &yes},
+		},
+		{
+			name:    "stock of a lot-tracked product",
+			product: entities.Product{Stock: 5, TrackLots: true},
+			dto:     dtos.ProductUpdateRequestDto{Stock: 4},
+			wantErr: "stock of lot-tracked product Laptop cannot be edited",
+		},
+		{
+			name:    "start tracking lots",
+			product: entities.Product{},
+			dto:     dtos.ProductUpdateRequestDto{TrackLots: &yes},
+			wantErr: "lot tracking of product Laptop cannot be changed",
+		},
+		{
+			name:    "stop tracking lots",
+			product: entities.Product{TrackLots: true},
+			dto:     dtos.ProductUpdateRequestDto{TrackLots: &no},
+			wantErr: "lot tracking of product Laptop cannot be changed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+			product.ID, product.Name, product.Price, product.Active = 1, "Laptop", 9000000, true
+			products := newProductRepositoryStub(product)
+			serials := &productSerialRepositoryStub{serials: tt.serials}
+			service := NewProductService(products, nil, nil, nil, nil, serials, entities.DefaultScaleBarcodeFormat())
+
+			dto := tt.dto
+			dto.Name, dto.Price = "Laptop", 9000000
+			_, err := service.Update(context.Background(), store, 1, &dto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Update() error = %v, want %q", err, tt.wantErr)
+				}
+				if stored := products.products[1]; stored.Stock != tt.product.Stock || stored.Serialized != tt.product.Serialized || stored.TrackLots != tt.product.TrackLots {
+					t.Errorf("stock, serialized, track lots = %v, %v, %v, want them unchanged", stored.Stock, stored.Serialized, stored.TrackLots)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if stored := products.products[1]; stored.Stock != dto.Stock {
+				t.Errorf("stock = %v, want %v", stored.Stock, dto.Stock)
+			}
+		})
+	}
+}
+
+func TestUpdateProductUnit(t *testing.T) {
+	other := storeProduct{2, 1}
+
+	tests := []struct {
+		name      string
+		stock     float64
+		stock2    float64
+		inTransit float64
+		unit      string
+		wantErr   string
+	}{
+		{name: "no stock anywhere", unit: entities.UnitKilogram},
+		{name: "same unit with stock", stock: 10, unit: entities.UnitPiece},
+		{name: "stock at this store", stock: 10, unit: entities.UnitKilogram, wantErr: "still has 10 pcs in stock and 0 pcs in transit across its stores"},
+		{name: "stock at another store", stock2: 3, unit: entities.UnitKilogram, wantErr: "still has 3 pcs in stock"},
+		{name: "stock in transit", inTransit: 2, unit: entities.UnitKilogram, wantErr: "0 pcs in stock and 2 pcs in transit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, products, _ := newProductService(entities.Product{ID: 1, Name: "Gula", Price: 15000, Stock: tt.stock, Active: true})
+			products.stock[other] = tt.stock2
+			products.transit[other] = tt.inTransit
+
+			unit := tt.unit
+			_, err := service.Update(context.Background(), entities.DefaultStoreID, 1, &dtos.ProductUpdateRequestDto{Name: "Gula", Price: 15000, Stock: tt.stock, Unit: &unit})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Update() error = %v, want %q", err, tt.wantErr)
+				}
+				if stored := products.products[1]; stored.Unit != entities.UnitPiece {
+					t.Errorf("unit = %s, want %s", stored.Unit, entities.UnitPiece)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			if stored := products.products[1]; stored.Unit != tt.unit {
+				t.Errorf("unit = %s, want %s", stored.Unit, tt.unit)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type promotionServiceImpl struct {
+	repository         repositories.PromotionRepository
+	productRepository  repositories.ProductRepository
+	categoryRepository repositories.CategoryRepository
+	mapper             *mappers.PromotionMapper
+	location           *time.Location
+}
+
+// NewPromotionService creates a new instance of PromotionService
+func NewPromotionService(
+	repository repositories.PromotionRepository,
+	productRepository repositories.ProductRepository,
+	categoryRepository repositories.CategoryRepository,
+	location *time.Location,
+) services.PromotionService {
+	return &promotionServiceImpl{
+		repository:         repository,
+		productRepository:  productRepository,
+		categoryRepository: categoryRepository,
+		mapper:             &mappers.PromotionMapper{Location: location},
+		location:           location,
+	}
+}
+
+// GetAll retrieves all promotions, or only those running now
+func (s *promotionServiceImpl) GetAll(ctx context.Context, runningOnly bool) ([]dtos.PromotionDto, error) {
+	promotions, err := s.repository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all promotions: %w", err)
+	}
+
+	if runningOnly {
+		now := time.Now()
+		running := []entities.Promotion{}
+		for _, promotion := range promotions {
+			if promotion.ActiveAt(now, s.location) {
+				running = append(running, promotion)
+			}
+		}
+		promotions = running
+	}
+
+	return s.mapper.ToDtoList(promotions), nil
+}
+
+// GetByID retrieves a promotion by ID
+func (s *promotionServiceImpl) GetByID(ctx context.Context, id int) (*dtos.PromotionDto, error) {
+	promotion, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promotion by id %d: %w", id, err)
+	}
+
+	if promotion == nil {
+		return nil, fmt.Errorf("promotion with id %d not found", id)
+	}
+
+	return s.mapper.ToDto(promotion), nil
+}
+
+// Create creates a new promotion
+func (s *promotionServiceImpl) Create(ctx context.Context, dto *dtos.PromotionRequestDto) (*dtos.PromotionDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("create request dto cannot be nil")
+	}
+
+	promotion := s.mapper.ToEntity(dto)
+	if err := s.validate(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	err := s.repository.Create(ctx, promotion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	// Reload to include product names
+	return s.GetByID(ctx, promotion.ID)
+}
+
+// Update replaces the settings of an existing promotion
+func (s *promotionServiceImpl) Update(ctx context.Context, id int, dto *dtos.PromotionRequestDto) (*dtos.PromotionDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("update request dto cannot be nil")
+	}
+
+	// Check if promotion exists
+	existingPromotion, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find promotion by id %d: %w", id, err)
+	}
+
+	if existingPromotion == nil {
+		return nil, fmt.Errorf("promotion with id %d not found", id)
+	}
+
+	s.mapper.UpdateEntity(existingPromotion, dto)
+	if err := s.validate(ctx, existingPromotion); err != nil {
+		return nil, err
+	}
+
+	err = s.repository.Update(ctx, existingPromotion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+// Delete deletes a promotion by ID
+func (s *promotionServiceImpl) Delete(ctx context.Context, id int) error {
+	// Check if promotion exists
+	existingPromotion, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find promotion by id %d: %w", id, err)
+	}
+
+	if existingPromotion == nil {
+		return fmt.Errorf("promotion with id %d not found", id)
+	}
+
+	err = s.repository.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+
+	return nil
+}
+
+// validate checks a promotion's settings and that the products and category it names exist
+func (s *promotionServiceImpl) validate(ctx context.Context, promotion *entities.Promotion) error {
+	if err := promotion.Validate(); err != nil {
+		return err
+	}
+
+	for _, item := range promotion.Items {
+		product, err := s.productRepository.FindByID(ctx, entities.DefaultStoreID, item.ProductID)
+		if err != nil {
+			return fmt.Errorf("failed to find product by id %d: %w", item.ProductID, err)
+		}
+		if product == nil {
+			return fmt.Errorf("product with id %d not found", item.ProductID)
+		}
+		if product.IsArchived() {
+			return fmt.Errorf("product %s is archived", product.Name)
+		}
+		if product.HasVariants {
+			return fmt.Errorf("product %s has variants; list the variants instead", product.Name)
+		}
+	}
+
+	if promotion.CategoryID != nil {
+		category, err := s.categoryRepository.FindByID(ctx, *promotion.CategoryID)
+		if err != nil {
+			return fmt.Errorf("failed to find category by id %d: %w", *promotion.CategoryID, err)
+		}
+		if category == nil {
+			return fmt.Errorf("category with id %d not found", *promotion.CategoryID)
+		}
+		if category.IsArchived() {
+			return fmt.Errorf("category %s is archived", category.Name)
+		}
+	}
+
+	return nil
+}
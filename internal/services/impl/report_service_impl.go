@@ -3,45 +3,69 @@ package impl
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
 	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
 	"github.com/gustionusamba24/kasir-api-go/internal/services"
 )
 
 type reportServiceImpl struct {
 	transactionRepository repositories.TransactionRepository
+	lotRepository         repositories.ProductLotRepository
+	lotMapper             *mappers.ProductLotMapper
 }
 
-func NewReportService(transactionRepository repositories.TransactionRepository) services.ReportService {
+func NewReportService(transactionRepository repositories.TransactionRepository, lotRepository repositories.ProductLotRepository) services.ReportService {
 	return &reportServiceImpl{
 		transactionRepository: transactionRepository,
+		lotRepository:         lotRepository,
+		lotMapper:             &mappers.ProductLotMapper{},
 	}
 }
 
-func (s *reportServiceImpl) GetTodayReport(ctx context.Context) (*dtos.TodayReportDto, error) {
+func (s *reportServiceImpl) GetTodayReport(ctx context.Context, storeID int, byCategory bool) (*dtos.TodayReportDto, error) {
 	// Get today's total revenue
-	totalRevenue, err := s.transactionRepository.GetTodayRevenue(ctx)
+	totalRevenue, err := s.transactionRepository.GetTodayRevenue(ctx, storeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get today's revenue: %w", err)
 	}
 
+	// Get today's cost of goods sold
+	totalCost, err := s.transactionRepository.GetTodayCostOfGoodsSold(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get today's cost of goods sold: %w", err)
+	}
+
 	// Get today's transaction count
-	totalTransactions, err := s.transactionRepository.GetTodayTransactionCount(ctx)
+	totalTransactions, err := s.transactionRepository.GetTodayTransactionCount(ctx, storeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get today's transaction count: %w", err)
 	}
 
+	// Get the components of today's total
+	totals, err := s.transactionRepository.GetTodaySalesTotals(ctx, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get today's sales totals: %w", err)
+	}
+
 	// Get today's best selling product
-	productName, qtySold, err := s.transactionRepository.GetTodayBestSellingProduct(ctx)
+	productName, qtySold, err := s.transactionRepository.GetTodayBestSellingProduct(ctx, storeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get today's best selling product: %w", err)
 	}
 
 	// Build report DTO
 	report := &dtos.TodayReportDto{
-		TotalRevenue:      totalRevenue,
-		TotalTransactions: totalTransactions,
+		StoreID:            storeID,
+		TotalRevenue:       totalRevenue,
+		TotalCost:          totalCost,
+		GrossProfit:        totalRevenue - totalCost,
+		GrossMarginPercent: grossMarginPercent(totalRevenue, totalCost),
+		TotalTransactions:  totalTransactions,
+		Totals:             toSalesTotalsDto(totals),
 	}
 
 	// Only add best selling product if there are transactions today
@@ -52,34 +76,60 @@ func (s *reportServiceImpl) GetTodayReport(ctx context.Context) (*dtos.TodayRepo
 		}
 	}
 
+	// Roll sales up to top-level categories when asked for
+	if byCategory {
+		sales, err := s.transactionRepository.GetTodayCategorySales(ctx, storeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get today's category sales: %w", err)
+		}
+		report.Categories = toCategorySalesDtos(sales)
+	}
+
 	return report, nil
 }
 
-func (s *reportServiceImpl) GetDateRangeReport(ctx context.Context, startDate, endDate string) (*dtos.DateRangeReportDto, error) {
+func (s *reportServiceImpl) GetDateRangeReport(ctx context.Context, storeID int, startDate, endDate string, byCategory bool) (*dtos.DateRangeReportDto, error) {
 	// Get date range total revenue
-	totalRevenue, err := s.transactionRepository.GetDateRangeRevenue(ctx, startDate, endDate)
+	totalRevenue, err := s.transactionRepository.GetDateRangeRevenue(ctx, storeID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get date range revenue: %w", err)
 	}
 
+	// Get date range cost of goods sold
+	totalCost, err := s.transactionRepository.GetDateRangeCostOfGoodsSold(ctx, storeID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range cost of goods sold: %w", err)
+	}
+
 	// Get date range transaction count
-	totalTransactions, err := s.transactionRepository.GetDateRangeTransactionCount(ctx, startDate, endDate)
+	totalTransactions, err := s.transactionRepository.GetDateRangeTransactionCount(ctx, storeID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get date range transaction count: %w", err)
 	}
 
+	// Get the components of the date range total
+	totals, err := s.transactionRepository.GetDateRangeSalesTotals(ctx, storeID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range sales totals: %w", err)
+	}
+
 	// Get date range best selling product
-	productName, qtySold, err := s.transactionRepository.GetDateRangeBestSellingProduct(ctx, startDate, endDate)
+	productName, qtySold, err := s.transactionRepository.GetDateRangeBestSellingProduct(ctx, storeID, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get date range best selling product: %w", err)
 	}
 
 	// Build report DTO
 	report := &dtos.DateRangeReportDto{
-		StartDate:         startDate,
-		EndDate:           endDate,
-		TotalRevenue:      totalRevenue,
-		TotalTransactions: totalTransactions,
+		StoreID:            storeID,
+		StartDate:          startDate,
+		EndDate:            endDate,
+		TotalRevenue:       totalRevenue,
+		TotalCost:          totalCost,
+		GrossProfit:        totalRevenue - totalCost,
+		GrossMarginPercent: grossMarginPercent(totalRevenue, totalCost),
+		TotalTransactions:  totalTransactions,
+		Totals:             toSalesTotalsDto(totals),
 	}
 
 	// Only add best selling product if there are transactions in the date range
@@ -90,5 +140,142 @@ func (s *reportServiceImpl) GetDateRangeReport(ctx context.Context, startDate, e
 		}
 	}
 
+	// Roll sales up to top-level categories when asked for
+	if byCategory {
+		sales, err := s.transactionRepository.GetDateRangeCategorySales(ctx, storeID, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get date range category sales: %w", err)
+		}
+		report.Categories = toCategorySalesDtos(sales)
+	}
+
+	return report, nil
+}
+
+func (s *reportServiceImpl) GetTaxReport(ctx context.Context, storeID int, startDate, endDate string) (*dtos.TaxReportDto, error) {
+	summaries, err := s.transactionRepository.GetDateRangeTaxSummary(ctx, storeID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range tax summary: %w", err)
+	}
+
+	report := &dtos.TaxReportDto{
+		StoreID:   storeID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Rates:     make([]dtos.TaxSummaryDto, len(summaries)),
+	}
+
+	for i, summary := range summaries {
+		report.Rates[i] = dtos.TaxSummaryDto{
+			TaxRateID:        summary.TaxRateID,
+			Name:             summary.Name,
+			Rate:             summary.Rate,
+			TransactionCount: summary.TransactionCount,
+			TaxableAmount:    summary.TaxableAmount,
+			TaxAmount:        summary.TaxAmount,
+		}
+		report.TotalTaxable += summary.TaxableAmount
+		report.TotalTax += summary.TaxAmount
+	}
+
 	return report, nil
 }
+
+func (s *reportServiceImpl) GetPaymentReport(ctx context.Context, storeID int, startDate, endDate string) (*dtos.PaymentReportDto, error) {
+	summaries, err := s.transactionRepository.GetDateRangePaymentSummary(ctx, storeID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get date range payment summary: %w", err)
+	}
+
+	report := &dtos.PaymentReportDto{
+		StoreID:   storeID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Methods:   make([]dtos.PaymentSummaryDto, len(summaries)),
+	}
+
+	for i, summary := range summaries {
+		// The store keeps what was handed over less the change given back
+		net := summary.Amount - summary.ChangeAmount
+		report.Methods[i] = dtos.PaymentSummaryDto{
+			Method:           summary.Method,
+			PaymentCount:     summary.PaymentCount,
+			TransactionCount: summary.TransactionCount,
+			Amount:           summary.Amount,
+			ChangeAmount:     summary.ChangeAmount,
+			NetAmount:        net,
+		}
+		report.TotalReceived += net
+		if summary.Method == entities.PaymentCash {
+			report.CashInDrawer = net
+		}
+	}
+
+	return report, nil
+}
+
+func (s *reportServiceImpl) GetExpiringReport(ctx context.Context, storeID, days int) (*dtos.ExpiringReportDto, error) {
+	if days < 0 {
+		return nil, fmt.Errorf("days cannot be negative")
+	}
+
+	lots, err := s.lotRepository.FindExpiring(ctx, storeID, days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring lots: %w", err)
+	}
+
+	report := &dtos.ExpiringReportDto{
+		StoreID:   storeID,
+		Days:      days,
+		TotalLots: len(lots),
+		Lots:      s.lotMapper.ToDtoList(lots),
+	}
+
+	if report.Lots == nil {
+		report.Lots = []dtos.ProductLotDto{}
+	}
+
+	for _, lot := range lots {
+		report.TotalQuantity += lot.Quantity
+	}
+
+	return report, nil
+}
+
+// toSalesTotalsDto converts the components of a total to a DTO
+func toSalesTotalsDto(totals *entities.SalesTotals) dtos.SalesTotalsDto {
+	return dtos.SalesTotalsDto{
+		GrossAmount:    totals.GrossAmount,
+		DiscountAmount: totals.DiscountAmount,
+		ServiceCharge:  totals.ServiceCharge,
+		TaxIncluded:    totals.TaxIncluded,
+		TaxAdded:       totals.TaxAdded,
+		RoundingAmount: totals.RoundingAmount,
+		TotalAmount:    totals.TotalAmount,
+	}
+}
+
+// toCategorySalesDtos converts category sales to DTOs with their gross profit
+func toCategorySalesDtos(sales []entities.CategorySales) []dtos.CategorySalesDto {
+	result := make([]dtos.CategorySalesDto, len(sales))
+	for i, category := range sales {
+		result[i] = dtos.CategorySalesDto{
+			CategoryID:   category.CategoryID,
+			CategoryName: category.CategoryName,
+			Revenue:      category.Revenue,
+			Cost:         category.Cost,
+			GrossProfit:  category.Revenue - category.Cost,
+			QtySold:      category.QtySold,
+		}
+	}
+	return result
+}
+
+// grossMarginPercent returns gross profit as a percentage of revenue, rounded to two decimals
+func grossMarginPercent(revenue, cost int) float64 {
+	if revenue == 0 {
+		return 0
+	}
+	margin := float64(revenue-cost) / float64(revenue) * 100
+	return math.Round(margin*100) / 100
+}
@@ -0,0 +1,26 @@
+package impl
+
+import "testing"
+
+func TestGrossMarginPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		revenue int
+		cost    int
+		want    float64
+	}{
+		{name: "no sales", revenue: 0, cost: 0, want: 0},
+		{name: "typical margin", revenue: 100000, cost: 75000, want: 25},
+		{name: "rounded to two decimals", revenue: 30000, cost: 20000, want: 33.33},
+		{name: "nothing spent", revenue: 50000, cost: 0, want: 100},
+		{name: "sold below cost", revenue: 40000, cost: 50000, want: -25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grossMarginPercent(tt.revenue, tt.cost); got != tt.want {
+				t.Errorf("grossMarginPercent(%d, %d) = %v, want %v", tt.revenue, tt.cost, got, tt.want)
+			}
+		})
+	}
+}
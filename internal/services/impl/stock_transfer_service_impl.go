@@ -0,0 +1,248 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type stockTransferServiceImpl struct {
+	repository        repositories.StockTransferRepository
+	storeRepository   repositories.StoreRepository
+	productRepository repositories.ProductRepository
+	mapper            *mappers.StockTransferMapper
+}
+
+// NewStockTransferService creates a new instance of StockTransferService
+func NewStockTransferService(
+	repository repositories.StockTransferRepository,
+	storeRepository repositories.StoreRepository,
+	productRepository repositories.ProductRepository,
+) services.StockTransferService {
+	return &stockTransferServiceImpl{
+		repository:        repository,
+		storeRepository:   storeRepository,
+		productRepository: productRepository,
+		mapper:            &mappers.StockTransferMapper{},
+	}
+}
+
+// GetAll retrieves stock transfers, optionally filtered by status and store
+func (s *stockTransferServiceImpl) GetAll(ctx context.Context, status string, storeID int) ([]dtos.StockTransferDto, error) {
+	if status != "" && !isStockTransferStatus(status) {
+		return nil, fmt.Errorf("invalid stock transfer status %q", status)
+	}
+
+	transfers, err := s.repository.FindAll(ctx, status, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock transfers: %w", err)
+	}
+
+	return s.mapper.ToDtoList(transfers), nil
+}
+
+// GetByID retrieves a stock transfer by ID
+func (s *stockTransferServiceImpl) GetByID(ctx context.Context, id int) (*dtos.StockTransferDto, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDto(transfer), nil
+}
+
+// Create requests a stock transfer between two stores
+func (s *stockTransferServiceImpl) Create(ctx context.Context, dto *dtos.StockTransferCreateRequestDto) (*dtos.StockTransferDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("create request dto cannot be nil")
+	}
+
+	if len(dto.Items) == 0 {
+		return nil, fmt.Errorf("stock transfer items cannot be empty")
+	}
+
+	if dto.SourceStoreID == dto.DestinationStoreID {
+		return nil, fmt.Errorf("source and destination store must be different")
+	}
+
+	// Validate both stores exist
+	for _, storeID := range []int{dto.SourceStoreID, dto.DestinationStoreID} {
+		store, err := s.storeRepository.FindByID(ctx, storeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find store by id %d: %w", storeID, err)
+		}
+		if store == nil {
+			return nil, fmt.Errorf("store with id %d not found", storeID)
+		}
+	}
+
+	// Validate items
+	seen := make(map[int]bool)
+	for _, item := range dto.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity for product %d must be greater than 0", item.ProductID)
+		}
+
+		if seen[item.ProductID] {
+			return nil, fmt.Errorf("product %d is listed more than once", item.ProductID)
+		}
+		seen[item.ProductID] = true
+
+		product, err := s.productRepository.FindByID(ctx, dto.SourceStoreID, item.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find product by id %d: %w", item.ProductID, err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("product with id %d not found", item.ProductID)
+		}
+
+		if err := product.ValidateQuantity(item.Quantity); err != nil {
+			return nil, err
+		}
+	}
+
+	transfer := s.mapper.ToEntity(dto)
+
+	err := s.repository.Create(ctx, transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock transfer: %w", err)
+	}
+
+	// Reload to include product names
+	return s.GetByID(ctx, transfer.ID)
+}
+
+// Ship ships a requested transfer, deducting stock from the source store
+func (s *stockTransferServiceImpl) Ship(ctx context.Context, id int) (*dtos.StockTransferDto, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != entities.StockTransferStatusRequested {
+		return nil, fmt.Errorf("stock transfer %d cannot be shipped because it is %s", id, transfer.Status)
+	}
+
+	// Ship everything that was requested
+	for i := range transfer.Items {
+		transfer.Items[i].QuantityShipped = transfer.Items[i].QuantityRequested
+	}
+
+	if err := s.repository.Ship(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("failed to ship stock transfer: %w", err)
+	}
+
+	// Reload to include the serial numbers shipped
+	return s.GetByID(ctx, transfer.ID)
+}
+
+// Receive receives a shipped transfer, adding the received quantities to the destination store
+func (s *stockTransferServiceImpl) Receive(ctx context.Context, id int, dto *dtos.StockTransferReceiveRequestDto) (*dtos.StockTransferDto, error) {
+	if dto == nil {
+		dto = &dtos.StockTransferReceiveRequestDto{}
+	}
+
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != entities.StockTransferStatusShipped {
+		return nil, fmt.Errorf("stock transfer %d cannot be received because it is %s", id, transfer.Status)
+	}
+
+	// Everything shipped is assumed to arrive unless the receiver reports otherwise
+	itemIndex := make(map[int]int)
+	for i := range transfer.Items {
+		transfer.Items[i].QuantityReceived = transfer.Items[i].QuantityShipped
+		itemIndex[transfer.Items[i].ProductID] = i
+	}
+
+	hasDiscrepancy := false
+	for _, received := range dto.Items {
+		i, ok := itemIndex[received.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("product %d is not part of stock transfer %d", received.ProductID, id)
+		}
+
+		if received.QuantityReceived < 0 {
+			return nil, fmt.Errorf("received quantity for product %d cannot be negative", received.ProductID)
+		}
+
+		transfer.Items[i].QuantityReceived = received.QuantityReceived
+		if transfer.Items[i].Discrepancy() != 0 {
+			hasDiscrepancy = true
+		}
+
+		// Serialized units that arrived are named so the rest stay in transit
+		if received.SerialNumbers != nil {
+			serialNumbers, err := normalizeSerialNumbers(received.SerialNumbers, int(received.QuantityReceived))
+			if err != nil {
+				return nil, fmt.Errorf("product %d: %w", received.ProductID, err)
+			}
+			transfer.Items[i].ReceivedSerialNumbers = serialNumbers
+		}
+	}
+
+	// Short or over deliveries must be explained so they can be followed up
+	if hasDiscrepancy && dto.Note == "" {
+		return nil, fmt.Errorf("a note is required when received quantities differ from shipped quantities")
+	}
+	transfer.ReceivingNote = dto.Note
+
+	if err := s.repository.Receive(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("failed to receive stock transfer: %w", err)
+	}
+
+	// Reload to include the serial numbers received
+	return s.GetByID(ctx, transfer.ID)
+}
+
+// Cancel cancels a transfer that has not been shipped yet
+func (s *stockTransferServiceImpl) Cancel(ctx context.Context, id int) (*dtos.StockTransferDto, error) {
+	transfer, err := s.findTransfer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.Status != entities.StockTransferStatusRequested {
+		return nil, fmt.Errorf("stock transfer %d cannot be cancelled because it is %s", id, transfer.Status)
+	}
+
+	if err := s.repository.Cancel(ctx, transfer); err != nil {
+		return nil, fmt.Errorf("failed to cancel stock transfer: %w", err)
+	}
+
+	return s.mapper.ToDto(transfer), nil
+}
+
+// findTransfer loads a stock transfer and reports a not found error if it does not exist
+func (s *stockTransferServiceImpl) findTransfer(ctx context.Context, id int) (*entities.StockTransfer, error) {
+	transfer, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock transfer by id %d: %w", id, err)
+	}
+
+	if transfer == nil {
+		return nil, fmt.Errorf("stock transfer with id %d not found", id)
+	}
+
+	return transfer, nil
+}
+
+// isStockTransferStatus reports whether status is a known stock transfer status
+func isStockTransferStatus(status string) bool {
+	switch status {
+	case entities.StockTransferStatusRequested,
+		entities.StockTransferStatusShipped,
+		entities.StockTransferStatusReceived,
+		entities.StockTransferStatusCancelled:
+		return true
+	}
+	return false
+}
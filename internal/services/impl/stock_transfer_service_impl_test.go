@@ -0,0 +1,252 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// stockTransferRepositoryStub keeps transfers in memory and moves stock the way
+// the database does: shipping takes it from the source store and puts it in
+// transit, receiving takes it out of transit and adds what arrived to the destination
+type stockTransferRepositoryStub struct {
+	repositories.StockTransferRepository
+	transfers map[int]*entities.StockTransfer
+	products  *productRepositoryStub
+}
+
+func (r *stockTransferRepositoryStub) Create(ctx context.Context, transfer *entities.StockTransfer) error {
+	transfer.ID = len(r.transfers) + 1
+	transfer.Status = entities.StockTransferStatusRequested
+	stored := *transfer
+	stored.Items = append([]entities.StockTransferItem(nil), transfer.Items...)
+	r.transfers[transfer.ID] = &stored
+	return nil
+}
+
+func (r *stockTransferRepositoryStub) FindByID(ctx context.Context, id int) (*entities.StockTransfer, error) {
+	transfer, ok := r.transfers[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *transfer
+	found.Items = append([]entities.StockTransferItem(nil), transfer.Items...)
+	return &found, nil
+}
+
+func (r *stockTransferRepositoryStub) Ship(ctx context.Context, transfer *entities.StockTransfer) error {
+	for _, item := range transfer.Items {
+		if r.products.storeStock(transfer.SourceStoreID, item.ProductID) < item.QuantityShipped {
+			return fmt.Errorf("insufficient stock for product %d at store %d", item.ProductID, transfer.SourceStoreID)
+		}
+	}
+	for _, item := range transfer.Items {
+		source := storeProduct{transfer.SourceStoreID, item.ProductID}
+		r.products.setStoreStock(source, r.products.storeStock(source.storeID, source.productID)-item.QuantityShipped)
+		r.products.transit[storeProduct{transfer.DestinationStoreID, item.ProductID}] += item.QuantityShipped
+	}
+	return r.save(transfer, entities.StockTransferStatusShipped)
+}
+
+func (r *stockTransferRepositoryStub) Receive(ctx context.Context, transfer *entities.StockTransfer) error {
+	for _, item := range transfer.Items {
+		destination := storeProduct{transfer.DestinationStoreID, item.ProductID}
+		r.products.transit[destination] -= item.QuantityShipped
+		r.products.setStoreStock(destination, r.products.storeStock(destination.storeID, destination.productID)+item.QuantityReceived)
+	}
+	return r.save(transfer, entities.StockTransferStatusReceived)
+}
+
+func (r *stockTransferRepositoryStub) Cancel(ctx context.Context, transfer *entities.StockTransfer) error {
+	return r.save(transfer, entities.StockTransferStatusCancelled)
+}
+
+func (r *stockTransferRepositoryStub) save(transfer *entities.StockTransfer, status string) error {
+	transfer.Status = status
+	stored := *transfer
+	stored.Items = append([]entities.StockTransferItem(nil), transfer.Items...)
+	r.transfers[transfer.ID] = &stored
+	return nil
+}
+
+// storeStock returns the stock of a product at a store
+func (r *productRepositoryStub) storeStock(storeID, productID int) float64 {
+	product, _ := r.FindByID(context.Background(), storeID, productID)
+	return product.Stock
+}
+
+// setStoreStock sets the stock of a product at a store
+func (r *productRepositoryStub) setStoreStock(key storeProduct, quantity float64) {
+	if key.storeID == entities.DefaultStoreID {
+		r.products[key.productID].Stock = quantity
+		return
+	}
+	r.stock[key] = quantity
+}
+
+func newStockTransferService() (*stockTransferServiceImpl, *productRepositoryStub) {
+	products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+	transfers := &stockTransferRepositoryStub{transfers: make(map[int]*entities.StockTransfer), products: products}
+	service := NewStockTransferService(transfers, newStoreRepositoryStub(1, 2), products).(*stockTransferServiceImpl)
+	return service, products
+}
+
+func TestStockTransferCreate(t *testing.T) {
+	item := func(productID int, quantity float64) dtos.StockTransferItemRequestDto {
+		return dtos.StockTransferItemRequestDto{ProductID: productID, Quantity: quantity}
+	}
+
+	tests := []struct {
+		name    string
+		dto     dtos.StockTransferCreateRequestDto
+		wantErr string
+	}{
+		{name: "requested", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 1, DestinationStoreID: 2, Items: []dtos.StockTransferItemRequestDto{item(1, 4)}}},
+		{name: "no items", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 1, DestinationStoreID: 2}, wantErr: "items cannot be empty"},
+		{name: "same store", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 2, DestinationStoreID: 2, Items: []dtos.StockTransferItemRequestDto{item(1, 4)}}, wantErr: "source and destination store must be different"},
+		{name: "unknown store", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 1, DestinationStoreID: 3, Items: []dtos.StockTransferItemRequestDto{item(1, 4)}}, wantErr: "store with id 3 not found"},
+		{name: "no quantity", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 1, DestinationStoreID: 2, Items: []dtos.StockTransferItemRequestDto{item(1, 0)}}, wantErr: "must be greater than 0"},
+		{name: "product listed twice", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 1, DestinationStoreID: 2, Items: []dtos.StockTransferItemRequestDto{item(1, 1), item(1, 2)}}, wantErr: "listed more than once"},
+		{name: "unknown product", dto: dtos.StockTransferCreateRequestDto{SourceStoreID: 1, DestinationStoreID: 2, Items: []dtos.StockTransferItemRequestDto{item(9, 1)}}, wantErr: "product with id 9 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, products := newStockTransferService()
+
+			dto := tt.dto
+			transfer, err := service.Create(context.Background(), &dto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			// A request moves no stock until it ships
+			if transfer.Status != entities.StockTransferStatusRequested {
+				t.Errorf("status = %s, want %s", transfer.Status, entities.StockTransferStatusRequested)
+			}
+			if stock := products.storeStock(1, 1); stock != 10 {
+				t.Errorf("source stock = %v, want 10", stock)
+			}
+		})
+	}
+}
+
+func TestStockTransferLifecycle(t *testing.T) {
+	// step is an action on the transfer and what it should lead to
+	type step struct {
+		action string
+		// received and note are sent with a receive
+		received *float64
+		note     string
+		wantErr  string
+		// wantStatus, and the stock at each store and in transit to store 2 after the step
+		wantStatus      string
+		wantSource      float64
+		wantDestination float64
+		wantInTransit   float64
+	}
+	three := 3.0
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "shipped stock is in transit until received",
+			steps: []step{
+				{action: "ship", wantStatus: entities.StockTransferStatusShipped, wantSource: 6, wantInTransit: 4},
+				{action: "receive", wantStatus: entities.StockTransferStatusReceived, wantSource: 6, wantDestination: 4},
+			},
+		},
+		{
+			name: "short delivery needs a note",
+			steps: []step{
+				{action: "ship", wantStatus: entities.StockTransferStatusShipped, wantSource: 6, wantInTransit: 4},
+				{action: "receive", received: &three, wantErr: "a note is required", wantStatus: entities.StockTransferStatusShipped, wantSource: 6, wantInTransit: 4},
+				{action: "receive", received: &three, note: "One box damaged", wantStatus: entities.StockTransferStatusReceived, wantSource: 6, wantDestination: 3},
+			},
+		},
+		{
+			name: "cancelled before shipping",
+			steps: []step{
+				{action: "cancel", wantStatus: entities.StockTransferStatusCancelled, wantSource: 10},
+				{action: "ship", wantErr: "cannot be shipped because it is cancelled", wantStatus: entities.StockTransferStatusCancelled, wantSource: 10},
+			},
+		},
+		{
+			name: "shipped transfer cannot be cancelled or shipped again",
+			steps: []step{
+				{action: "ship", wantStatus: entities.StockTransferStatusShipped, wantSource: 6, wantInTransit: 4},
+				{action: "cancel", wantErr: "cannot be cancelled because it is shipped", wantStatus: entities.StockTransferStatusShipped, wantSource: 6, wantInTransit: 4},
+				{action: "ship", wantErr: "cannot be shipped because it is shipped", wantStatus: entities.StockTransferStatusShipped, wantSource: 6, wantInTransit: 4},
+			},
+		},
+		{
+			name: "requested transfer cannot be received",
+			steps: []step{
+				{action: "receive", wantErr: "cannot be received because it is requested", wantStatus: entities.StockTransferStatusRequested, wantSource: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, products := newStockTransferService()
+			ctx := context.Background()
+
+			created, err := service.Create(ctx, &dtos.StockTransferCreateRequestDto{
+				SourceStoreID:      1,
+				DestinationStoreID: 2,
+				Items:              []dtos.StockTransferItemRequestDto{{ProductID: 1, Quantity: 4}},
+			})
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			for i, step := range tt.steps {
+				switch step.action {
+				case "ship":
+					_, err = service.Ship(ctx, created.ID)
+				case "receive":
+					dto := &dtos.StockTransferReceiveRequestDto{Note: step.note}
+					if step.received != nil {
+						dto.Items = []dtos.StockTransferReceiveItemRequestDto{{ProductID: 1, QuantityReceived: *step.received}}
+					}
+					_, err = service.Receive(ctx, created.ID, dto)
+				case "cancel":
+					_, err = service.Cancel(ctx, created.ID)
+				}
+
+				if step.wantErr == "" && err != nil {
+					t.Fatalf("step %d (%s): error = %v", i, step.action, err)
+				}
+				if step.wantErr != "" && (err == nil || !strings.Contains(err.Error(), step.wantErr)) {
+					t.Fatalf("step %d (%s): error = %v, want %q", i, step.action, err, step.wantErr)
+				}
+
+				transfer, _ := service.GetByID(ctx, created.ID)
+				destination, _ := products.FindByID(ctx, 2, 1)
+				if transfer.Status != step.wantStatus {
+					t.Errorf("step %d (%s): status = %s, want %s", i, step.action, transfer.Status, step.wantStatus)
+				}
+				if source := products.storeStock(1, 1); source != step.wantSource {
+					t.Errorf("step %d (%s): source stock = %v, want %v", i, step.action, source, step.wantSource)
+				}
+				if destination.Stock != step.wantDestination || destination.InTransit != step.wantInTransit {
+					t.Errorf("step %d (%s): destination stock, in transit = %v, %v, want %v, %v", i, step.action, destination.Stock, destination.InTransit, step.wantDestination, step.wantInTransit)
+				}
+			}
+		})
+	}
+}
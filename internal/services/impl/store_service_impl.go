@@ -0,0 +1,213 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type storeServiceImpl struct {
+	repository        repositories.StoreRepository
+	productRepository repositories.ProductRepository
+	mapper            *mappers.StoreMapper
+	productMapper     *mappers.ProductMapper
+}
+
+// NewStoreService creates a new instance of StoreService
+func NewStoreService(
+	repository repositories.StoreRepository,
+	productRepository repositories.ProductRepository,
+) services.StoreService {
+	return &storeServiceImpl{
+		repository:        repository,
+		productRepository: productRepository,
+		mapper:            &mappers.StoreMapper{},
+		productMapper:     &mappers.ProductMapper{},
+	}
+}
+
+// GetAll retrieves all stores
+func (s *storeServiceImpl) GetAll(ctx context.Context) ([]dtos.StoreDto, error) {
+	stores, err := s.repository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all stores: %w", err)
+	}
+
+	return s.mapper.ToDtoList(stores), nil
+}
+
+// GetByID retrieves a store by ID
+func (s *storeServiceImpl) GetByID(ctx context.Context, id int) (*dtos.StoreDto, error) {
+	store, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get store by id %d: %w", id, err)
+	}
+
+	if store == nil {
+		return nil, fmt.Errorf("store with id %d not found", id)
+	}
+
+	return s.mapper.ToDto(store), nil
+}
+
+// Create creates a new store
+func (s *storeServiceImpl) Create(ctx context.Context, dto *dtos.StoreCreateRequestDto) (*dtos.StoreDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("create request dto cannot be nil")
+	}
+
+	// Convert DTO to request
+	request := s.mapper.ToCreateRequest(dto)
+
+	// Convert request to entity
+	store := s.mapper.ToEntity(request)
+
+	if err := store.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Save to repository
+	err := s.repository.Create(ctx, store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create store: %w", err)
+	}
+
+	// Return created store as DTO
+	return s.mapper.ToDto(store), nil
+}
+
+// Update updates an existing store
+func (s *storeServiceImpl) Update(ctx context.Context, id int, dto *dtos.StoreUpdateRequestDto) (*dtos.StoreDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("update request dto cannot be nil")
+	}
+
+	// Check if store exists
+	existingStore, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store by id %d: %w", id, err)
+	}
+
+	if existingStore == nil {
+		return nil, fmt.Errorf("store with id %d not found", id)
+	}
+
+	// Convert DTO to request
+	request := s.mapper.ToUpdateRequest(dto)
+
+	// Update entity with request data
+	s.mapper.UpdateEntity(existingStore, request)
+	existingStore.ID = id // Ensure ID is preserved
+
+	if err := existingStore.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Save updated entity
+	err = s.repository.Update(ctx, existingStore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update store: %w", err)
+	}
+
+	// Return updated store as DTO
+	return s.mapper.ToDto(existingStore), nil
+}
+
+// Delete deletes a store by ID
+func (s *storeServiceImpl) Delete(ctx context.Context, id int) error {
+	if id == entities.DefaultStoreID {
+		return fmt.Errorf("the main store cannot be deleted")
+	}
+
+	// Check if store exists
+	existingStore, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find store by id %d: %w", id, err)
+	}
+
+	if existingStore == nil {
+		return fmt.Errorf("store with id %d not found", id)
+	}
+
+	// Delete store
+	err = s.repository.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete store: %w", err)
+	}
+
+	return nil
+}
+
+// GetProducts retrieves the catalog with the stock levels and prices of a store
+func (s *storeServiceImpl) GetProducts(ctx context.Context, id int) ([]dtos.ProductDto, error) {
+	// Check if store exists
+	existingStore, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store by id %d: %w", id, err)
+	}
+
+	if existingStore == nil {
+		return nil, fmt.Errorf("store with id %d not found", id)
+	}
+
+	products, err := s.productRepository.FindAll(ctx, id, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products for store id %d: %w", id, err)
+	}
+
+	return s.productMapper.ToDtoList(products), nil
+}
+
+// UpdateStock sets a store's stock level and price override for a product
+func (s *storeServiceImpl) UpdateStock(ctx context.Context, id int, dto *dtos.StoreStockUpdateRequestDto) (*dtos.ProductDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("stock update request dto cannot be nil")
+	}
+
+	if dto.Stock < 0 {
+		return nil, fmt.Errorf("stock cannot be negative")
+	}
+
+	if dto.Price != nil && *dto.Price <= 0 {
+		return nil, fmt.Errorf("price must be greater than 0")
+	}
+
+	// Check if store exists
+	existingStore, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store by id %d: %w", id, err)
+	}
+
+	if existingStore == nil {
+		return nil, fmt.Errorf("store with id %d not found", id)
+	}
+
+	// Check if product exists
+	product, err := s.productRepository.FindByID(ctx, id, dto.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find product by id %d: %w", dto.ProductID, err)
+	}
+
+	if product == nil {
+		return nil, fmt.Errorf("product with id %d not found", dto.ProductID)
+	}
+
+	if err := product.ValidateStock(dto.Stock); err != nil {
+		return nil, err
+	}
+
+	err = s.productRepository.UpdateStoreStock(ctx, id, dto.ProductID, dto.Stock, dto.Price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update store stock: %w", err)
+	}
+
+	product.Stock = dto.Stock
+	product.StorePrice = dto.Price
+
+	return s.productMapper.ToDto(product), nil
+}
@@ -0,0 +1,124 @@
+package impl
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// storeRepositoryStub keeps stores in memory
+type storeRepositoryStub struct {
+	repositories.StoreRepository
+	stores  map[int]*entities.Store
+	deleted []int
+}
+
+func newStoreRepositoryStub(ids ...int) *storeRepositoryStub {
+	repository := &storeRepositoryStub{stores: make(map[int]*entities.Store)}
+	for _, id := range ids {
+		repository.stores[id] = &entities.Store{ID: id, Name: "Store"}
+	}
+	return repository
+}
+
+func (r *storeRepositoryStub) FindByID(ctx context.Context, id int) (*entities.Store, error) {
+	store, ok := r.stores[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *store
+	return &found, nil
+}
+
+func (r *storeRepositoryStub) Delete(ctx context.Context, id int) error {
+	r.deleted = append(r.deleted, id)
+	return nil
+}
+
+func TestStoreUpdateStock(t *testing.T) {
+	price := func(p float64) *float64 { return &p }
+
+	tests := []struct {
+		name      string
+		storeID   int
+		dto       dtos.StoreStockUpdateRequestDto
+		wantErr   string
+		wantPrice float64
+	}{
+		{name: "stock and price override", storeID: 2, dto: dtos.StoreStockUpdateRequestDto{ProductID: 1, Stock: 8, Price: price(4500)}, wantPrice: 4500},
+		{name: "no override sells at the catalog price", storeID: 2, dto: dtos.StoreStockUpdateRequestDto{ProductID: 1, Stock: 8}, wantPrice: 5000},
+		{name: "negative stock", storeID: 2, dto: dtos.StoreStockUpdateRequestDto{ProductID: 1, Stock: -1}, wantErr: "stock cannot be negative"},
+		{name: "zero price", storeID: 2, dto: dtos.StoreStockUpdateRequestDto{ProductID: 1, Stock: 1, Price: price(0)}, wantErr: "price must be greater than 0"},
+		{name: "unknown store", storeID: 3, dto: dtos.StoreStockUpdateRequestDto{ProductID: 1, Stock: 1}, wantErr: "store with id 3 not found"},
+		{name: "unknown product", storeID: 2, dto: dtos.StoreStockUpdateRequestDto{ProductID: 9, Stock: 1}, wantErr: "product with id 9 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 20, Active: true})
+			service := NewStoreService(newStoreRepositoryStub(1, 2), products)
+
+			dto := tt.dto
+			product, err := service.UpdateStock(context.Background(), tt.storeID, &dto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UpdateStock() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateStock() error = %v", err)
+			}
+
+			// Only the named store changes; the main store keeps its stock
+			stored, _ := products.FindByID(context.Background(), tt.storeID, 1)
+			main, _ := products.FindByID(context.Background(), entities.DefaultStoreID, 1)
+			if stored.Stock != tt.dto.Stock || stored.SellingPrice() != tt.wantPrice {
+				t.Errorf("store stock, price = %v, %v, want %v, %v", stored.Stock, stored.SellingPrice(), tt.dto.Stock, tt.wantPrice)
+			}
+			if main.Stock != 20 || main.SellingPrice() != 5000 {
+				t.Errorf("main store stock, price = %v, %v, want 20, 5000", main.Stock, main.SellingPrice())
+			}
+			if product.Stock != tt.dto.Stock {
+				t.Errorf("returned stock = %v, want %v", product.Stock, tt.dto.Stock)
+			}
+		})
+	}
+}
+
+func TestStoreDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int
+		wantErr string
+	}{
+		{name: "branch store", id: 2},
+		{name: "main store", id: entities.DefaultStoreID, wantErr: "the main store cannot be deleted"},
+		{name: "unknown store", id: 3, wantErr: "store with id 3 not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stores := newStoreRepositoryStub(1, 2)
+			service := NewStoreService(stores, newProductRepositoryStub())
+
+			err := service.Delete(context.Background(), tt.id)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Delete() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(stores.deleted) != 0 {
+					t.Errorf("deleted stores %v, want none", stores.deleted)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type taxRateServiceImpl struct {
+	repository repositories.TaxRateRepository
+	mapper     *mappers.TaxRateMapper
+}
+
+// NewTaxRateService creates a new instance of TaxRateService
+func NewTaxRateService(repository repositories.TaxRateRepository) services.TaxRateService {
+	return &taxRateServiceImpl{
+		repository: repository,
+		mapper:     &mappers.TaxRateMapper{},
+	}
+}
+
+// GetAll retrieves all tax rates
+func (s *taxRateServiceImpl) GetAll(ctx context.Context) ([]dtos.TaxRateDto, error) {
+	taxRates, err := s.repository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all tax rates: %w", err)
+	}
+
+	return s.mapper.ToDtoList(taxRates), nil
+}
+
+// GetByID retrieves a tax rate by ID
+func (s *taxRateServiceImpl) GetByID(ctx context.Context, id int) (*dtos.TaxRateDto, error) {
+	taxRate, err := findTaxRate(ctx, s.repository, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDto(taxRate), nil
+}
+
+// Create creates a new tax rate
+func (s *taxRateServiceImpl) Create(ctx context.Context, dto *dtos.TaxRateRequestDto) (*dtos.TaxRateDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("create request dto cannot be nil")
+	}
+
+	if dto.Rate == nil {
+		return nil, fmt.Errorf("rate is required")
+	}
+
+	taxRate := s.mapper.ToEntity(dto)
+	if err := taxRate.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.repository.Create(ctx, taxRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tax rate: %w", err)
+	}
+
+	return s.mapper.ToDto(taxRate), nil
+}
+
+// Update replaces the settings of an existing tax rate. Past transactions keep
+// the rate they were charged.
+func (s *taxRateServiceImpl) Update(ctx context.Context, id int, dto *dtos.TaxRateRequestDto) (*dtos.TaxRateDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("update request dto cannot be nil")
+	}
+
+	if dto.Rate == nil {
+		return nil, fmt.Errorf("rate is required")
+	}
+
+	existingTaxRate, err := findTaxRate(ctx, s.repository, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mapper.UpdateEntity(existingTaxRate, dto)
+	if err := existingTaxRate.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = s.repository.Update(ctx, existingTaxRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update tax rate: %w", err)
+	}
+
+	return s.mapper.ToDto(existingTaxRate), nil
+}
+
+// Delete deletes a tax rate that no product or category is assigned to
+func (s *taxRateServiceImpl) Delete(ctx context.Context, id int) error {
+	existingTaxRate, err := findTaxRate(ctx, s.repository, id)
+	if err != nil {
+		return err
+	}
+
+	products, categories, err := s.repository.CountAssignments(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check tax rate %d: %w", id, err)
+	}
+	if products > 0 || categories > 0 {
+		return fmt.Errorf("tax rate %s is assigned to %d products and %d categories; assign them another rate first", existingTaxRate.Name, products, categories)
+	}
+
+	err = s.repository.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete tax rate: %w", err)
+	}
+
+	return nil
+}
+
+// validateTaxRateID checks that the tax rate assigned to a product or category exists
+func validateTaxRateID(ctx context.Context, repository repositories.TaxRateRepository, id *int) error {
+	if id == nil {
+		return nil
+	}
+	_, err := findTaxRate(ctx, repository, *id)
+	return err
+}
+
+// findTaxRate retrieves a tax rate by ID, failing when it does not exist
+func findTaxRate(ctx context.Context, repository repositories.TaxRateRepository, id int) (*entities.TaxRate, error) {
+	taxRate, err := repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rate by id %d: %w", id, err)
+	}
+
+	if taxRate == nil {
+		return nil, fmt.Errorf("tax rate with id %d not found", id)
+	}
+
+	return taxRate, nil
+}
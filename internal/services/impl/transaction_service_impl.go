@@ -3,10 +3,15 @@ package impl
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
 	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/gateway"
 	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/pricing"
 	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
 	"github.com/gustionusamba24/kasir-api-go/internal/services"
 )
@@ -14,97 +19,485 @@ import (
 type transactionServiceImpl struct {
 	transactionRepository repositories.TransactionRepository
 	productRepository     repositories.ProductRepository
+	storeRepository       repositories.StoreRepository
+	serialRepository      repositories.ProductSerialRepository
+	promotionRepository   repositories.PromotionRepository
+	voucherRepository     repositories.VoucherRepository
+	taxRateRepository     repositories.TaxRateRepository
 	mapper                *mappers.TransactionMapper
+	serialMapper          *mappers.ProductSerialMapper
+	scaleFormat           entities.ScaleBarcodeFormat
+	provider              gateway.PaymentProvider
+	paymentTimeout        time.Duration
+	location              *time.Location
 }
 
 func NewTransactionService(
 	transactionRepository repositories.TransactionRepository,
 	productRepository repositories.ProductRepository,
+	storeRepository repositories.StoreRepository,
+	serialRepository repositories.ProductSerialRepository,
+	promotionRepository repositories.PromotionRepository,
+	voucherRepository repositories.VoucherRepository,
+	taxRateRepository repositories.TaxRateRepository,
+	scaleFormat entities.ScaleBarcodeFormat,
+	provider gateway.PaymentProvider,
+	paymentTimeout time.Duration,
+	location *time.Location,
 ) services.TransactionService {
 	return &transactionServiceImpl{
 		transactionRepository: transactionRepository,
 		productRepository:     productRepository,
+		storeRepository:       storeRepository,
+		serialRepository:      serialRepository,
+		promotionRepository:   promotionRepository,
+		voucherRepository:     voucherRepository,
+		taxRateRepository:     taxRateRepository,
 		mapper:                &mappers.TransactionMapper{},
+		serialMapper:          &mappers.ProductSerialMapper{},
+		scaleFormat:           scaleFormat,
+		provider:              provider,
+		paymentTimeout:        paymentTimeout,
+		location:              location,
 	}
 }
 
-func (s *transactionServiceImpl) Checkout(ctx context.Context, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error) {
+// price prices a checkout request at a store without recording anything. It
+// returns the transaction with its details, discounts, taxes and amounts, and the
+// requested payments.
+func (s *transactionServiceImpl) price(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*entities.Transaction, []entities.TransactionPayment, error) {
 	if dto == nil {
-		return nil, fmt.Errorf("checkout request cannot be nil")
+		return nil, nil, fmt.Errorf("checkout request cannot be nil")
 	}
 
 	if len(dto.Items) == 0 {
-		return nil, fmt.Errorf("checkout items cannot be empty")
+		return nil, nil, fmt.Errorf("checkout items cannot be empty")
+	}
+
+	// Validate the selling store
+	store, err := s.storeRepository.FindByID(ctx, storeID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find store with id %d: %w", storeID, err)
+	}
+	if store == nil {
+		return nil, nil, fmt.Errorf("store with id %d not found", storeID)
 	}
 
 	// Build transaction with details
 	var transaction entities.Transaction
 	var details []entities.TransactionDetail
-	totalAmount := 0
+	cart := pricing.Cart{At: time.Now(), Location: s.location, TaxMode: store.TaxMode, ServiceChargeRate: store.ServiceChargeRate}
+
+	// Round the total to the store's cash rounding when any of it is paid in cash.
+	// Without payments the total is taken as paid in cash exactly. Drafts are
+	// rounded when they are completed and paid.
+	if dto.Draft && len(dto.Payments) > 0 {
+		return nil, nil, fmt.Errorf("a draft is saved without payments; pay it when completing it")
+	}
+	payments, err := s.toPayments(dto.Payments)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !dto.Draft && (len(payments) == 0 || entities.HasCash(payments)) {
+		cart.RoundTo = store.CashRounding
+	}
+
+	// Tax the service charge at the default rate when the store makes it taxable
+	if store.ServiceChargeRate > 0 && store.ServiceChargeTaxable {
+		cart.ServiceChargeTaxRate, err = s.taxRateRepository.FindDefault(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get default tax rate: %w", err)
+		}
+	}
+
+	customerRef := strings.TrimSpace(dto.CustomerRef)
+	if len(customerRef) > 100 {
+		return nil, nil, fmt.Errorf("customer_ref cannot be longer than 100 characters")
+	}
+
+	// Promotions running at the time of sale are applied first, then line discounts,
+	// vouchers and the cart discount
+	promotions, err := s.promotionRepository.FindActive(ctx, cart.At)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get active promotions: %w", err)
+	}
+	rules := []pricing.Rule{pricing.Promotions(promotions)}
+	requested := make(map[int]float64)
+	serialsInCart := make(map[string]bool)
 
-	for _, item := range dto.Items {
+	for i, item := range dto.Items {
 		// Get product to validate and calculate subtotal
-		product, err := s.productRepository.FindByID(ctx, item.ProductID)
+		product, label, err := s.findCheckoutProduct(ctx, storeID, item)
 		if err != nil {
-			return nil, fmt.Errorf("failed to find product with id %d: %w", item.ProductID, err)
+			return nil, nil, err
 		}
-		if product == nil {
-			return nil, fmt.Errorf("product with id %d not found", item.ProductID)
+		item.ProductID = product.ID
+
+		// Scale labels carry the weight or price of the item, so the label sets the quantity
+		if label != nil {
+			item.Quantity, err = label.Quantity(product)
+			if err != nil {
+				return nil, nil, err
+			}
+			item.Unit = ""
+		}
+
+		if product.IsArchived() {
+			return nil, nil, fmt.Errorf("product %s is archived", product.Name)
+		}
+
+		// Parents of variants are not sold themselves; the cashier picks a variant
+		if product.HasVariants {
+			return nil, nil, fmt.Errorf("product %s has variants; select a variant to sell", product.Name)
 		}
 
-		// Check stock availability
-		if product.Stock < item.Quantity {
-			return nil, fmt.Errorf("insufficient stock for product %s (available: %d, requested: %d)", 
-				product.Name, product.Stock, item.Quantity)
+		// Convert the quantity to the product's stock unit, such as grams of a product sold by the kg
+		item.Quantity, err = product.ConvertQuantity(item.Quantity, item.Unit)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := product.ValidateQuantity(item.Quantity); err != nil {
+			return nil, nil, err
+		}
+
+		// Check stock availability at this store, counting earlier lines for the same product
+		requested[item.ProductID] = entities.RoundQuantity(requested[item.ProductID] + item.Quantity)
+		if product.Stock < requested[item.ProductID] {
+			return nil, nil, fmt.Errorf("insufficient stock for product %s (available: %g %s, requested: %g %s)", 
+				product.Name, product.Stock, product.Unit, requested[item.ProductID], product.Unit)
 		}
 
 		// Check if product is active
 		if !product.Active {
-			return nil, fmt.Errorf("product %s is not active", product.Name)
+			return nil, nil, fmt.Errorf("product %s is not active", product.Name)
+		}
+
+		// Serialized products must name the unit sold, once per cart
+		var serialNumbers []string
+		if product.Serialized {
+			serialNumbers, err = normalizeSerialNumbers(item.SerialNumbers, int(item.Quantity))
+			if err != nil {
+				return nil, nil, fmt.Errorf("product %s: %w", product.Name, err)
+			}
+			for _, serialNumber := range serialNumbers {
+				if serialsInCart[serialNumber] {
+					return nil, nil, fmt.Errorf("serial number %s is listed more than once", serialNumber)
+				}
+				serialsInCart[serialNumber] = true
+			}
+		} else if len(item.SerialNumbers) > 0 {
+			return nil, nil, fmt.Errorf("product %s is not serialized", product.Name)
+		}
+
+		// Serial numbers are only recorded once sold, so a draft cannot hold them
+		if product.Serialized && dto.Draft {
+			return nil, nil, fmt.Errorf("product %s is serialized and cannot be saved in a draft", product.Name)
+		}
+
+		// Bundles take their components out of stock and cost the sum of their components
+		unitCost := product.Cost
+		var components []entities.BundleComponent
+		if product.IsBundle {
+			components, err = s.productRepository.FindComponents(ctx, storeID, product.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get components of product %s: %w", product.Name, err)
+			}
+			unitCost = 0
+			for _, component := range components {
+				unitCost += component.Cost * component.Quantity
+			}
+		}
+
+		// Calculate gross amount (store price * quantity), rounded to whole rupiah for weighed items
+		gross := int(math.Round(product.SellingPrice() * item.Quantity))
+		if label != nil && label.Price != nil {
+			gross = int(math.Round(*label.Price))
 		}
+		cart.Lines = append(cart.Lines, &pricing.Line{Product: product, Quantity: item.Quantity, Gross: gross})
 
-		// Calculate subtotal (price * quantity)
-		subtotal := int(product.Price * float64(item.Quantity))
-		totalAmount += subtotal
+		if item.Discount != nil {
+			discount, err := toDiscount(item.Discount)
+			if err != nil {
+				return nil, nil, fmt.Errorf("product %s: %w", product.Name, err)
+			}
+			rules = append(rules, pricing.LineDiscount{Line: i, Discount: discount, Reason: item.Discount.Reason})
+		}
 
-		// Create transaction detail
+		// Create transaction detail, snapshotting the current average cost
 		detail := entities.TransactionDetail{
-			ProductID:   item.ProductID,
-			ProductName: product.Name,
-			Quantity:    item.Quantity,
-			Subtotal:    subtotal,
+			ProductID:     item.ProductID,
+			ProductName:   product.Name,
+			Quantity:      item.Quantity,
+			Unit:          product.Unit,
+			UnitCost:      unitCost,
+			SerialNumbers: serialNumbers,
+			Components:    components,
 		}
 		details = append(details, detail)
+	}
+
+	// Tax each line at the rate of its product, its category or the default rate
+	productIDs := make([]int, len(details))
+	for i, detail := range details {
+		productIDs[i] = detail.ProductID
+	}
+	taxRates, err := s.taxRateRepository.FindForProducts(ctx, productIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tax rates: %w", err)
+	}
+	for i, line := range cart.Lines {
+		if taxRate, ok := taxRates[details[i].ProductID]; ok {
+			line.TaxRate = &taxRate
+		}
+	}
+
+	vouchers, err := s.findVouchers(ctx, dto.VoucherCodes, customerRef, cart.At)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, voucher := range vouchers {
+		rules = append(rules, pricing.VoucherDiscount{Voucher: voucher})
+	}
 
-		// Update product stock
-		product.Stock -= item.Quantity
-		err = s.productRepository.Update(ctx, product)
+	if dto.Discount != nil {
+		discount, err := toDiscount(dto.Discount)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update product stock: %w", err)
+			return nil, nil, err
 		}
+		rules = append(rules, pricing.CartDiscount{Discount: discount, Reason: dto.Discount.Reason})
 	}
 
-	transaction.TotalAmount = totalAmount
+	if err := pricing.Price(&cart, rules...); err != nil {
+		return nil, nil, err
+	}
+
+	for i, line := range cart.Lines {
+		details[i].GrossAmount = line.Gross
+		details[i].DiscountAmount = line.Discount
+		details[i].Subtotal = line.Net()
+		details[i].Discounts = line.Discounts
+		if line.TaxRate != nil {
+			details[i].TaxRateID = &line.TaxRate.ID
+			details[i].TaxRate = line.TaxRate.Rate
+			details[i].TaxableAmount = line.Taxable
+			details[i].TaxAmount = line.Tax
+		}
+	}
+
+	transaction.StoreID = storeID
+	transaction.CustomerRef = customerRef
+	transaction.GrossAmount = cart.Gross()
+	transaction.DiscountAmount = transaction.GrossAmount - cart.Net()
+	transaction.ServiceCharge = cart.ServiceCharge
+	transaction.TaxMode = store.TaxMode
+	transaction.TaxAmount = cart.Tax()
+	transaction.RoundingAmount = cart.Rounding
+	transaction.TotalAmount = cart.Total()
+
 	transaction.Details = details
+	transaction.Discounts = cart.Discounts
+	transaction.Taxes = cart.Taxes()
+
+	return &transaction, payments, nil
+}
+
+func (s *transactionServiceImpl) Checkout(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error) {
+	transaction, payments, err := s.price(ctx, storeID, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Draft {
+		// A draft has no payment method until it is paid
+		transaction.Status = entities.TransactionStatusDraft
+		transaction.PaymentMethod = ""
+	} else if err := s.settle(transaction, payments, time.Now()); err != nil {
+		return nil, err
+	}
 
-	// Create transaction with details in database
-	err := s.transactionRepository.Create(ctx, &transaction)
+	// Create transaction with details and deduct store stock in database
+	err = s.transactionRepository.Create(ctx, transaction)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	if transaction.Status == entities.TransactionStatusPending {
+		if err := s.chargePayments(ctx, transaction); err != nil {
+			return nil, err
+		}
+	}
+
 	// Return transaction DTO
-	return s.mapper.ToDto(&transaction), nil
+	return s.mapper.ToDto(transaction), nil
 }
 
-func (s *transactionServiceImpl) GetByID(ctx context.Context, id int) (*dtos.TransactionDto, error) {
-	transaction, err := s.transactionRepository.FindByID(ctx, id)
+// Quote prices a checkout request without recording it or taking stock
+func (s *transactionServiceImpl) Quote(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error) {
+	transaction, _, err := s.price(ctx, storeID, dto)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction by id %d: %w", id, err)
+		return nil, err
 	}
 
-	if transaction == nil {
-		return nil, fmt.Errorf("transaction with id %d not found", id)
+	return s.mapper.ToDto(transaction), nil
+}
+
+// toPayments converts the requested payments. Gateway payments stay pending until
+// the gateway confirms them.
+func (s *transactionServiceImpl) toPayments(requested []dtos.PaymentDto) ([]entities.TransactionPayment, error) {
+	payments := make([]entities.TransactionPayment, len(requested))
+	for i, payment := range requested {
+		payments[i] = entities.TransactionPayment{Method: payment.Method, Amount: payment.Amount, Reference: payment.Reference, Status: entities.PaymentStatusPaid}
+
+		if payment.Gateway {
+			if s.provider == nil {
+				return nil, fmt.Errorf("no payment gateway is configured; set PAYMENT_PROVIDER")
+			}
+			payments[i].Provider = s.provider.Name()
+			payments[i].Status = entities.PaymentStatusPending
+		}
+	}
+	return payments, nil
+}
+
+// settle takes the payments of a transaction, rejecting an underpayment, and works
+// out the change. Without payments the total is taken as paid in cash exactly. A
+// transaction waiting for gateway payments is pending, holding its stock until they
+// are confirmed or the payment timeout runs out; otherwise it is completed.
+func (s *transactionServiceImpl) settle(transaction *entities.Transaction, payments []entities.TransactionPayment, at time.Time) error {
+	if len(payments) == 0 && transaction.TotalAmount > 0 {
+		payments = []entities.TransactionPayment{{Method: entities.PaymentCash, Amount: transaction.TotalAmount, Status: entities.PaymentStatusPaid}}
+	}
+
+	var err error
+	transaction.PaidAmount, transaction.ChangeAmount, err = entities.SettlePayments(payments, transaction.TotalAmount)
+	if err != nil {
+		return err
+	}
+	transaction.PaymentMethod = entities.PaymentMethodOf(payments)
+	transaction.Payments = payments
+
+	transaction.Status = entities.TransactionStatusCompleted
+	transaction.ExpiresAt = nil
+	if entities.HasPending(payments) {
+		expiresAt := at.Add(s.paymentTimeout)
+		transaction.Status = entities.TransactionStatusPending
+		transaction.ExpiresAt = &expiresAt
+	}
+	return nil
+}
+
+// chargePayments creates a gateway charge for each pending payment of a transaction.
+// When the gateway refuses a charge the transaction fails and its stock is put back.
+func (s *transactionServiceImpl) chargePayments(ctx context.Context, transaction *entities.Transaction) error {
+	for i := range transaction.Payments {
+		payment := &transaction.Payments[i]
+		if payment.Status != entities.PaymentStatusPending {
+			continue
+		}
+
+		result, err := s.provider.CreateCharge(ctx, gateway.Charge{
+			Reference: fmt.Sprintf("TRX-%d-%d", transaction.ID, payment.ID),
+			Method:    payment.Method,
+			Amount:    payment.Amount,
+			ExpiresAt: *transaction.ExpiresAt,
+		})
+		if err == nil {
+			err = s.transactionRepository.SetPaymentCharge(ctx, payment.ID, result.ProviderRef, result.CheckoutURL)
+		}
+		if err != nil {
+			if _, releaseErr := s.transactionRepository.ReleasePending(ctx, transaction.ID, entities.TransactionStatusFailed, time.Now()); releaseErr != nil {
+				return fmt.Errorf("failed to charge %s payment: %v; failed to release transaction %d: %w", payment.Method, err, transaction.ID, releaseErr)
+			}
+			return fmt.Errorf("failed to charge %s payment: %w", payment.Method, err)
+		}
+
+		payment.ProviderRef = result.ProviderRef
+		payment.CheckoutURL = result.CheckoutURL
+	}
+
+	return nil
+}
+
+// toDiscount converts a requested discount and checks that it is valid
+func toDiscount(dto *dtos.DiscountDto) (entities.Discount, error) {
+	discount := entities.Discount{Type: dto.Type, Value: dto.Value}
+	if err := discount.Validate(); err != nil {
+		return entities.Discount{}, err
+	}
+	return discount, nil
+}
+
+// findVouchers looks up the vouchers redeemed at checkout and checks that each
+// can be redeemed by the customer, returning the reason when one cannot
+func (s *transactionServiceImpl) findVouchers(ctx context.Context, codes []string, customerRef string, at time.Time) ([]entities.Voucher, error) {
+	var vouchers []entities.Voucher
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		code = entities.NormalizeVoucherCode(code)
+		if code == "" {
+			return nil, fmt.Errorf("voucher code cannot be empty")
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("voucher %s is listed more than once", code)
+		}
+		seen[code] = true
+
+		voucher, err := s.voucherRepository.FindByCode(ctx, code)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find voucher %s: %w", code, err)
+		}
+		if voucher == nil {
+			return nil, fmt.Errorf("unknown voucher code %s", code)
+		}
+
+		if err := voucher.CheckRedeemable(at); err != nil {
+			return nil, err
+		}
+
+		if voucher.PerCustomerLimit != nil {
+			if customerRef == "" {
+				return nil, fmt.Errorf("voucher %s is limited per customer; customer_ref is required", code)
+			}
+			count, err := s.voucherRepository.CountRedemptions(ctx, voucher.ID, customerRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check redemptions of voucher %s: %w", code, err)
+			}
+			if count >= *voucher.PerCustomerLimit {
+				return nil, fmt.Errorf("voucher %s has reached its limit of %d uses per customer", code, *voucher.PerCustomerLimit)
+			}
+		}
+
+		vouchers = append(vouchers, *voucher)
+	}
+
+	return vouchers, nil
+}
+
+// findCheckoutProduct resolves a checkout item to a product by ID or scanned barcode.
+// A scanned scale label is returned along with its product.
+func (s *transactionServiceImpl) findCheckoutProduct(ctx context.Context, storeID int, item dtos.CheckoutItemDto) (*entities.Product, *entities.ScaleBarcode, error) {
+	if item.ProductID > 0 {
+		product, err := s.productRepository.FindByID(ctx, storeID, item.ProductID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find product with id %d: %w", item.ProductID, err)
+		}
+		if product == nil {
+			return nil, nil, fmt.Errorf("product with id %d not found", item.ProductID)
+		}
+		return product, nil, nil
+	}
+
+	if item.Barcode == "" {
+		return nil, nil, fmt.Errorf("product_id or barcode is required for each item")
+	}
+
+	return findProductByBarcode(ctx, s.productRepository, s.scaleFormat, storeID, item.Barcode)
+}
+
+func (s *transactionServiceImpl) GetByID(ctx context.Context, storeID, id int) (*dtos.TransactionDto, error) {
+	transaction, err := s.findTransaction(ctx, storeID, id)
+	if err != nil {
+		return nil, err
 	}
 
 	return s.mapper.ToDto(transaction), nil
@@ -118,3 +511,145 @@ func (s *transactionServiceImpl) GetAll(ctx context.Context) ([]dtos.Transaction
 
 	return s.mapper.ToDtoList(transactions), nil
 }
+
+// Complete completes a draft transaction with its payments
+func (s *transactionServiceImpl) Complete(ctx context.Context, storeID, id int, dto *dtos.TransactionCompleteRequestDto) (*dtos.TransactionDto, error) {
+	if dto == nil {
+		dto = &dtos.TransactionCompleteRequestDto{}
+	}
+
+	transaction, err := s.findTransaction(ctx, storeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction.Status != entities.TransactionStatusDraft {
+		return nil, fmt.Errorf("transaction %d cannot be completed because it is %s", id, transaction.Status)
+	}
+
+	store, err := s.storeRepository.FindByID(ctx, transaction.StoreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find store with id %d: %w", transaction.StoreID, err)
+	}
+	if store == nil {
+		return nil, fmt.Errorf("store with id %d not found", transaction.StoreID)
+	}
+
+	// Round the total to the store's cash rounding now that the payments are known
+	payments, err := s.toPayments(dto.Payments)
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 || entities.HasCash(payments) {
+		transaction.RoundingAmount = pricing.Rounding(transaction.TotalAmount, store.CashRounding)
+		transaction.TotalAmount += transaction.RoundingAmount
+	}
+
+	if err := s.settle(transaction, payments, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Bundles take their components out of stock
+	for i := range transaction.Details {
+		detail := &transaction.Details[i]
+		detail.Components, err = s.productRepository.FindComponents(ctx, transaction.StoreID, detail.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get components of product %s: %w", detail.ProductName, err)
+		}
+	}
+
+	if err := s.transactionRepository.Complete(ctx, transaction); err != nil {
+		return nil, fmt.Errorf("failed to complete transaction: %w", err)
+	}
+
+	if transaction.Status == entities.TransactionStatusPending {
+		if err := s.chargePayments(ctx, transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.GetByID(ctx, storeID, id)
+}
+
+// Void voids a transaction, putting back any stock it took
+func (s *transactionServiceImpl) Void(ctx context.Context, storeID, id int, dto *dtos.TransactionReasonRequestDto) (*dtos.TransactionDto, error) {
+	return s.cancel(ctx, storeID, id, entities.TransactionStatusVoided, dto, s.transactionRepository.Void)
+}
+
+// Refund refunds a completed transaction, putting back its stock
+func (s *transactionServiceImpl) Refund(ctx context.Context, storeID, id int, dto *dtos.TransactionReasonRequestDto) (*dtos.TransactionDto, error) {
+	return s.cancel(ctx, storeID, id, entities.TransactionStatusRefunded, dto, s.transactionRepository.Refund)
+}
+
+// cancel checks the reason and the transition to status, then voids or refunds
+// the transaction with the given repository method
+func (s *transactionServiceImpl) cancel(ctx context.Context, storeID, id int, status string, dto *dtos.TransactionReasonRequestDto, apply func(ctx context.Context, id int, reason string) error) (*dtos.TransactionDto, error) {
+	reason := ""
+	if dto != nil {
+		reason = strings.TrimSpace(dto.Reason)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("a reason is required")
+	}
+	if len(reason) > 255 {
+		return nil, fmt.Errorf("reason cannot be longer than 255 characters")
+	}
+
+	transaction, err := s.findTransaction(ctx, storeID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := transaction.CheckTransition(status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := apply(ctx, id, reason); err != nil {
+		return nil, fmt.Errorf("failed to update transaction %d: %w", id, err)
+	}
+
+	return s.GetByID(ctx, storeID, id)
+}
+
+// findTransaction retrieves a transaction of a store, failing when the store has no such transaction
+func (s *transactionServiceImpl) findTransaction(ctx context.Context, storeID, id int) (*entities.Transaction, error) {
+	transaction, err := s.transactionRepository.FindByStoreAndID(ctx, storeID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by id %d: %w", id, err)
+	}
+
+	if transaction == nil {
+		return nil, fmt.Errorf("transaction with id %d not found at store %d", id, storeID)
+	}
+
+	return transaction, nil
+}
+
+func (s *transactionServiceImpl) LookupSerial(ctx context.Context, serialNumber string) (*dtos.ProductSerialDto, error) {
+	serialNumber = strings.TrimSpace(serialNumber)
+	if serialNumber == "" {
+		return nil, fmt.Errorf("serial number is required")
+	}
+
+	serial, err := s.serialRepository.FindBySerialNumber(ctx, serialNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find serial number %s: %w", serialNumber, err)
+	}
+
+	if serial == nil {
+		return nil, fmt.Errorf("serial number %s not found", serialNumber)
+	}
+
+	result := s.serialMapper.ToDto(serial)
+
+	// Attach the sale for warranty claims
+	if serial.TransactionID != nil {
+		transaction, err := s.transactionRepository.FindByID(ctx, *serial.TransactionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction by id %d: %w", *serial.TransactionID, err)
+		}
+		result.Transaction = s.mapper.ToDto(transaction)
+	}
+
+	return result, nil
+}
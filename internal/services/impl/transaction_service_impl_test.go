@@ -0,0 +1,529 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+)
+
+// checkoutRepository records the transactions checked out
+type checkoutRepository struct {
+	repositories.TransactionRepository
+	created []entities.Transaction
+}
+
+func (r *checkoutRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	transaction.ID = len(r.created) + 1
+	r.created = append(r.created, *transaction)
+	return nil
+}
+
+// noPromotions runs no promotions
+type noPromotions struct {
+	repositories.PromotionRepository
+}
+
+func (noPromotions) FindActive(ctx context.Context, at time.Time) ([]entities.Promotion, error) {
+	return nil, nil
+}
+
+// noTaxes charges no tax on any product
+type noTaxes struct {
+	repositories.TaxRateRepository
+}
+
+func (noTaxes) FindForProducts(ctx context.Context, productIDs []int) (map[int]entities.TaxRate, error) {
+	return nil, nil
+}
+
+// newCheckoutService creates a transaction service over the given repositories;
+// checkouts in these tests need no others
+func newCheckoutService(transactions repositories.TransactionRepository, products repositories.ProductRepository, stores repositories.StoreRepository) *transactionServiceImpl {
+	return NewTransactionService(transactions, products, stores, nil, noPromotions{}, nil, noTaxes{}, entities.DefaultScaleBarcodeFormat(), nil, 0, time.UTC).(*transactionServiceImpl)
+}
+
+func TestCheckoutStoreStock(t *testing.T) {
+	tests := []struct {
+		name      string
+		storeID   int
+		items     []dtos.CheckoutItemDto
+		wantErr   string
+		wantTotal int
+	}{
+		{name: "sells from the main store", storeID: 1, items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 3}}, wantTotal: 15000},
+		{name: "sells at the store price", storeID: 2, items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 2}}, wantTotal: 9000},
+		{name: "stock of another store does not count", storeID: 2, items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 3}}, wantErr: "insufficient stock for product Kopi (available: 2 pcs, requested: 3 pcs)"},
+		{name: "lines of the same product add up", storeID: 2, items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 1}, {ProductID: 1, Quantity: 2}}, wantErr: "available: 2 pcs, requested: 3 pcs"},
+		{name: "product without stock at the store", storeID: 3, items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 1}}, wantErr: "available: 0 pcs, requested: 1 pcs"},
+		{name: "unknown store", storeID: 4, items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 1}}, wantErr: "store with id 4 not found"},
+		{name: "inactive product", storeID: 1, items: []dtos.CheckoutItemDto{{ProductID: 2, Quantity: 1}}, wantErr: "product Teh is not active"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProductRepositoryStub(
+				entities.Product{ID: 1, Name: "Kopi", Price: 5000, Cost: 3000, Stock: 10, Active: true},
+				entities.Product{ID: 2, Name: "Teh", Price: 4000, Stock: 10, Active: false},
+			)
+			products.stock[storeProduct{2, 1}] = 2
+			products.prices[storeProduct{2, 1}] = 4500
+			transactions := &checkoutRepository{}
+			service := newCheckoutService(transactions, products, newStoreRepositoryStub(1, 2, 3))
+
+			transaction, err := service.Checkout(context.Background(), tt.storeID, &dtos.TransactionCreateRequestDto{Items: tt.items})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Checkout() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(transactions.created) != 0 {
+					t.Errorf("created %d transactions, want 0", len(transactions.created))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Checkout() error = %v", err)
+			}
+
+			if transaction.StoreID != tt.storeID || transaction.TotalAmount != tt.wantTotal {
+				t.Errorf("store, total = %d, %d, want %d, %d", transaction.StoreID, transaction.TotalAmount, tt.storeID, tt.wantTotal)
+			}
+			if cost := transactions.created[0].Details[0].UnitCost; cost != 3000 {
+				t.Errorf("unit cost = %v, want the average cost at the time of sale", cost)
+			}
+		})
+	}
+}
+
+func TestCheckoutBundle(t *testing.T) {
+	tests := []struct {
+		name     string
+		storeID  int
+		quantity float64
+		wantErr  string
+	}{
+		{name: "sells from component stock", storeID: 1, quantity: 2},
+		{name: "scarcest component limits the bundle", storeID: 1, quantity: 5, wantErr: "insufficient stock for product Paket Sarapan (available: 4 pcs, requested: 5 pcs)"},
+		{name: "component stock of the selling store", storeID: 2, quantity: 2, wantErr: "available: 1 pcs, requested: 2 pcs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProductRepositoryStub(
+				entities.Product{ID: 1, Name: "Paket Sarapan", Price: 12000, Active: true},
+				entities.Product{ID: 2, Name: "Kopi", Price: 5000, Cost: 3000, Stock: 10, Active: true},
+				entities.Product{ID: 3, Name: "Roti", Price: 8000, Cost: 4000, Stock: 9, Active: true},
+			)
+			products.components[1] = []entities.BundleComponent{
+				{BundleID: 1, ComponentID: 2, Quantity: 1},
+				{BundleID: 1, ComponentID: 3, Quantity: 2},
+			}
+			products.stock[storeProduct{2, 2}] = 5
+			products.stock[storeProduct{2, 3}] = 3
+			transactions := &checkoutRepository{}
+			service := newCheckoutService(transactions, products, newStoreRepositoryStub(1, 2))
+
+			items := []dtos.CheckoutItemDto{{ProductID: 1, Quantity: tt.quantity}}
+			transaction, err := service.Checkout(context.Background(), tt.storeID, &dtos.TransactionCreateRequestDto{Items: items})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Checkout() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Checkout() error = %v", err)
+			}
+
+			if transaction.TotalAmount != 24000 {
+				t.Errorf("total = %d, want 24000", transaction.TotalAmount)
+			}
+			detail := transactions.created[0].Details[0]
+			if detail.UnitCost != 11000 {
+				t.Errorf("unit cost = %v, want the sum of the component costs", detail.UnitCost)
+			}
+			if len(detail.Components) != 2 {
+				t.Errorf("components = %+v, want both components to be taken out of stock", detail.Components)
+			}
+		})
+	}
+}
+
+// voucherRepositoryStub keeps vouchers in memory. It redeems them the way the
+// database does, one checkout at a time, checking the limits again as it does.
+type voucherRepositoryStub struct {
+	repositories.VoucherRepository
+	mu          sync.Mutex
+	vouchers    map[string]*entities.Voucher
+	redemptions []entities.VoucherRedemption
+	// lookups, when set, holds each lookup until every checkout has looked its
+	// vouchers up, so they all pass validation before any of them redeems
+	lookups *sync.WaitGroup
+}
+
+func (r *voucherRepositoryStub) FindByCode(ctx context.Context, code string) (*entities.Voucher, error) {
+	r.mu.Lock()
+	voucher, ok := r.vouchers[code]
+	var found entities.Voucher
+	if ok {
+		found = *voucher
+	}
+	r.mu.Unlock()
+
+	if r.lookups != nil {
+		r.lookups.Done()
+		r.lookups.Wait()
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &found, nil
+}
+
+func (r *voucherRepositoryStub) CountRedemptions(ctx context.Context, voucherID int, customerRef string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.count(voucherID, customerRef), nil
+}
+
+func (r *voucherRepositoryStub) count(voucherID int, customerRef string) int {
+	count := 0
+	for _, redemption := range r.redemptions {
+		if redemption.VoucherID == voucherID && redemption.CustomerRef == customerRef {
+			count++
+		}
+	}
+	return count
+}
+
+// redeem records a redemption unless the voucher can no longer be redeemed at the given time
+func (r *voucherRepositoryStub) redeem(voucherID int, transaction *entities.Transaction, amount int, at time.Time) error {
+	for _, voucher := range r.vouchers {
+		if voucher.ID != voucherID {
+			continue
+		}
+		if err := voucher.CheckRedeemable(at); err != nil {
+			return err
+		}
+		if limit := voucher.PerCustomerLimit; limit != nil && r.count(voucherID, transaction.CustomerRef) >= *limit {
+			return fmt.Errorf("voucher %s has reached its limit of %d uses per customer", voucher.Code, *limit)
+		}
+		voucher.TimesRedeemed++
+		r.redemptions = append(r.redemptions, entities.VoucherRedemption{VoucherID: voucherID, TransactionID: transaction.ID, CustomerRef: transaction.CustomerRef, Amount: amount})
+		return nil
+	}
+	return fmt.Errorf("voucher %d can no longer be redeemed", voucherID)
+}
+
+// voucherCheckoutRepository records transactions and redeems their vouchers in
+// the same step, failing the transaction if any voucher cannot be redeemed
+type voucherCheckoutRepository struct {
+	checkoutRepository
+	vouchers *voucherRepositoryStub
+}
+
+func (r *voucherCheckoutRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	r.vouchers.mu.Lock()
+	defer r.vouchers.mu.Unlock()
+
+	now := time.Now()
+	transaction.ID = len(r.created) + 1
+	for _, discount := range transaction.Discounts {
+		if discount.VoucherID == nil {
+			continue
+		}
+		if err := r.vouchers.redeem(*discount.VoucherID, transaction, discount.Amount, now); err != nil {
+			return err
+		}
+	}
+	r.created = append(r.created, *transaction)
+	return nil
+}
+
+func TestCheckoutVouchers(t *testing.T) {
+	limit := func(n int) *int { return &n }
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	voucher := func(change func(v *entities.Voucher)) entities.Voucher {
+		v := entities.Voucher{ID: 1, Code: "HEMAT10", Type: entities.DiscountPercent, Value: 10, Active: true}
+		if change != nil {
+			change(&v)
+		}
+		return v
+	}
+
+	tests := []struct {
+		name    string
+		voucher entities.Voucher
+		// redeemedBy lists the customers who redeemed the voucher before
+		redeemedBy  []string
+		codes       []string
+		customerRef string
+		wantErr     string
+		wantTotal   int
+	}{
+		{name: "redeemed", voucher: voucher(nil), wantTotal: 13500},
+		{name: "code in any case", voucher: voucher(nil), codes: []string{" hemat10 "}, wantTotal: 13500},
+		{name: "unknown code", voucher: voucher(nil), codes: []string{"HEMAT20"}, wantErr: "unknown voucher code HEMAT20"},
+		{name: "listed twice", voucher: voucher(nil), codes: []string{"HEMAT10", "hemat10"}, wantErr: "voucher HEMAT10 is listed more than once"},
+		{name: "inactive", voucher: voucher(func(v *entities.Voucher) { v.Active = false }), wantErr: "voucher HEMAT10 is inactive"},
+		{name: "not started", voucher: voucher(func(v *entities.Voucher) { v.StartsAt = &future }), wantErr: "voucher HEMAT10 is not valid until"},
+		{name: "expired", voucher: voucher(func(v *entities.Voucher) { v.ExpiresAt = &past }), wantErr: "voucher HEMAT10 expired on"},
+		{name: "before expiry", voucher: voucher(func(v *entities.Voucher) { v.StartsAt, v.ExpiresAt = &past, &future }), wantTotal: 13500},
+		{name: "last use of the usage limit", voucher: voucher(func(v *entities.Voucher) { v.UsageLimit = limit(2) }), redeemedBy: []string{"A"}, wantTotal: 13500},
+		{name: "usage limit reached", voucher: voucher(func(v *entities.Voucher) { v.UsageLimit = limit(2) }), redeemedBy: []string{"A", "B"}, wantErr: "voucher HEMAT10 has reached its usage limit"},
+		{name: "per customer limit needs the customer", voucher: voucher(func(v *entities.Voucher) { v.PerCustomerLimit = limit(1) }), wantErr: "customer_ref is required"},
+		{name: "redeemed again by the same customer", voucher: voucher(func(v *entities.Voucher) { v.PerCustomerLimit = limit(1) }), redeemedBy: []string{"A"}, customerRef: "A", wantErr: "voucher HEMAT10 has reached its limit of 1 uses per customer"},
+		{name: "redeemed by another customer", voucher: voucher(func(v *entities.Voucher) { v.PerCustomerLimit = limit(1) }), redeemedBy: []string{"A"}, customerRef: "B", wantTotal: 13500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+			v := tt.voucher
+			vouchers := &voucherRepositoryStub{vouchers: map[string]*entities.Voucher{v.Code: &v}}
+			for i, customerRef := range tt.redeemedBy {
+				v.TimesRedeemed++
+				vouchers.redemptions = append(vouchers.redemptions, entities.VoucherRedemption{VoucherID: v.ID, TransactionID: 100 + i, CustomerRef: customerRef})
+			}
+			transactions := &voucherCheckoutRepository{vouchers: vouchers}
+			service := newCheckoutService(transactions, products, newStoreRepositoryStub(1))
+			service.voucherRepository = vouchers
+
+			codes := tt.codes
+			if codes == nil {
+				codes = []string{v.Code}
+			}
+			dto := &dtos.TransactionCreateRequestDto{Items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 3}}, VoucherCodes: codes, CustomerRef: tt.customerRef}
+			transaction, err := service.Checkout(context.Background(), 1, dto)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Checkout() error = %v, want %q", err, tt.wantErr)
+				}
+				if v.TimesRedeemed != len(tt.redeemedBy) || len(transactions.created) != 0 {
+					t.Errorf("times redeemed, transactions = %d, %d, want %d, 0", v.TimesRedeemed, len(transactions.created), len(tt.redeemedBy))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Checkout() error = %v", err)
+			}
+
+			if transaction.TotalAmount != tt.wantTotal {
+				t.Errorf("total = %d, want %d", transaction.TotalAmount, tt.wantTotal)
+			}
+			if v.TimesRedeemed != len(tt.redeemedBy)+1 {
+				t.Errorf("times redeemed = %d, want %d", v.TimesRedeemed, len(tt.redeemedBy)+1)
+			}
+		})
+	}
+}
+
+func TestCheckoutVoucherRedeemedConcurrently(t *testing.T) {
+	limit := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		voucher entities.Voucher
+		// customerRefs are the customers checking out at the same time, one checkout each
+		customerRefs []string
+		wantRedeemed int
+		wantErr      string
+	}{
+		{
+			name:         "last use taken by another checkout",
+			voucher:      entities.Voucher{UsageLimit: limit(1)},
+			customerRefs: []string{"A", "B", "C"},
+			wantRedeemed: 1,
+			wantErr:      "voucher HEMAT10 has reached its usage limit",
+		},
+		{
+			name:         "same customer at two tills",
+			voucher:      entities.Voucher{PerCustomerLimit: limit(1)},
+			customerRefs: []string{"A", "A"},
+			wantRedeemed: 1,
+			wantErr:      "voucher HEMAT10 has reached its limit of 1 uses per customer",
+		},
+		{
+			name:         "within the limits",
+			voucher:      entities.Voucher{UsageLimit: limit(3), PerCustomerLimit: limit(1)},
+			customerRefs: []string{"A", "B", "C"},
+			wantRedeemed: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+			v := tt.voucher
+			v.ID, v.Code, v.Type, v.Value, v.Active = 1, "HEMAT10", entities.DiscountPercent, 10, true
+			lookups := &sync.WaitGroup{}
+			lookups.Add(len(tt.customerRefs))
+			vouchers := &voucherRepositoryStub{vouchers: map[string]*entities.Voucher{v.Code: &v}, lookups: lookups}
+			transactions := &voucherCheckoutRepository{vouchers: vouchers}
+			service := newCheckoutService(transactions, products, newStoreRepositoryStub(1))
+			service.voucherRepository = vouchers
+
+			errs := make([]error, len(tt.customerRefs))
+			var checkouts sync.WaitGroup
+			for i, customerRef := range tt.customerRefs {
+				checkouts.Add(1)
+				go func() {
+					defer checkouts.Done()
+					dto := &dtos.TransactionCreateRequestDto{Items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 1}}, VoucherCodes: []string{"HEMAT10"}, CustomerRef: customerRef}
+					_, errs[i] = service.Checkout(context.Background(), 1, dto)
+				}()
+			}
+			checkouts.Wait()
+
+			succeeded := 0
+			for _, err := range errs {
+				switch {
+				case err == nil:
+					succeeded++
+				case tt.wantErr == "" || !strings.Contains(err.Error(), tt.wantErr):
+					t.Errorf("Checkout() error = %v, want %q", err, tt.wantErr)
+				}
+			}
+			if succeeded != tt.wantRedeemed || v.TimesRedeemed != tt.wantRedeemed || len(transactions.created) != tt.wantRedeemed {
+				t.Errorf("succeeded, times redeemed, transactions = %d, %d, %d, want %d each", succeeded, v.TimesRedeemed, len(transactions.created), tt.wantRedeemed)
+			}
+		})
+	}
+}
+
+// storeTransactionRepository keeps checked out transactions, finds them by their
+// store and completes, voids and refunds them
+type storeTransactionRepository struct {
+	checkoutRepository
+}
+
+func (r *storeTransactionRepository) Create(ctx context.Context, transaction *entities.Transaction) error {
+	transaction.CreatedAt = time.Now()
+	return r.checkoutRepository.Create(ctx, transaction)
+}
+
+func (r *storeTransactionRepository) FindByStoreAndID(ctx context.Context, storeID, id int) (*entities.Transaction, error) {
+	for _, transaction := range r.created {
+		if transaction.ID == id && transaction.StoreID == storeID {
+			return &transaction, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *storeTransactionRepository) Complete(ctx context.Context, transaction *entities.Transaction) error {
+	r.created[transaction.ID-1] = *transaction
+	return nil
+}
+
+func (r *storeTransactionRepository) Void(ctx context.Context, id int, reason string) error {
+	r.created[id-1].Status = entities.TransactionStatusVoided
+	return nil
+}
+
+func (r *storeTransactionRepository) Refund(ctx context.Context, id int, reason string) error {
+	r.created[id-1].Status = entities.TransactionStatusRefunded
+	return nil
+}
+
+func TestTransactionStoreScope(t *testing.T) {
+	reason := &dtos.TransactionReasonRequestDto{Reason: "Wrong item"}
+
+	tests := []struct {
+		name    string
+		action  string
+		storeID int
+		draft   bool
+		wantErr string
+		// wantStatus is the status of the transaction after the action
+		wantStatus string
+	}{
+		{name: "get at its store", action: "get", storeID: 1, wantStatus: entities.TransactionStatusCompleted},
+		{name: "get at another store", action: "get", storeID: 2, wantErr: "transaction with id 1 not found at store 2", wantStatus: entities.TransactionStatusCompleted},
+		{name: "complete at its store", action: "complete", storeID: 1, draft: true, wantStatus: entities.TransactionStatusCompleted},
+		{name: "complete at another store", action: "complete", storeID: 2, draft: true, wantErr: "transaction with id 1 not found at store 2", wantStatus: entities.TransactionStatusDraft},
+		{name: "void at its store", action: "void", storeID: 1, wantStatus: entities.TransactionStatusVoided},
+		{name: "void at another store", action: "void", storeID: 2, wantErr: "transaction with id 1 not found at store 2", wantStatus: entities.TransactionStatusCompleted},
+		{name: "refund at its store", action: "refund", storeID: 1, wantStatus: entities.TransactionStatusRefunded},
+		{name: "refund at another store", action: "refund", storeID: 2, wantErr: "transaction with id 1 not found at store 2", wantStatus: entities.TransactionStatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+			transactions := &storeTransactionRepository{}
+			service := newCheckoutService(transactions, products, newStoreRepositoryStub(1, 2))
+
+			_, err := service.Checkout(ctx, 1, &dtos.TransactionCreateRequestDto{Items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 1}}, Draft: tt.draft})
+			if err != nil {
+				t.Fatalf("Checkout() error = %v", err)
+			}
+
+			switch tt.action {
+			case "get":
+				_, err = service.GetByID(ctx, tt.storeID, 1)
+			case "complete":
+				_, err = service.Complete(ctx, tt.storeID, 1, nil)
+			case "void":
+				_, err = service.Void(ctx, tt.storeID, 1, reason)
+			case "refund":
+				_, err = service.Refund(ctx, tt.storeID, 1, reason)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("%s: error = %v", tt.action, err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("%s: error = %v, want %q", tt.action, err, tt.wantErr)
+			}
+
+			if status := transactions.created[0].Status; status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDraftPaymentMethod(t *testing.T) {
+	tests := []struct {
+		name       string
+		payments   []dtos.PaymentDto
+		wantMethod string
+	}{
+		{name: "paid in cash exactly", wantMethod: entities.PaymentCash},
+		{name: "paid by debit card", payments: []dtos.PaymentDto{{Method: entities.PaymentDebit, Amount: 5000}}, wantMethod: entities.PaymentDebit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			products := newProductRepositoryStub(entities.Product{ID: 1, Name: "Kopi", Price: 5000, Stock: 10, Active: true})
+			transactions := &storeTransactionRepository{}
+			service := newCheckoutService(transactions, products, newStoreRepositoryStub(1))
+
+			draft, err := service.Checkout(ctx, 1, &dtos.TransactionCreateRequestDto{Items: []dtos.CheckoutItemDto{{ProductID: 1, Quantity: 1}}, Draft: true})
+			if err != nil {
+				t.Fatalf("Checkout() error = %v", err)
+			}
+
+			// A draft is not paid yet, so it has no payment method
+			if draft.PaymentMethod != "" || transactions.created[0].PaymentMethod != "" {
+				t.Errorf("draft payment method = %q, want none", transactions.created[0].PaymentMethod)
+			}
+
+			completed, err := service.Complete(ctx, 1, draft.ID, &dtos.TransactionCompleteRequestDto{Payments: tt.payments})
+			if err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			if completed.PaymentMethod != tt.wantMethod {
+				t.Errorf("payment method = %q, want %q", completed.PaymentMethod, tt.wantMethod)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package impl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/entities"
+	"github.com/gustionusamba24/kasir-api-go/internal/mappers"
+	"github.com/gustionusamba24/kasir-api-go/internal/repositories"
+	"github.com/gustionusamba24/kasir-api-go/internal/services"
+)
+
+type voucherServiceImpl struct {
+	repository repositories.VoucherRepository
+	mapper     *mappers.VoucherMapper
+}
+
+// NewVoucherService creates a new instance of VoucherService
+func NewVoucherService(repository repositories.VoucherRepository) services.VoucherService {
+	return &voucherServiceImpl{
+		repository: repository,
+		mapper:     &mappers.VoucherMapper{},
+	}
+}
+
+// GetAll retrieves all vouchers
+func (s *voucherServiceImpl) GetAll(ctx context.Context) ([]dtos.VoucherDto, error) {
+	vouchers, err := s.repository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all vouchers: %w", err)
+	}
+
+	return s.mapper.ToDtoList(vouchers), nil
+}
+
+// GetByID retrieves a voucher by ID
+func (s *voucherServiceImpl) GetByID(ctx context.Context, id int) (*dtos.VoucherDto, error) {
+	voucher, err := s.findVoucher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.mapper.ToDto(voucher), nil
+}
+
+// Create creates a new voucher
+func (s *voucherServiceImpl) Create(ctx context.Context, dto *dtos.VoucherRequestDto) (*dtos.VoucherDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("create request dto cannot be nil")
+	}
+
+	voucher := s.mapper.ToEntity(dto)
+	if err := voucher.Validate(); err != nil {
+		return nil, err
+	}
+
+	err := s.repository.Create(ctx, voucher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create voucher: %w", err)
+	}
+
+	return s.mapper.ToDto(voucher), nil
+}
+
+// Update replaces the settings of an existing voucher
+func (s *voucherServiceImpl) Update(ctx context.Context, id int, dto *dtos.VoucherRequestDto) (*dtos.VoucherDto, error) {
+	if dto == nil {
+		return nil, fmt.Errorf("update request dto cannot be nil")
+	}
+
+	existingVoucher, err := s.findVoucher(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Codes printed on redeemed vouchers stay fixed so redemptions remain traceable
+	if existingVoucher.TimesRedeemed > 0 && entities.NormalizeVoucherCode(dto.Code) != existingVoucher.Code {
+		return nil, fmt.Errorf("voucher %s has been redeemed; its code cannot be changed", existingVoucher.Code)
+	}
+
+	s.mapper.UpdateEntity(existingVoucher, dto)
+	if err := existingVoucher.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = s.repository.Update(ctx, existingVoucher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update voucher: %w", err)
+	}
+
+	return s.mapper.ToDto(existingVoucher), nil
+}
+
+// Delete deletes a voucher that has never been redeemed
+func (s *voucherServiceImpl) Delete(ctx context.Context, id int) error {
+	existingVoucher, err := s.findVoucher(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if existingVoucher.TimesRedeemed > 0 {
+		return fmt.Errorf("voucher %s has been redeemed and cannot be deleted; deactivate it instead", existingVoucher.Code)
+	}
+
+	err = s.repository.Delete(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete voucher: %w", err)
+	}
+
+	return nil
+}
+
+// GetRedemptions retrieves the transactions a voucher was redeemed on
+func (s *voucherServiceImpl) GetRedemptions(ctx context.Context, id int) ([]dtos.VoucherRedemptionDto, error) {
+	if _, err := s.findVoucher(ctx, id); err != nil {
+		return nil, err
+	}
+
+	redemptions, err := s.repository.FindRedemptions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redemptions of voucher %d: %w", id, err)
+	}
+
+	return s.mapper.ToRedemptionDtoList(redemptions), nil
+}
+
+// findVoucher retrieves a voucher by ID, failing when it does not exist
+func (s *voucherServiceImpl) findVoucher(ctx context.Context, id int) (*entities.Voucher, error) {
+	voucher, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get voucher by id %d: %w", id, err)
+	}
+
+	if voucher == nil {
+		return nil, fmt.Errorf("voucher with id %d not found", id)
+	}
+
+	return voucher, nil
+}
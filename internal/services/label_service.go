@@ -0,0 +1,12 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type LabelService interface {
+	// Generate renders shelf labels for products with the prices of the given store
+	Generate(ctx context.Context, storeID int, dto *dtos.LabelRequestDto) (*dtos.LabelFileDto, error)
+}
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type PaymentService interface {
+	// GetQRIS builds the dynamic QRIS code for the pending QRIS payment of a pending transaction
+	GetQRIS(ctx context.Context, transactionID int) (*dtos.QRISDto, error)
+
+	// RenderQRIS renders the QRIS code of a transaction as a PNG image of size x size pixels
+	RenderQRIS(ctx context.Context, transactionID, size int) ([]byte, error)
+
+	// HandleWebhook verifies a webhook from the payment gateway and records the payment outcome
+	// it reports, returning the payment's transaction
+	HandleWebhook(ctx context.Context, header http.Header, body []byte) (*dtos.TransactionDto, error)
+
+	// ExpirePending expires the pending transactions whose payments were not confirmed in time,
+	// putting their stock back, and returns how many expired
+	ExpirePending(ctx context.Context) (int, error)
+}
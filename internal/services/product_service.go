@@ -7,24 +7,57 @@ import (
 )
 
 type ProductService interface {
-	// GetAll retrieves all products
-	GetAll(ctx context.Context) ([]dtos.ProductDto, error)
+	// GetAll retrieves all products with the stock of the given store
+	GetAll(ctx context.Context, storeID int, includeArchived bool) ([]dtos.ProductDto, error)
 
-	// GetByID retrieves a product by ID
-	GetByID(ctx context.Context, id int) (*dtos.ProductDto, error)
+	// GetByID retrieves a product by ID with the stock of the given store
+	GetByID(ctx context.Context, storeID, id int) (*dtos.ProductDto, error)
 
-	// GetByCategoryID retrieves all products by category ID
-	GetByCategoryID(ctx context.Context, categoryID int) ([]dtos.ProductDto, error)
+	// GetByCategoryID retrieves all products by category ID, and those of its subcategories when includeDescendants is set
+	GetByCategoryID(ctx context.Context, storeID, categoryID int, includeDescendants, includeArchived bool) ([]dtos.ProductDto, error)
+
+	// Lookup finds the product a scanned barcode or SKU belongs to
+	Lookup(ctx context.Context, storeID int, barcode, sku string) (*dtos.ProductDto, error)
 
 	// Search searches products by name and active status
-	Search(ctx context.Context, name string, active *bool) ([]dtos.ProductDto, error)
+	Search(ctx context.Context, storeID int, name string, active *bool, includeArchived bool) ([]dtos.ProductDto, error)
 
-	// Create creates a new product
-	Create(ctx context.Context, dto *dtos.ProductCreateRequestDto) (*dtos.ProductDto, error)
+	// Create creates a new product with its initial stock held by the given store
+	Create(ctx context.Context, storeID int, dto *dtos.ProductCreateRequestDto) (*dtos.ProductDto, error)
 
-	// Update updates an existing product
-	Update(ctx context.Context, id int, dto *dtos.ProductUpdateRequestDto) (*dtos.ProductDto, error)
+	// Update updates an existing product and the stock of the given store
+	Update(ctx context.Context, storeID, id int, dto *dtos.ProductUpdateRequestDto) (*dtos.ProductDto, error)
 
-	// Delete deletes a product by ID
+	// Delete archives a product by ID
 	Delete(ctx context.Context, id int) error
+
+	// Restore brings back an archived product
+	Restore(ctx context.Context, storeID, id int) (*dtos.ProductDto, error)
+
+	// ReceiveGoods records incoming stock for a product at a store and updates its weighted average cost
+	ReceiveGoods(ctx context.Context, storeID, id int, dto *dtos.GoodsReceiptCreateRequestDto) (*dtos.GoodsReceiptDto, error)
+
+	// GetReceipts retrieves the goods receipt history of a product
+	GetReceipts(ctx context.Context, id int) ([]dtos.GoodsReceiptDto, error)
+
+	// GetLots retrieves the lots of a product held by a store, earliest expiry first
+	GetLots(ctx context.Context, storeID, id int) ([]dtos.ProductLotDto, error)
+
+	// GetSerials retrieves the unsold serial numbers of a product held by a store
+	GetSerials(ctx context.Context, storeID, id int) ([]dtos.ProductSerialDto, error)
+
+	// SetComponents replaces the components of a bundle; an empty list makes it a regular product again
+	SetComponents(ctx context.Context, storeID, id int, dto *dtos.BundleComponentsUpdateRequestDto) (*dtos.ProductDto, error)
+
+	// GetComponents retrieves the components of a bundle with their stock at the given store
+	GetComponents(ctx context.Context, storeID, id int) ([]dtos.BundleComponentDto, error)
+
+	// SetOptions replaces the option axes, such as Size and Color, of a parent product
+	SetOptions(ctx context.Context, storeID, id int, dto *dtos.ProductOptionsUpdateRequestDto) (*dtos.ProductDto, error)
+
+	// GetVariants retrieves the variants of a parent product with the stock of the given store
+	GetVariants(ctx context.Context, storeID, id int) ([]dtos.ProductDto, error)
+
+	// CreateVariant creates a variant of a parent product for one combination of option values
+	CreateVariant(ctx context.Context, storeID, id int, dto *dtos.ProductVariantCreateRequestDto) (*dtos.ProductDto, error)
 }
@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type PromotionService interface {
+	// GetAll retrieves all promotions, or only those running now
+	GetAll(ctx context.Context, runningOnly bool) ([]dtos.PromotionDto, error)
+
+	// GetByID retrieves a promotion by ID
+	GetByID(ctx context.Context, id int) (*dtos.PromotionDto, error)
+
+	// Create creates a new promotion
+	Create(ctx context.Context, dto *dtos.PromotionRequestDto) (*dtos.PromotionDto, error)
+
+	// Update replaces the settings of an existing promotion
+	Update(ctx context.Context, id int, dto *dtos.PromotionRequestDto) (*dtos.PromotionDto, error)
+
+	// Delete deletes a promotion by ID
+	Delete(ctx context.Context, id int) error
+}
@@ -7,9 +7,18 @@ import (
 )
 
 type ReportService interface {
-	// GetTodayReport retrieves today's transaction report
-	GetTodayReport(ctx context.Context) (*dtos.TodayReportDto, error)
+	// GetTodayReport retrieves today's transaction report for a store, with sales per top-level category when byCategory is set
+	GetTodayReport(ctx context.Context, storeID int, byCategory bool) (*dtos.TodayReportDto, error)
 
-	// GetDateRangeReport retrieves transaction report for a specific date range
-	GetDateRangeReport(ctx context.Context, startDate, endDate string) (*dtos.DateRangeReportDto, error)
+	// GetDateRangeReport retrieves transaction report of a store for a specific date range, with sales per top-level category when byCategory is set
+	GetDateRangeReport(ctx context.Context, storeID int, startDate, endDate string, byCategory bool) (*dtos.DateRangeReportDto, error)
+
+	// GetTaxReport retrieves the tax charged per rate by a store within a date range
+	GetTaxReport(ctx context.Context, storeID int, startDate, endDate string) (*dtos.TaxReportDto, error)
+
+	// GetPaymentReport retrieves the payments taken per method by a store within a date range
+	GetPaymentReport(ctx context.Context, storeID int, startDate, endDate string) (*dtos.PaymentReportDto, error)
+
+	// GetExpiringReport retrieves the lots of a store expiring within the given number of days
+	GetExpiringReport(ctx context.Context, storeID, days int) (*dtos.ExpiringReportDto, error)
 }
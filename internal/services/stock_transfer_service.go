@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type StockTransferService interface {
+	// GetAll retrieves stock transfers, optionally filtered by status and store
+	GetAll(ctx context.Context, status string, storeID int) ([]dtos.StockTransferDto, error)
+
+	// GetByID retrieves a stock transfer by ID
+	GetByID(ctx context.Context, id int) (*dtos.StockTransferDto, error)
+
+	// Create requests a stock transfer between two stores
+	Create(ctx context.Context, dto *dtos.StockTransferCreateRequestDto) (*dtos.StockTransferDto, error)
+
+	// Ship ships a requested transfer, deducting stock from the source store
+	Ship(ctx context.Context, id int) (*dtos.StockTransferDto, error)
+
+	// Receive receives a shipped transfer, adding the received quantities to the destination store
+	Receive(ctx context.Context, id int, dto *dtos.StockTransferReceiveRequestDto) (*dtos.StockTransferDto, error)
+
+	// Cancel cancels a transfer that has not been shipped yet
+	Cancel(ctx context.Context, id int) (*dtos.StockTransferDto, error)
+}
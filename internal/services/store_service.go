@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type StoreService interface {
+	// GetAll retrieves all stores
+	GetAll(ctx context.Context) ([]dtos.StoreDto, error)
+
+	// GetByID retrieves a store by ID
+	GetByID(ctx context.Context, id int) (*dtos.StoreDto, error)
+
+	// Create creates a new store
+	Create(ctx context.Context, dto *dtos.StoreCreateRequestDto) (*dtos.StoreDto, error)
+
+	// Update updates an existing store
+	Update(ctx context.Context, id int, dto *dtos.StoreUpdateRequestDto) (*dtos.StoreDto, error)
+
+	// Delete deletes a store by ID
+	Delete(ctx context.Context, id int) error
+
+	// GetProducts retrieves the catalog with the stock levels and prices of a store
+	GetProducts(ctx context.Context, id int) ([]dtos.ProductDto, error)
+
+	// UpdateStock sets a store's stock level and price override for a product
+	UpdateStock(ctx context.Context, id int, dto *dtos.StoreStockUpdateRequestDto) (*dtos.ProductDto, error)
+}
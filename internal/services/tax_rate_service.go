@@ -0,0 +1,24 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type TaxRateService interface {
+	// GetAll retrieves all tax rates
+	GetAll(ctx context.Context) ([]dtos.TaxRateDto, error)
+
+	// GetByID retrieves a tax rate by ID
+	GetByID(ctx context.Context, id int) (*dtos.TaxRateDto, error)
+
+	// Create creates a new tax rate
+	Create(ctx context.Context, dto *dtos.TaxRateRequestDto) (*dtos.TaxRateDto, error)
+
+	// Update replaces the settings of an existing tax rate
+	Update(ctx context.Context, id int, dto *dtos.TaxRateRequestDto) (*dtos.TaxRateDto, error)
+
+	// Delete deletes a tax rate that no product or category is assigned to
+	Delete(ctx context.Context, id int) error
+}
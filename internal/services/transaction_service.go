@@ -7,12 +7,28 @@ import (
 )
 
 type TransactionService interface {
-	// Checkout creates a new transaction from checkout request
-	Checkout(ctx context.Context, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error)
+	// Checkout creates a new transaction from checkout request at the given store
+	Checkout(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error)
 
-	// GetByID retrieves a transaction by ID
-	GetByID(ctx context.Context, id int) (*dtos.TransactionDto, error)
+	// Quote prices a checkout request at the given store without recording it or taking stock
+	Quote(ctx context.Context, storeID int, dto *dtos.TransactionCreateRequestDto) (*dtos.TransactionDto, error)
+
+	// GetByID retrieves a transaction of the given store by ID
+	GetByID(ctx context.Context, storeID, id int) (*dtos.TransactionDto, error)
 
 	// GetAll retrieves all transactions
 	GetAll(ctx context.Context) ([]dtos.TransactionDto, error)
+
+	// Complete completes a draft transaction of the given store, taking its payments and its stock
+	Complete(ctx context.Context, storeID, id int, dto *dtos.TransactionCompleteRequestDto) (*dtos.TransactionDto, error)
+
+	// Void voids a draft or pending transaction of the given store, or a completed one on the
+	// day of sale, putting back any stock it took
+	Void(ctx context.Context, storeID, id int, dto *dtos.TransactionReasonRequestDto) (*dtos.TransactionDto, error)
+
+	// Refund refunds a completed transaction of the given store, putting back its stock
+	Refund(ctx context.Context, storeID, id int, dto *dtos.TransactionReasonRequestDto) (*dtos.TransactionDto, error)
+
+	// LookupSerial retrieves a serial number with the transaction it was sold in, for warranty claims
+	LookupSerial(ctx context.Context, serialNumber string) (*dtos.ProductSerialDto, error)
 }
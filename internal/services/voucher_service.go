@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gustionusamba24/kasir-api-go/internal/domain/dtos"
+)
+
+type VoucherService interface {
+	// GetAll retrieves all vouchers
+	GetAll(ctx context.Context) ([]dtos.VoucherDto, error)
+
+	// GetByID retrieves a voucher by ID
+	GetByID(ctx context.Context, id int) (*dtos.VoucherDto, error)
+
+	// Create creates a new voucher
+	Create(ctx context.Context, dto *dtos.VoucherRequestDto) (*dtos.VoucherDto, error)
+
+	// Update replaces the settings of an existing voucher
+	Update(ctx context.Context, id int, dto *dtos.VoucherRequestDto) (*dtos.VoucherDto, error)
+
+	// Delete deletes a voucher that has never been redeemed
+	Delete(ctx context.Context, id int) error
+
+	// GetRedemptions retrieves the transactions a voucher was redeemed on
+	GetRedemptions(ctx context.Context, id int) ([]dtos.VoucherRedemptionDto, error)
+}
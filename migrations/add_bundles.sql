@@ -0,0 +1,32 @@
+-- Migration: Add bundle products
+-- A bundle (such as "Socks Pack of 3" or a gift basket) is a product made up of
+-- other products. It holds no stock of its own: its availability is derived from
+-- its components and selling it takes the components out of stock.
+
+CREATE TABLE IF NOT EXISTS bundle_components (
+    id SERIAL PRIMARY KEY,
+    bundle_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    UNIQUE (bundle_id, component_id),
+    CHECK (bundle_id <> component_id)
+);
+
+-- Record the components each sold bundle took out of stock, so voids and refunds
+-- put back exactly those even after the bundle's components change
+CREATE TABLE IF NOT EXISTS transaction_detail_components (
+    id SERIAL PRIMARY KEY,
+    transaction_detail_id INTEGER NOT NULL REFERENCES transaction_details(id) ON DELETE CASCADE,
+    component_id INTEGER NOT NULL REFERENCES products(id) ON DELETE RESTRICT,
+    quantity NUMERIC(14,3) NOT NULL CHECK (quantity > 0)
+);
+
+-- Bundles sold before components were recorded took the components they have now
+INSERT INTO transaction_detail_components (transaction_detail_id, component_id, quantity)
+SELECT td.id, bc.component_id, td.quantity * bc.quantity
+FROM transaction_details td
+JOIN bundle_components bc ON bc.bundle_id = td.product_id
+WHERE NOT EXISTS (SELECT 1 FROM transaction_detail_components tdc WHERE tdc.transaction_detail_id = td.id);
+
+CREATE INDEX IF NOT EXISTS idx_bundle_components_component_id ON bundle_components(component_id);
+CREATE INDEX IF NOT EXISTS idx_transaction_detail_components_detail_id ON transaction_detail_components(transaction_detail_id);
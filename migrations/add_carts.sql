@@ -0,0 +1,45 @@
+-- Migration: Add carts
+-- A cart keeps a customer's basket as entered so a cashier can park it and serve
+-- the next customer, then resume it at any terminal of the store. Its totals are
+-- priced live and nothing is taken from stock until it is checked out into a
+-- transaction. Open and parked carts expire once left untouched for too long.
+
+-- Create carts table
+CREATE TABLE IF NOT EXISTS carts (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'open'
+        CHECK (status IN ('open', 'parked', 'checked_out', 'expired')),
+    terminal_id VARCHAR(50),
+    customer_ref VARCHAR(100),
+    voucher_codes TEXT[],
+    discount_type VARCHAR(10) CHECK (discount_type IN ('percent', 'amount')),
+    discount_value NUMERIC(14,2),
+    discount_reason VARCHAR(255),
+    note VARCHAR(255),
+    transaction_id INTEGER REFERENCES transactions(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    parked_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- Create cart items table; a line names a product or a scanned barcode
+CREATE TABLE IF NOT EXISTS cart_items (
+    id SERIAL PRIMARY KEY,
+    cart_id INTEGER NOT NULL REFERENCES carts(id) ON DELETE CASCADE,
+    product_id INTEGER REFERENCES products(id),
+    barcode VARCHAR(50),
+    quantity NUMERIC(14,3) NOT NULL CHECK (quantity > 0),
+    unit VARCHAR(20),
+    serial_numbers TEXT[],
+    discount_type VARCHAR(10) CHECK (discount_type IN ('percent', 'amount')),
+    discount_value NUMERIC(14,2),
+    discount_reason VARCHAR(255),
+    CHECK (product_id IS NOT NULL OR barcode IS NOT NULL)
+);
+
+-- Create indexes for listing a store's carts, expiring carts and loading items
+CREATE INDEX IF NOT EXISTS idx_carts_store_status ON carts(store_id, status, updated_at);
+CREATE INDEX IF NOT EXISTS idx_carts_active_expires_at ON carts(expires_at) WHERE status IN ('open', 'parked');
+CREATE INDEX IF NOT EXISTS idx_cart_items_cart_id ON cart_items(cart_id);
@@ -0,0 +1,7 @@
+-- Migration: Nest categories
+-- A category can sit under a parent category (Electronics > Accessories > Cables).
+-- Top-level categories have no parent. Cycles are rejected by the API.
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
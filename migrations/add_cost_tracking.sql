@@ -0,0 +1,21 @@
+-- Migration: Add cost price tracking
+-- This migration adds a weighted average cost to products, a goods receipt log
+-- that drives the average, and a cost snapshot on each transaction detail
+
+-- Add average cost column to products
+ALTER TABLE products ADD COLUMN IF NOT EXISTS cost NUMERIC(15, 2) NOT NULL DEFAULT 0;
+
+-- Create goods receipts table to record incoming stock and its unit cost
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    unit_cost NUMERIC(15, 2) NOT NULL CHECK (unit_cost >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index on product_id for receipt history lookups
+CREATE INDEX IF NOT EXISTS idx_goods_receipts_product_id ON goods_receipts(product_id);
+
+-- Snapshot the product cost on each sold line so margins survive later cost changes
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS unit_cost NUMERIC(15, 2) NOT NULL DEFAULT 0;
@@ -0,0 +1,26 @@
+-- Migration: Add line and cart discounts
+-- Transactions and their details keep the gross amount before discounts, the
+-- discount taken off and the net amount sold for (total_amount and subtotal).
+-- Cart discounts are spread over the details, so the subtotals still add up to
+-- the total. Each discount given is recorded with the reason it applied.
+
+-- Add gross and discount amounts, treating existing sales as undiscounted
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS gross_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS discount_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS gross_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS discount_amount INTEGER NOT NULL DEFAULT 0;
+
+UPDATE transactions SET gross_amount = total_amount WHERE gross_amount = 0;
+UPDATE transaction_details SET gross_amount = subtotal WHERE gross_amount = 0;
+
+-- Create applied discounts table; line discounts name their detail
+CREATE TABLE IF NOT EXISTS transaction_discounts (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    transaction_detail_id INTEGER REFERENCES transaction_details(id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transaction_discounts_transaction_id ON transaction_discounts(transaction_id);
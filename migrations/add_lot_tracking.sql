@@ -0,0 +1,50 @@
+-- Migration: Add lot and expiry date tracking
+-- Products with track_lots enabled must name a lot (with an optional expiry date)
+-- on every goods receipt. Checkout and transfer shipping draw stock from lots
+-- first-expiry-first-out and record which lots each line drew from.
+
+-- Add lot tracking flag to products
+ALTER TABLE products ADD COLUMN IF NOT EXISTS track_lots BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create product lots table, one row per lot held by a store
+CREATE TABLE IF NOT EXISTS product_lots (
+    id SERIAL PRIMARY KEY,
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity INTEGER NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (store_id, product_id, lot_number)
+);
+
+-- Record the lot of each goods receipt
+ALTER TABLE goods_receipts ADD COLUMN IF NOT EXISTS lot_number VARCHAR(100);
+ALTER TABLE goods_receipts ADD COLUMN IF NOT EXISTS expiry_date DATE;
+
+-- Record which lots each transaction detail drew from. Lot number and expiry
+-- date are copied so the history survives the lot being removed.
+CREATE TABLE IF NOT EXISTS transaction_detail_lots (
+    id SERIAL PRIMARY KEY,
+    transaction_detail_id INTEGER NOT NULL REFERENCES transaction_details(id) ON DELETE CASCADE,
+    lot_id INTEGER REFERENCES product_lots(id) ON DELETE SET NULL,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+-- Record which lots were shipped for each stock transfer item so the
+-- destination store receives the same lots
+CREATE TABLE IF NOT EXISTS stock_transfer_item_lots (
+    id SERIAL PRIMARY KEY,
+    transfer_item_id INTEGER NOT NULL REFERENCES stock_transfer_items(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+-- Create indexes for FEFO picking and expiry reports
+CREATE INDEX IF NOT EXISTS idx_product_lots_store_product ON product_lots(store_id, product_id, expiry_date);
+CREATE INDEX IF NOT EXISTS idx_product_lots_expiry_date ON product_lots(expiry_date) WHERE quantity > 0;
+CREATE INDEX IF NOT EXISTS idx_transaction_detail_lots_detail_id ON transaction_detail_lots(transaction_detail_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_item_lots_item_id ON stock_transfer_item_lots(transfer_item_id);
@@ -0,0 +1,43 @@
+-- Migration: Add multi-outlet support
+-- This migration introduces stores, moves product stock into a per-store table
+-- with optional store price overrides, and scopes transactions and goods receipts
+-- to the store they happened at. Existing stock and history move to the main store.
+
+-- Create stores table
+CREATE TABLE IF NOT EXISTS stores (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create the main store that owns all existing stock (id 1 is the default store)
+INSERT INTO stores (id, name) VALUES (1, 'Main Store') ON CONFLICT (id) DO NOTHING;
+SELECT setval('stores_id_seq', GREATEST((SELECT MAX(id) FROM stores), 1));
+
+-- Create per-store stock table; price overrides the catalog price when not null
+CREATE TABLE IF NOT EXISTS product_stock (
+    store_id INTEGER NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    price NUMERIC(15, 2) CHECK (price > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (store_id, product_id)
+);
+
+-- Create index on product_id for cross-store stock lookups
+CREATE INDEX IF NOT EXISTS idx_product_stock_product_id ON product_stock(product_id);
+
+-- Move existing stock into the main store and drop the global stock column
+INSERT INTO product_stock (store_id, product_id, quantity)
+SELECT 1, id, stock FROM products
+ON CONFLICT (store_id, product_id) DO NOTHING;
+
+ALTER TABLE products DROP COLUMN IF EXISTS stock;
+
+-- Scope transactions and goods receipts to a store
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS store_id INTEGER NOT NULL DEFAULT 1 REFERENCES stores(id);
+CREATE INDEX IF NOT EXISTS idx_transactions_store_id_created_at ON transactions(store_id, created_at);
+
+ALTER TABLE goods_receipts ADD COLUMN IF NOT EXISTS store_id INTEGER NOT NULL DEFAULT 1 REFERENCES stores(id);
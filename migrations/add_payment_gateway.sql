@@ -0,0 +1,31 @@
+-- Migration: Add payment gateway
+-- Card, e-wallet and QRIS payments can be charged through a payment gateway that
+-- confirms them asynchronously through a signed webhook. Until then the
+-- transaction is pending and holds its stock. It completes once every gateway
+-- payment is paid, and fails or expires otherwise, putting its stock back.
+
+-- Add transaction status; earlier transactions were completed at checkout
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'completed';
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_status_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_status_check
+    CHECK (status IN ('pending', 'completed', 'failed', 'expired'));
+
+-- Record when a pending transaction stops waiting for its payments
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
+
+-- Add payment status; earlier payments were taken at the counter
+ALTER TABLE transaction_payments ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'paid';
+ALTER TABLE transaction_payments DROP CONSTRAINT IF EXISTS transaction_payments_status_check;
+ALTER TABLE transaction_payments ADD CONSTRAINT transaction_payments_status_check
+    CHECK (status IN ('pending', 'paid', 'failed', 'expired'));
+
+-- Record the gateway charge of each payment charged through a gateway
+ALTER TABLE transaction_payments ADD COLUMN IF NOT EXISTS provider VARCHAR(50);
+ALTER TABLE transaction_payments ADD COLUMN IF NOT EXISTS provider_ref VARCHAR(100);
+ALTER TABLE transaction_payments ADD COLUMN IF NOT EXISTS checkout_url TEXT;
+ALTER TABLE transaction_payments ADD COLUMN IF NOT EXISTS resolved_at TIMESTAMP;
+
+-- Create indexes for webhook lookups, expiring pending transactions and reports
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transaction_payments_provider_ref ON transaction_payments(provider, provider_ref) WHERE provider_ref IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_pending_expires_at ON transactions(expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_transactions_store_status_created_at ON transactions(store_id, status, created_at);
@@ -0,0 +1,30 @@
+-- Migration: Add payments
+-- A checkout is paid with one or more tenders: cash, debit or credit card, QRIS,
+-- e-wallet or a prepaid gift voucher. Only cash gives change. Every payment is
+-- kept with its transaction for reports and cash drawer reconciliation.
+
+-- Record the amount paid and the change given on each transaction
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS paid_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS change_amount INTEGER NOT NULL DEFAULT 0 CHECK (change_amount >= 0);
+
+-- Earlier transactions were paid exactly
+UPDATE transactions SET paid_amount = total_amount WHERE paid_amount = 0;
+
+-- Payment method now names the tender, or split for several; non_cash remains
+-- on transactions recorded before payments were kept
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_payment_method_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_payment_method_check
+    CHECK (payment_method IN ('cash', 'debit', 'credit', 'qris', 'e_wallet', 'voucher', 'split', 'non_cash'));
+
+-- Create transaction payments table; change is given from the cash payment
+CREATE TABLE IF NOT EXISTS transaction_payments (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    method VARCHAR(20) NOT NULL CHECK (method IN ('cash', 'debit', 'credit', 'qris', 'e_wallet', 'voucher')),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    change_amount INTEGER NOT NULL DEFAULT 0 CHECK (change_amount >= 0 AND change_amount <= amount),
+    reference VARCHAR(100)
+);
+
+-- Create index for loading a transaction's payments
+CREATE INDEX IF NOT EXISTS idx_transaction_payments_transaction_id ON transaction_payments(transaction_id);
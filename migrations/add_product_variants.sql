@@ -0,0 +1,21 @@
+-- Migration: Add product variants
+-- A parent product defines up to three option axes (such as Size and Color).
+-- Each variant is a product row of its own pointing at its parent, so it keeps
+-- its own price, per-store stock, SKU and barcodes and is what checkout sells.
+
+-- Link variants to their parent and record the option value per axis, in axis order
+ALTER TABLE products ADD COLUMN IF NOT EXISTS parent_id INTEGER REFERENCES products(id) ON DELETE CASCADE;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS option_values TEXT[];
+
+CREATE INDEX IF NOT EXISTS idx_products_parent_id ON products(parent_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_parent_option_values ON products(parent_id, option_values) WHERE parent_id IS NOT NULL;
+
+-- Create product options table holding the option axes of a parent product
+CREATE TABLE IF NOT EXISTS product_options (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    values TEXT[] NOT NULL,
+    position INTEGER NOT NULL,
+    UNIQUE (product_id, name)
+);
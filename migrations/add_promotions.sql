@@ -0,0 +1,53 @@
+-- Migration: Add promotions
+-- Promotions are discount rules applied automatically at checkout: buy X get Y,
+-- quantity tier prices, a percentage off a category, and fixed bundle prices.
+-- Each promotion can be limited to a date range, days of the week and hours of
+-- the day. Higher priority promotions apply first; promotions that are not
+-- stackable keep other promotions off the lines they discount.
+
+-- Create promotions table
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(30) NOT NULL
+        CHECK (type IN ('buy_x_get_y', 'quantity_tier', 'category_percent', 'bundle_price')),
+    priority INTEGER NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    days_of_week INTEGER[] NOT NULL DEFAULT '{}',
+    start_time TIME,
+    end_time TIME,
+    buy_quantity INTEGER NOT NULL DEFAULT 0,
+    get_quantity INTEGER NOT NULL DEFAULT 0,
+    category_id INTEGER REFERENCES categories(id) ON DELETE CASCADE,
+    percent NUMERIC(5, 2) NOT NULL DEFAULT 0,
+    bundle_price INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at IS NULL OR starts_at IS NULL OR ends_at > starts_at)
+);
+
+-- Create promoted products table; quantity is the units per bundle for bundle prices
+CREATE TABLE IF NOT EXISTS promotion_items (
+    id SERIAL PRIMARY KEY,
+    promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL DEFAULT 1 CHECK (quantity > 0),
+    UNIQUE (promotion_id, product_id)
+);
+
+-- Create quantity tiers table
+CREATE TABLE IF NOT EXISTS promotion_tiers (
+    id SERIAL PRIMARY KEY,
+    promotion_id INTEGER NOT NULL REFERENCES promotions(id) ON DELETE CASCADE,
+    min_quantity NUMERIC(14, 3) NOT NULL CHECK (min_quantity > 0),
+    unit_price INTEGER NOT NULL CHECK (unit_price > 0),
+    UNIQUE (promotion_id, min_quantity)
+);
+
+-- Create indexes for checkout lookups
+CREATE INDEX IF NOT EXISTS idx_promotions_active ON promotions(priority DESC, id) WHERE active;
+CREATE INDEX IF NOT EXISTS idx_promotion_items_promotion_id ON promotion_items(promotion_id);
+CREATE INDEX IF NOT EXISTS idx_promotion_tiers_promotion_id ON promotion_tiers(promotion_id);
@@ -0,0 +1,7 @@
+-- Migration: Add PLU codes for scale printed barcodes
+-- Deli and produce scales print in-store EAN-13 labels (prefix 2x) that carry
+-- the item's PLU and its weight or price. The PLU is stored without the leading
+-- zeros scales pad it with.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS plu VARCHAR(12);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_plu ON products(plu) WHERE plu IS NOT NULL;
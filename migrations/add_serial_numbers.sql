@@ -0,0 +1,47 @@
+-- Migration: Add serial number tracking
+-- Serialized products record one serial number per unit. Serial numbers enter
+-- stock through goods receipts and must be named at checkout, so the sale of
+-- any unit can be looked up for warranty claims. store_id is the store holding
+-- the unit now: it is cleared while the unit travels on a stock transfer and set
+-- to the destination store once it arrives.
+
+-- Add serialized flag to products
+ALTER TABLE products ADD COLUMN IF NOT EXISTS serialized BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create serial inventory table
+CREATE TABLE IF NOT EXISTS product_serials (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    serial_number VARCHAR(100) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'in_stock'
+        CHECK (status IN ('in_stock', 'sold')),
+    received_store_id INTEGER NOT NULL REFERENCES stores(id),
+    store_id INTEGER REFERENCES stores(id),
+    goods_receipt_id INTEGER REFERENCES goods_receipts(id) ON DELETE SET NULL,
+    transaction_detail_id INTEGER REFERENCES transaction_details(id) ON DELETE SET NULL,
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sold_at TIMESTAMP
+);
+
+-- Record which serials were shipped for each stock transfer item, and which of
+-- them arrived, so the destination store receives the same units
+CREATE TABLE IF NOT EXISTS stock_transfer_item_serials (
+    id SERIAL PRIMARY KEY,
+    transfer_item_id INTEGER NOT NULL REFERENCES stock_transfer_items(id) ON DELETE CASCADE,
+    serial_id INTEGER NOT NULL REFERENCES product_serials(id) ON DELETE CASCADE,
+    received BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (transfer_item_id, serial_id)
+);
+
+-- Serials received before stores were tracked are still at the store that
+-- received them; serials shipped and not received stay in transit
+ALTER TABLE product_serials ADD COLUMN IF NOT EXISTS store_id INTEGER REFERENCES stores(id);
+UPDATE product_serials SET store_id = received_store_id WHERE store_id IS NULL AND id NOT IN (
+    SELECT serial_id FROM stock_transfer_item_serials WHERE NOT received
+);
+
+-- Create indexes for stock listing and sale lookups
+CREATE INDEX IF NOT EXISTS idx_product_serials_product_status ON product_serials(product_id, status);
+CREATE INDEX IF NOT EXISTS idx_product_serials_store_product ON product_serials(store_id, product_id) WHERE status = 'in_stock';
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_item_serials_item_id ON stock_transfer_item_serials(transfer_item_id);
+CREATE INDEX IF NOT EXISTS idx_product_serials_transaction_detail_id ON product_serials(transaction_detail_id);
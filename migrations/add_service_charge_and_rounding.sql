@@ -0,0 +1,16 @@
+-- Migration: Add service charge and cash rounding
+-- A store can add a percentage service charge to the cart, optionally taxed at
+-- the default tax rate, and round totals paid in cash to a multiple of an
+-- amount such as Rp 100. Both are kept as separate amounts on the transaction
+-- so the components add up to its total.
+
+-- Add service charge and cash rounding settings to stores
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS service_charge_rate NUMERIC(5, 2) NOT NULL DEFAULT 0 CHECK (service_charge_rate >= 0 AND service_charge_rate < 100);
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS service_charge_taxable BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS cash_rounding INTEGER NOT NULL DEFAULT 0 CHECK (cash_rounding >= 0 AND cash_rounding <= 1000);
+
+-- Record the service charge, rounding and payment method of each transaction;
+-- rounding is negative when the total was rounded down
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS service_charge INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS rounding_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payment_method VARCHAR(20) NOT NULL DEFAULT 'cash' CHECK (payment_method IN ('cash', 'non_cash'));
@@ -0,0 +1,18 @@
+-- Migration: Add SKU and barcodes to products
+-- Each product has an optional unique SKU and any number of barcodes. Barcodes
+-- are EAN-13 or UPC-A and are stored in EAN-13 form (UPC-A with a leading zero)
+-- so that either form scans to the same product.
+
+-- Add SKU column to products
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products(sku) WHERE sku IS NOT NULL;
+
+-- Create product barcodes table; the unique constraint also serves scanner lookups
+CREATE TABLE IF NOT EXISTS product_barcodes (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    barcode VARCHAR(13) NOT NULL UNIQUE CHECK (barcode ~ '^[0-9]{13}$'),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_barcodes_product_id ON product_barcodes(product_id);
@@ -0,0 +1,10 @@
+-- Migration: Archive products and categories instead of deleting them
+-- Deleting a product or category sets deleted_at, so transactions, receipts and
+-- reports that reference it keep working and it can be restored later. Archived
+-- rows are hidden from listings unless asked for.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_products_not_deleted ON products(id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_categories_not_deleted ON categories(id) WHERE deleted_at IS NULL;
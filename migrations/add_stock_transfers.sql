@@ -0,0 +1,36 @@
+-- Migration: Add inter-store stock transfers
+-- A transfer moves requested -> shipped -> received (or requested -> cancelled).
+-- Shipping deducts stock from the source store; receiving adds the received
+-- quantities to the destination store. Shipped minus received is the discrepancy.
+
+-- Create stock transfers table
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id SERIAL PRIMARY KEY,
+    source_store_id INTEGER NOT NULL REFERENCES stores(id),
+    destination_store_id INTEGER NOT NULL REFERENCES stores(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'requested'
+        CHECK (status IN ('requested', 'shipped', 'received', 'cancelled')),
+    note TEXT NOT NULL DEFAULT '',
+    receiving_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    shipped_at TIMESTAMP,
+    received_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    CHECK (source_store_id <> destination_store_id)
+);
+
+-- Create stock transfer items table
+CREATE TABLE IF NOT EXISTS stock_transfer_items (
+    id SERIAL PRIMARY KEY,
+    transfer_id INTEGER NOT NULL REFERENCES stock_transfers(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id),
+    quantity_requested INTEGER NOT NULL CHECK (quantity_requested > 0),
+    quantity_shipped INTEGER NOT NULL DEFAULT 0,
+    quantity_received INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (transfer_id, product_id)
+);
+
+-- Create indexes for in-transit lookups and store filtering
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_status_destination ON stock_transfers(status, destination_store_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfers_source ON stock_transfers(source_store_id);
+CREATE INDEX IF NOT EXISTS idx_stock_transfer_items_product_id ON stock_transfer_items(product_id);
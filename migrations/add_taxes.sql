@@ -0,0 +1,51 @@
+-- Migration: Add taxes
+-- Tax rates (such as PPN) are assigned to products or categories, with one rate
+-- marked as the default for everything else. Each store either includes tax in
+-- its prices or adds it on top. Tax is worked out per line at checkout and the
+-- breakdown by rate is kept with the transaction.
+
+-- Create tax rates table; rate is a percentage
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    rate NUMERIC(5, 2) NOT NULL CHECK (rate >= 0 AND rate < 100),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Allow at most one default rate
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_default ON tax_rates(is_default) WHERE is_default;
+
+-- Assign rates to products and categories; unassigned ones fall back to the
+-- nearest parent category and then to the default rate
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_rate_id INTEGER REFERENCES tax_rates(id);
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS tax_rate_id INTEGER REFERENCES tax_rates(id);
+
+-- Say whether a store's prices include tax or have it added on top
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS tax_mode VARCHAR(20) NOT NULL DEFAULT 'inclusive' CHECK (tax_mode IN ('inclusive', 'exclusive'));
+
+-- Record the tax mode and tax charged on each transaction
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tax_mode VARCHAR(20) NOT NULL DEFAULT 'inclusive' CHECK (tax_mode IN ('inclusive', 'exclusive'));
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS tax_amount INTEGER NOT NULL DEFAULT 0;
+
+-- Record the tax on each transaction line
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS tax_rate_id INTEGER REFERENCES tax_rates(id) ON DELETE SET NULL;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS tax_rate NUMERIC(5, 2) NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS taxable_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE transaction_details ADD COLUMN IF NOT EXISTS tax_amount INTEGER NOT NULL DEFAULT 0;
+
+-- Create transaction taxes table with the tax charged per rate; name and rate
+-- are copied so the breakdown survives later changes to the rate
+CREATE TABLE IF NOT EXISTS transaction_taxes (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    tax_rate_id INTEGER REFERENCES tax_rates(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    rate NUMERIC(5, 2) NOT NULL,
+    taxable_amount INTEGER NOT NULL,
+    tax_amount INTEGER NOT NULL
+);
+
+-- Create index for loading a transaction's taxes
+CREATE INDEX IF NOT EXISTS idx_transaction_taxes_transaction_id ON transaction_taxes(transaction_id);
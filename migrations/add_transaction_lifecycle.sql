@@ -0,0 +1,32 @@
+-- Migration: Add transaction lifecycle
+-- A transaction moves through draft, pending and completed, and can end failed,
+-- expired, voided or refunded. Each move is stamped with when it happened, and
+-- voids and refunds record why. Reports count sales when they complete and
+-- refunds when they are made.
+
+-- Stamp each status a transaction enters
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS expired_at TIMESTAMP;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS voided_at TIMESTAMP;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS refunded_at TIMESTAMP;
+
+-- Record why a transaction was voided or refunded
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS void_reason VARCHAR(255);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS refund_reason VARCHAR(255);
+
+-- Earlier completed transactions completed at checkout
+UPDATE transactions SET completed_at = created_at WHERE status = 'completed' AND completed_at IS NULL;
+
+-- Allow drafts, voids and refunds
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_status_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_status_check
+    CHECK (status IN ('draft', 'pending', 'completed', 'failed', 'expired', 'voided', 'refunded'));
+
+-- Voids and refunds refund paid payments and cancel pending ones
+ALTER TABLE transaction_payments DROP CONSTRAINT IF EXISTS transaction_payments_status_check;
+ALTER TABLE transaction_payments ADD CONSTRAINT transaction_payments_status_check
+    CHECK (status IN ('pending', 'paid', 'failed', 'expired', 'refunded', 'cancelled'));
+
+-- Drafts are not paid yet, so they have no payment method until they are completed
+ALTER TABLE transactions ALTER COLUMN payment_method DROP NOT NULL;
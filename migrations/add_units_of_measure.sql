@@ -0,0 +1,31 @@
+-- Migration: Add units of measure and decimal quantities
+-- Each product is stocked and sold in a unit of measure (pcs, kg, g, L or ml)
+-- with a configured number of decimal places, so that coffee beans can be sold
+-- by the gram and rice by the kilo. Purchasing units such as a box of 24 pcs
+-- are stored as conversions to the stock unit. Quantities become NUMERIC(14,3).
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS unit VARCHAR(10) NOT NULL DEFAULT 'pcs'
+    CHECK (unit IN ('pcs', 'kg', 'g', 'L', 'ml'));
+ALTER TABLE products ADD COLUMN IF NOT EXISTS quantity_precision SMALLINT NOT NULL DEFAULT 0
+    CHECK (quantity_precision BETWEEN 0 AND 3);
+
+-- Create product unit conversions table; factor is the number of stock units in one unit
+CREATE TABLE IF NOT EXISTS product_unit_conversions (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    unit VARCHAR(20) NOT NULL,
+    factor NUMERIC(14,3) NOT NULL CHECK (factor > 0),
+    UNIQUE (product_id, unit)
+);
+
+-- Allow decimal quantities wherever stock is held or moved
+ALTER TABLE product_stock ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE product_lots ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE goods_receipts ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE transaction_details ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE transaction_detail_lots ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE stock_transfer_items ALTER COLUMN quantity_requested TYPE NUMERIC(14,3);
+ALTER TABLE stock_transfer_items ALTER COLUMN quantity_shipped TYPE NUMERIC(14,3);
+ALTER TABLE stock_transfer_items ALTER COLUMN quantity_received TYPE NUMERIC(14,3);
+ALTER TABLE stock_transfer_item_lots ALTER COLUMN quantity TYPE NUMERIC(14,3);
+ALTER TABLE bundle_components ALTER COLUMN quantity TYPE NUMERIC(14,3);
@@ -0,0 +1,45 @@
+-- Migration: Add vouchers
+-- Voucher codes take a percentage or a fixed amount off the cart at checkout.
+-- A voucher can require a minimum spend, cap its discount, run between two
+-- dates and be limited to a number of redemptions overall and per customer.
+-- Every redemption is recorded with its transaction.
+
+-- Create vouchers table; codes are stored upper-cased
+CREATE TABLE IF NOT EXISTS vouchers (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description VARCHAR(255) NOT NULL DEFAULT '',
+    type VARCHAR(20) NOT NULL CHECK (type IN ('percent', 'amount')),
+    value NUMERIC(15, 2) NOT NULL CHECK (value > 0),
+    max_discount INTEGER NOT NULL DEFAULT 0 CHECK (max_discount >= 0),
+    min_spend INTEGER NOT NULL DEFAULT 0 CHECK (min_spend >= 0),
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    per_customer_limit INTEGER CHECK (per_customer_limit > 0),
+    starts_at TIMESTAMP,
+    expires_at TIMESTAMP,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    times_redeemed INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (usage_limit IS NULL OR times_redeemed <= usage_limit)
+);
+
+-- Identify the customer on a transaction for per-customer voucher limits
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS customer_ref VARCHAR(100);
+
+-- Link voucher discounts to their voucher
+ALTER TABLE transaction_discounts ADD COLUMN IF NOT EXISTS voucher_id INTEGER REFERENCES vouchers(id);
+
+-- Create redemptions table
+CREATE TABLE IF NOT EXISTS voucher_redemptions (
+    id SERIAL PRIMARY KEY,
+    voucher_id INTEGER NOT NULL REFERENCES vouchers(id),
+    transaction_id INTEGER NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    customer_ref VARCHAR(100),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    redeemed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes for redemption history and per-customer limits
+CREATE INDEX IF NOT EXISTS idx_voucher_redemptions_voucher_customer ON voucher_redemptions(voucher_id, customer_ref);
+CREATE INDEX IF NOT EXISTS idx_voucher_redemptions_transaction_id ON voucher_redemptions(transaction_id);